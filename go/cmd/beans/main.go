@@ -0,0 +1,137 @@
+// beans - COINjecture node utility CLI
+//
+// Currently home to archive management: packing historical blocks and
+// receipts into era files, and verifying them.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/archive"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "beans",
+	Short: "COINjecture node utilities",
+}
+
+var archiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Create and inspect era-file block archives",
+}
+
+var (
+	archiveFrom      uint64
+	archiveTo        uint64
+	archiveOut       string
+	archiveDB        string
+	archiveNetworkID uint32
+)
+
+var archiveExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export a contiguous range of finalized blocks to a new era file",
+	RunE:  runArchiveExport,
+}
+
+var archiveVerifyCmd = &cobra.Command{
+	Use:   "verify <file>",
+	Short: "Recompute an era file's accumulator and per-block hashes and check them against its contents",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runArchiveVerify,
+}
+
+func init() {
+	archiveExportCmd.Flags().Uint64Var(&archiveFrom, "from", 0, "first block number to export (inclusive)")
+	archiveExportCmd.Flags().Uint64Var(&archiveTo, "to", 0, "last block number to export (inclusive)")
+	archiveExportCmd.Flags().StringVar(&archiveOut, "out", "", "output era file path (required, must not already exist)")
+	archiveExportCmd.Flags().StringVar(&archiveDB, "db", "coinjecture.db", "state database to read blocks and receipts from")
+	archiveExportCmd.Flags().Uint32Var(&archiveNetworkID, "network-id", 1, "network ID recorded in the era file header")
+	archiveExportCmd.MarkFlagRequired("out")
+
+	archiveCmd.AddCommand(archiveExportCmd)
+	archiveCmd.AddCommand(archiveVerifyCmd)
+	rootCmd.AddCommand(archiveCmd)
+}
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runArchiveExport(cmd *cobra.Command, args []string) error {
+	if archiveTo < archiveFrom {
+		return fmt.Errorf("--to (%d) must be >= --from (%d)", archiveTo, archiveFrom)
+	}
+
+	log := logger.NewLogger("info")
+
+	sm, err := state.NewStateManager(archiveDB, log)
+	if err != nil {
+		return fmt.Errorf("failed to open state database: %w", err)
+	}
+	defer sm.Close()
+
+	count := archiveTo - archiveFrom + 1
+	w, err := archive.NewWriter(archiveOut, archiveNetworkID, archiveFrom, uint32(count))
+	if err != nil {
+		return fmt.Errorf("failed to create era file: %w", err)
+	}
+
+	for n := archiveFrom; n <= archiveTo; n++ {
+		block, err := sm.GetBlock(n)
+		if err != nil {
+			w.Abort(archiveOut)
+			return fmt.Errorf("failed to load block %d: %w", n, err)
+		}
+
+		receipts, err := sm.GetReceipts(n)
+		if err != nil {
+			w.Abort(archiveOut)
+			return fmt.Errorf("failed to load receipts for block %d: %w", n, err)
+		}
+
+		if err := w.Append(block, receipts); err != nil {
+			w.Abort(archiveOut)
+			return fmt.Errorf("failed to append block %d: %w", n, err)
+		}
+	}
+
+	if err := w.Finalize(); err != nil {
+		return fmt.Errorf("failed to finalize era file: %w", err)
+	}
+
+	log.WithFields(logger.Fields{
+		"from":  archiveFrom,
+		"to":    archiveTo,
+		"count": count,
+		"out":   archiveOut,
+	}).Info("Exported era file")
+
+	fmt.Printf("Exported blocks %d-%d (%d blocks) to %s\n", archiveFrom, archiveTo, count, archiveOut)
+	return nil
+}
+
+func runArchiveVerify(cmd *cobra.Command, args []string) error {
+	path := args[0]
+
+	r, err := archive.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open era file: %w", err)
+	}
+	defer r.Close()
+
+	if err := r.Verify(); err != nil {
+		return fmt.Errorf("%s: verification FAILED: %w", path, err)
+	}
+
+	fmt.Printf("%s: OK (%d blocks starting at %d, network %d)\n", path, r.Count(), r.StartBlock(), r.NetworkID())
+	return nil
+}
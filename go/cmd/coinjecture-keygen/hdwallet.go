@@ -0,0 +1,219 @@
+// BIP39 mnemonic generation/recovery and SLIP-0010 Ed25519 HD derivation
+
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// ed25519SeedKey is the fixed HMAC key SLIP-0010 uses to derive an
+	// Ed25519 master key and chain code from a BIP39 seed.
+	ed25519SeedKey = "ed25519 seed"
+
+	// mnemonicSaltPrefix precedes the passphrase in the PBKDF2 salt, per
+	// BIP39's "mnemonic to seed" conversion.
+	mnemonicSaltPrefix = "mnemonic"
+
+	// pbkdf2Iterations and seedSize match BIP39's mnemonic-to-seed spec.
+	pbkdf2Iterations = 2048
+	seedSize         = 64
+
+	// defaultEntropyBits is the entropy width used when -mnemonic is
+	// passed without an explicit -entropy-bits, producing a 24-word
+	// mnemonic.
+	defaultEntropyBits = 256
+
+	// defaultHDPath is BIP44's external-chain path for account 0, index
+	// 0, under a placeholder coin type; callers normally override this
+	// via -hd-path.
+	defaultHDPath = "m/44'/1337'/0'/0'/0'"
+)
+
+// generateMnemonic reads entropyBits of randomness from crypto/rand (via
+// readRandomBytes; generateKeypair draws from the same crypto/rand.Reader),
+// appends a SHA-256 checksum, and renders the result as a space-separated
+// BIP39 mnemonic. entropyBits must be 128 or 256 (12 or 24 words).
+func generateMnemonic(entropyBits int) (string, error) {
+	if entropyBits != 128 && entropyBits != 256 {
+		return "", fmt.Errorf("entropy bits must be 128 or 256, got %d", entropyBits)
+	}
+
+	entropy, err := readRandomBytes(entropyBits / 8)
+	if err != nil {
+		return "", fmt.Errorf("failed to read entropy: %w", err)
+	}
+	defer secureZero(entropy)
+
+	return entropyToMnemonic(entropy)
+}
+
+// entropyToMnemonic implements BIP39 section "Generating the mnemonic
+// code": checksum = the first entropyBits/32 bits of SHA-256(entropy),
+// appended to entropy before splitting the combined bits into 11-bit
+// word indices.
+func entropyToMnemonic(entropy []byte) (string, error) {
+	entropyBits := len(entropy) * 8
+	checksumBits := entropyBits / 32
+
+	checksum := sha256.Sum256(entropy)
+	bits := make([]bool, entropyBits+checksumBits)
+	for i := 0; i < entropyBits; i++ {
+		bits[i] = entropy[i/8]&(1<<(7-uint(i%8))) != 0
+	}
+	for i := 0; i < checksumBits; i++ {
+		bits[entropyBits+i] = checksum[i/8]&(1<<(7-uint(i%8))) != 0
+	}
+
+	wordCount := len(bits) / 11
+	words := make([]string, wordCount)
+	for i := 0; i < wordCount; i++ {
+		var index int
+		for b := 0; b < 11; b++ {
+			index <<= 1
+			if bits[i*11+b] {
+				index |= 1
+			}
+		}
+		words[i] = bip39WordList[index]
+	}
+
+	return strings.Join(words, " "), nil
+}
+
+// mnemonicToSeed converts a mnemonic (and optional passphrase) into a
+// 64-byte seed via PBKDF2-HMAC-SHA512, per BIP39's "From mnemonic to
+// seed" section. It does not validate the mnemonic's checksum: that only
+// guards against typos, and a seed can still be derived either way.
+func mnemonicToSeed(mnemonic, passphrase string) []byte {
+	salt := mnemonicSaltPrefix + passphrase
+	return pbkdf2.Key([]byte(normalizeMnemonic(mnemonic)), []byte(salt), pbkdf2Iterations, seedSize, sha512.New)
+}
+
+// normalizeMnemonic collapses any run of whitespace between words to a
+// single space, so a mnemonic pasted with extra spacing or newlines
+// still derives the same seed.
+func normalizeMnemonic(mnemonic string) string {
+	return strings.Join(strings.Fields(mnemonic), " ")
+}
+
+// parseHDPath splits a derivation path like "m/44'/1337'/0'/0'/0'" into
+// its child indices. SLIP-0010 Ed25519 derivation only defines hardened
+// children, so every segment is treated as hardened regardless of
+// whether it carries a trailing ' or h/H marker.
+func parseHDPath(path string) ([]uint32, error) {
+	segments := strings.Split(path, "/")
+	if len(segments) == 0 || segments[0] != "m" {
+		return nil, fmt.Errorf("hd path must start with \"m\", got %q", path)
+	}
+
+	indices := make([]uint32, 0, len(segments)-1)
+	for _, seg := range segments[1:] {
+		seg = strings.TrimSuffix(strings.TrimSuffix(seg, "'"), "h")
+		seg = strings.TrimSuffix(seg, "H")
+
+		n, err := strconv.ParseUint(seg, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hd path segment %q: %w", seg, err)
+		}
+		if n >= hardenedOffset {
+			return nil, fmt.Errorf("hd path segment %d already hardened; omit the high bit, it is implied", n)
+		}
+		indices = append(indices, uint32(n)+hardenedOffset)
+	}
+
+	return indices, nil
+}
+
+// hardenedOffset is added to every child index during SLIP-0010 Ed25519
+// derivation (BIP32's 0x80000000 hardened marker).
+const hardenedOffset = 1 << 31
+
+// deriveEd25519Key implements SLIP-0010's Ed25519 master-key and
+// hardened-child derivation: a master HMAC-SHA512 keyed on "ed25519
+// seed", then one hardened child step per entry in path. The returned
+// 32 bytes are an Ed25519 seed, suitable for ed25519.NewKeyFromSeed.
+func deriveEd25519Key(seed []byte, path []uint32) ([]byte, error) {
+	key, chainCode := ed25519MasterKey(seed)
+	defer secureZero(chainCode)
+
+	for _, index := range path {
+		var err error
+		key, chainCode, err = ed25519ChildKey(key, chainCode, index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+// ed25519MasterKey computes SLIP-0010's master key and chain code from a
+// BIP39 seed: HMAC-SHA512("ed25519 seed", seed), split into two 32-byte
+// halves.
+func ed25519MasterKey(seed []byte) (key, chainCode []byte) {
+	mac := hmac.New(sha512.New, []byte(ed25519SeedKey))
+	mac.Write(seed)
+	i := mac.Sum(nil)
+	return i[:32], i[32:]
+}
+
+// ed25519ChildKey derives one hardened SLIP-0010 child step: HMAC-SHA512
+// keyed on the parent chain code, over 0x00 || parentKey || ser32(index),
+// split into a new 32-byte key and chain code. index must already carry
+// the hardened offset.
+func ed25519ChildKey(parentKey, parentChainCode []byte, index uint32) (key, chainCode []byte, err error) {
+	if index < hardenedOffset {
+		return nil, nil, fmt.Errorf("ed25519 SLIP-0010 only supports hardened derivation, got non-hardened index %d", index)
+	}
+
+	data := make([]byte, 0, 1+32+4)
+	data = append(data, 0x00)
+	data = append(data, parentKey...)
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, parentChainCode)
+	mac.Write(data)
+	i := mac.Sum(nil)
+	return i[:32], i[32:], nil
+}
+
+// deriveKeypairFromMnemonic derives an Ed25519 KeyPair deterministically
+// from mnemonic+passphrase along hdPath, the inverse of generateMnemonic
+// plus its associated derivation: the same inputs always produce the
+// same keypair, which is what makes -restore work.
+func deriveKeypairFromMnemonic(mnemonic, passphrase, hdPath string) (*KeyPair, error) {
+	path, err := parseHDPath(hdPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hd path: %w", err)
+	}
+
+	seed := mnemonicToSeed(mnemonic, passphrase)
+	defer secureZero(seed)
+
+	edSeed, err := deriveEd25519Key(seed, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	defer secureZero(edSeed)
+
+	privateKey := ed25519.NewKeyFromSeed(edSeed)
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+
+	return &KeyPair{
+		PublicKey:  publicKey,
+		PrivateKey: privateKey,
+		Created:    time.Now().UTC(),
+		Version:    Version,
+	}, nil
+}
@@ -0,0 +1,123 @@
+// HSM- and YubiKey-backed key generation, built on pkg/signer's
+// Signer interface: the private key is generated inside the token and
+// this process never sees it, only the resulting public key and a
+// reference (slot + CKA_LABEL) to where it lives.
+
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/signer"
+)
+
+const (
+	backendMemory  = "memory"
+	backendPKCS11  = "pkcs11"
+	backendYubiKey = "yubikey"
+)
+
+// HSMKeyMetadata is the on-disk record of an HSM- or YubiKey-backed
+// keypair: unlike KeyMetadata, it carries no private key at all, ever —
+// only the public key and enough of a reference (backend, slot, label)
+// for a future process to re-open the same key via
+// signer.OpenEd25519Key/OpenYubiKeySigner.
+type HSMKeyMetadata struct {
+	PublicKeyHex string    `yaml:"public_key_hex"`
+	Backend      string    `yaml:"backend"`
+	PKCS11Lib    string    `yaml:"pkcs11_lib"`
+	Slot         uint      `yaml:"slot"`
+	Label        string    `yaml:"label"`
+	Created      time.Time `yaml:"created"`
+	Version      string    `yaml:"version"`
+	Comment      string    `yaml:"comment,omitempty"`
+}
+
+// runHSMGenerate generates a new Ed25519 key pair on the HSM or YubiKey
+// config describes, and writes its public key and reference to disk
+// (or stdout, for -no-files). The private key is never read by this
+// process: it's created and used exclusively on the token.
+func runHSMGenerate(config *Config) error {
+	pin := os.Getenv(config.PINEnv)
+
+	var s *signer.PKCS11Signer
+	var err error
+	switch config.Backend {
+	case backendPKCS11:
+		s, err = signer.GenerateEd25519Key(signer.PKCS11Config{
+			LibPath: config.PKCS11Lib,
+			Slot:    config.Slot,
+			PIN:     pin,
+			Label:   config.Label,
+		})
+	case backendYubiKey:
+		s, err = signer.GenerateYubiKeySigner(signer.YubiKeyConfig{
+			LibPath: config.PKCS11Lib,
+			Slot:    config.Slot,
+			PIN:     pin,
+			Label:   config.Label,
+		})
+	default:
+		return fmt.Errorf("unknown HSM backend %q", config.Backend)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to generate key on %s: %w", config.Backend, err)
+	}
+	defer s.Close()
+
+	publicKeyHex := hex.EncodeToString(s.Public())
+	metadata := HSMKeyMetadata{
+		PublicKeyHex: publicKeyHex,
+		Backend:      config.Backend,
+		PKCS11Lib:    config.PKCS11Lib,
+		Slot:         config.Slot,
+		Label:        config.Label,
+		Created:      time.Now().UTC(),
+		Version:      Version,
+		Comment:      fmt.Sprintf("COINjecture validator keypair - generated %s (%s-backed, private key never left the token)", time.Now().UTC().Format(time.RFC3339), config.Backend),
+	}
+
+	if config.JsonOutput {
+		fmt.Printf("{\"public_key\":\"%s\",\"backend\":\"%s\",\"slot\":%d,\"label\":\"%s\"}\n",
+			publicKeyHex, config.Backend, config.Slot, config.Label)
+	} else {
+		fmt.Printf("Public Key: %s\n", publicKeyHex)
+		fmt.Printf("Backend:    %s (slot %d, label %q)\n", config.Backend, config.Slot, config.Label)
+		fmt.Println("Private key generated on-token; this process never saw it.")
+	}
+
+	if config.NoFiles {
+		return nil
+	}
+
+	if err := os.MkdirAll(config.OutputDir, 0750); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	filename := fmt.Sprintf("%s1", config.Prefix)
+	metadataPath := filepath.Join(config.OutputDir, filename+".yaml")
+	data, err := yaml.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal HSM key metadata: %w", err)
+	}
+	if err := os.WriteFile(metadataPath, data, ConfigFileMode); err != nil {
+		return fmt.Errorf("failed to write HSM key metadata: %w", err)
+	}
+
+	publicKeyPath := filepath.Join(config.OutputDir, filename+".pub")
+	if err := os.WriteFile(publicKeyPath, []byte(publicKeyHex), PublicKeyFileMode); err != nil {
+		return fmt.Errorf("failed to write public key: %w", err)
+	}
+
+	if config.Verbose {
+		fmt.Printf("Saved HSM key reference to %s\n", metadataPath)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+// Passphrase-encrypted keystore mode for saveKeypair, built on
+// pkg/keystore's scrypt+XChaCha20-Poly1305 sealing.
+
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/keystore"
+)
+
+// KeystoreFileMode matches ConfigFileMode: a keystore file is sealed,
+// but still only readable by its owner and group.
+const KeystoreFileMode = ConfigFileMode
+
+// promptKeystorePassphrase reads a new keystore passphrase twice (to
+// catch typos) and rejects it unless keystore.EstimatePasswordStrength
+// scores it at least keystore.MinPasswordScore, printing the
+// estimator's feedback either way.
+func promptKeystorePassphrase() (string, error) {
+	for {
+		passphrase, err := readPassphrase("Enter keystore passphrase: ")
+		if err != nil {
+			return "", err
+		}
+
+		score, feedback := keystore.EstimatePasswordStrength(passphrase)
+		for _, line := range feedback {
+			fmt.Printf("  - %s\n", line)
+		}
+		if score < keystore.MinPasswordScore {
+			secureZero([]byte(passphrase))
+			fmt.Printf("Passphrase too weak (score %d/4, need %d/4); try again.\n\n", score, keystore.MinPasswordScore)
+			continue
+		}
+
+		confirm, err := readPassphrase("Confirm keystore passphrase: ")
+		if err != nil {
+			secureZero([]byte(passphrase))
+			return "", err
+		}
+		if confirm != passphrase {
+			secureZero([]byte(passphrase))
+			secureZero([]byte(confirm))
+			fmt.Println("Passphrases did not match; try again.")
+			continue
+		}
+		secureZero([]byte(confirm))
+
+		return passphrase, nil
+	}
+}
+
+// readPassphrase reads a line from stdin without echoing it back when
+// stdin is a terminal, falling back to a plain (echoed) read when it
+// isn't — e.g. when a passphrase is piped in for scripted key creation.
+func readPassphrase(prompt string) (string, error) {
+	fmt.Print(prompt)
+
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		b, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Println()
+		if err != nil {
+			return "", fmt.Errorf("failed to read passphrase: %w", err)
+		}
+		return string(b), nil
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// saveEncryptedKeypair seals kp's private key under passphrase (see
+// pkg/keystore.Seal) and writes it to "<dir>/<filename>.keystore.json",
+// alongside the same plaintext public key and metadata files saveKeypair
+// writes — only the private key ever touches disk encrypted.
+func saveEncryptedKeypair(kp *KeyPair, dir, filename, passphrase string) error {
+	sealed, err := keystore.Seal(kp.PublicKey, kp.PrivateKey, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to seal private key: %w", err)
+	}
+
+	data, err := json.MarshalIndent(sealed, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal keystore file: %w", err)
+	}
+
+	keystorePath := filepath.Join(dir, filename+".keystore.json")
+	if err := os.WriteFile(keystorePath, data, KeystoreFileMode); err != nil {
+		return fmt.Errorf("failed to write keystore file: %w", err)
+	}
+
+	// Public key (0644 permissions - world readable), same as the
+	// unencrypted path.
+	publicKeyPath := filepath.Join(dir, filename+".pub")
+	publicKeyHex := hex.EncodeToString(kp.PublicKey)
+	if err := os.WriteFile(publicKeyPath, []byte(publicKeyHex), PublicKeyFileMode); err != nil {
+		return fmt.Errorf("failed to write public key: %w", err)
+	}
+
+	metadata := KeyMetadata{
+		PublicKeyHex: publicKeyHex,
+		Created:      kp.Created,
+		Version:      kp.Version,
+		Comment:      fmt.Sprintf("COINjecture validator keypair - generated %s (encrypted keystore)", kp.Created.Format(time.RFC3339)),
+	}
+
+	metadataPath := filepath.Join(dir, filename+".yaml")
+	metaData, err := yaml.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	if err := os.WriteFile(metadataPath, metaData, ConfigFileMode); err != nil {
+		return fmt.Errorf("failed to write metadata: %w", err)
+	}
+
+	return nil
+}
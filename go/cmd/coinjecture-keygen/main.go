@@ -57,6 +57,20 @@ type Config struct {
 	JsonOutput   bool
 	Verbose      bool
 	SecureRandom bool
+
+	Mnemonic    bool
+	Restore     string
+	Passphrase  string
+	HDPath      string
+	EntropyBits int
+
+	Encrypt bool
+
+	Backend   string
+	PKCS11Lib string
+	Slot      uint
+	PINEnv    string
+	Label     string
 }
 
 func main() {
@@ -67,6 +81,13 @@ func main() {
 		fmt.Printf("Generating %d Ed25519 keypair(s)...\n\n", config.Count)
 	}
 
+	if config.Backend != backendMemory {
+		if err := runHSMGenerate(config); err != nil {
+			fatal("Failed to generate HSM-backed keypair: %v", err)
+		}
+		return
+	}
+
 	// Ensure output directory exists
 	if !config.NoFiles {
 		if err := os.MkdirAll(config.OutputDir, 0750); err != nil {
@@ -75,24 +96,75 @@ func main() {
 	}
 
 	// Generate keypairs
-	keypairs := make([]*KeyPair, config.Count)
-	for i := 0; i < config.Count; i++ {
-		kp, err := generateKeypair(config.SecureRandom)
+	var keypairs []*KeyPair
+	switch {
+	case config.Restore != "":
+		kp, err := deriveKeypairFromMnemonic(config.Restore, config.Passphrase, config.HDPath)
+		if err != nil {
+			fatal("Failed to restore keypair: %v", err)
+		}
+		keypairs = []*KeyPair{kp}
+
+		if config.Verbose {
+			fmt.Printf("Restored keypair from mnemonic along %s\n", config.HDPath)
+		}
+
+	case config.Mnemonic:
+		mnemonic, err := generateMnemonic(config.EntropyBits)
+		if err != nil {
+			fatal("Failed to generate mnemonic: %v", err)
+		}
+
+		fmt.Println("\nMnemonic (write this down, it will not be shown again):")
+		fmt.Printf("  %s\n\n", mnemonic)
+
+		kp, err := deriveKeypairFromMnemonic(mnemonic, config.Passphrase, config.HDPath)
 		if err != nil {
-			fatal("Failed to generate keypair %d: %v", i+1, err)
+			fatal("Failed to derive keypair from mnemonic: %v", err)
 		}
-		keypairs[i] = kp
+		keypairs = []*KeyPair{kp}
 
 		if config.Verbose {
-			fmt.Printf("Generated keypair %d/%d\n", i+1, config.Count)
+			fmt.Printf("Derived keypair along %s\n", config.HDPath)
+		}
+
+	default:
+		keypairs = make([]*KeyPair, config.Count)
+		for i := 0; i < config.Count; i++ {
+			kp, err := generateKeypair(config.SecureRandom)
+			if err != nil {
+				fatal("Failed to generate keypair %d: %v", i+1, err)
+			}
+			keypairs[i] = kp
+
+			if config.Verbose {
+				fmt.Printf("Generated keypair %d/%d\n", i+1, config.Count)
+			}
 		}
 	}
 
 	// Save keypairs to files
 	if !config.NoFiles {
+		var passphrase string
+		if config.Encrypt {
+			var err error
+			passphrase, err = promptKeystorePassphrase()
+			if err != nil {
+				fatal("Failed to read passphrase: %v", err)
+			}
+			defer secureZero([]byte(passphrase))
+		}
+
 		for i, kp := range keypairs {
 			filename := fmt.Sprintf("%s%d", config.Prefix, i+1)
-			if err := saveKeypair(kp, config.OutputDir, filename); err != nil {
+
+			var err error
+			if config.Encrypt {
+				err = saveEncryptedKeypair(kp, config.OutputDir, filename, passphrase)
+			} else {
+				err = saveKeypair(kp, config.OutputDir, filename)
+			}
+			if err != nil {
 				fatal("Failed to save keypair %d: %v", i+1, err)
 			}
 
@@ -131,6 +203,20 @@ func parseFlags() *Config {
 	flag.BoolVar(&config.Verbose, "verbose", true, "Verbose output")
 	flag.BoolVar(&config.SecureRandom, "secure", true, "Use crypto/rand for secure random generation")
 
+	flag.BoolVar(&config.Mnemonic, "mnemonic", false, "Generate a BIP39 mnemonic and derive the keypair from it (SLIP-0010 Ed25519)")
+	flag.StringVar(&config.Restore, "restore", "", "Restore a keypair from an existing BIP39 mnemonic instead of generating a new one")
+	flag.StringVar(&config.Passphrase, "passphrase", "", "Optional BIP39 passphrase (the mnemonic's \"25th word\")")
+	flag.StringVar(&config.HDPath, "hd-path", defaultHDPath, "SLIP-0010 hardened derivation path, e.g. m/44'/1337'/0'/0'/0'")
+	flag.IntVar(&config.EntropyBits, "entropy-bits", defaultEntropyBits, "Mnemonic entropy in bits: 128 (12 words) or 256 (24 words)")
+
+	flag.BoolVar(&config.Encrypt, "encrypt", false, "Seal private keys at rest in a passphrase-encrypted .keystore.json instead of writing raw hex")
+
+	flag.StringVar(&config.Backend, "backend", backendMemory, "Where the private key lives: memory, pkcs11, or yubikey. Non-memory backends never expose the private key to this process.")
+	flag.StringVar(&config.PKCS11Lib, "pkcs11-lib", "", "Path to the PKCS#11 module (e.g. /usr/lib/softhsm2.so); defaults to the YubiKey ykcs11 module path for -backend yubikey")
+	flag.UintVar(&config.Slot, "slot", 0, "PKCS#11 slot number")
+	flag.StringVar(&config.PINEnv, "pin-env", "", "Name of the environment variable holding the HSM/token PIN (never pass the PIN itself as a flag)")
+	flag.StringVar(&config.Label, "label", "", "CKA_LABEL to generate or look up the key pair under on the token")
+
 	showVersion := flag.Bool("version", false, "Show version and exit")
 	flag.Parse()
 
@@ -146,6 +232,45 @@ func parseFlags() *Config {
 	if config.Count > 100 {
 		fatal("Count cannot exceed 100 (safety limit)")
 	}
+	if config.Restore != "" && config.Mnemonic {
+		fatal("-mnemonic and -restore are mutually exclusive")
+	}
+	if (config.Mnemonic || config.Restore != "") && config.Count != 1 {
+		fatal("-mnemonic and -restore derive exactly one keypair; -count is not supported with them")
+	}
+	if config.Mnemonic && config.EntropyBits != 128 && config.EntropyBits != 256 {
+		fatal("-entropy-bits must be 128 or 256")
+	}
+	if config.Encrypt && config.NoFiles {
+		fatal("-encrypt requires writing files; it cannot be combined with -no-files")
+	}
+
+	if config.Backend != backendMemory {
+		if config.Backend != backendPKCS11 && config.Backend != backendYubiKey {
+			fatal("-backend must be one of: %s, %s, %s", backendMemory, backendPKCS11, backendYubiKey)
+		}
+		if config.Count != 1 {
+			fatal("-backend %s generates exactly one keypair; -count is not supported with it", config.Backend)
+		}
+		if config.Mnemonic || config.Restore != "" {
+			fatal("-backend %s cannot be combined with -mnemonic or -restore: the key never leaves the token", config.Backend)
+		}
+		if config.Encrypt {
+			fatal("-backend %s cannot be combined with -encrypt: there is no private key for this process to seal", config.Backend)
+		}
+		if config.Backend == backendPKCS11 && config.PKCS11Lib == "" {
+			fatal("-pkcs11-lib is required with -backend pkcs11")
+		}
+		if config.Label == "" {
+			fatal("-label is required with -backend %s", config.Backend)
+		}
+		if config.PINEnv == "" {
+			fatal("-pin-env is required with -backend %s", config.Backend)
+		}
+		if os.Getenv(config.PINEnv) == "" {
+			fatal("environment variable %s (set via -pin-env) is empty", config.PINEnv)
+		}
+	}
 
 	return config
 }
@@ -0,0 +1,149 @@
+// COINjecture Validator Key Load Utility
+//
+// Companion to coinjecture-keygen's -encrypt mode: decrypts a
+// "*.keystore.json" file produced by it back into an in-memory Ed25519
+// KeyPair, without ever writing the private key to disk unless -output
+// is explicitly requested.
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/term"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/keystore"
+)
+
+const (
+	// PrivateKeyFileMode matches coinjecture-keygen's, for the rare
+	// -output case that writes the decrypted key back to disk.
+	PrivateKeyFileMode = 0600
+)
+
+// Config represents command-line configuration.
+type Config struct {
+	KeystorePath   string
+	PassphraseFile string
+	Output         string
+	JsonOutput     bool
+}
+
+func main() {
+	config := parseFlags()
+
+	data, err := os.ReadFile(config.KeystorePath)
+	if err != nil {
+		fatal("Failed to read keystore file: %v", err)
+	}
+
+	var sealed keystore.File
+	if err := json.Unmarshal(data, &sealed); err != nil {
+		fatal("Failed to parse keystore file: %v", err)
+	}
+
+	passphrase, err := resolvePassphrase(config.PassphraseFile)
+	if err != nil {
+		fatal("Failed to read passphrase: %v", err)
+	}
+	defer secureZero([]byte(passphrase))
+
+	privateKey, err := keystore.Open(&sealed, passphrase)
+	if err != nil {
+		fatal("Failed to decrypt keystore: %v", err)
+	}
+	defer secureZero(privateKey)
+
+	publicKey := ed25519.PrivateKey(privateKey).Public().(ed25519.PublicKey)
+
+	if config.Output != "" {
+		if err := os.WriteFile(config.Output, []byte(hex.EncodeToString(privateKey)), PrivateKeyFileMode); err != nil {
+			fatal("Failed to write decrypted private key: %v", err)
+		}
+		fmt.Printf("Wrote decrypted private key to %s\n", config.Output)
+	}
+
+	if config.JsonOutput {
+		fmt.Printf("{\"public_key\":\"%s\",\"sealed_at\":\"%s\"}\n", hex.EncodeToString(publicKey), sealed.Created.Format(time.RFC3339))
+		return
+	}
+
+	fmt.Printf("Public Key:  %s\n", hex.EncodeToString(publicKey))
+	fmt.Printf("Sealed At:   %s\n", sealed.Created.Format(time.RFC3339))
+	if config.Output == "" {
+		fmt.Println("Private key decrypted in memory only (pass -output to write it to disk).")
+	}
+}
+
+// parseFlags parses command-line flags.
+func parseFlags() *Config {
+	config := &Config{}
+
+	flag.StringVar(&config.KeystorePath, "file", "", "Path to a .keystore.json file produced by coinjecture-keygen -encrypt")
+	flag.StringVar(&config.PassphraseFile, "passphrase-file", "", "Read the keystore passphrase from this file instead of prompting (e.g. for scripted use)")
+	flag.StringVar(&config.Output, "output", "", "Write the decrypted private key (hex) to this path instead of keeping it in memory only")
+	flag.BoolVar(&config.JsonOutput, "json", false, "Output in JSON format")
+
+	flag.Parse()
+
+	if config.KeystorePath == "" {
+		fatal("-file is required")
+	}
+
+	return config
+}
+
+// resolvePassphrase reads the keystore passphrase from passphraseFile
+// if given, otherwise prompts for it without echoing (falling back to a
+// plain read when stdin isn't a terminal).
+func resolvePassphrase(passphraseFile string) (string, error) {
+	if passphraseFile != "" {
+		data, err := os.ReadFile(passphraseFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read passphrase file: %w", err)
+		}
+		return trimNewline(string(data)), nil
+	}
+
+	fmt.Print("Enter keystore passphrase: ")
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil {
+			return "", fmt.Errorf("failed to read passphrase: %w", err)
+		}
+		return trimNewline(line), nil
+	}
+
+	b, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	return string(b), nil
+}
+
+func trimNewline(s string) string {
+	for len(s) > 0 && (s[len(s)-1] == '\n' || s[len(s)-1] == '\r') {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+// secureZero zeros out sensitive data in memory (defense in depth).
+func secureZero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// fatal prints error and exits with non-zero status.
+func fatal(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "ERROR: "+format+"\n", args...)
+	os.Exit(1)
+}
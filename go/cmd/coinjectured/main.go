@@ -32,7 +32,9 @@ import (
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/metrics"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/p2p"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/signer"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/tokenomics"
 
 	"github.com/spf13/cobra"
 )
@@ -130,6 +132,33 @@ func runDaemon(cmd *cobra.Command, args []string) {
 	defer stateManager.Close()
 	log.Info("State manager initialized")
 
+	// 4a. Genesis document: if configured, load and verify its hash
+	// before applying any allocations, so a tampered or mismatched
+	// genesis file stops the node here rather than silently diverging
+	// from the rest of the network.
+	if cfg.Genesis.Path != "" {
+		genesisDoc, err := tokenomics.LoadGenesisVerified(cfg.Genesis.Path, cfg.Genesis.ExpectedHash)
+		if err != nil {
+			log.WithError(err).Fatal("Failed to load genesis document")
+		}
+
+		allocations, err := genesisDoc.ResolveAllocations()
+		if err != nil {
+			log.WithError(err).Fatal("Failed to resolve genesis allocations")
+		}
+
+		vestingTracker := tokenomics.NewVestingTracker(stateManager, log)
+		if err := tokenomics.ApplyGenesisAllocations(allocations, stateManager, vestingTracker, log); err != nil {
+			log.WithError(err).Fatal("Failed to apply genesis allocations")
+		}
+
+		log.WithFields(logger.Fields{
+			"chain_id":     genesisDoc.ChainID,
+			"genesis_hash": genesisDoc.GenesisHash(),
+			"allocations":  len(allocations),
+		}).Info("Genesis document applied")
+	}
+
 	// 5. IPFS client with pinning quorum
 	ipfsClient, err := ipfs.NewIPFSClient(cfg.IPFS, log)
 	if err != nil {
@@ -187,12 +216,38 @@ func runDaemon(cmd *cobra.Command, args []string) {
 			log.Info("Single validator mode (this node is the only validator)")
 		}
 
+		// Resolve the validator's block-signing backend: an HSM/YubiKey
+		// config (see pkg/signer) if one is configured, otherwise the
+		// in-memory ValidatorKey above. Either way, block signing talks
+		// only to the signer.Signer interface, never to a raw private
+		// key, so an HSM-backed validator never has its key materialize
+		// in this process.
+		var validatorSigner signer.Signer
+		if cfg.Consensus.SignerBackend != "" && cfg.Consensus.SignerBackend != "memory" {
+			pkcs11Cfg := signer.PKCS11Config{
+				LibPath: cfg.Consensus.PKCS11Lib,
+				Slot:    cfg.Consensus.Slot,
+				PIN:     os.Getenv(cfg.Consensus.PINEnv),
+				Label:   cfg.Consensus.Label,
+			}
+			hsmSigner, err := signer.OpenEd25519Key(pkcs11Cfg)
+			if err != nil {
+				log.WithError(err).Fatal("Failed to open HSM-backed validator key")
+			}
+			validatorSigner = hsmSigner
+			log.WithFields(logger.Fields{
+				"backend": cfg.Consensus.SignerBackend,
+				"label":   cfg.Consensus.Label,
+			}).Info("Validator signing key loaded from HSM")
+		}
+
 		// Create consensus config
 		consensusCfg := consensus.ConsensusConfig{
-			BlockTime:    cfg.Consensus.BlockTime,
-			Validators:   validators,
-			ValidatorKey: validatorKey,
-			IsValidator:  true, // Always true in single-node or configured validator
+			BlockTime:       cfg.Consensus.BlockTime,
+			Validators:      validators,
+			ValidatorKey:    validatorKey,
+			ValidatorSigner: validatorSigner, // nil unless an HSM backend is configured; engine falls back to ValidatorKey
+			IsValidator:     true,            // Always true in single-node or configured validator
 		}
 
 		// Initialize consensus engine
@@ -201,15 +256,15 @@ func runDaemon(cmd *cobra.Command, args []string) {
 		// Set block callback for P2P broadcasting
 		consensusEngine.SetNewBlockCallback(func(block *consensus.Block) {
 			log.WithFields(logger.Fields{
-				"block_number": block.BlockNumber,
-				"block_hash":   fmt.Sprintf("%x", block.BlockHash[:8]),
-				"tx_count":     len(block.Transactions),
+				"block_number": block.Number(),
+				"block_hash":   fmt.Sprintf("%x", block.Hash()[:8]),
+				"tx_count":     len(block.Transactions()),
 			}).Info("New block produced, broadcasting to network")
 
 			// Convert and broadcast block via P2P
 			blockMsg := p2p.BlockToP2PMessage(block)
 			if err := p2pManager.BroadcastBlock(blockMsg); err != nil {
-				log.WithError(err).WithField("block_number", block.BlockNumber).Error("Failed to broadcast block")
+				log.WithError(err).WithField("block_number", block.Number()).Error("Failed to broadcast block")
 			}
 		})
 
@@ -226,6 +281,11 @@ func runDaemon(cmd *cobra.Command, args []string) {
 			return nil
 		})
 
+		// Wire the consensus engine as SyncManager's header chain validator
+		// so headers-first catch-up rejects an invalid or equivocating
+		// chain before any block bodies are downloaded for it.
+		p2pManager.SetHeaderValidator(consensusEngine)
+
 		// Start consensus engine
 		if err := consensusEngine.Start(); err != nil {
 			log.WithError(err).Fatal("Failed to start consensus engine")
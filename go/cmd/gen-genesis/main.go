@@ -0,0 +1,126 @@
+// COINjecture Genesis File Generator
+//
+// Emits tokenomics.DefaultGenesisAllocations as a signed genesis document
+// (see pkg/tokenomics.GenesisDoc) so existing deployments that relied on
+// the hardcoded allocation function can migrate to a file-based genesis
+// with a verifiable hash, the same way cosmos-sdk/algorand/lotus nodes
+// ship a genesis.json rather than baking allocations into the binary.
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/tokenomics"
+)
+
+// Config represents command-line configuration.
+type Config struct {
+	Output      string
+	ChainID     string
+	GenesisTime int64
+
+	Team       string
+	Foundation string
+	Backers    string
+	Ecosystem  string
+	Liquidity  string
+	Community  string
+}
+
+func main() {
+	config := parseFlags()
+
+	teamAddr, err := decodeAddress(config.Team)
+	if err != nil {
+		fatal("Invalid -team address: %v", err)
+	}
+	foundationAddr, err := decodeAddress(config.Foundation)
+	if err != nil {
+		fatal("Invalid -foundation address: %v", err)
+	}
+	backersAddr, err := decodeAddress(config.Backers)
+	if err != nil {
+		fatal("Invalid -backers address: %v", err)
+	}
+	ecosystemAddr, err := decodeAddress(config.Ecosystem)
+	if err != nil {
+		fatal("Invalid -ecosystem address: %v", err)
+	}
+	liquidityAddr, err := decodeAddress(config.Liquidity)
+	if err != nil {
+		fatal("Invalid -liquidity address: %v", err)
+	}
+	communityAddr, err := decodeAddress(config.Community)
+	if err != nil {
+		fatal("Invalid -community address: %v", err)
+	}
+
+	allocations := tokenomics.DefaultGenesisAllocations(
+		teamAddr, foundationAddr, backersAddr, ecosystemAddr, liquidityAddr, communityAddr,
+	)
+
+	doc := tokenomics.NewGenesisDoc(config.ChainID, config.GenesisTime, tokenomics.ConsensusParams{
+		BlockTimeSeconds: 2,
+		GasLimit:         30_000_000,
+	}, allocations)
+
+	if err := doc.Save(config.Output); err != nil {
+		fatal("Failed to write genesis file: %v", err)
+	}
+
+	fmt.Printf("Wrote genesis document to %s\n", config.Output)
+	fmt.Printf("Genesis hash: %s\n", doc.GenesisHash())
+	fmt.Println("Configure nodes to verify against this hash before starting.")
+}
+
+// parseFlags parses command-line flags.
+func parseFlags() *Config {
+	config := &Config{}
+
+	flag.StringVar(&config.Output, "output", "genesis.yaml", "Path to write the genesis document to (.json for JSON, otherwise YAML)")
+	flag.StringVar(&config.ChainID, "chain-id", "coinjecture-mainnet-1", "Chain identifier to embed in the genesis document")
+	flag.Int64Var(&config.GenesisTime, "genesis-time", 0, "Genesis time as Unix seconds")
+
+	flag.StringVar(&config.Team, "team", "", "Team & Advisors address (hex, 32 bytes)")
+	flag.StringVar(&config.Foundation, "foundation", "", "Foundation & Treasury address (hex, 32 bytes)")
+	flag.StringVar(&config.Backers, "backers", "", "Early Backers & Investors address (hex, 32 bytes)")
+	flag.StringVar(&config.Ecosystem, "ecosystem", "", "Ecosystem Development address (hex, 32 bytes)")
+	flag.StringVar(&config.Liquidity, "liquidity", "", "Liquidity Pools address (hex, 32 bytes)")
+	flag.StringVar(&config.Community, "community", "", "Community Rewards address (hex, 32 bytes)")
+
+	flag.Parse()
+
+	for name, value := range map[string]string{
+		"team": config.Team, "foundation": config.Foundation, "backers": config.Backers,
+		"ecosystem": config.Ecosystem, "liquidity": config.Liquidity, "community": config.Community,
+	} {
+		if value == "" {
+			fatal("-%s is required", name)
+		}
+	}
+
+	return config
+}
+
+// decodeAddress hex-decodes a 32-byte address.
+func decodeAddress(s string) ([32]byte, error) {
+	var addr [32]byte
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return addr, err
+	}
+	if len(b) != 32 {
+		return addr, fmt.Errorf("expected 32 bytes, got %d", len(b))
+	}
+	copy(addr[:], b)
+	return addr, nil
+}
+
+// fatal prints error and exits with non-zero status.
+func fatal(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "ERROR: "+format+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,117 @@
+// State Transition Tool (state-t8n)
+//
+// Modeled on Ethereum's `evm t8n` / Erigon's ExecuteBlockEphemerally:
+// consumes a JSON prestate (alloc.json: account balances/nonces), an env
+// file (block number, validator, timestamp, base reward), and an ordered
+// list of transactions, replays them ephemerally against an in-memory
+// state.StateManager, and emits the poststate alloc, a result.json of
+// receipts/gas/fee-split, and the computed state root.
+//
+// This lets harnesses like cmd/validate-supply's vector corpus run
+// without a persistent database, and makes it possible to diff a
+// reference implementation against an experimental branch, or replay a
+// historical block, purely from JSON fixtures — t8n.go's TxData has the
+// same legacy fields cmd/validate-supply's TxData uses, so a fixture's
+// transaction list loads in both tools; the fee-cap fields
+// (max_fee_cap/max_priority_fee) are read only by cmd/validate-supply.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+func main() {
+	allocPath := flag.String("alloc", "", "Path to the prestate alloc.json (required)")
+	envPath := flag.String("env", "", "Path to the env.json describing block number/validator/timestamp/base-reward (required)")
+	txsPath := flag.String("txs", "", "Path to the JSON transaction list (required)")
+	outAllocPath := flag.String("output-alloc", "alloc.json", "Path to write the computed poststate alloc to")
+	outResultPath := flag.String("output-result", "result.json", "Path to write the execution result (receipts/gas/fee-split/state-root) to")
+	flag.Parse()
+
+	if *allocPath == "" || *envPath == "" || *txsPath == "" {
+		fatal("-alloc, -env, and -txs are all required")
+	}
+
+	alloc, err := loadAlloc(*allocPath)
+	if err != nil {
+		fatal("failed to load prestate: %v", err)
+	}
+	env, err := loadEnv(*envPath)
+	if err != nil {
+		fatal("failed to load env: %v", err)
+	}
+	txs, err := loadTxs(*txsPath)
+	if err != nil {
+		fatal("failed to load transactions: %v", err)
+	}
+
+	log := logger.NewLogger("error")
+
+	postAlloc, result, err := RunT8n(alloc, env, txs, log)
+	if err != nil {
+		fatal("state transition failed: %v", err)
+	}
+
+	if err := writeJSON(*outAllocPath, postAlloc); err != nil {
+		fatal("failed to write poststate alloc: %v", err)
+	}
+	if err := writeJSON(*outResultPath, result); err != nil {
+		fatal("failed to write result: %v", err)
+	}
+
+	fmt.Printf("Wrote poststate alloc to %s\n", *outAllocPath)
+	fmt.Printf("Wrote result to %s (state root %s)\n", *outResultPath, result.StateRoot)
+}
+
+func loadAlloc(path string) (Alloc, error) {
+	var alloc Alloc
+	if err := readJSON(path, &alloc); err != nil {
+		return nil, err
+	}
+	return alloc, nil
+}
+
+func loadEnv(path string) (Env, error) {
+	var env Env
+	if err := readJSON(path, &env); err != nil {
+		return Env{}, err
+	}
+	return env, nil
+}
+
+func loadTxs(path string) ([]TxData, error) {
+	var txs []TxData
+	if err := readJSON(path, &txs); err != nil {
+		return nil, err
+	}
+	return txs, nil
+}
+
+func readJSON(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return nil
+}
+
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func fatal(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "state-t8n: "+format+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,344 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/consensus"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
+)
+
+// Fee-distribution ratios, matching cmd/validate-supply's Critical
+// Complex Equilibrium split (see tokenomics.UnitCircleNormalization /
+// CriticalConstant for the closed-form derivation this tool's sibling
+// keeps its own copy of the same constants for).
+const (
+	validatorFeeRatio = 0.4142
+	burnFeeRatio      = 0.2929
+	treasuryFeeRatio  = 0.2929
+)
+
+// TxData is the transaction shape, carrying the same legacy fields as
+// cmd/validate-supply's TxData. It has no MaxFeeCap/MaxPriorityFee
+// fee-cap fields: this tool ignores them when a fixture sets them, and
+// treats every transaction's whole Fee as split-able fees.
+type TxData struct {
+	Hash     string `json:"hash"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Amount   uint64 `json:"amount"`
+	Fee      uint64 `json:"fee"`
+	Nonce    uint64 `json:"nonce"`
+	GasLimit uint64 `json:"gas_limit"`
+	GasPrice uint64 `json:"gas_price"`
+	TxType   uint8  `json:"tx_type"`
+}
+
+// AllocEntry is one account's prestate/poststate in an alloc.json file.
+type AllocEntry struct {
+	Balance uint64 `json:"balance"`
+	Nonce   uint64 `json:"nonce"`
+}
+
+// Alloc is a full alloc.json document: hex address (no 0x prefix) ->
+// AllocEntry.
+type Alloc map[string]AllocEntry
+
+// Env is the env.json document: the block-level context transactions
+// execute against.
+type Env struct {
+	BlockNumber uint64 `json:"block_number"`
+	Validator   string `json:"validator"` // hex address, block producer
+	Timestamp   int64  `json:"timestamp"`
+	BaseReward  uint64 `json:"base_reward"` // new-emission block reward, in wei
+}
+
+// FeeSplit is the 41.42/29.29/29.29 validator/burn/treasury breakdown of
+// a block's total transaction fees.
+type FeeSplit struct {
+	ValidatorFee uint64 `json:"validator_fee"`
+	BurnFee      uint64 `json:"burn_fee"`
+	TreasuryFee  uint64 `json:"treasury_fee"`
+}
+
+// ReceiptJSON is consensus.Receipt's JSON form: its [32]byte fields
+// don't marshal usefully as raw arrays, so hashes/roots are hex-encoded.
+type ReceiptJSON struct {
+	TxHash            string `json:"tx_hash"`
+	Status            string `json:"status"`
+	GasUsed           uint64 `json:"gas_used"`
+	CumulativeGasUsed uint64 `json:"cumulative_gas_used"`
+	PostStateRoot     string `json:"post_state_root"`
+}
+
+// Result is the result.json document: per-transaction receipts plus the
+// block-level gas/fee/state-root summary.
+type Result struct {
+	Receipts        []ReceiptJSON `json:"receipts"`
+	GasUsed         uint64        `json:"gas_used"`
+	TotalFees       uint64        `json:"total_fees"`
+	FeeSplit        FeeSplit      `json:"fee_split"`
+	ValidatorReward uint64        `json:"validator_reward"` // base_reward + fee_split.validator_fee
+	StateRoot       string        `json:"state_root"`
+}
+
+// RunT8n seeds alloc into a fresh in-memory state.StateManager, applies
+// txs in order (each getting its own receipt with a post-transaction
+// state root, the same per-tx granularity consensus.Receipt supports),
+// credits env.Validator with env.BaseReward plus its fee share, and
+// returns the poststate alloc and a Result.
+func RunT8n(alloc Alloc, env Env, txs []TxData, log *logger.Logger) (Alloc, *Result, error) {
+	sm, err := state.NewStateManager(":memory:", log)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create in-memory state manager: %w", err)
+	}
+	defer sm.Close()
+
+	if err := seedAlloc(sm, alloc); err != nil {
+		return nil, nil, fmt.Errorf("failed to seed prestate: %w", err)
+	}
+
+	validator, err := decodeAddress(env.Validator)
+	if err != nil {
+		return nil, nil, fmt.Errorf("env: validator: %w", err)
+	}
+
+	backend := consensus.DefaultBackend()
+
+	var receipts []ReceiptJSON
+	var cumulativeGas, totalFees uint64
+
+	for _, tx := range txs {
+		status, gasUsed, err := applyTx(sm, tx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("tx %q: %w", tx.Hash, err)
+		}
+		cumulativeGas += gasUsed
+		if status == consensus.ReceiptStatusSuccess {
+			totalFees += tx.Fee
+		}
+
+		postRoot, err := computeStateRoot(sm, backend)
+		if err != nil {
+			return nil, nil, fmt.Errorf("tx %q: %w", tx.Hash, err)
+		}
+
+		receipts = append(receipts, ReceiptJSON{
+			TxHash:            tx.Hash,
+			Status:            receiptStatusName(status),
+			GasUsed:           gasUsed,
+			CumulativeGasUsed: cumulativeGas,
+			PostStateRoot:     hex.EncodeToString(postRoot[:]),
+		})
+	}
+
+	feeSplit := FeeSplit{
+		ValidatorFee: uint64(float64(totalFees) * validatorFeeRatio),
+		BurnFee:      uint64(float64(totalFees) * burnFeeRatio),
+		TreasuryFee:  uint64(float64(totalFees) * treasuryFeeRatio),
+	}
+	validatorReward := env.BaseReward + feeSplit.ValidatorFee
+
+	if err := mintReward(sm, validator, validatorReward, feeSplit.BurnFee, feeSplit.TreasuryFee); err != nil {
+		return nil, nil, fmt.Errorf("failed to distribute block reward: %w", err)
+	}
+
+	stateRoot, err := computeStateRoot(sm, backend)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to compute final state root: %w", err)
+	}
+
+	postAlloc, err := snapshotAlloc(sm)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to snapshot poststate: %w", err)
+	}
+
+	return postAlloc, &Result{
+		Receipts:        receipts,
+		GasUsed:         cumulativeGas,
+		TotalFees:       totalFees,
+		FeeSplit:        feeSplit,
+		ValidatorReward: validatorReward,
+		StateRoot:       hex.EncodeToString(stateRoot[:]),
+	}, nil
+}
+
+// seedAlloc writes alloc's accounts directly into state, in address
+// order so equal fixtures always seed in the same sequence.
+func seedAlloc(sm *state.StateManager, alloc Alloc) error {
+	addrs := make([]string, 0, len(alloc))
+	for addr := range alloc {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+
+	for _, hexAddr := range addrs {
+		addr, err := decodeAddress(hexAddr)
+		if err != nil {
+			return fmt.Errorf("account %q: %w", hexAddr, err)
+		}
+		entry := alloc[hexAddr]
+		if err := sm.UpdateAccount(addr, entry.Balance, entry.Nonce); err != nil {
+			return fmt.Errorf("account %q: %w", hexAddr, err)
+		}
+	}
+	return nil
+}
+
+// snapshotAlloc reads every account currently in sm back out as an
+// Alloc — the inverse of seedAlloc, used to produce the poststate
+// alloc.json.
+func snapshotAlloc(sm *state.StateManager) (Alloc, error) {
+	snapshot, err := sm.GetAccountSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account snapshot: %w", err)
+	}
+
+	alloc := make(Alloc, len(snapshot))
+	for addr, account := range snapshot {
+		alloc[hex.EncodeToString(addr[:])] = AllocEntry{Balance: account.Balance, Nonce: account.Nonce}
+	}
+	return alloc, nil
+}
+
+// applyTx applies a single transaction to sm, mirroring
+// cmd/validate-supply's applyVectorBlock but per-transaction so every tx
+// gets its own receipt. A transaction that can't afford amount+fee fails
+// (ReceiptStatusFailed, charged its full GasLimit) rather than aborting
+// the run, matching evm t8n's per-tx failure semantics.
+func applyTx(sm *state.StateManager, tx TxData) (consensus.ReceiptStatus, uint64, error) {
+	from, err := decodeAddress(tx.From)
+	if err != nil {
+		return 0, 0, fmt.Errorf("from: %w", err)
+	}
+	to, err := decodeAddress(tx.To)
+	if err != nil {
+		return 0, 0, fmt.Errorf("to: %w", err)
+	}
+
+	sender, err := sm.GetAccount(from)
+	if err != nil {
+		return 0, 0, fmt.Errorf("sender account: %w", err)
+	}
+
+	totalCost := tx.Amount + tx.Fee
+	if sender.Balance < totalCost {
+		return consensus.ReceiptStatusFailed, tx.GasLimit, nil
+	}
+
+	recipient, err := sm.GetAccount(to)
+	if err != nil {
+		recipient = &state.Account{Address: to}
+	}
+
+	sender.Balance -= totalCost
+	sender.Nonce++
+	recipient.Balance += tx.Amount
+
+	if err := sm.UpdateAccount(sender.Address, sender.Balance, sender.Nonce); err != nil {
+		return 0, 0, fmt.Errorf("update sender: %w", err)
+	}
+	if err := sm.UpdateAccount(recipient.Address, recipient.Balance, recipient.Nonce); err != nil {
+		return 0, 0, fmt.Errorf("update recipient: %w", err)
+	}
+
+	return consensus.ReceiptStatusSuccess, tx.GasLimit, nil
+}
+
+// mintReward pays the validator's block reward plus fee share, burns
+// burnFee to the all-zero address, and pays treasuryFee to the all-0xFF
+// address — the same special addresses cmd/validate-supply's vector
+// driver uses, so a fixture replayed through both tools lands on the
+// same poststate.
+func mintReward(sm *state.StateManager, validator [32]byte, validatorAmount, burnFee, treasuryFee uint64) error {
+	if err := mintAccount(sm, validator, validatorAmount); err != nil {
+		return fmt.Errorf("validator reward: %w", err)
+	}
+	if burnFee > 0 {
+		if err := mintAccount(sm, [32]byte{}, burnFee); err != nil {
+			return fmt.Errorf("fee burn: %w", err)
+		}
+	}
+	if treasuryFee > 0 {
+		if err := mintAccount(sm, treasuryAddress(), treasuryFee); err != nil {
+			return fmt.Errorf("treasury allocation: %w", err)
+		}
+	}
+	return nil
+}
+
+func mintAccount(sm *state.StateManager, addr [32]byte, amount uint64) error {
+	account, err := sm.GetAccount(addr)
+	if err != nil {
+		account = &state.Account{Address: addr}
+	}
+	return sm.UpdateAccount(addr, account.Balance+amount, account.Nonce)
+}
+
+func treasuryAddress() [32]byte {
+	var addr [32]byte
+	for i := range addr {
+		addr[i] = 0xFF
+	}
+	return addr
+}
+
+// computeStateRoot hashes every account currently in sm (address,
+// balance, nonce, in address order for determinism) into a leaf and
+// combines them via backend's Merkle tree — the same account-hash
+// construction pkg/consensus's ComputeStateRoot exercises, applied here
+// to this tool's own ephemeral state rather than a fixed test fixture.
+func computeStateRoot(sm *state.StateManager, backend consensus.CryptoBackend) ([32]byte, error) {
+	snapshot, err := sm.GetAccountSnapshot()
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("failed to snapshot state: %w", err)
+	}
+
+	addrs := make([][32]byte, 0, len(snapshot))
+	for addr := range snapshot {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return bytes.Compare(addrs[i][:], addrs[j][:]) < 0 })
+
+	leaves := make([][32]byte, len(addrs))
+	for i, addr := range addrs {
+		account := snapshot[addr]
+		buf := make([]byte, 0, 32+8+8)
+		buf = append(buf, addr[:]...)
+		buf = appendUint64(buf, account.Balance)
+		buf = appendUint64(buf, account.Nonce)
+		leaves[i] = backend.SHA256(buf)
+	}
+
+	return backend.MerkleRoot(leaves), nil
+}
+
+func appendUint64(buf []byte, v uint64) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	return append(buf, b[:]...)
+}
+
+func decodeAddress(s string) ([32]byte, error) {
+	var addr [32]byte
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return addr, err
+	}
+	if len(b) != 32 {
+		return addr, fmt.Errorf("expected 32 bytes, got %d", len(b))
+	}
+	copy(addr[:], b)
+	return addr, nil
+}
+
+func receiptStatusName(status consensus.ReceiptStatus) string {
+	if status == consensus.ReceiptStatusSuccess {
+		return "success"
+	}
+	return "failed"
+}
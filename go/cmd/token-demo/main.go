@@ -19,6 +19,7 @@ import (
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/tokenomics"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/tokenomics/vesting"
 )
 
 func main() {
@@ -227,6 +228,57 @@ func main() {
 
 	log.Info("")
 
+	// ==================== DEMO 8: Vesting Schedules ====================
+
+	log.Info("🔒 DEMO 8: Vesting Schedules")
+	log.Info("─────────────────────────────────────────────────────")
+
+	vestingLedger := vesting.NewLedger(stateManager, log)
+	teamMember := generateDemoAddress("TEAM_MEMBER")
+
+	schedule := vesting.Schedule{
+		ID:          "team-member-1",
+		Beneficiary: teamMember,
+		Total:       tokenomics.WeiPerCoin * 1000, // 1000 $BEANS
+		Cliff:       20,
+		Duration:    100,
+		StartBlock:  0,
+		Revocable:   true,
+	}
+	if err := vestingLedger.CreateSchedule(schedule); err != nil {
+		log.WithError(err).Fatal("Failed to create vesting schedule")
+	}
+
+	locked, err := vestingLedger.LockedSupply()
+	if err != nil {
+		log.WithError(err).Error("Failed to get locked supply")
+	} else {
+		log.WithFields(logger.Fields{
+			"schedule_id": schedule.ID,
+			"beneficiary": fmt.Sprintf("%x", teamMember[:8]),
+			"total":       tokenomics.FormatCoinAmount(schedule.Total),
+			"cliff":       schedule.Cliff,
+			"duration":    schedule.Duration,
+			"locked":      tokenomics.FormatCoinAmount(locked),
+		}).Info("Vesting schedule created")
+	}
+
+	// Release whatever's claimable at a few checkpoints, the first of them
+	// before the cliff (so it releases nothing).
+	for _, atBlock := range []uint64{10, 30, 60, 100} {
+		released, err := vestingLedger.Release(schedule.ID, atBlock)
+		if err != nil {
+			log.WithError(err).Error("Failed to release vested tokens")
+			continue
+		}
+		log.WithFields(logger.Fields{
+			"at_block": atBlock,
+			"released": tokenomics.FormatCoinAmount(released),
+		}).Info("Vesting release checkpoint")
+	}
+
+	log.Info("")
+
 	// ==================== SUMMARY ====================
 
 	log.Info("═══════════════════════════════════════════════════════")
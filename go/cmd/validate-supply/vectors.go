@@ -0,0 +1,639 @@
+// Test-vector driver for supply-dynamics conformance.
+//
+// A vector is a portable JSON fixture describing a synthetic initial
+// account snapshot, an ordered stream of blocks/transactions, and the
+// expected final balances and fee-distribution ratios. Feeding a vector
+// through runVector exercises the same state.StateManager +
+// fee-distribution path the live-database analysis above uses, so a
+// vector failure means this tool's behavior has drifted from the
+// reference implementation it was generated against — the same role
+// Filecoin's test-vectors submodule plays for its implementations.
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/tokenomics"
+)
+
+// CorpusReport is the top-level machine-readable result of a -vector /
+// -vectors-dir run, written to -report (or stdout) for CI to parse.
+type CorpusReport struct {
+	Passed  bool            `json:"passed"`
+	Vectors []*VectorReport `json:"vectors"`
+}
+
+// runVectorCorpus runs every vector named by vectorPath/vectorsDir,
+// writes the combined report to reportPath (or stdout if empty), and
+// exits non-zero if any vector failed — the shape a CI step expects.
+func runVectorCorpus(vectorPath, vectorsDir, reportPath string) {
+	log := logger.NewLogger("error")
+
+	paths, err := loadVectorPaths(vectorPath, vectorsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "validate-supply: %v\n", err)
+		os.Exit(1)
+	}
+
+	corpus := &CorpusReport{Passed: true}
+	for _, path := range paths {
+		dbPath := path + ".vector-scratch.db"
+		os.Remove(dbPath)
+
+		report, err := runVector(path, dbPath, log)
+		os.Remove(dbPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "validate-supply: %s: %v\n", path, err)
+			os.Exit(1)
+		}
+
+		corpus.Vectors = append(corpus.Vectors, report)
+		if !report.Passed {
+			corpus.Passed = false
+		}
+	}
+
+	out, err := json.MarshalIndent(corpus, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "validate-supply: failed to encode report: %v\n", err)
+		os.Exit(1)
+	}
+
+	if reportPath != "" {
+		if err := os.WriteFile(reportPath, out, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "validate-supply: failed to write report: %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		fmt.Println(string(out))
+	}
+
+	if !corpus.Passed {
+		os.Exit(1)
+	}
+}
+
+// ratioTolerance mirrors the +/-0.02 percentage-point band the live
+// analysis above uses for the 41.42/29.29/29.29 split.
+const ratioTolerance = 0.02
+
+// VectorAccount seeds one account in the scratch state manager before
+// any vector blocks are applied.
+type VectorAccount struct {
+	Address string `json:"address"`
+	Balance uint64 `json:"balance"`
+	Nonce   uint64 `json:"nonce"`
+}
+
+// VectorBlock is one block's worth of transactions to apply, in order.
+//
+// Signers lists every validator co-signing the block; when set (more
+// than one entry), the validator reward is split across them by
+// Vector.PowerTable's stake-weighted shares instead of paid entirely to
+// Validator. When empty, Validator alone receives the full reward, the
+// pre-power-table behavior.
+type VectorBlock struct {
+	Number       uint64   `json:"number"`
+	Validator    string   `json:"validator"`
+	Signers      []string `json:"signers,omitempty"`
+	Transactions []TxData `json:"transactions"`
+}
+
+// VectorValidatorPower is one entry of Vector.PowerTable: the stake
+// snapshot a vector asserts reward splits against, mirroring
+// tokenomics.Validator's fields a vector actually needs.
+type VectorValidatorPower struct {
+	Address              string `json:"address"`
+	QualityAdjustedPower uint64 `json:"quality_adjusted_power"`
+	Active               bool   `json:"active"`
+}
+
+// VectorExpected is the outcome a vector asserts after every block has
+// been applied.
+//
+// ValidatorPercent/BurnPercent/TreasuryPercent are ratios of the
+// priority-tip split only (post base-fee-burn); BaseFeeBurn is checked
+// as an independent absolute amount, since it's produced by the base-fee
+// market controller rather than a fixed ratio and a vector with no
+// MaxFeeCap-bearing transactions expects it to be exactly 0.
+type VectorExpected struct {
+	FinalBalances    map[string]uint64 `json:"final_balances"`
+	TotalSupply      uint64            `json:"total_supply"`
+	ValidatorPercent float64           `json:"validator_percent"`
+	BurnPercent      float64           `json:"burn_percent"`
+	TreasuryPercent  float64           `json:"treasury_percent"`
+	BaseFeeBurn      uint64            `json:"base_fee_burn"`
+}
+
+// VectorCoin asserts registry.Registry's per-coin invariant
+// (emission + fees_in - burns == circulating) for one registered
+// coin_id other than $BEANS: Emission/FeesIn/Burns are the fixture's
+// claimed lifetime totals for that coin, and ExpectedCirculating is what
+// every account's balance under that coin_id should sum to once the
+// vector's blocks have run. $BEANS itself is still validated by
+// checkVectorSupply/checkVectorBalances above; Coins only covers assets
+// registry.Registry has issued.
+type VectorCoin struct {
+	CoinID              string `json:"coin_id"`
+	Emission            uint64 `json:"emission"`
+	FeesIn              uint64 `json:"fees_in"`
+	Burns               uint64 `json:"burns"`
+	ExpectedCirculating uint64 `json:"expected_circulating"`
+}
+
+// Vector is a single conformance fixture: an initial snapshot, a block
+// stream, and the expected result of applying it.
+//
+// PowerTable is an optional validator-power snapshot, used for the
+// lifetime of the vector, that VectorBlock.Signers draws from to split a
+// block's validator reward across co-signers; a vector with no
+// PowerTable pays Validator the whole reward as before.
+type Vector struct {
+	Name            string                 `json:"name"`
+	InitialAccounts []VectorAccount        `json:"initial_accounts"`
+	PowerTable      []VectorValidatorPower `json:"power_table,omitempty"`
+	Blocks          []VectorBlock          `json:"blocks"`
+	Expected        VectorExpected         `json:"expected"`
+	Coins           []VectorCoin           `json:"coins,omitempty"`
+}
+
+// VectorCheck is one pass/fail assertion within a vector's report.
+type VectorCheck struct {
+	Name     string `json:"name"`
+	Passed   bool   `json:"passed"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// VectorReport is the machine-readable result of running one vector,
+// suitable for a CI step to parse without re-deriving pass/fail itself.
+type VectorReport struct {
+	Vector string        `json:"vector"`
+	Path   string        `json:"path"`
+	Passed bool          `json:"passed"`
+	Checks []VectorCheck `json:"checks"`
+	Error  string        `json:"error,omitempty"`
+}
+
+// runVector loads the vector at path, replays it against a fresh
+// state.StateManager at dbPath, and diffs the resulting snapshot against
+// the vector's expectations. It only returns a non-nil error for
+// problems outside the vector itself (can't open the scratch database);
+// a malformed or failing vector is reported via VectorReport.Error /
+// VectorReport.Passed instead, so one bad fixture doesn't abort a CI run
+// over the whole corpus.
+func runVector(path, dbPath string, log *logger.Logger) (*VectorReport, error) {
+	report := &VectorReport{Vector: filepath.Base(path), Path: path}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		report.Error = fmt.Sprintf("failed to read vector: %v", err)
+		return report, nil
+	}
+
+	var vec Vector
+	if err := json.Unmarshal(raw, &vec); err != nil {
+		report.Error = fmt.Sprintf("failed to parse vector: %v", err)
+		return report, nil
+	}
+	if vec.Name != "" {
+		report.Vector = vec.Name
+	}
+
+	stateManager, err := state.NewStateManager(dbPath, log)
+	if err != nil {
+		return report, fmt.Errorf("failed to create scratch state manager: %w", err)
+	}
+	defer stateManager.Close()
+
+	if err := seedVectorAccounts(stateManager, vec.InitialAccounts); err != nil {
+		report.Error = fmt.Sprintf("failed to seed initial accounts: %v", err)
+		return report, nil
+	}
+
+	baseFeeCfg := tokenomics.DefaultBaseFeeConfig()
+	baseFee := baseFeeCfg.InitialBaseFee
+	var parentGasUsed uint64
+
+	powerTable, err := buildVectorPowerTable(vec.PowerTable)
+	if err != nil {
+		report.Error = fmt.Sprintf("power table: %v", err)
+		return report, nil
+	}
+
+	var totalFees, totalValidatorFees, totalBurnFees, totalTreasuryFees, totalBaseFeeBurn uint64
+	for i, block := range vec.Blocks {
+		if i > 0 {
+			baseFee = tokenomics.ComputeNextBaseFee(baseFeeCfg, baseFee, parentGasUsed)
+		}
+
+		validator, err := decodeVectorAddress(block.Validator)
+		if err != nil {
+			report.Error = fmt.Sprintf("block %d: validator: %v", block.Number, err)
+			return report, nil
+		}
+
+		blockTips, blockBaseFeeBurn, blockGasUsed, err := applyVectorBlock(stateManager, block, baseFee)
+		if err != nil {
+			report.Error = fmt.Sprintf("block %d: %v", block.Number, err)
+			return report, nil
+		}
+		parentGasUsed = blockGasUsed
+
+		validatorFee := uint64(float64(blockTips) * 0.4142)
+		burnFee := uint64(float64(blockTips) * 0.2929)
+		treasuryFee := uint64(float64(blockTips) * 0.2929)
+
+		if len(block.Signers) > 1 && powerTable != nil {
+			if err := mintVectorRewardMultiValidator(stateManager, block.Signers, powerTable, BlockReward+validatorFee, burnFee+blockBaseFeeBurn, treasuryFee); err != nil {
+				report.Error = fmt.Sprintf("block %d: failed to distribute power-weighted rewards: %v", block.Number, err)
+				return report, nil
+			}
+		} else if err := mintVectorReward(stateManager, validator, BlockReward+validatorFee, burnFee+blockBaseFeeBurn, treasuryFee); err != nil {
+			report.Error = fmt.Sprintf("block %d: failed to distribute rewards: %v", block.Number, err)
+			return report, nil
+		}
+
+		totalFees += blockTips
+		totalValidatorFees += validatorFee
+		totalBurnFees += burnFee
+		totalTreasuryFees += treasuryFee
+		totalBaseFeeBurn += blockBaseFeeBurn
+	}
+
+	snapshot, err := stateManager.GetAccountSnapshot()
+	if err != nil {
+		return report, fmt.Errorf("failed to get account snapshot: %w", err)
+	}
+
+	report.Checks = checkVectorBalances(vec.Expected.FinalBalances, snapshot)
+	report.Checks = append(report.Checks, checkVectorSupply(vec.Expected.TotalSupply, snapshot))
+	if totalFees > 0 {
+		report.Checks = append(report.Checks, checkVectorRatios(vec.Expected, totalValidatorFees, totalBurnFees, totalTreasuryFees, totalFees)...)
+	}
+	report.Checks = append(report.Checks, checkVectorBaseFeeBurn(vec.Expected.BaseFeeBurn, totalBaseFeeBurn))
+
+	for _, coin := range vec.Coins {
+		coinSnapshot, err := stateManager.GetAccountSnapshotForCoin(coin.CoinID)
+		if err != nil {
+			report.Error = fmt.Sprintf("coin %s: failed to get account snapshot: %v", coin.CoinID, err)
+			return report, nil
+		}
+		report.Checks = append(report.Checks, checkVectorCoinSupply(coin, coinSnapshot)...)
+	}
+
+	report.Passed = true
+	for _, c := range report.Checks {
+		if !c.Passed {
+			report.Passed = false
+			break
+		}
+	}
+
+	return report, nil
+}
+
+// seedVectorAccounts writes each initial account directly into state,
+// the same way mintToAccount/applyTransaction elsewhere create accounts
+// that don't exist yet: UpdateAccount is the only write path.
+func seedVectorAccounts(sm *state.StateManager, accounts []VectorAccount) error {
+	for _, a := range accounts {
+		addr, err := decodeVectorAddress(a.Address)
+		if err != nil {
+			return fmt.Errorf("account %q: %w", a.Address, err)
+		}
+		if err := sm.UpdateAccount(addr, a.Balance, a.Nonce); err != nil {
+			return fmt.Errorf("account %q: %w", a.Address, err)
+		}
+	}
+	return nil
+}
+
+// applyVectorBlock applies every transaction in block to state, mirroring
+// BlockProcessor.applyTransaction, and returns the block's total priority
+// tips, its total base-fee burn (both split out of tx.Fee via
+// txBaseFeeBurn at the given baseFee), and its total gas used, which the
+// caller feeds into tokenomics.ComputeNextBaseFee for the next block.
+func applyVectorBlock(sm *state.StateManager, block VectorBlock, baseFee uint64) (tips, baseFeeBurn, gasUsed uint64, err error) {
+	for _, tx := range block.Transactions {
+		from, err := decodeVectorAddress(tx.From)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("tx %q: from: %w", tx.Hash, err)
+		}
+		to, err := decodeVectorAddress(tx.To)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("tx %q: to: %w", tx.Hash, err)
+		}
+
+		sender, err := sm.GetAccount(from)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("tx %q: sender account: %w", tx.Hash, err)
+		}
+
+		recipient, err := sm.GetAccount(to)
+		if err != nil {
+			recipient = &state.Account{Address: to}
+		}
+
+		totalCost := tx.Amount + tx.Fee
+		if sender.Balance < totalCost {
+			return 0, 0, 0, fmt.Errorf("tx %q: insufficient balance: need %d, have %d", tx.Hash, totalCost, sender.Balance)
+		}
+
+		sender.Balance -= totalCost
+		sender.Nonce++
+		recipient.Balance += tx.Amount
+
+		if err := sm.UpdateAccount(sender.Address, sender.Balance, sender.Nonce); err != nil {
+			return 0, 0, 0, fmt.Errorf("tx %q: update sender: %w", tx.Hash, err)
+		}
+		if err := sm.UpdateAccount(recipient.Address, recipient.Balance, recipient.Nonce); err != nil {
+			return 0, 0, 0, fmt.Errorf("tx %q: update recipient: %w", tx.Hash, err)
+		}
+
+		burn, tip := txBaseFeeBurn(tx, baseFee)
+		baseFeeBurn += burn
+		tips += tip
+		gasUsed += tx.GasLimit
+	}
+
+	return tips, baseFeeBurn, gasUsed, nil
+}
+
+// mintVectorReward pays a block's validator reward plus fee share, burns
+// burnFee to the tool's burn address, and pays treasuryFee to its
+// treasury address, mirroring the 0xFF.../0x00... special addresses used
+// by the live-database analysis above.
+func mintVectorReward(sm *state.StateManager, validator [32]byte, validatorAmount, burnFee, treasuryFee uint64) error {
+	var treasuryAddr, burnAddr [32]byte
+	for i := 0; i < 32; i++ {
+		treasuryAddr[i] = 0xFF
+		burnAddr[i] = 0x00
+	}
+
+	if err := mintVectorAccount(sm, validator, validatorAmount); err != nil {
+		return fmt.Errorf("validator reward: %w", err)
+	}
+	if burnFee > 0 {
+		if err := mintVectorAccount(sm, burnAddr, burnFee); err != nil {
+			return fmt.Errorf("fee burn: %w", err)
+		}
+	}
+	if treasuryFee > 0 {
+		if err := mintVectorAccount(sm, treasuryAddr, treasuryFee); err != nil {
+			return fmt.Errorf("treasury allocation: %w", err)
+		}
+	}
+	return nil
+}
+
+// buildVectorPowerTable decodes powerVec into a tokenomics.PowerTable, or
+// returns nil if powerVec is empty — the "no power table" case where
+// every block pays its Validator the full reward.
+func buildVectorPowerTable(powerVec []VectorValidatorPower) (*tokenomics.PowerTable, error) {
+	if len(powerVec) == 0 {
+		return nil, nil
+	}
+
+	validators := make([]tokenomics.Validator, 0, len(powerVec))
+	for _, v := range powerVec {
+		addr, err := decodeVectorAddress(v.Address)
+		if err != nil {
+			return nil, fmt.Errorf("validator %q: %w", v.Address, err)
+		}
+		validators = append(validators, tokenomics.Validator{
+			Address:              addr,
+			QualityAdjustedPower: v.QualityAdjustedPower,
+			Active:               v.Active,
+		})
+	}
+	return tokenomics.NewPowerTable(validators), nil
+}
+
+// mintVectorRewardMultiValidator is mintVectorReward's power-weighted
+// counterpart for a block with more than one signer: validatorAmount is
+// split across signers by pt's power shares (restricted to just this
+// block's signers, so a validator absent from signers earns nothing for
+// a block it didn't co-sign), while burn and treasury are unaffected by
+// how many validators signed.
+func mintVectorRewardMultiValidator(sm *state.StateManager, signerHex []string, pt *tokenomics.PowerTable, validatorAmount, burnFee, treasuryFee uint64) error {
+	var treasuryAddr, burnAddr [32]byte
+	for i := 0; i < 32; i++ {
+		treasuryAddr[i] = 0xFF
+		burnAddr[i] = 0x00
+	}
+
+	signing := make([]tokenomics.Validator, 0, len(signerHex))
+	for _, hexAddr := range signerHex {
+		addr, err := decodeVectorAddress(hexAddr)
+		if err != nil {
+			return fmt.Errorf("signer %q: %w", hexAddr, err)
+		}
+		for _, v := range pt.Validators() {
+			if v.Address == addr {
+				signing = append(signing, v)
+				break
+			}
+		}
+	}
+
+	shares := tokenomics.NewPowerTable(signing).Split(validatorAmount)
+	for _, signer := range signing {
+		amount := shares[signer.Address]
+		if amount == 0 {
+			continue
+		}
+		if err := mintVectorAccount(sm, signer.Address, amount); err != nil {
+			return fmt.Errorf("validator reward: %w", err)
+		}
+	}
+
+	if burnFee > 0 {
+		if err := mintVectorAccount(sm, burnAddr, burnFee); err != nil {
+			return fmt.Errorf("fee burn: %w", err)
+		}
+	}
+	if treasuryFee > 0 {
+		if err := mintVectorAccount(sm, treasuryAddr, treasuryFee); err != nil {
+			return fmt.Errorf("treasury allocation: %w", err)
+		}
+	}
+	return nil
+}
+
+func mintVectorAccount(sm *state.StateManager, address [32]byte, amount uint64) error {
+	account, err := sm.GetAccount(address)
+	if err != nil {
+		account = &state.Account{Address: address}
+	}
+	return sm.UpdateAccount(address, account.Balance+amount, account.Nonce)
+}
+
+func decodeVectorAddress(hexAddr string) ([32]byte, error) {
+	var addr [32]byte
+	data, err := hex.DecodeString(hexAddr)
+	if err != nil {
+		return addr, err
+	}
+	if len(data) != 32 {
+		return addr, fmt.Errorf("expected 32 bytes, got %d", len(data))
+	}
+	copy(addr[:], data)
+	return addr, nil
+}
+
+func checkVectorBalances(expected map[string]uint64, snapshot map[[32]byte]*state.Account) []VectorCheck {
+	addrs := make([]string, 0, len(expected))
+	for addr := range expected {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+
+	checks := make([]VectorCheck, 0, len(addrs))
+	for _, hexAddr := range addrs {
+		want := expected[hexAddr]
+		addr, err := decodeVectorAddress(hexAddr)
+		if err != nil {
+			checks = append(checks, VectorCheck{
+				Name:     fmt.Sprintf("final_balance[%s]", hexAddr),
+				Passed:   false,
+				Expected: fmt.Sprintf("%d", want),
+				Actual:   fmt.Sprintf("invalid address: %v", err),
+			})
+			continue
+		}
+
+		var got uint64
+		if account, ok := snapshot[addr]; ok {
+			got = account.Balance
+		}
+
+		checks = append(checks, VectorCheck{
+			Name:     fmt.Sprintf("final_balance[%s]", hexAddr),
+			Passed:   got == want,
+			Expected: fmt.Sprintf("%d", want),
+			Actual:   fmt.Sprintf("%d", got),
+		})
+	}
+	return checks
+}
+
+func checkVectorSupply(expected uint64, snapshot map[[32]byte]*state.Account) VectorCheck {
+	var actual uint64
+	for _, account := range snapshot {
+		actual += account.Balance
+	}
+	return VectorCheck{
+		Name:     "total_supply",
+		Passed:   actual == expected,
+		Expected: fmt.Sprintf("%d", expected),
+		Actual:   fmt.Sprintf("%d", actual),
+	}
+}
+
+func checkVectorRatios(expected VectorExpected, validatorFees, burnFees, treasuryFees, totalFees uint64) []VectorCheck {
+	validatorPercent := float64(validatorFees) / float64(totalFees) * 100
+	burnPercent := float64(burnFees) / float64(totalFees) * 100
+	treasuryPercent := float64(treasuryFees) / float64(totalFees) * 100
+
+	return []VectorCheck{
+		{
+			Name:     "validator_percent",
+			Passed:   withinTolerance(validatorPercent, expected.ValidatorPercent, ratioTolerance),
+			Expected: fmt.Sprintf("%.2f%%", expected.ValidatorPercent),
+			Actual:   fmt.Sprintf("%.2f%%", validatorPercent),
+		},
+		{
+			Name:     "burn_percent",
+			Passed:   withinTolerance(burnPercent, expected.BurnPercent, ratioTolerance),
+			Expected: fmt.Sprintf("%.2f%%", expected.BurnPercent),
+			Actual:   fmt.Sprintf("%.2f%%", burnPercent),
+		},
+		{
+			Name:     "treasury_percent",
+			Passed:   withinTolerance(treasuryPercent, expected.TreasuryPercent, ratioTolerance),
+			Expected: fmt.Sprintf("%.2f%%", expected.TreasuryPercent),
+			Actual:   fmt.Sprintf("%.2f%%", treasuryPercent),
+		},
+	}
+}
+
+// checkVectorBaseFeeBurn verifies the base-fee burn stream independently
+// of the tip-split ratio checks above: it's an exact amount (the market
+// controller, not a fixed percentage of tips), so a vector with no
+// MaxFeeCap-bearing transactions expects exactly 0.
+func checkVectorBaseFeeBurn(expected, actual uint64) VectorCheck {
+	return VectorCheck{
+		Name:     "base_fee_burn",
+		Passed:   actual == expected,
+		Expected: fmt.Sprintf("%d", expected),
+		Actual:   fmt.Sprintf("%d", actual),
+	}
+}
+
+// checkVectorCoinSupply asserts coin's per-coin supply invariant —
+// emission + fees_in - burns == circulating — against both the
+// fixture's own claimed totals and the coin_id's actual summed account
+// balances, so a fixture that's internally inconsistent is caught the
+// same way a database that doesn't match it is.
+func checkVectorCoinSupply(coin VectorCoin, snapshot map[[32]byte]*state.Account) []VectorCheck {
+	var actualCirculating uint64
+	for _, account := range snapshot {
+		actualCirculating += account.Balance
+	}
+
+	claimedCirculating := coin.Emission + coin.FeesIn - coin.Burns
+
+	return []VectorCheck{
+		{
+			Name:     fmt.Sprintf("coin_supply_invariant:%s", coin.CoinID),
+			Passed:   claimedCirculating == coin.ExpectedCirculating,
+			Expected: fmt.Sprintf("%d", coin.ExpectedCirculating),
+			Actual:   fmt.Sprintf("%d", claimedCirculating),
+		},
+		{
+			Name:     fmt.Sprintf("coin_circulating:%s", coin.CoinID),
+			Passed:   actualCirculating == coin.ExpectedCirculating,
+			Expected: fmt.Sprintf("%d", coin.ExpectedCirculating),
+			Actual:   fmt.Sprintf("%d", actualCirculating),
+		},
+	}
+}
+
+func withinTolerance(actual, expected, tolerance float64) bool {
+	diff := actual - expected
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= tolerance
+}
+
+// loadVectorPaths resolves the -vector/-vectors-dir flags into a sorted
+// list of vector file paths.
+func loadVectorPaths(vectorPath, vectorsDir string) ([]string, error) {
+	if vectorPath != "" {
+		return []string{vectorPath}, nil
+	}
+
+	entries, err := os.ReadDir(vectorsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vectors directory: %w", err)
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		paths = append(paths, filepath.Join(vectorsDir, entry.Name()))
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
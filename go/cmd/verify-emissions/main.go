@@ -4,7 +4,10 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
 	"fmt"
+	"os"
 )
 
 const (
@@ -17,6 +20,73 @@ const (
 	MinBlockReward      = 100_000_000                // 0.1 BEANS
 )
 
+// VestingGrant is the JSON representation of one genesis vesting grant,
+// loaded from the file passed via -vesting. It mirrors
+// tokenomics.VestingTracker's vesting math (before start+cliff: 0
+// vested; after start+duration: fully vested; otherwise linear) without
+// importing the tokenomics package, matching this tool's existing
+// practice of keeping its own copy of the emission constants.
+type VestingGrant struct {
+	Beneficiary    string `json:"beneficiary"` // hex-encoded address, for display only
+	TotalAmount    uint64 `json:"total_amount"`
+	StartBlock     uint64 `json:"start_block"`
+	CliffBlocks    uint64 `json:"cliff_blocks"`
+	DurationBlocks uint64 `json:"duration_blocks"`
+}
+
+func (g VestingGrant) vestedAt(block uint64) uint64 {
+	if block < g.StartBlock+g.CliffBlocks {
+		return 0
+	}
+	if block >= g.StartBlock+g.DurationBlocks {
+		return g.TotalAmount
+	}
+	return g.TotalAmount * (block - g.StartBlock) / g.DurationBlocks
+}
+
+func loadVestingSchedule(path string) ([]VestingGrant, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vesting schedule: %w", err)
+	}
+
+	var grants []VestingGrant
+	if err := json.Unmarshal(data, &grants); err != nil {
+		return nil, fmt.Errorf("failed to parse vesting schedule: %w", err)
+	}
+
+	return grants, nil
+}
+
+func printVestingSchedule(grants []VestingGrant, blockCheckpoints []uint64) {
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════")
+	fmt.Println("  Vesting Schedule")
+	fmt.Println("═══════════════════════════════════════════════════════")
+	fmt.Println()
+
+	var totalGranted uint64
+	for _, g := range grants {
+		totalGranted += g.TotalAmount
+	}
+	fmt.Printf("Grants Loaded:         %d\n", len(grants))
+	fmt.Printf("Total Granted:         %s BEANS\n", formatBeans(totalGranted))
+	fmt.Println()
+
+	fmt.Printf("%-15s %-15s %-15s\n", "Block", "Vested", "Unvested")
+	fmt.Println("─────────────────────────────────────────────────────────────")
+
+	for _, block := range blockCheckpoints {
+		var vested uint64
+		for _, g := range grants {
+			vested += g.vestedAt(block)
+		}
+		unvested := totalGranted - vested
+
+		fmt.Printf("%-15d %-15s %-15s\n", block, formatBeans(vested), formatBeans(unvested))
+	}
+}
+
 func formatBeans(wei uint64) string {
 	coins := float64(wei) / float64(WeiPerCoin)
 	if coins >= 1_000_000 {
@@ -28,6 +98,9 @@ func formatBeans(wei uint64) string {
 }
 
 func main() {
+	vestingPath := flag.String("vesting", "", "path to a JSON vesting schedule ([]VestingGrant) to report vested-over-time for")
+	flag.Parse()
+
 	fmt.Println("═══════════════════════════════════════════════════════")
 	fmt.Println("  $BEANS Emission Verification")
 	fmt.Println("  Confirming cumulative emissions ≤ 11M BEANS")
@@ -51,6 +124,7 @@ func main() {
 	totalEmitted := uint64(0)
 	period := 1
 	startBlock := uint64(0)
+	periodStarts := []uint64{startBlock}
 
 	fmt.Printf("%-8s %-15s %-15s %-15s %-15s %-15s\n",
 		"Period", "Start Block", "End Block", "Reward/Block", "Period Total", "Cumulative")
@@ -82,6 +156,7 @@ func main() {
 
 		// Next period
 		startBlock = endBlock + 1
+		periodStarts = append(periodStarts, startBlock)
 		currentReward = currentReward / 2
 		period++
 
@@ -129,4 +204,13 @@ func main() {
 	}
 
 	fmt.Println()
+
+	if *vestingPath != "" {
+		grants, err := loadVestingSchedule(*vestingPath)
+		if err != nil {
+			fmt.Printf("⚠️  Failed to load vesting schedule: %v\n", err)
+			return
+		}
+		printVestingSchedule(grants, periodStarts)
+	}
 }
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/consensus"
+)
+
+// api.NewServer (referenced by cmd/coinjectured/main.go) has no source
+// in this tree yet, the same pre-existing gap as pkg/mempool and
+// pkg/state — there's no router here for GET /tx/{hash}/proof or POST
+// /tx/verify to be registered on. TxProof and verifyTxProof are the
+// handler-independent pieces those two endpoints would be built from: a
+// one-line registration once Server exists, not a reason to invent
+// Server here.
+
+// TxProof is the payload GET /tx/{hash}/proof returns: enough for a
+// light client to verify a transaction's inclusion under a block it
+// already has the header (and therefore MerkleRoot) for, without
+// downloading or replaying the block's other transactions.
+type TxProof struct {
+	BlockHash  [32]byte              `json:"block_hash"`
+	MerkleRoot [32]byte              `json:"merkle_root"`
+	Proof      consensus.MerkleProof `json:"proof"`
+}
+
+// verifyTxProof backs POST /tx/verify: it re-derives the root from leaf
+// and proof and reports whether it matches root, the same
+// light-client-side check consensus.VerifyInclusionProof performs,
+// exposed here so a caller doesn't need to import pkg/consensus
+// directly just to check one proof.
+func verifyTxProof(root, leaf [32]byte, proof consensus.MerkleProof) (bool, error) {
+	return consensus.VerifyInclusionProof(root, leaf, proof)
+}
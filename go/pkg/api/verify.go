@@ -1,6 +1,3 @@
-//go:build cgo
-// +build cgo
-
 package api
 
 import (
@@ -10,9 +7,12 @@ import (
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/consensus"
 )
 
-// verifyProofWithRust verifies a proof using Rust consensus engine via CGO.
+// verifyProofWithRust verifies a subset-sum proof via consensus.DefaultBackend,
+// which resolves to the Rust/CGO implementation or the pure-Go fallback
+// depending on how the binary was built.
 //
-// This function is only available when CGO is enabled.
+// The name is historical: the function no longer hard-codes CGO, but
+// the Rust implementation remains the reference backend.
 func verifyProofWithRust(proof *struct {
 	ProblemType string
 	Tier        string
@@ -68,15 +68,11 @@ func verifyProofWithRust(proof *struct {
 		Timestamp: time.Now().Unix(), // Current timestamp for solution
 	}
 
-	// Budget limits (tier-appropriate)
-	budget := &consensus.VerifyBudget{
-		MaxOps:         100000,
-		MaxDurationMs:  10000, // 10 seconds max
-		MaxMemoryBytes: 100_000_000, // 100MB max
-	}
+	// Budget limits, scaled to the caller's tier (MOBILE gets a much
+	// smaller budget than CLUSTER).
+	budget := consensus.BudgetFor(tier)
 
-	// Call Rust verification via CGO
-	isValid, err := consensus.VerifySubsetSum(problem, solution, budget)
+	isValid, err := consensus.DefaultBackend().VerifySubsetSum(problem, solution, budget)
 	if err != nil {
 		return false, err
 	}
@@ -0,0 +1,204 @@
+package archive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/consensus"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
+)
+
+func testBlockAndReceipts(t *testing.T, number uint64, parentHash [32]byte) (*consensus.Block, consensus.Receipts) {
+	t.Helper()
+
+	var validator [32]byte
+	validator[0] = byte(number)
+
+	tx := &mempool.Transaction{
+		Hash:     [32]byte{byte(number), 1},
+		Amount:   1000,
+		Fee:      10,
+		GasLimit: 21000,
+	}
+
+	block := consensus.NewBlock(number, parentHash, validator, []*mempool.Transaction{tx})
+
+	receipts := consensus.Receipts{
+		{
+			TxHash:            tx.Hash,
+			Status:            consensus.ReceiptStatusSuccess,
+			GasUsed:           tx.GasLimit,
+			CumulativeGasUsed: tx.GasLimit,
+		},
+	}
+
+	header := block.Header()
+	header.ReceiptsRoot = consensus.ComputeReceiptsRoot(receipts)
+	block = block.WithSeal(header)
+
+	return block, receipts
+}
+
+func TestWriterReader_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "0-2.era")
+
+	w, err := NewWriter(path, 1, 0, 3)
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+
+	var blocks []*consensus.Block
+	var receiptsByBlock []consensus.Receipts
+	var parentHash [32]byte
+
+	for n := uint64(0); n < 3; n++ {
+		block, receipts := testBlockAndReceipts(t, n, parentHash)
+		if err := w.Append(block, receipts); err != nil {
+			t.Fatalf("Append(%d): %v", n, err)
+		}
+		blocks = append(blocks, block)
+		receiptsByBlock = append(receiptsByBlock, receipts)
+		parentHash = block.Hash()
+	}
+
+	if err := w.Finalize(); err != nil {
+		t.Fatalf("Finalize: %v", err)
+	}
+
+	r, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer r.Close()
+
+	if r.StartBlock() != 0 || r.Count() != 3 || r.NetworkID() != 1 {
+		t.Fatalf("unexpected header: start=%d count=%d network=%d", r.StartBlock(), r.Count(), r.NetworkID())
+	}
+
+	for n := uint64(0); n < 3; n++ {
+		got, err := r.BlockByNumber(n)
+		if err != nil {
+			t.Fatalf("BlockByNumber(%d): %v", n, err)
+		}
+		if got.Hash() != blocks[n].Hash() {
+			t.Errorf("block %d: hash mismatch: got %x, want %x", n, got.Hash(), blocks[n].Hash())
+		}
+
+		gotReceipts, err := r.ReceiptsByNumber(n)
+		if err != nil {
+			t.Fatalf("ReceiptsByNumber(%d): %v", n, err)
+		}
+		if len(gotReceipts) != len(receiptsByBlock[n]) {
+			t.Errorf("block %d: got %d receipts, want %d", n, len(gotReceipts), len(receiptsByBlock[n]))
+		}
+	}
+
+	if err := r.Verify(); err != nil {
+		t.Errorf("Verify on an untampered archive: %v", err)
+	}
+}
+
+func TestWriterReader_RejectsOutOfOrderAppend(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "oo.era")
+
+	w, err := NewWriter(path, 1, 0, 2)
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+
+	block, receipts := testBlockAndReceipts(t, 1, [32]byte{})
+	if err := w.Append(block, receipts); err == nil {
+		t.Fatal("expected out-of-order append (block 1 before block 0) to fail")
+	}
+}
+
+func TestWriterReader_RejectsIncompleteRange(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "incomplete.era")
+
+	w, err := NewWriter(path, 1, 0, 2)
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+
+	block, receipts := testBlockAndReceipts(t, 0, [32]byte{})
+	if err := w.Append(block, receipts); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+
+	if err := w.Finalize(); err == nil {
+		t.Fatal("expected Finalize to fail when fewer blocks than declared were appended")
+	}
+}
+
+func TestReader_VerifyDetectsTampering(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tampered.era")
+
+	w, err := NewWriter(path, 1, 0, 1)
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+
+	block, receipts := testBlockAndReceipts(t, 0, [32]byte{})
+	if err := w.Append(block, receipts); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if err := w.Finalize(); err != nil {
+		t.Fatalf("Finalize: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	// Flip a byte inside the compressed record stream (just past the
+	// fixed header), corrupting the archived block without touching the
+	// header, trailer, or index.
+	data[fileHeaderSize+4] ^= 0xFF
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	r, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer r.Close()
+
+	if err := r.Verify(); err == nil {
+		t.Fatal("expected Verify to detect tampering with the archived record")
+	}
+}
+
+func TestEncodeDecodeRecord_RoundTrip(t *testing.T) {
+	block, receipts := testBlockAndReceipts(t, 5, [32]byte{9})
+
+	rec := record{
+		hash:     block.Hash(),
+		header:   block.Header(),
+		txs:      block.Transactions(),
+		receipts: receipts,
+	}
+
+	decoded, err := decodeRecord(encodeRecord(rec))
+	if err != nil {
+		t.Fatalf("decodeRecord: %v", err)
+	}
+
+	if decoded.hash != rec.hash {
+		t.Errorf("hash: got %x, want %x", decoded.hash, rec.hash)
+	}
+	if decoded.header.BlockNumber != rec.header.BlockNumber {
+		t.Errorf("BlockNumber: got %d, want %d", decoded.header.BlockNumber, rec.header.BlockNumber)
+	}
+	if len(decoded.txs) != len(rec.txs) || decoded.txs[0].Hash != rec.txs[0].Hash {
+		t.Errorf("txs did not round-trip: got %+v, want %+v", decoded.txs, rec.txs)
+	}
+	if len(decoded.receipts) != len(rec.receipts) {
+		t.Errorf("receipts did not round-trip: got %d, want %d", len(decoded.receipts), len(rec.receipts))
+	}
+}
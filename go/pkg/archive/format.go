@@ -0,0 +1,228 @@
+// Package archive implements an era-file style archival format for
+// historical blocks: a contiguous range of blocks, bodies, and receipts
+// packed into a single append-only file, self-verifying via a Merkle
+// accumulator over the range's block hashes. It lets a full node prune
+// old history from its hot store (pkg/state) while still being able to
+// serve it to peers doing fast sync.
+package archive
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/consensus"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
+)
+
+// BlocksPerFile is the default number of blocks packed into one era
+// file. It is a recommendation for callers (e.g. the export CLI), not
+// enforced by Writer itself — Writer accepts whatever count it's given.
+const BlocksPerFile = 8192
+
+// magic identifies a COINjecture era file. version is bumped if the
+// on-disk layout below ever changes incompatibly.
+var magic = [4]byte{'C', 'J', 'E', '1'}
+
+// formatVersion 2 switched record's receipt encoding from manual
+// per-field serialization to a length-prefixed consensus.Receipt.Encode()
+// blob per receipt, so that logs/bloom/contract-address fields added to
+// Receipt don't require format.go to track Receipt's layout by hand.
+const formatVersion uint32 = 2
+
+// fileHeaderSize is the size in bytes of the fixed header at the start
+// of every era file: magic(4) + version(4) + networkID(4) +
+// startBlock(8) + count(4).
+const fileHeaderSize = 4 + 4 + 4 + 8 + 4
+
+// trailerSize is the size in bytes of the fixed trailer at the end of
+// every era file: accumulatorOffset(8) + indexOffset(8). Reader locates
+// it by seeking to the last trailerSize bytes, so it never needs to
+// parse the variable-length entry stream to find the index.
+const trailerSize = 8 + 8
+
+// fileHeader is the era file's fixed-size preamble.
+type fileHeader struct {
+	NetworkID  uint32
+	StartBlock uint64
+	Count      uint32
+}
+
+func (h fileHeader) marshal() []byte {
+	buf := make([]byte, 0, fileHeaderSize)
+	buf = append(buf, magic[:]...)
+	buf = appendUint32(buf, formatVersion)
+	buf = appendUint32(buf, h.NetworkID)
+	buf = appendUint64(buf, h.StartBlock)
+	buf = appendUint32(buf, h.Count)
+	return buf
+}
+
+func unmarshalFileHeader(data []byte) (fileHeader, error) {
+	if len(data) < fileHeaderSize {
+		return fileHeader{}, fmt.Errorf("era file header too short: got %d bytes, need %d", len(data), fileHeaderSize)
+	}
+	if [4]byte(data[0:4]) != magic {
+		return fileHeader{}, fmt.Errorf("not a COINjecture era file: bad magic %x", data[0:4])
+	}
+
+	off := 4
+	var version, networkID, count uint32
+	var startBlock uint64
+
+	version, off = readUint32(data, off)
+	if version != formatVersion {
+		return fileHeader{}, fmt.Errorf("unsupported era file version %d", version)
+	}
+	networkID, off = readUint32(data, off)
+	startBlock, off = readUint64(data, off)
+	count, _ = readUint32(data, off)
+
+	return fileHeader{NetworkID: networkID, StartBlock: startBlock, Count: count}, nil
+}
+
+// record is the decoded form of one archived (Header, Body, Receipts)
+// triple, plus the block hash it had at write time so Verify can check
+// that hash against what the header/body actually hash to once
+// reconstructed.
+type record struct {
+	hash     [32]byte
+	header   *consensus.Header
+	txs      []*mempool.Transaction
+	receipts consensus.Receipts
+}
+
+// encodeRecord serializes a record in canonical, fixed-field-order form,
+// in the same little-endian / length-prefixed style header_codec.go uses
+// for consensus.BlockHeader. This is the payload that gets
+// snappy-compressed by Writer.Append, so it is not itself compressed.
+func encodeRecord(r record) []byte {
+	h := r.header
+	buf := make([]byte, 0, 256+len(h.ExtraData))
+
+	buf = append(buf, r.hash[:]...)
+
+	buf = appendUint64(buf, h.BlockNumber)
+	buf = append(buf, h.ParentHash[:]...)
+	buf = append(buf, h.TxRoot[:]...)
+	buf = append(buf, h.StateRoot[:]...)
+	buf = append(buf, h.ReceiptsRoot[:]...)
+	buf = append(buf, h.Validator[:]...)
+	buf = appendUint64(buf, uint64(h.Timestamp))
+	buf = appendUint64(buf, h.GasLimit)
+	buf = appendUint64(buf, h.GasUsed)
+	buf = appendUint32(buf, uint32(len(h.ExtraData)))
+	buf = append(buf, h.ExtraData...)
+	buf = appendUint64(buf, h.Nonce)
+	buf = appendUint64(buf, h.Difficulty)
+
+	buf = appendUint32(buf, uint32(len(r.txs)))
+	for _, tx := range r.txs {
+		buf = append(buf, tx.Hash[:]...)
+		buf = append(buf, tx.From[:]...)
+		buf = append(buf, tx.To[:]...)
+		buf = appendUint64(buf, tx.Amount)
+		buf = appendUint64(buf, tx.Fee)
+		buf = appendUint64(buf, tx.GasLimit)
+		buf = appendUint64(buf, tx.Nonce)
+	}
+
+	buf = appendUint32(buf, uint32(len(r.receipts)))
+	for _, rc := range r.receipts {
+		encoded := rc.Encode()
+		buf = appendUint32(buf, uint32(len(encoded)))
+		buf = append(buf, encoded...)
+	}
+
+	return buf
+}
+
+func decodeRecord(data []byte) (record, error) {
+	const minSize = 32 + 8 + 32*4 + 8 + 8 + 8 + 4 + 8 + 8 + 4 + 4
+	if len(data) < minSize {
+		return record{}, fmt.Errorf("era record too short: got %d bytes, need at least %d", len(data), minSize)
+	}
+
+	off := 0
+	var r record
+	off += copy(r.hash[:], data[off:off+32])
+
+	h := &consensus.Header{}
+	h.BlockNumber, off = readUint64(data, off)
+	off += copy(h.ParentHash[:], data[off:off+32])
+	off += copy(h.TxRoot[:], data[off:off+32])
+	off += copy(h.StateRoot[:], data[off:off+32])
+	off += copy(h.ReceiptsRoot[:], data[off:off+32])
+	off += copy(h.Validator[:], data[off:off+32])
+
+	var ts uint64
+	ts, off = readUint64(data, off)
+	h.Timestamp = int64(ts)
+	h.GasLimit, off = readUint64(data, off)
+	h.GasUsed, off = readUint64(data, off)
+
+	var extraLen uint32
+	extraLen, off = readUint32(data, off)
+	if off+int(extraLen) > len(data) {
+		return record{}, fmt.Errorf("era record extra_data length %d overruns record", extraLen)
+	}
+	h.ExtraData = append([]byte(nil), data[off:off+int(extraLen)]...)
+	off += int(extraLen)
+
+	h.Nonce, off = readUint64(data, off)
+	h.Difficulty, off = readUint64(data, off)
+	r.header = h
+
+	var txCount uint32
+	txCount, off = readUint32(data, off)
+	r.txs = make([]*mempool.Transaction, txCount)
+	for i := range r.txs {
+		tx := &mempool.Transaction{}
+		off += copy(tx.Hash[:], data[off:off+32])
+		off += copy(tx.From[:], data[off:off+32])
+		off += copy(tx.To[:], data[off:off+32])
+		tx.Amount, off = readUint64(data, off)
+		tx.Fee, off = readUint64(data, off)
+		tx.GasLimit, off = readUint64(data, off)
+		tx.Nonce, off = readUint64(data, off)
+		r.txs[i] = tx
+	}
+
+	var receiptCount uint32
+	receiptCount, off = readUint32(data, off)
+	r.receipts = make(consensus.Receipts, receiptCount)
+	for i := range r.receipts {
+		var encodedLen uint32
+		encodedLen, off = readUint32(data, off)
+		if off+int(encodedLen) > len(data) {
+			return record{}, fmt.Errorf("era record receipt %d length %d overruns record", i, encodedLen)
+		}
+		rc, err := consensus.DecodeReceipt(data[off : off+int(encodedLen)])
+		if err != nil {
+			return record{}, fmt.Errorf("era record receipt %d: %w", i, err)
+		}
+		off += int(encodedLen)
+		r.receipts[i] = rc
+	}
+
+	return r, nil
+}
+
+func appendUint32(buf []byte, v uint32) []byte {
+	var tmp [4]byte
+	binary.LittleEndian.PutUint32(tmp[:], v)
+	return append(buf, tmp[:]...)
+}
+
+func appendUint64(buf []byte, v uint64) []byte {
+	var tmp [8]byte
+	binary.LittleEndian.PutUint64(tmp[:], v)
+	return append(buf, tmp[:]...)
+}
+
+func readUint32(data []byte, off int) (uint32, int) {
+	return binary.LittleEndian.Uint32(data[off : off+4]), off + 4
+}
+
+func readUint64(data []byte, off int) (uint64, int) {
+	return binary.LittleEndian.Uint64(data[off : off+8]), off + 8
+}
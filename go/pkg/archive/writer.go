@@ -0,0 +1,150 @@
+package archive
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/golang/snappy"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/consensus"
+)
+
+// Writer packs a contiguous, known-size range of blocks into a new era
+// file. Blocks must be appended in order starting at the range's first
+// block number; Finalize writes the accumulator and index and closes
+// the file once every block in the range has been appended.
+//
+// Era files are append-only: Writer refuses to overwrite an existing
+// path, so a range is only ever written once. If Append or Finalize
+// return an error, the partially-written file is left on disk — the
+// caller should remove it before retrying, the same way it would clean
+// up after any other failed export.
+type Writer struct {
+	f       *os.File
+	header  fileHeader
+	next    uint64
+	offsets []uint64
+	hashes  [][32]byte
+	closed  bool
+}
+
+// NewWriter creates a new era file at path for the count blocks starting
+// at startBlock. networkID is recorded in the header so a Reader can
+// refuse to mix archives from different networks.
+func NewWriter(path string, networkID uint32, startBlock uint64, count uint32) (*Writer, error) {
+	if count == 0 {
+		return nil, fmt.Errorf("era file must cover at least one block")
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create era file %s: %w", path, err)
+	}
+
+	header := fileHeader{NetworkID: networkID, StartBlock: startBlock, Count: count}
+	if _, err := f.Write(header.marshal()); err != nil {
+		f.Close()
+		os.Remove(path)
+		return nil, fmt.Errorf("failed to write era file header: %w", err)
+	}
+
+	return &Writer{
+		f:       f,
+		header:  header,
+		next:    startBlock,
+		offsets: make([]uint64, 0, count),
+		hashes:  make([][32]byte, 0, count),
+	}, nil
+}
+
+// Append writes the next block in the range and its receipts. block.Number()
+// must equal the block number the writer expects next.
+func (w *Writer) Append(block *consensus.Block, receipts consensus.Receipts) error {
+	if w.closed {
+		return fmt.Errorf("era writer is already finalized")
+	}
+	if uint32(len(w.offsets)) >= w.header.Count {
+		return fmt.Errorf("era writer already has its full range of %d blocks", w.header.Count)
+	}
+	if block.Number() != w.next {
+		return fmt.Errorf("out-of-order append: expected block %d, got %d", w.next, block.Number())
+	}
+
+	raw := encodeRecord(record{
+		hash:     block.Hash(),
+		header:   block.Header(),
+		txs:      block.Transactions(),
+		receipts: receipts,
+	})
+	compressed := snappy.Encode(nil, raw)
+
+	offset, err := w.f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("failed to determine write offset: %w", err)
+	}
+
+	if _, err := w.f.Write(appendUint32(nil, uint32(len(compressed)))); err != nil {
+		return fmt.Errorf("failed to write record length for block %d: %w", block.Number(), err)
+	}
+	if _, err := w.f.Write(compressed); err != nil {
+		return fmt.Errorf("failed to write record for block %d: %w", block.Number(), err)
+	}
+
+	w.offsets = append(w.offsets, uint64(offset))
+	w.hashes = append(w.hashes, block.Hash())
+	w.next++
+	return nil
+}
+
+// Finalize writes the Merkle accumulator over the range's block hashes
+// and the per-block offset index, then closes the file. It fails if
+// fewer blocks than the range's declared count have been appended.
+func (w *Writer) Finalize() error {
+	if w.closed {
+		return fmt.Errorf("era writer is already finalized")
+	}
+	if uint32(len(w.offsets)) != w.header.Count {
+		return fmt.Errorf("era range incomplete: appended %d of %d blocks", len(w.offsets), w.header.Count)
+	}
+
+	accumulatorOffset, err := w.f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("failed to determine accumulator offset: %w", err)
+	}
+	root := consensus.DefaultBackend().MerkleRoot(w.hashes)
+	if _, err := w.f.Write(root[:]); err != nil {
+		return fmt.Errorf("failed to write accumulator root: %w", err)
+	}
+
+	indexOffset, err := w.f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("failed to determine index offset: %w", err)
+	}
+	idx := make([]byte, 0, len(w.offsets)*8)
+	for _, off := range w.offsets {
+		idx = appendUint64(idx, off)
+	}
+	if _, err := w.f.Write(idx); err != nil {
+		return fmt.Errorf("failed to write index: %w", err)
+	}
+
+	trailer := appendUint64(appendUint64(nil, uint64(accumulatorOffset)), uint64(indexOffset))
+	if _, err := w.f.Write(trailer); err != nil {
+		return fmt.Errorf("failed to write trailer: %w", err)
+	}
+
+	w.closed = true
+	return w.f.Close()
+}
+
+// Abort closes and removes the era file being written, for callers that
+// hit an error partway through an export and want to clean up rather
+// than leave a truncated file behind.
+func (w *Writer) Abort(path string) error {
+	if !w.closed {
+		w.f.Close()
+		w.closed = true
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,95 @@
+// Package beacon provides a source of unbiased public randomness
+// (a drand-style randomness beacon) for code that needs a schedule or
+// selection all honest nodes can derive identically, without a leader
+// or round of its own: equilibrium-gossip jitter, validator/leader
+// selection, and anything else that today would otherwise have to
+// fall back to a locally-biased source like the block hash alone.
+package beacon
+
+import "context"
+
+// BeaconEntry is one published round of a randomness beacon. Randomness
+// is the per-round output everything else derives jitter/selection
+// from; Signature is the raw BLS signature the entry's randomness is
+// computed from, kept around so VerifyEntry can check chained rounds
+// without re-fetching.
+type BeaconEntry struct {
+	Round         uint64
+	Randomness    [32]byte
+	Signature     []byte
+	PreviousRound uint64
+}
+
+// BeaconAPI is what the rest of the tree (CIDGossip jitter, consensus
+// leader selection) depends on, so it can be written once against
+// either DrandBeacon or, in tests, MockBeacon.
+type BeaconAPI interface {
+	// Entry fetches (or returns from cache) the beacon entry for round.
+	Entry(ctx context.Context, round uint64) (BeaconEntry, error)
+
+	// VerifyEntry checks that cur chains from prev under the beacon's
+	// configured public key. prev may be the zero BeaconEntry only for
+	// a chain's genesis round.
+	VerifyEntry(prev, cur BeaconEntry) error
+
+	// NewEntries streams every entry as it's observed (via polling or a
+	// push subscription, depending on the implementation), so callers
+	// that want to react to new rounds don't have to poll Entry
+	// themselves. The channel is closed when the beacon is stopped.
+	NewEntries() <-chan BeaconEntry
+
+	// LatestRound returns the highest round number seen so far, or 0
+	// if no entry has been observed yet.
+	LatestRound() uint64
+}
+
+// BeaconNetwork describes one drand network this node knows how to
+// speak to. Start pins the block height at which this node switches to
+// this network, so an upgrade to a new chain (new public key, new
+// period) can be scheduled the same way a hard fork is, rather than
+// requiring every node to flip over at the same wall-clock instant.
+type BeaconNetwork struct {
+	Name        string
+	Endpoint    string
+	ChainHash   string
+	PublicKey   []byte
+	GenesisTime int64
+	Period      int64
+	Start       uint64
+}
+
+// BeaconNetworks is an ordered-by-Start list of networks a beacon
+// consumer is configured to use. ActiveNetwork picks the one in effect
+// for a given block height: the highest Start that is <= height.
+type BeaconNetworks []BeaconNetwork
+
+// ActiveNetwork returns the network active at blockNumber: the entry
+// with the highest Start that is <= blockNumber. ok is false if
+// blockNumber is before every configured network's Start (including the
+// case where the list is empty).
+func (ns BeaconNetworks) ActiveNetwork(blockNumber uint64) (network BeaconNetwork, ok bool) {
+	for _, n := range ns {
+		if n.Start > blockNumber {
+			continue
+		}
+		if !ok || n.Start > network.Start {
+			network = n
+			ok = true
+		}
+	}
+	return network, ok
+}
+
+// EquilibriumSeed derives the per-block seed CIDGossip's equilibrium
+// jitter is drawn from: entry.Randomness XORed byte-by-byte against
+// blockNumber's big-endian encoding. Every honest node computes the
+// same beacon entry for a given round and the same blockNumber for a
+// given block, so they all derive the same seed and therefore the same
+// jitter schedule without any additional coordination.
+func EquilibriumSeed(entry BeaconEntry, blockNumber uint64) [32]byte {
+	seed := entry.Randomness
+	for i := 0; i < 8; i++ {
+		seed[31-i] ^= byte(blockNumber >> (8 * i))
+	}
+	return seed
+}
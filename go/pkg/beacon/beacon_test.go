@@ -0,0 +1,105 @@
+package beacon
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBeaconNetworks_ActiveNetwork(t *testing.T) {
+	networks := BeaconNetworks{
+		{Name: "genesis", Start: 0},
+		{Name: "upgrade", Start: 1_000_000},
+	}
+
+	if n, ok := networks.ActiveNetwork(0); !ok || n.Name != "genesis" {
+		t.Fatalf("expected genesis network at height 0, got %+v (ok=%v)", n, ok)
+	}
+	if n, ok := networks.ActiveNetwork(999_999); !ok || n.Name != "genesis" {
+		t.Fatalf("expected genesis network just before upgrade height, got %+v (ok=%v)", n, ok)
+	}
+	if n, ok := networks.ActiveNetwork(1_000_000); !ok || n.Name != "upgrade" {
+		t.Fatalf("expected upgrade network at its Start height, got %+v (ok=%v)", n, ok)
+	}
+	if n, ok := networks.ActiveNetwork(5_000_000); !ok || n.Name != "upgrade" {
+		t.Fatalf("expected upgrade network to remain active past its Start height, got %+v (ok=%v)", n, ok)
+	}
+}
+
+func TestBeaconNetworks_ActiveNetwork_NoneConfigured(t *testing.T) {
+	var networks BeaconNetworks
+	if _, ok := networks.ActiveNetwork(0); ok {
+		t.Fatal("expected no active network when none are configured")
+	}
+}
+
+func TestEquilibriumSeed_DifferentBlocksDeriveDifferentSeeds(t *testing.T) {
+	entry := BeaconEntry{Randomness: [32]byte{1, 2, 3, 4}}
+
+	seedA := EquilibriumSeed(entry, 1)
+	seedB := EquilibriumSeed(entry, 2)
+	if seedA == seedB {
+		t.Fatal("expected different block numbers to derive different seeds from the same beacon entry")
+	}
+}
+
+func TestMockBeacon_SeedAndVerifyChain(t *testing.T) {
+	mb := NewMockBeacon()
+
+	first := mb.Seed(1)
+	second := mb.Seed(2)
+
+	if mb.LatestRound() != 2 {
+		t.Fatalf("expected latest round 2, got %d", mb.LatestRound())
+	}
+
+	if err := mb.VerifyEntry(first, second); err != nil {
+		t.Fatalf("expected chained rounds to verify, got error: %v", err)
+	}
+
+	bogus := BeaconEntry{Round: 2, PreviousRound: 1}
+	if err := mb.VerifyEntry(BeaconEntry{Round: 5}, bogus); err == nil {
+		t.Fatal("expected verification to fail when PreviousRound doesn't match prev.Round")
+	}
+}
+
+func TestMockBeacon_EntryUnseededRoundErrors(t *testing.T) {
+	mb := NewMockBeacon()
+	if _, err := mb.Entry(nil, 42); err == nil {
+		t.Fatal("expected an error for an unseeded round")
+	}
+}
+
+func TestNullBeacon_EntryNeverErrorsAndIsDeterministic(t *testing.T) {
+	nb := NewNullBeacon()
+
+	first, err := nb.Entry(nil, 7)
+	if err != nil {
+		t.Fatalf("expected NullBeacon to synthesize an entry without seeding, got error: %v", err)
+	}
+	if nb.LatestRound() != 7 {
+		t.Fatalf("expected latest round 7, got %d", nb.LatestRound())
+	}
+
+	second, err := nb.Entry(nil, 7)
+	if err != nil {
+		t.Fatalf("Entry: %v", err)
+	}
+	if first.Randomness != second.Randomness || first.PreviousRound != second.PreviousRound || !bytes.Equal(first.Signature, second.Signature) {
+		t.Fatal("expected repeated Entry calls for the same round to return the same entry")
+	}
+}
+
+func TestNullBeacon_VerifyEntryChecksChaining(t *testing.T) {
+	nb := NewNullBeacon()
+	first, _ := nb.Entry(nil, 1)
+	second, _ := nb.Entry(nil, 2)
+
+	if err := nb.VerifyEntry(first, second); err != nil {
+		t.Fatalf("expected chained rounds to verify, got error: %v", err)
+	}
+
+	bogus := BeaconEntry{Round: 2, PreviousRound: 1}
+	if err := nb.VerifyEntry(BeaconEntry{Round: 5}, bogus); err == nil {
+		t.Fatal("expected verification to fail when PreviousRound doesn't match prev.Round")
+	}
+}
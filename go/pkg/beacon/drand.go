@@ -0,0 +1,293 @@
+package beacon
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+// DefaultPollInterval is how often DrandBeacon checks /public/latest for
+// a new round when no explicit Period is configured for the active
+// network (Period should normally be used instead, since it lets
+// DrandBeacon sleep until the round is actually due rather than
+// polling blindly).
+const DefaultPollInterval = 2 * time.Second
+
+// BLSVerifier is the subset of a BLS verification backend DrandBeacon
+// needs to check a published round's signature, kept narrow the same
+// way CryptoBackendVerifier is in pkg/consensus so DrandBeacon doesn't
+// force a specific BLS library on every caller (and so tests can supply
+// a fake).
+type BLSVerifier interface {
+	// VerifyBeaconSignature checks signature against round and
+	// previousSignature under publicKey, following drand's chained
+	// randomness scheme (round N's message is
+	// SHA256(previousSignature || round)).
+	VerifyBeaconSignature(publicKey []byte, previousSignature []byte, round uint64, signature []byte) error
+}
+
+// drandRoundResponse is the JSON body drand's HTTP API returns from both
+// /public/latest and /public/{round}.
+type drandRoundResponse struct {
+	Round             uint64 `json:"round"`
+	Randomness        string `json:"randomness"`
+	Signature         string `json:"signature"`
+	PreviousSignature string `json:"previous_signature"`
+}
+
+// DrandBeacon implements BeaconAPI against a drand HTTP relay,
+// verifying each round's BLS signature against the active network's
+// chain-info public key and caching entries by round so repeated
+// Entry(ctx, round) calls for already-seen rounds don't re-fetch.
+type DrandBeacon struct {
+	log        *logger.Logger
+	httpClient *http.Client
+	verifier   BLSVerifier
+
+	mu          sync.RWMutex
+	network     BeaconNetwork
+	cache       map[uint64]BeaconEntry
+	latestRound uint64
+
+	subscribersMu sync.Mutex
+	subscribers   []chan BeaconEntry
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewDrandBeacon creates a DrandBeacon that speaks to network and
+// verifies every round it fetches against network.PublicKey. httpClient
+// may be nil, in which case http.DefaultClient is used.
+func NewDrandBeacon(network BeaconNetwork, verifier BLSVerifier, httpClient *http.Client, log *logger.Logger) (*DrandBeacon, error) {
+	if verifier == nil {
+		return nil, fmt.Errorf("drand beacon requires a BLS verifier")
+	}
+	if network.Endpoint == "" || network.ChainHash == "" {
+		return nil, fmt.Errorf("drand beacon network %q is missing an endpoint or chain hash", network.Name)
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &DrandBeacon{
+		log:        log,
+		httpClient: httpClient,
+		verifier:   verifier,
+		network:    network,
+		cache:      make(map[uint64]BeaconEntry),
+		stop:       make(chan struct{}),
+	}, nil
+}
+
+// SetNetwork switches which drand network DrandBeacon fetches from,
+// e.g. when the local chain crosses a BeaconNetwork.Start height and
+// moves to a newer network. It does not clear the existing cache: round
+// numbers are only meaningful within a network, but old entries are
+// harmless to keep around and Entry/VerifyEntry are always called with
+// rounds from the currently active network in practice.
+func (b *DrandBeacon) SetNetwork(network BeaconNetwork) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.network = network
+}
+
+// Entry fetches the beacon entry for round, verifying its BLS signature
+// before returning it. round == 0 fetches the latest published round.
+func (b *DrandBeacon) Entry(ctx context.Context, round uint64) (BeaconEntry, error) {
+	if round != 0 {
+		if entry, ok := b.cachedEntry(round); ok {
+			return entry, nil
+		}
+	}
+
+	b.mu.RLock()
+	network := b.network
+	b.mu.RUnlock()
+
+	path := "latest"
+	if round != 0 {
+		path = fmt.Sprintf("%d", round)
+	}
+	url := fmt.Sprintf("%s/%s/public/%s", network.Endpoint, network.ChainHash, path)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return BeaconEntry{}, fmt.Errorf("failed to build drand request: %w", err)
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return BeaconEntry{}, fmt.Errorf("drand request to %s failed: %w", network.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return BeaconEntry{}, fmt.Errorf("drand %s returned status %d", network.Name, resp.StatusCode)
+	}
+
+	var parsed drandRoundResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return BeaconEntry{}, fmt.Errorf("failed to parse drand response from %s: %w", network.Name, err)
+	}
+
+	entry, err := b.verifyAndBuildEntry(network, parsed)
+	if err != nil {
+		return BeaconEntry{}, err
+	}
+
+	b.storeEntry(entry)
+	return entry, nil
+}
+
+// verifyAndBuildEntry decodes the hex-encoded fields of a drand
+// response and verifies its signature before returning a BeaconEntry.
+func (b *DrandBeacon) verifyAndBuildEntry(network BeaconNetwork, parsed drandRoundResponse) (BeaconEntry, error) {
+	randomness, err := hex.DecodeString(parsed.Randomness)
+	if err != nil || len(randomness) != 32 {
+		return BeaconEntry{}, fmt.Errorf("drand %s round %d has malformed randomness", network.Name, parsed.Round)
+	}
+	signature, err := hex.DecodeString(parsed.Signature)
+	if err != nil {
+		return BeaconEntry{}, fmt.Errorf("drand %s round %d has malformed signature: %w", network.Name, parsed.Round, err)
+	}
+	previousSignature, err := hex.DecodeString(parsed.PreviousSignature)
+	if err != nil {
+		return BeaconEntry{}, fmt.Errorf("drand %s round %d has malformed previous signature: %w", network.Name, parsed.Round, err)
+	}
+
+	if err := b.verifier.VerifyBeaconSignature(network.PublicKey, previousSignature, parsed.Round, signature); err != nil {
+		return BeaconEntry{}, fmt.Errorf("drand %s round %d failed signature verification: %w", network.Name, parsed.Round, err)
+	}
+
+	var randomnessArr [32]byte
+	copy(randomnessArr[:], randomness)
+
+	return BeaconEntry{
+		Round:         parsed.Round,
+		Randomness:    randomnessArr,
+		Signature:     signature,
+		PreviousRound: parsed.Round - 1,
+	}, nil
+}
+
+// VerifyEntry checks that cur's PreviousRound/signature chain from prev,
+// re-running BLS verification against the active network's public key.
+// prev may be the zero BeaconEntry only when cur is the chain's genesis
+// round.
+func (b *DrandBeacon) VerifyEntry(prev, cur BeaconEntry) error {
+	if cur.PreviousRound != prev.Round && prev.Round != 0 {
+		return fmt.Errorf("beacon round %d does not chain from round %d", cur.Round, prev.Round)
+	}
+
+	b.mu.RLock()
+	network := b.network
+	b.mu.RUnlock()
+
+	return b.verifier.VerifyBeaconSignature(network.PublicKey, prev.Signature, cur.Round, cur.Signature)
+}
+
+// NewEntries returns a channel every newly observed entry is sent on.
+// Start must be called for entries to actually be polled and pushed.
+func (b *DrandBeacon) NewEntries() <-chan BeaconEntry {
+	ch := make(chan BeaconEntry, 8)
+	b.subscribersMu.Lock()
+	b.subscribers = append(b.subscribers, ch)
+	b.subscribersMu.Unlock()
+	return ch
+}
+
+// LatestRound returns the highest round number DrandBeacon has observed
+// (via Entry or the background poll loop), or 0 if none yet.
+func (b *DrandBeacon) LatestRound() uint64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.latestRound
+}
+
+// Start begins polling the active network for new rounds in the
+// background, pushing each newly observed entry to every NewEntries
+// subscriber, until ctx is cancelled or Stop is called.
+func (b *DrandBeacon) Start(ctx context.Context) {
+	go b.pollLoop(ctx)
+}
+
+// Stop halts the background poll loop and closes every subscriber
+// channel. Safe to call more than once.
+func (b *DrandBeacon) Stop() {
+	b.stopOnce.Do(func() {
+		close(b.stop)
+		b.subscribersMu.Lock()
+		for _, ch := range b.subscribers {
+			close(ch)
+		}
+		b.subscribers = nil
+		b.subscribersMu.Unlock()
+	})
+}
+
+func (b *DrandBeacon) pollLoop(ctx context.Context) {
+	interval := DefaultPollInterval
+	b.mu.RLock()
+	if b.network.Period > 0 {
+		interval = time.Duration(b.network.Period) * time.Second
+	}
+	b.mu.RUnlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-b.stop:
+			return
+		case <-ticker.C:
+			entry, err := b.Entry(ctx, 0)
+			if err != nil {
+				b.log.WithError(err).Warn("Failed to poll drand beacon for latest round")
+				continue
+			}
+			b.publish(entry)
+		}
+	}
+}
+
+func (b *DrandBeacon) publish(entry BeaconEntry) {
+	b.subscribersMu.Lock()
+	defer b.subscribersMu.Unlock()
+	for _, ch := range b.subscribers {
+		select {
+		case ch <- entry:
+		default:
+			// Subscriber isn't keeping up; drop rather than block the
+			// poll loop, the same back-pressure choice CIDGossip's
+			// queue makes for slow consumers.
+		}
+	}
+}
+
+func (b *DrandBeacon) cachedEntry(round uint64) (BeaconEntry, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	entry, ok := b.cache[round]
+	return entry, ok
+}
+
+func (b *DrandBeacon) storeEntry(entry BeaconEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.cache[entry.Round] = entry
+	if entry.Round > b.latestRound {
+		b.latestRound = entry.Round
+	}
+}
+
+var _ BeaconAPI = (*DrandBeacon)(nil)
@@ -0,0 +1,101 @@
+package beacon
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+)
+
+// MockBeacon is a deterministic, signature-free BeaconAPI for tests:
+// Randomness is just SHA256 of "mock-beacon-round-<round>", so tests can compute the expected
+// value for any round without running a real drand network or BLS
+// verifier. Entries must be seeded with Seed before Entry/VerifyEntry
+// can return them.
+type MockBeacon struct {
+	mu      sync.RWMutex
+	entries map[uint64]BeaconEntry
+	latest  uint64
+
+	subscribersMu sync.Mutex
+	subscribers   []chan BeaconEntry
+}
+
+// NewMockBeacon creates an empty MockBeacon.
+func NewMockBeacon() *MockBeacon {
+	return &MockBeacon{entries: make(map[uint64]BeaconEntry)}
+}
+
+// Seed deterministically generates and stores an entry for round,
+// returning it. PreviousRound is round-1, matching drand's chained
+// scheme, so VerifyEntry on consecutively seeded rounds succeeds.
+func (m *MockBeacon) Seed(round uint64) BeaconEntry {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("mock-beacon-round-%d", round)))
+	entry := BeaconEntry{
+		Round:         round,
+		Randomness:    sum,
+		Signature:     sum[:],
+		PreviousRound: round - 1,
+	}
+
+	m.mu.Lock()
+	m.entries[round] = entry
+	if round > m.latest {
+		m.latest = round
+	}
+	m.mu.Unlock()
+
+	m.publish(entry)
+	return entry
+}
+
+// Entry returns the seeded entry for round, or an error if it hasn't
+// been seeded yet.
+func (m *MockBeacon) Entry(_ context.Context, round uint64) (BeaconEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	entry, ok := m.entries[round]
+	if !ok {
+		return BeaconEntry{}, fmt.Errorf("mock beacon has no entry for round %d", round)
+	}
+	return entry, nil
+}
+
+// VerifyEntry checks only that cur chains from prev by round number
+// (no real signature to check), matching how real chains reject a
+// gap or out-of-order round.
+func (m *MockBeacon) VerifyEntry(prev, cur BeaconEntry) error {
+	if prev.Round != 0 && cur.PreviousRound != prev.Round {
+		return fmt.Errorf("beacon round %d does not chain from round %d", cur.Round, prev.Round)
+	}
+	return nil
+}
+
+// NewEntries returns a channel every Seed call is pushed to.
+func (m *MockBeacon) NewEntries() <-chan BeaconEntry {
+	ch := make(chan BeaconEntry, 8)
+	m.subscribersMu.Lock()
+	m.subscribers = append(m.subscribers, ch)
+	m.subscribersMu.Unlock()
+	return ch
+}
+
+// LatestRound returns the highest round seeded so far.
+func (m *MockBeacon) LatestRound() uint64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.latest
+}
+
+func (m *MockBeacon) publish(entry BeaconEntry) {
+	m.subscribersMu.Lock()
+	defer m.subscribersMu.Unlock()
+	for _, ch := range m.subscribers {
+		select {
+		case ch <- entry:
+		default:
+		}
+	}
+}
+
+var _ BeaconAPI = (*MockBeacon)(nil)
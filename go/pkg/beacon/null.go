@@ -0,0 +1,96 @@
+package beacon
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+)
+
+// NullBeacon is the BeaconAPI for a single-node or dev-mode deployment
+// with no real drand network configured: it synthesizes a
+// SHA256(round)-derived entry for whatever round Entry is asked about,
+// the same deterministic scheme MockBeacon uses, but never requires a
+// prior Seed call — a node with no BeaconNetworks configured can still
+// run leader election against it rather than leaving that code path
+// unable to run at all.
+type NullBeacon struct {
+	mu     sync.Mutex
+	cache  map[uint64]BeaconEntry
+	latest uint64
+
+	subscribersMu sync.Mutex
+	subscribers   []chan BeaconEntry
+}
+
+// NewNullBeacon creates an empty NullBeacon.
+func NewNullBeacon() *NullBeacon {
+	return &NullBeacon{cache: make(map[uint64]BeaconEntry)}
+}
+
+// Entry computes (or returns the cached) entry for round. Unlike
+// MockBeacon, it never errors for an unseeded round — there is nothing
+// to seed.
+func (n *NullBeacon) Entry(_ context.Context, round uint64) (BeaconEntry, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if entry, ok := n.cache[round]; ok {
+		return entry, nil
+	}
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("null-beacon-round-%d", round)))
+	entry := BeaconEntry{
+		Round:         round,
+		Randomness:    sum,
+		Signature:     sum[:],
+		PreviousRound: round - 1,
+	}
+	n.cache[round] = entry
+	if round > n.latest {
+		n.latest = round
+	}
+
+	n.publish(entry)
+	return entry, nil
+}
+
+// VerifyEntry checks only that cur chains from prev by round number, the
+// same bookkeeping-only check MockBeacon makes in place of a real
+// signature — NullBeacon signs nothing a real validator key backs.
+func (n *NullBeacon) VerifyEntry(prev, cur BeaconEntry) error {
+	if prev.Round != 0 && cur.PreviousRound != prev.Round {
+		return fmt.Errorf("beacon round %d does not chain from round %d", cur.Round, prev.Round)
+	}
+	return nil
+}
+
+// NewEntries returns a channel every Entry call's freshly computed
+// (never previously cached) round is pushed to.
+func (n *NullBeacon) NewEntries() <-chan BeaconEntry {
+	ch := make(chan BeaconEntry, 8)
+	n.subscribersMu.Lock()
+	n.subscribers = append(n.subscribers, ch)
+	n.subscribersMu.Unlock()
+	return ch
+}
+
+// LatestRound returns the highest round computed so far.
+func (n *NullBeacon) LatestRound() uint64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.latest
+}
+
+func (n *NullBeacon) publish(entry BeaconEntry) {
+	n.subscribersMu.Lock()
+	defer n.subscribersMu.Unlock()
+	for _, ch := range n.subscribers {
+		select {
+		case ch <- entry:
+		default:
+		}
+	}
+}
+
+var _ BeaconAPI = (*NullBeacon)(nil)
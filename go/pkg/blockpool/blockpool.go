@@ -0,0 +1,233 @@
+// Package blockpool provides a staging area between raw block gossip
+// and a finalized chain, so that a block which is valid but ends up on
+// a losing fork doesn't take its transactions down with it.
+//
+// It has no dependency on p2p or consensus: blocks are tracked by hash
+// with an opaque Payload the owning package can type-assert back to its
+// own concrete block type, the same narrow-coupling approach
+// pkg/consensus/cache.go's BlockStore interface and pkg/p2p/
+// sync_manager.go's HeaderValidator interface use to avoid import
+// cycles with the packages that actually consume them.
+package blockpool
+
+import "sync"
+
+// Default tier sizes. knownBlocks sees every gossiped block (including
+// ones that never get validated), so it gets a larger budget than
+// acceptedBlocks, which only holds validated-but-not-yet-finalized
+// blocks across whatever fork depth is actually in flight.
+const (
+	DefaultMaxKnownBlocks    = 4096
+	DefaultMaxAcceptedBlocks = 1024
+)
+
+// Config controls BlockPool's two LRU tiers.
+type Config struct {
+	MaxKnownBlocks    int
+	MaxAcceptedBlocks int
+}
+
+// DefaultConfig returns the standard tier sizes.
+func DefaultConfig() Config {
+	return Config{
+		MaxKnownBlocks:    DefaultMaxKnownBlocks,
+		MaxAcceptedBlocks: DefaultMaxAcceptedBlocks,
+	}
+}
+
+// Entry is one block BlockPool tracks: enough to do fork bookkeeping
+// (Hash/ParentHash/Number) without depending on the caller's concrete
+// block type. Payload carries that concrete block (e.g. a
+// *p2p.BlockMessage), opaque to BlockPool itself.
+type Entry struct {
+	Hash       [32]byte
+	ParentHash [32]byte
+	Number     uint64
+	Payload    interface{}
+}
+
+// Stats is a snapshot of pool occupancy and lifetime reinjection count,
+// suitable for exposing through something like GetNetworkStats.
+type Stats struct {
+	KnownCount    int
+	AcceptedCount int
+	ReinjectedTxs uint64
+}
+
+// BlockPool is a two-tier staging area sitting between raw block gossip
+// and a caller's consensus callback:
+//
+//   - knownBlocks: every gossiped block seen recently, deduped by hash,
+//     bounded LRU. Lets a caller answer "have I already seen this one"
+//     without re-validating or re-forwarding it.
+//   - acceptedBlocks: the subset that passed validation but isn't
+//     finalized yet, so it can still be pruned if its branch loses a
+//     fork choice (see PruneAcceptedBlocks).
+type BlockPool struct {
+	mu sync.Mutex
+
+	cfg Config
+
+	knownOrder []([32]byte)
+	known      map[[32]byte]Entry
+
+	acceptedOrder []([32]byte)
+	accepted      map[[32]byte]Entry
+
+	reinjectedTxs uint64
+}
+
+// NewBlockPool creates a BlockPool with the given tier sizes.
+func NewBlockPool(cfg Config) *BlockPool {
+	if cfg.MaxKnownBlocks <= 0 {
+		cfg.MaxKnownBlocks = DefaultMaxKnownBlocks
+	}
+	if cfg.MaxAcceptedBlocks <= 0 {
+		cfg.MaxAcceptedBlocks = DefaultMaxAcceptedBlocks
+	}
+
+	return &BlockPool{
+		cfg:      cfg,
+		known:    make(map[[32]byte]Entry),
+		accepted: make(map[[32]byte]Entry),
+	}
+}
+
+// MarkKnown records entry as seen, evicting the oldest known block if
+// the tier is full. Returns false if entry.Hash was already known (and
+// leaves the existing record in place), true if it's new.
+func (p *BlockPool) MarkKnown(entry Entry) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, exists := p.known[entry.Hash]; exists {
+		return false
+	}
+
+	if len(p.knownOrder) >= p.cfg.MaxKnownBlocks {
+		oldest := p.knownOrder[0]
+		p.knownOrder = p.knownOrder[1:]
+		delete(p.known, oldest)
+	}
+
+	p.known[entry.Hash] = entry
+	p.knownOrder = append(p.knownOrder, entry.Hash)
+	return true
+}
+
+// IsKnown reports whether hash has already been recorded via MarkKnown.
+func (p *BlockPool) IsKnown(hash [32]byte) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, ok := p.known[hash]
+	return ok
+}
+
+// Accept records entry as validated-but-not-finalized, evicting the
+// oldest accepted block (without reinjecting its transactions — a
+// caller that cares about that should have pruned it explicitly first)
+// if the tier is full.
+func (p *BlockPool) Accept(entry Entry) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, exists := p.accepted[entry.Hash]; exists {
+		return
+	}
+
+	if len(p.acceptedOrder) >= p.cfg.MaxAcceptedBlocks {
+		oldest := p.acceptedOrder[0]
+		p.acceptedOrder = p.acceptedOrder[1:]
+		delete(p.accepted, oldest)
+	}
+
+	p.accepted[entry.Hash] = entry
+	p.acceptedOrder = append(p.acceptedOrder, entry.Hash)
+}
+
+// BlockByHash returns the payload tracked for hash, checking the
+// accepted tier first (more likely to still be relevant) and falling
+// back to the known tier.
+func (p *BlockPool) BlockByHash(hash [32]byte) (interface{}, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if entry, ok := p.accepted[hash]; ok {
+		return entry.Payload, true
+	}
+	if entry, ok := p.known[hash]; ok {
+		return entry.Payload, true
+	}
+	return nil, false
+}
+
+// PruneAcceptedBlocks removes every hash in losingHashes from the
+// accepted tier (hashes not present there are skipped), calling
+// reinject with each pruned block's Payload so the caller can pull its
+// transactions back into the mempool before the block is forgotten.
+// Returns the number of blocks actually pruned.
+func (p *BlockPool) PruneAcceptedBlocks(losingHashes [][32]byte, reinject func(payload interface{})) int {
+	p.mu.Lock()
+	toReinject := make([]interface{}, 0, len(losingHashes))
+	pruned := 0
+	for _, hash := range losingHashes {
+		entry, ok := p.accepted[hash]
+		if !ok {
+			continue
+		}
+		delete(p.accepted, hash)
+		toReinject = append(toReinject, entry.Payload)
+		pruned++
+	}
+	if pruned > 0 {
+		p.acceptedOrder = removeHashes(p.acceptedOrder, losingHashes)
+	}
+	p.mu.Unlock()
+
+	if reinject != nil {
+		for _, payload := range toReinject {
+			reinject(payload)
+		}
+	}
+
+	return pruned
+}
+
+func removeHashes(order []([32]byte), remove [][32]byte) []([32]byte) {
+	toRemove := make(map[[32]byte]bool, len(remove))
+	for _, h := range remove {
+		toRemove[h] = true
+	}
+
+	kept := order[:0:0]
+	for _, h := range order {
+		if !toRemove[h] {
+			kept = append(kept, h)
+		}
+	}
+	return kept
+}
+
+// AddReinjectedTxs increments the lifetime reinjected-transaction
+// counter. The caller (not BlockPool) knows the concrete transaction
+// type, so it does the actual mempool.StoreTx calls and reports the
+// count here afterwards.
+func (p *BlockPool) AddReinjectedTxs(n int) {
+	if n <= 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.reinjectedTxs += uint64(n)
+}
+
+// Stats returns a snapshot of pool occupancy and lifetime reinjections.
+func (p *BlockPool) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return Stats{
+		KnownCount:    len(p.known),
+		AcceptedCount: len(p.accepted),
+		ReinjectedTxs: p.reinjectedTxs,
+	}
+}
@@ -0,0 +1,87 @@
+package blockpool
+
+import "testing"
+
+func TestBlockPool_MarkKnownDedupesAndEvicts(t *testing.T) {
+	pool := NewBlockPool(Config{MaxKnownBlocks: 2, MaxAcceptedBlocks: 2})
+
+	if !pool.MarkKnown(Entry{Hash: [32]byte{1}}) {
+		t.Fatal("expected first MarkKnown of a new hash to report new")
+	}
+	if pool.MarkKnown(Entry{Hash: [32]byte{1}}) {
+		t.Fatal("expected MarkKnown of an already-known hash to report not-new")
+	}
+
+	pool.MarkKnown(Entry{Hash: [32]byte{2}})
+	pool.MarkKnown(Entry{Hash: [32]byte{3}}) // evicts hash{1}, tier capped at 2
+
+	if pool.IsKnown([32]byte{1}) {
+		t.Fatal("expected oldest known block to be evicted once over capacity")
+	}
+	if !pool.IsKnown([32]byte{2}) || !pool.IsKnown([32]byte{3}) {
+		t.Fatal("expected the two most recent known blocks to remain")
+	}
+}
+
+func TestBlockPool_PruneAcceptedBlocksReinjectsLosingBranch(t *testing.T) {
+	pool := NewBlockPool(DefaultConfig())
+
+	winner := Entry{Hash: [32]byte{1}, Number: 1, Payload: "winning-block"}
+	loserA := Entry{Hash: [32]byte{2}, Number: 1, Payload: "losing-block-a"}
+	loserB := Entry{Hash: [32]byte{3}, Number: 2, Payload: "losing-block-b"}
+
+	pool.Accept(winner)
+	pool.Accept(loserA)
+	pool.Accept(loserB)
+
+	var reinjected []interface{}
+	pruned := pool.PruneAcceptedBlocks([][32]byte{loserA.Hash, loserB.Hash}, func(payload interface{}) {
+		reinjected = append(reinjected, payload)
+	})
+
+	if pruned != 2 {
+		t.Fatalf("expected 2 blocks pruned, got %d", pruned)
+	}
+	if len(reinjected) != 2 {
+		t.Fatalf("expected 2 payloads reinjected, got %d", len(reinjected))
+	}
+	if _, ok := pool.BlockByHash(winner.Hash); !ok {
+		t.Fatal("expected winning block to remain in the accepted tier")
+	}
+	if _, ok := pool.BlockByHash(loserA.Hash); ok {
+		t.Fatal("expected pruned block to no longer be retrievable")
+	}
+
+	stats := pool.Stats()
+	if stats.AcceptedCount != 1 {
+		t.Fatalf("expected 1 accepted block remaining, got %d", stats.AcceptedCount)
+	}
+}
+
+func TestBlockPool_AddReinjectedTxsAccumulates(t *testing.T) {
+	pool := NewBlockPool(DefaultConfig())
+
+	pool.AddReinjectedTxs(3)
+	pool.AddReinjectedTxs(2)
+	pool.AddReinjectedTxs(0) // no-op
+
+	if got := pool.Stats().ReinjectedTxs; got != 5 {
+		t.Fatalf("expected 5 reinjected txs, got %d", got)
+	}
+}
+
+func TestBlockPool_BlockByHashChecksAcceptedBeforeKnown(t *testing.T) {
+	pool := NewBlockPool(DefaultConfig())
+	hash := [32]byte{9}
+
+	pool.MarkKnown(Entry{Hash: hash, Payload: "known-version"})
+	pool.Accept(Entry{Hash: hash, Payload: "accepted-version"})
+
+	payload, ok := pool.BlockByHash(hash)
+	if !ok {
+		t.Fatal("expected block to be found")
+	}
+	if payload != "accepted-version" {
+		t.Fatalf("expected accepted-tier payload to take priority, got %v", payload)
+	}
+}
@@ -0,0 +1,242 @@
+// Package blocksync is a dedicated fast-sync scheduler, inspired by
+// Tendermint's blockchain reactor: it fans requests for a contiguous
+// range of heights out across whatever peers are available round-robin,
+// tracks which height is in flight to which peer, times out and
+// redispatches slow requests, and bans peers that repeatedly serve bad
+// blocks.
+//
+// It has no dependency on p2p or consensus: heights and peer identities
+// are plain values, and a received block is an opaque Payload the owning
+// package type-asserts back to its own concrete type, the same
+// narrow-coupling approach pkg/blockpool and pkg/dispute already use to
+// avoid import cycles with the packages that actually consume them.
+//
+// This is the active-request counterpart to pkg/blockpool: blockpool
+// stages blocks that already arrived (via gossip) before they're
+// finalized, while blocksync decides which heights are still missing and
+// asks peers for them directly — the mechanism a node that's 100+ blocks
+// behind needs, where waiting on gossip alone would take too long.
+package blocksync
+
+import (
+	"sync"
+	"time"
+)
+
+// Default tuning: a request window wide enough to keep several peers
+// busy at once, a generous per-request timeout (block-fetch-and-verify
+// over a stream, not a single RPC), and a small failure budget before a
+// peer is banned outright.
+const (
+	DefaultWindowSize      = 64
+	DefaultRequestTimeout  = 15 * time.Second
+	DefaultMaxPeerFailures = 5
+
+	// BadBlockPenalty is the score penalty applied (via PeerPenalizer)
+	// the moment a peer is banned from the pool for serving bad blocks.
+	BadBlockPenalty = 500
+)
+
+// Config controls BlockPool's scheduling behavior.
+type Config struct {
+	WindowSize      int
+	RequestTimeout  time.Duration
+	MaxPeerFailures int
+}
+
+// DefaultConfig returns the standard tuning.
+func DefaultConfig() Config {
+	return Config{
+		WindowSize:      DefaultWindowSize,
+		RequestTimeout:  DefaultRequestTimeout,
+		MaxPeerFailures: DefaultMaxPeerFailures,
+	}
+}
+
+// PeerPenalizer is the subset of peer-reputation tracking BlockPool needs
+// to apply a penalty once it bans a peer, narrowed the same way
+// pkg/dispute.PeerScorer scopes its own dependency on peer scoring.
+type PeerPenalizer interface {
+	ApplyPenalty(identity string, penalty int)
+}
+
+// Request is one height BlockPool wants fetched, and the peer it was
+// assigned to.
+type Request struct {
+	Height uint64
+	Peer   string
+}
+
+// pendingRequest tracks one in-flight Request so RecordTimeout can tell
+// how long it's been outstanding.
+type pendingRequest struct {
+	peer        string
+	requestedAt time.Time
+}
+
+// BlockPool assigns missing heights to peers round-robin, keeps track of
+// what's still in flight, and redispatches or bans peers as needed. It
+// does not fetch anything itself — Schedule/RecordTimeout only decide
+// what to request and from whom; the caller (p2p) owns the actual
+// request/response exchange.
+type BlockPool struct {
+	mu sync.Mutex
+
+	cfg       Config
+	penalizer PeerPenalizer
+
+	peers    []string
+	nextPeer int
+	failures map[string]int
+	banned   map[string]bool
+
+	pending map[uint64]pendingRequest
+}
+
+// NewBlockPool creates a BlockPool. penalizer may be nil (bans are then
+// tracked internally but no score penalty is applied).
+func NewBlockPool(cfg Config, penalizer PeerPenalizer) *BlockPool {
+	if cfg.WindowSize <= 0 {
+		cfg.WindowSize = DefaultWindowSize
+	}
+	if cfg.RequestTimeout <= 0 {
+		cfg.RequestTimeout = DefaultRequestTimeout
+	}
+	if cfg.MaxPeerFailures <= 0 {
+		cfg.MaxPeerFailures = DefaultMaxPeerFailures
+	}
+
+	return &BlockPool{
+		cfg:       cfg,
+		penalizer: penalizer,
+		failures:  make(map[string]int),
+		banned:    make(map[string]bool),
+		pending:   make(map[uint64]pendingRequest),
+	}
+}
+
+// SetPeers replaces the round-robin peer set, dropping any peer that's
+// already banned.
+func (p *BlockPool) SetPeers(peers []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	kept := make([]string, 0, len(peers))
+	for _, peer := range peers {
+		if !p.banned[peer] {
+			kept = append(kept, peer)
+		}
+	}
+	p.peers = kept
+	if p.nextPeer >= len(p.peers) {
+		p.nextPeer = 0
+	}
+}
+
+// nextPeerLocked returns the next round-robin peer, or "" if none are
+// available. Callers must hold p.mu.
+func (p *BlockPool) nextPeerLocked() string {
+	if len(p.peers) == 0 {
+		return ""
+	}
+	peer := p.peers[p.nextPeer%len(p.peers)]
+	p.nextPeer++
+	return peer
+}
+
+// Schedule returns a Request for every height in [base+1, target] that
+// isn't already pending, up to WindowSize requests at a time, each
+// assigned a peer round-robin. Heights beyond what any peer is available
+// for are simply omitted from the result — the caller is expected to
+// call Schedule again once peers free up or more become known.
+func (p *BlockPool) Schedule(base, target uint64, now time.Time) []Request {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var requests []Request
+	for h := base + 1; h <= target && len(requests) < p.cfg.WindowSize; h++ {
+		if _, inFlight := p.pending[h]; inFlight {
+			continue
+		}
+		peer := p.nextPeerLocked()
+		if peer == "" {
+			break
+		}
+		p.pending[h] = pendingRequest{peer: peer, requestedAt: now}
+		requests = append(requests, Request{Height: h, Peer: peer})
+	}
+	return requests
+}
+
+// RecordTimeout redispatches any request that's been pending longer than
+// RequestTimeout, assigning it to the next peer in the round-robin
+// rotation (which may be the same peer again, if none other is
+// available).
+func (p *BlockPool) RecordTimeout(now time.Time) []Request {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var requests []Request
+	for height, req := range p.pending {
+		if now.Sub(req.requestedAt) < p.cfg.RequestTimeout {
+			continue
+		}
+		peer := p.nextPeerLocked()
+		if peer == "" {
+			continue
+		}
+		p.pending[height] = pendingRequest{peer: peer, requestedAt: now}
+		requests = append(requests, Request{Height: height, Peer: peer})
+	}
+	return requests
+}
+
+// Fulfill marks height as no longer in flight, because a valid block for
+// it was received (from whatever peer — not necessarily the one it was
+// last assigned to, since a redispatched request's original response may
+// still arrive late).
+func (p *BlockPool) Fulfill(height uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.pending, height)
+}
+
+// RecordBadBlock counts a bad (invalid or unresponsive) block against
+// peer, banning and penalizing it once MaxPeerFailures is reached.
+// Returns true if this call caused peer to be banned.
+func (p *BlockPool) RecordBadBlock(peer string) bool {
+	p.mu.Lock()
+	p.failures[peer]++
+	banned := p.failures[peer] >= p.cfg.MaxPeerFailures && !p.banned[peer]
+	if banned {
+		p.banned[peer] = true
+		kept := p.peers[:0:0]
+		for _, existing := range p.peers {
+			if existing != peer {
+				kept = append(kept, existing)
+			}
+		}
+		p.peers = kept
+	}
+	p.mu.Unlock()
+
+	if banned && p.penalizer != nil {
+		p.penalizer.ApplyPenalty(peer, BadBlockPenalty)
+	}
+	return banned
+}
+
+// IsBanned reports whether peer has been banned from the pool.
+func (p *BlockPool) IsBanned(peer string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.banned[peer]
+}
+
+// PendingCount returns how many heights currently have an in-flight
+// request, suitable for exposing through something like GetNetworkStats.
+func (p *BlockPool) PendingCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.pending)
+}
@@ -0,0 +1,147 @@
+package blocksync
+
+import (
+	"testing"
+	"time"
+)
+
+type recordingPenalizer struct {
+	penalized map[string]int
+}
+
+func (r *recordingPenalizer) ApplyPenalty(identity string, penalty int) {
+	if r.penalized == nil {
+		r.penalized = make(map[string]int)
+	}
+	r.penalized[identity] += penalty
+}
+
+func TestBlockPool_ScheduleAssignsPeersRoundRobin(t *testing.T) {
+	pool := NewBlockPool(DefaultConfig(), nil)
+	pool.SetPeers([]string{"peerA", "peerB"})
+
+	now := time.Unix(0, 0)
+	requests := pool.Schedule(0, 4, now)
+
+	if len(requests) != 4 {
+		t.Fatalf("expected 4 requests for heights 1-4, got %d", len(requests))
+	}
+	for i, want := range []string{"peerA", "peerB", "peerA", "peerB"} {
+		if requests[i].Height != uint64(i+1) || requests[i].Peer != want {
+			t.Fatalf("request %d = %+v, want height %d assigned to %s", i, requests[i], i+1, want)
+		}
+	}
+
+	// Re-scheduling immediately should request nothing new: every height
+	// in range is already pending.
+	if again := pool.Schedule(0, 4, now); len(again) != 0 {
+		t.Fatalf("expected no new requests while everything is still pending, got %+v", again)
+	}
+}
+
+func TestBlockPool_RecordTimeoutRedispatches(t *testing.T) {
+	pool := NewBlockPool(Config{WindowSize: 10, RequestTimeout: time.Second, MaxPeerFailures: 5}, nil)
+	pool.SetPeers([]string{"peerA", "peerB"})
+
+	start := time.Unix(0, 0)
+	pool.Schedule(0, 1, start) // height 1 -> peerA
+
+	if timedOut := pool.RecordTimeout(start.Add(500 * time.Millisecond)); len(timedOut) != 0 {
+		t.Fatalf("expected no timeouts before RequestTimeout elapses, got %+v", timedOut)
+	}
+
+	timedOut := pool.RecordTimeout(start.Add(2 * time.Second))
+	if len(timedOut) != 1 || timedOut[0].Height != 1 || timedOut[0].Peer != "peerB" {
+		t.Fatalf("expected height 1 redispatched to peerB, got %+v", timedOut)
+	}
+}
+
+func TestBlockPool_RecordBadBlockBansAndPenalizesAfterThreshold(t *testing.T) {
+	penalizer := &recordingPenalizer{}
+	pool := NewBlockPool(Config{WindowSize: 10, RequestTimeout: time.Second, MaxPeerFailures: 2}, penalizer)
+	pool.SetPeers([]string{"peerA", "peerB"})
+
+	if pool.RecordBadBlock("peerA") {
+		t.Fatal("expected first bad block to not yet ban the peer")
+	}
+	if !pool.RecordBadBlock("peerA") {
+		t.Fatal("expected second bad block to ban the peer")
+	}
+	if !pool.IsBanned("peerA") {
+		t.Fatal("expected peerA to be banned")
+	}
+	if penalizer.penalized["peerA"] != BadBlockPenalty {
+		t.Fatalf("expected peerA to be penalized %d, got %d", BadBlockPenalty, penalizer.penalized["peerA"])
+	}
+
+	// A banned peer should never be (re)assigned by SetPeers/Schedule.
+	pool.SetPeers([]string{"peerA", "peerB"})
+	requests := pool.Schedule(0, 1, time.Unix(0, 0))
+	if len(requests) != 1 || requests[0].Peer != "peerB" {
+		t.Fatalf("expected the only request to go to peerB, got %+v", requests)
+	}
+}
+
+func TestBlockPool_FulfillClearsPending(t *testing.T) {
+	pool := NewBlockPool(DefaultConfig(), nil)
+	pool.SetPeers([]string{"peerA"})
+
+	pool.Schedule(0, 1, time.Unix(0, 0))
+	if got := pool.PendingCount(); got != 1 {
+		t.Fatalf("expected 1 pending request, got %d", got)
+	}
+
+	pool.Fulfill(1)
+	if got := pool.PendingCount(); got != 0 {
+		t.Fatalf("expected 0 pending requests after Fulfill, got %d", got)
+	}
+}
+
+func TestBlockCache_PopContiguousDrainsInOrderRun(t *testing.T) {
+	cache := NewBlockCache()
+
+	cache.Add(3, [32]byte{3}, "block-3")
+	cache.Add(5, [32]byte{5}, "block-5")
+	cache.Add(4, [32]byte{4}, "block-4")
+
+	if cache.Len() != 3 {
+		t.Fatalf("expected 3 buffered blocks, got %d", cache.Len())
+	}
+
+	ready := cache.PopContiguous(3)
+	if len(ready) != 3 || ready[0] != "block-3" || ready[1] != "block-4" || ready[2] != "block-5" {
+		t.Fatalf("expected blocks 3,4,5 in order, got %+v", ready)
+	}
+	if cache.Len() != 0 {
+		t.Fatalf("expected cache to be drained, got %d remaining", cache.Len())
+	}
+}
+
+func TestBlockCache_PopContiguousStopsAtGap(t *testing.T) {
+	cache := NewBlockCache()
+
+	cache.Add(1, [32]byte{1}, "block-1")
+	cache.Add(3, [32]byte{3}, "block-3")
+
+	ready := cache.PopContiguous(1)
+	if len(ready) != 1 || ready[0] != "block-1" {
+		t.Fatalf("expected only block 1 to be ready, got %+v", ready)
+	}
+	if !cache.Has(3) {
+		t.Fatal("expected block 3 to remain buffered past the gap at height 2")
+	}
+}
+
+func TestBlockCache_ByHash(t *testing.T) {
+	cache := NewBlockCache()
+	hash := [32]byte{7}
+	cache.Add(1, hash, "block-1")
+
+	payload, ok := cache.ByHash(hash)
+	if !ok || payload != "block-1" {
+		t.Fatalf("expected to find block-1 by hash, got %v, %v", payload, ok)
+	}
+	if _, ok := cache.ByHash([32]byte{8}); ok {
+		t.Fatal("expected lookup of an unknown hash to miss")
+	}
+}
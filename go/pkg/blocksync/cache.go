@@ -0,0 +1,107 @@
+package blocksync
+
+import "sync"
+
+// cacheEntry is one buffered block: enough to do contiguity bookkeeping
+// (Height/Hash) without depending on the caller's concrete block type.
+// Payload carries that concrete block (e.g. a *p2p.BlockMessage), opaque
+// to BlockCache itself, the same approach pkg/blockpool.Entry takes.
+type cacheEntry struct {
+	Height  uint64
+	Hash    [32]byte
+	Payload interface{}
+}
+
+// BlockCache buffers blocks that arrive out of order — e.g. a fast-sync
+// response for height N+2 landing before N has — so they can be applied
+// the instant the missing height between them shows up, instead of being
+// discarded and re-requested.
+type BlockCache struct {
+	mu sync.Mutex
+
+	byHeight map[uint64]cacheEntry
+	byHash   map[[32]byte]cacheEntry
+}
+
+// NewBlockCache creates an empty BlockCache.
+func NewBlockCache() *BlockCache {
+	return &BlockCache{
+		byHeight: make(map[uint64]cacheEntry),
+		byHash:   make(map[[32]byte]cacheEntry),
+	}
+}
+
+// Add buffers payload under height and hash. A block already buffered at
+// height is left in place (first received wins; the caller's own
+// validation is what should have kept a conflicting block from reaching
+// here in the first place).
+func (c *BlockCache) Add(height uint64, hash [32]byte, payload interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.byHeight[height]; exists {
+		return
+	}
+	entry := cacheEntry{Height: height, Hash: hash, Payload: payload}
+	c.byHeight[height] = entry
+	c.byHash[hash] = entry
+}
+
+// Has reports whether height is currently buffered.
+func (c *BlockCache) Has(height uint64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.byHeight[height]
+	return ok
+}
+
+// ByHash returns the payload buffered under hash, if any.
+func (c *BlockCache) ByHash(hash [32]byte) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.byHash[hash]
+	if !ok {
+		return nil, false
+	}
+	return entry.Payload, true
+}
+
+// remove drops height (and its hash index entry) from the cache.
+// Callers must hold c.mu.
+func (c *BlockCache) remove(height uint64) {
+	entry, ok := c.byHeight[height]
+	if !ok {
+		return
+	}
+	delete(c.byHeight, height)
+	delete(c.byHash, entry.Hash)
+}
+
+// PopContiguous removes and returns, in order, every block buffered
+// starting at next and continuing without a gap (next, next+1, next+2,
+// ...), so a caller that just applied height next-1 can immediately
+// apply however much of the buffered run is now ready, instead of
+// draining the cache one height at a time.
+func (c *BlockCache) PopContiguous(next uint64) []interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var ready []interface{}
+	for {
+		entry, ok := c.byHeight[next]
+		if !ok {
+			break
+		}
+		ready = append(ready, entry.Payload)
+		c.remove(next)
+		next++
+	}
+	return ready
+}
+
+// Len returns how many blocks are currently buffered.
+func (c *BlockCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.byHeight)
+}
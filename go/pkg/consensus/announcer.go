@@ -0,0 +1,136 @@
+package consensus
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+// Broadcaster is the subset of a p2p manager BlockAnnouncer needs to
+// gossip a produced block to peers. It takes a *Block directly rather
+// than a p2p wire type so this package doesn't have to import p2p (which
+// already imports consensus); p2p.Manager satisfies this interface via
+// p2p.Manager.BroadcastConsensusBlock.
+type Broadcaster interface {
+	BroadcastConsensusBlock(block *Block) error
+}
+
+// EquivocationEvent describes a validator that announced two different
+// blocks at the same height: first is whichever block the announcer saw
+// first, second is the conflicting one that triggered detection.
+type EquivocationEvent struct {
+	Validator   [32]byte
+	BlockNumber uint64
+	First       *Block
+	Second      *Block
+}
+
+// EquivocationHook is called when BlockAnnouncer detects a validator
+// equivocating. Callers wire this up to whatever slashing/eviction
+// mechanism they have (see tokenomics for the reward side of validator
+// accounting); BlockAnnouncer itself has no opinion on the consequence.
+type EquivocationHook func(EquivocationEvent)
+
+// BlockRetractedEvent is emitted when a block that was already announced
+// to peers fails to commit locally, so peers know to drop it.
+type BlockRetractedEvent struct {
+	Block  *Block
+	Reason error
+}
+
+// RetractHook is called whenever BlockAnnouncer.Retract runs.
+type RetractHook func(BlockRetractedEvent)
+
+// announceKey identifies a validator's slot at a given height — the
+// granularity at which equivocation is detected.
+type announceKey struct {
+	blockNumber uint64
+	validator   [32]byte
+}
+
+// BlockAnnouncer gossips a validator's freshly sealed blocks to peers
+// before the local node commits them, following the pattern
+// go-ethereum's miner uses (post NewMinedBlockEvent before InsertChain
+// returns) to cut propagation latency: peers hear about a block the
+// instant it's signed rather than waiting on the producer's own state
+// commit.
+//
+// It also doubles as the equivocation detector: a validator announcing
+// two different blocks at the same (BlockNumber, Validator) is almost
+// always either a bug or a validator running two signers off the same
+// key, and either way peers and the slashing path need to know.
+type BlockAnnouncer struct {
+	broadcaster Broadcaster
+	log         *logger.Logger
+
+	onEquivocation EquivocationHook
+	onRetract      RetractHook
+
+	mu        sync.Mutex
+	announced map[announceKey]*Block
+}
+
+// NewBlockAnnouncer creates a BlockAnnouncer that gossips through
+// broadcaster. onEquivocation and onRetract may be nil.
+func NewBlockAnnouncer(broadcaster Broadcaster, onEquivocation EquivocationHook, onRetract RetractHook, log *logger.Logger) *BlockAnnouncer {
+	return &BlockAnnouncer{
+		broadcaster:    broadcaster,
+		log:            log,
+		onEquivocation: onEquivocation,
+		onRetract:      onRetract,
+		announced:      make(map[announceKey]*Block),
+	}
+}
+
+// Announce records block as this height's announcement for its
+// validator and gossips it to peers. If the validator already announced
+// a different block at the same height, Announce rejects it and fires
+// onEquivocation instead of broadcasting a second, conflicting block.
+func (a *BlockAnnouncer) Announce(block *Block) error {
+	key := announceKey{blockNumber: block.Number(), validator: block.Validator()}
+
+	a.mu.Lock()
+	if prev, ok := a.announced[key]; ok && prev.Hash() != block.Hash() {
+		a.mu.Unlock()
+
+		event := EquivocationEvent{
+			Validator:   block.Validator(),
+			BlockNumber: block.Number(),
+			First:       prev,
+			Second:      block,
+		}
+		if a.log != nil {
+			a.log.WithFields(logger.Fields{
+				"validator":    fmt.Sprintf("%x", block.Validator()[:8]),
+				"block_number": block.Number(),
+				"first_hash":   fmt.Sprintf("%x", prev.Hash()[:8]),
+				"second_hash":  fmt.Sprintf("%x", block.Hash()[:8]),
+			}).Warn("Equivocation detected: validator announced two different blocks at the same height")
+		}
+		if a.onEquivocation != nil {
+			a.onEquivocation(event)
+		}
+
+		return fmt.Errorf("equivocation: validator %x already announced a different block at height %d", block.Validator()[:8], block.Number())
+	}
+	a.announced[key] = block
+	a.mu.Unlock()
+
+	return a.broadcaster.BroadcastConsensusBlock(block)
+}
+
+// Retract tells peers to drop block, because it was already announced
+// but then failed to commit locally. reason is carried along for
+// logging/diagnostics; it is not sent to peers.
+func (a *BlockAnnouncer) Retract(block *Block, reason error) {
+	if a.log != nil {
+		a.log.WithFields(logger.Fields{
+			"block_number": block.Number(),
+			"block_hash":   fmt.Sprintf("%x", block.Hash()[:8]),
+		}).WithError(reason).Warn("Retracting announced block after local commit failure")
+	}
+	if a.onRetract != nil {
+		a.onRetract(BlockRetractedEvent{Block: block, Reason: reason})
+	}
+}
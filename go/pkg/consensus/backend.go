@@ -0,0 +1,24 @@
+package consensus
+
+// CryptoBackend abstracts the hashing and proof-verification primitives
+// that consensus code needs, so that code like HeaderChain and
+// api.verifyProofWithRust can be written once and run against either
+// the Rust/CGO implementation or a pure-Go fallback.
+//
+// SHA256, MerkleRoot, and HeaderHash don't return an error: both
+// backends are expected to always succeed for well-formed input, and a
+// backend that can't compute a hash has a bug, not a recoverable error
+// condition.
+type CryptoBackend interface {
+	SHA256(data []byte) [32]byte
+	MerkleRoot(txHashes [][32]byte) [32]byte
+	HeaderHash(header *BlockHeader) [32]byte
+	VerifySubsetSum(problem *SubsetSumProblem, solution *SubsetSumSolution, budget *VerifyBudget) (bool, error)
+}
+
+// DefaultBackend returns the CryptoBackend appropriate for how the
+// binary was built: the Rust/CGO backend when compiled with
+// CGO_ENABLED=1, or the pure-Go backend otherwise.
+func DefaultBackend() CryptoBackend {
+	return newPlatformBackend()
+}
@@ -0,0 +1,44 @@
+//go:build cgo
+// +build cgo
+
+package consensus
+
+import "fmt"
+
+// rustBackend implements CryptoBackend on top of the existing Rust/CGO
+// bindings in rust_bindings.go.
+type rustBackend struct{}
+
+func newPlatformBackend() CryptoBackend {
+	return rustBackend{}
+}
+
+// SHA256 panics if the Rust FFI call fails, since a well-formed input
+// should never cause SHA256Hash to error.
+func (rustBackend) SHA256(data []byte) [32]byte {
+	hash, err := SHA256Hash(data)
+	if err != nil {
+		panic(fmt.Sprintf("rust backend: SHA256 failed: %v", err))
+	}
+	return hash
+}
+
+func (rustBackend) MerkleRoot(txHashes [][32]byte) [32]byte {
+	root, err := ComputeMerkleRoot(txHashes)
+	if err != nil {
+		panic(fmt.Sprintf("rust backend: MerkleRoot failed: %v", err))
+	}
+	return root
+}
+
+func (rustBackend) HeaderHash(header *BlockHeader) [32]byte {
+	hash, err := ComputeHeaderHash(header)
+	if err != nil {
+		panic(fmt.Sprintf("rust backend: HeaderHash failed: %v", err))
+	}
+	return hash
+}
+
+func (rustBackend) VerifySubsetSum(problem *SubsetSumProblem, solution *SubsetSumSolution, budget *VerifyBudget) (bool, error) {
+	return VerifySubsetSum(problem, solution, budget)
+}
@@ -0,0 +1,8 @@
+//go:build !cgo
+// +build !cgo
+
+package consensus
+
+func newPlatformBackend() CryptoBackend {
+	return pureGoBackend{}
+}
@@ -0,0 +1,75 @@
+//go:build cgo
+// +build cgo
+
+package consensus
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBackendParity_AllVectors runs every golden vector through both the
+// Rust/CGO backend and the pure-Go backend and asserts they agree. This
+// complements TestRustParity_AllVectors (Rust-vs-expected) by also
+// catching Go-vs-Go divergence between the two CryptoBackend
+// implementations, which is the failure mode that only ever shows up
+// once someone builds with CGO_ENABLED=0.
+func TestBackendParity_AllVectors(t *testing.T) {
+	vectors := loadGoldenVectors(t)
+
+	rust := rustBackend{}
+	pure := pureGoBackend{}
+
+	var mismatches int
+
+	for _, vec := range vectors.Vectors {
+		switch vec.Operation {
+		case "SHA256":
+			input := hexDecode(t, vec.InputHex)
+			rustHash := rust.SHA256(input)
+			pureHash := pure.SHA256(input)
+			if !assert.Equal(t, rustHash, pureHash, "SHA256 backend divergence for %s", vec.TestName) {
+				mismatches++
+			}
+
+		case "MERKLE":
+			var txHashes [][32]byte
+			for _, hashHex := range vec.TxHashes {
+				txHashes = append(txHashes, hexDecode32(t, hashHex))
+			}
+			rustRoot := rust.MerkleRoot(txHashes)
+			pureRoot := pure.MerkleRoot(txHashes)
+			if !assert.Equal(t, rustRoot, pureRoot, "MerkleRoot backend divergence for %s", vec.TestName) {
+				mismatches++
+			}
+
+		case "BLOCK_HEADER":
+			require.NotNil(t, vec.Header, "Header cannot be nil")
+			header := &BlockHeader{
+				CodecVersion:     vec.Header.CodecVersion,
+				BlockIndex:       vec.Header.BlockIndex,
+				Timestamp:        vec.Header.Timestamp,
+				ParentHash:       hexDecode32(t, vec.Header.ParentHash),
+				MerkleRoot:       hexDecode32(t, vec.Header.MerkleRoot),
+				MinerAddress:     hexDecode32(t, vec.Header.MinerAddress),
+				Commitment:       hexDecode32(t, vec.Header.Commitment),
+				DifficultyTarget: vec.Header.DifficultyTarget,
+				Nonce:            vec.Header.Nonce,
+				ExtraData:        hexDecode(t, vec.Header.ExtraData),
+			}
+			rustHash := rust.HeaderHash(header)
+			pureHash := pure.HeaderHash(header)
+			if !assert.Equal(t, rustHash, pureHash, "HeaderHash backend divergence for %s (rust=%s pure=%s)",
+				vec.TestName, hex.EncodeToString(rustHash[:]), hex.EncodeToString(pureHash[:])) {
+				mismatches++
+			}
+		}
+	}
+
+	if mismatches > 0 {
+		t.Fatalf("%d vector(s) diverged between the Rust and pure-Go backends", mismatches)
+	}
+}
@@ -0,0 +1,361 @@
+// Package bft is a Tendermint-style two-phase (prevote/precommit)
+// finality gadget meant to run layered over whichever Engine proposes
+// blocks (RoundRobinEngine today): once a height's block gathers 2f+1
+// matching prevotes it's provisionally locked, and once it gathers
+// 2f+1 matching precommits it's final, giving deterministic finality
+// instead of relying solely on a fork-choice hash tiebreak.
+//
+// It is deliberately standalone, the same way wal and engine.go are:
+// nothing in this tree's block-production path (consensus.Producer and
+// its ConsensusConfig) feeds RoundState's tallies or reads Finality's
+// height. ConsensusConfig carries no BFTEnabled, F or VoteTransport
+// field (VoteTransport is defined here, since the vote shape it carries
+// belongs to this package), and no fork-choice rule in this tree
+// consults Finality.IsFinal before a reorg.
+package bft
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/consensus/wal"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/signer"
+)
+
+// VoteType distinguishes a prevote (the first phase, "I saw this
+// proposal") from a precommit (the second, "2f+1 others saw it too").
+type VoteType uint8
+
+const (
+	VotePrevote VoteType = iota
+	VotePrecommit
+)
+
+func (v VoteType) String() string {
+	switch v {
+	case VotePrevote:
+		return "prevote"
+	case VotePrecommit:
+		return "precommit"
+	default:
+		return "unknown"
+	}
+}
+
+// Vote is one validator's signed prevote or precommit for a given
+// height, round, and proposed block hash.
+type Vote struct {
+	Height    uint64
+	Round     uint64
+	Type      VoteType
+	BlockHash [32]byte
+	Validator [32]byte
+	Signature []byte
+}
+
+// signingBytes is what Validator's signature actually covers: every
+// field except the signature itself, the same "sign everything but the
+// signature" shape consensus.cliqueSigHash uses for block headers.
+func signingBytes(height, round uint64, voteType VoteType, blockHash [32]byte) []byte {
+	buf := make([]byte, 0, 8+8+1+32)
+	buf = binary.BigEndian.AppendUint64(buf, height)
+	buf = binary.BigEndian.AppendUint64(buf, round)
+	buf = append(buf, byte(voteType))
+	buf = append(buf, blockHash[:]...)
+	return buf
+}
+
+// SignVote builds and signs a Vote on behalf of validatorSigner.
+func SignVote(validatorSigner signer.Signer, height, round uint64, voteType VoteType, blockHash [32]byte) (Vote, error) {
+	msg := signingBytes(height, round, voteType, blockHash)
+	sig, err := validatorSigner.Sign(msg)
+	if err != nil {
+		return Vote{}, fmt.Errorf("bft: failed to sign %s for height %d: %w", voteType, height, err)
+	}
+
+	var validator [32]byte
+	copy(validator[:], validatorSigner.Public())
+
+	return Vote{
+		Height:    height,
+		Round:     round,
+		Type:      voteType,
+		BlockHash: blockHash,
+		Validator: validator,
+		Signature: sig,
+	}, nil
+}
+
+// VerifyVote checks that v's signature matches its claimed Validator.
+// It does not check whether Validator is an authorized validator —
+// that's the caller's responsibility, the same split VerifyHeader/
+// CliqueEngine.snapshot uses for block signatures.
+func VerifyVote(v Vote) bool {
+	msg := signingBytes(v.Height, v.Round, v.Type, v.BlockHash)
+	return ed25519.Verify(ed25519.PublicKey(v.Validator[:]), msg, v.Signature)
+}
+
+// VoteTransport broadcasts this validator's own votes to the rest of
+// the network and surfaces votes received from others.
+// consensus.ConsensusConfig has no field of this type.
+type VoteTransport interface {
+	Broadcast(Vote) error
+	Inbox() <-chan Vote
+}
+
+// Proposer returns the validator whose turn height+round is, the same
+// round-robin rule RoundRobinEngine uses for height alone, extended so
+// a timed-out round advances to the next validator rather than
+// re-proposing.
+func Proposer(validators [][32]byte, height, round uint64) ([32]byte, error) {
+	if len(validators) == 0 {
+		return [32]byte{}, fmt.Errorf("bft: no validators configured")
+	}
+	return validators[(height+round)%uint64(len(validators))], nil
+}
+
+// RoundTimeout returns how long to wait for round to gather quorum
+// before advancing to round+1, growing exponentially off base up to
+// max — the same escalating patience Tendermint gives a slow or
+// partitioned round before moving on.
+func RoundTimeout(round uint64, base, max time.Duration) time.Duration {
+	timeout := base
+	for i := uint64(0); i < round; i++ {
+		timeout *= 2
+		if timeout >= max {
+			return max
+		}
+	}
+	return timeout
+}
+
+// RoundState tallies prevotes and precommits for a single height
+// across however many rounds it takes to reach quorum. Quorum is
+// 2f+1 out of the validator set, the standard BFT threshold tolerating
+// up to f Byzantine validators out of N = 3f+1.
+type RoundState struct {
+	mu         sync.Mutex
+	validators map[[32]byte]bool
+	f          int
+	height     uint64
+
+	// round -> blockHash -> validator -> seen, tallied separately so a
+	// minority round's votes don't count toward another round's quorum.
+	prevotes   map[uint64]map[[32]byte]map[[32]byte]bool
+	precommits map[uint64]map[[32]byte]map[[32]byte]bool
+}
+
+// NewRoundState builds a RoundState for height over validators,
+// tolerating up to f Byzantine validators.
+func NewRoundState(validators [][32]byte, f int, height uint64) *RoundState {
+	set := make(map[[32]byte]bool, len(validators))
+	for _, v := range validators {
+		set[v] = true
+	}
+	return &RoundState{
+		validators: set,
+		f:          f,
+		height:     height,
+		prevotes:   make(map[uint64]map[[32]byte]map[[32]byte]bool),
+		precommits: make(map[uint64]map[[32]byte]map[[32]byte]bool),
+	}
+}
+
+// quorum is the number of matching votes needed: 2f+1.
+func (rs *RoundState) quorum() int {
+	return 2*rs.f + 1
+}
+
+// AddVote records v (which must be for this RoundState's height and
+// already signature-verified by the caller) and reports whether its
+// (round, blockHash, type) has now reached quorum.
+func (rs *RoundState) AddVote(v Vote) (reachedQuorum bool, err error) {
+	if v.Height != rs.height {
+		return false, fmt.Errorf("bft: vote for height %d does not belong to this round state (height %d)", v.Height, rs.height)
+	}
+	if !rs.validators[v.Validator] {
+		return false, fmt.Errorf("bft: %x is not a validator for height %d", v.Validator[:8], rs.height)
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	byRound := rs.prevotes
+	if v.Type == VotePrecommit {
+		byRound = rs.precommits
+	}
+
+	if byRound[v.Round] == nil {
+		byRound[v.Round] = make(map[[32]byte]map[[32]byte]bool)
+	}
+	if byRound[v.Round][v.BlockHash] == nil {
+		byRound[v.Round][v.BlockHash] = make(map[[32]byte]bool)
+	}
+	byRound[v.Round][v.BlockHash][v.Validator] = true
+
+	return len(byRound[v.Round][v.BlockHash]) >= rs.quorum(), nil
+}
+
+// Finality tracks the highest height this node has seen reach
+// precommit quorum, refusing to move it backwards. A driver's
+// GetStats(), once one exists, would expose FinalizedHeight() directly;
+// its forkChoice would check IsFinal before considering a reorg at or
+// below it.
+type Finality struct {
+	mu     sync.Mutex
+	height uint64
+	set    bool
+}
+
+// MarkFinal records height as finalized, rejecting any height at or
+// below what's already been finalized — finality can only move
+// forward.
+func (f *Finality) MarkFinal(height uint64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.set && height <= f.height {
+		return fmt.Errorf("bft: height %d is at or below the already-finalized height %d", height, f.height)
+	}
+	f.height = height
+	f.set = true
+	return nil
+}
+
+// FinalizedHeight returns the highest height marked final so far, and
+// whether any height has been finalized yet.
+func (f *Finality) FinalizedHeight() (height uint64, ok bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.height, f.set
+}
+
+// IsFinal reports whether height is at or below the finalized height —
+// forkChoice's reorg guard, once forkChoice exists.
+func (f *Finality) IsFinal(height uint64) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.set && height <= f.height
+}
+
+// ==================== Double-sign protection ====================
+
+// encodeVote serializes v for WAL persistence.
+func encodeVote(v Vote) []byte {
+	buf := make([]byte, 0, 8+8+1+32+32+len(v.Signature))
+	buf = binary.BigEndian.AppendUint64(buf, v.Height)
+	buf = binary.BigEndian.AppendUint64(buf, v.Round)
+	buf = append(buf, byte(v.Type))
+	buf = append(buf, v.BlockHash[:]...)
+	buf = append(buf, v.Validator[:]...)
+	buf = append(buf, v.Signature...)
+	return buf
+}
+
+// decodeVote is encodeVote's inverse.
+func decodeVote(buf []byte) (Vote, error) {
+	const fixedLen = 8 + 8 + 1 + 32 + 32
+	if len(buf) < fixedLen {
+		return Vote{}, fmt.Errorf("bft: truncated vote record")
+	}
+
+	v := Vote{
+		Height: binary.BigEndian.Uint64(buf[0:8]),
+		Round:  binary.BigEndian.Uint64(buf[8:16]),
+		Type:   VoteType(buf[16]),
+	}
+	copy(v.BlockHash[:], buf[17:49])
+	copy(v.Validator[:], buf[49:81])
+	v.Signature = append([]byte(nil), buf[81:]...)
+	return v, nil
+}
+
+// SignGuard prevents this validator from signing two conflicting votes
+// for the same (height, round, type) — the double-signing a slashing
+// condition would otherwise punish — by persisting every vote it signs
+// to a wal.Writer and replaying that history back on restart before
+// resuming.
+type SignGuard struct {
+	mu     sync.Mutex
+	writer *wal.Writer
+	// highest[type] is the highest (height, round) this validator has
+	// signed that vote type for.
+	highest map[VoteType]Vote
+}
+
+// NewSignGuard wraps writer (already positioned past any replayed
+// history — see LoadSignGuard) as a SignGuard.
+func NewSignGuard(writer *wal.Writer) *SignGuard {
+	return &SignGuard{writer: writer, highest: make(map[VoteType]Vote)}
+}
+
+// LoadSignGuard opens dir's WAL, replays every EventVoteSigned record
+// to reconstruct the highest vote signed per type, and returns a
+// SignGuard ready to keep appending to it.
+func LoadSignGuard(dir string, maxSegmentBytes int64) (*SignGuard, error) {
+	writer, err := wal.OpenWriter(dir, maxSegmentBytes)
+	if err != nil {
+		return nil, fmt.Errorf("bft: failed to open sign-guard WAL: %w", err)
+	}
+
+	guard := NewSignGuard(writer)
+	err = wal.Replay(dir, 0, func(r wal.Record) error {
+		if r.Kind != wal.EventVoteSigned {
+			return nil
+		}
+		v, err := decodeVote(r.Payload)
+		if err != nil {
+			return err
+		}
+		guard.recordLocked(v)
+		return nil
+	})
+	if err != nil {
+		writer.Close()
+		return nil, fmt.Errorf("bft: failed to replay sign-guard WAL: %w", err)
+	}
+
+	return guard, nil
+}
+
+// recordLocked updates highest[v.Type] to v if v is newer; it does not
+// itself check for equivocation (CheckAndRecord does) since replay
+// trusts whatever this validator already signed.
+func (g *SignGuard) recordLocked(v Vote) {
+	current, ok := g.highest[v.Type]
+	if !ok || v.Height > current.Height || (v.Height == current.Height && v.Round > current.Round) {
+		g.highest[v.Type] = v
+	}
+}
+
+// CheckAndRecord rejects v if it would equivocate against the highest
+// vote of the same type already signed — a second, different
+// blockHash at a (height, round) this validator already voted in — and
+// otherwise persists v to the WAL and records it as the new highest.
+func (g *SignGuard) CheckAndRecord(v Vote) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if current, ok := g.highest[v.Type]; ok {
+		if v.Height == current.Height && v.Round == current.Round && v.BlockHash != current.BlockHash {
+			return fmt.Errorf("bft: refusing to sign a conflicting %s for height %d round %d (already signed block %x)", v.Type, v.Height, v.Round, current.BlockHash[:8])
+		}
+		if v.Height < current.Height || (v.Height == current.Height && v.Round < current.Round) {
+			return fmt.Errorf("bft: refusing to sign a %s for height %d round %d older than the last signed (height %d round %d)", v.Type, v.Height, v.Round, current.Height, current.Round)
+		}
+	}
+
+	if err := g.writer.Append(wal.EventVoteSigned, v.Height, encodeVote(v)); err != nil {
+		return fmt.Errorf("bft: failed to persist signed vote: %w", err)
+	}
+	g.recordLocked(v)
+	return nil
+}
+
+// Close closes the SignGuard's underlying WAL.
+func (g *SignGuard) Close() error {
+	return g.writer.Close()
+}
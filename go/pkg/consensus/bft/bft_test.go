@@ -0,0 +1,220 @@
+package bft
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/signer"
+)
+
+func TestSignVoteThenVerifyVoteRoundTrips(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	s := signer.NewMemorySigner(pub, priv)
+
+	v, err := SignVote(s, 10, 0, VotePrevote, [32]byte{7})
+	if err != nil {
+		t.Fatalf("SignVote: %v", err)
+	}
+	if !VerifyVote(v) {
+		t.Fatal("expected VerifyVote to accept a vote signed by its own claimed validator")
+	}
+
+	v.BlockHash[0] ^= 0xff
+	if VerifyVote(v) {
+		t.Fatal("expected VerifyVote to reject a vote whose signed fields were altered after signing")
+	}
+}
+
+func TestProposerRotatesByHeightAndRound(t *testing.T) {
+	validators := [][32]byte{{1}, {2}, {3}}
+
+	author, err := Proposer(validators, 5, 0)
+	if err != nil {
+		t.Fatalf("Proposer: %v", err)
+	}
+	if author != validators[5%3] {
+		t.Fatalf("Proposer(5, 0) = %x, want %x", author[:8], validators[5%3][:8])
+	}
+
+	// A round advance should shift the proposer even at the same
+	// height, so a timed-out round doesn't just re-propose forever.
+	advanced, err := Proposer(validators, 5, 1)
+	if err != nil {
+		t.Fatalf("Proposer: %v", err)
+	}
+	if advanced == author {
+		t.Fatal("expected advancing the round to change the proposer")
+	}
+}
+
+func TestRoundTimeoutGrowsExponentiallyAndCaps(t *testing.T) {
+	base := 100 * time.Millisecond
+	max := 2 * time.Second
+
+	if got := RoundTimeout(0, base, max); got != base {
+		t.Fatalf("RoundTimeout(0) = %v, want %v", got, base)
+	}
+	if got := RoundTimeout(1, base, max); got != 2*base {
+		t.Fatalf("RoundTimeout(1) = %v, want %v", got, 2*base)
+	}
+	if got := RoundTimeout(10, base, max); got != max {
+		t.Fatalf("RoundTimeout(10) = %v, want the cap %v, got %v", max, got, got)
+	}
+}
+
+// TestRoundState_MajorityPartitionFinalizesWhileMinorityStalls splits 4
+// validators into a 3-validator majority and a 1-validator minority,
+// and asserts the majority reaches 2f+1 quorum (f=1, quorum=3) on its
+// own while the minority alone never can.
+func TestRoundState_MajorityPartitionFinalizesWhileMinorityStalls(t *testing.T) {
+	validators := make([][32]byte, 4)
+	signers := make([]signer.Signer, 4)
+	for i := range validators {
+		pub, priv, _ := ed25519.GenerateKey(nil)
+		signers[i] = signer.NewMemorySigner(pub, priv)
+		copy(validators[i][:], pub)
+	}
+
+	const f = 1
+	const height = 1
+	blockHash := [32]byte{9}
+
+	majority := []int{0, 1, 2}
+	minority := []int{3}
+
+	majorityState := NewRoundState(validators, f, height)
+	var majorityReachedQuorum bool
+	for _, i := range majority {
+		v, err := SignVote(signers[i], height, 0, VotePrecommit, blockHash)
+		if err != nil {
+			t.Fatalf("SignVote: %v", err)
+		}
+		if !VerifyVote(v) {
+			t.Fatal("expected a validly signed vote to verify")
+		}
+		quorum, err := majorityState.AddVote(v)
+		if err != nil {
+			t.Fatalf("AddVote: %v", err)
+		}
+		majorityReachedQuorum = quorum
+	}
+	if !majorityReachedQuorum {
+		t.Fatal("expected the 3-validator majority to reach 2f+1 quorum on its own")
+	}
+
+	minorityState := NewRoundState(validators, f, height)
+	var minorityReachedQuorum bool
+	for _, i := range minority {
+		v, err := SignVote(signers[i], height, 0, VotePrecommit, blockHash)
+		if err != nil {
+			t.Fatalf("SignVote: %v", err)
+		}
+		quorum, err := minorityState.AddVote(v)
+		if err != nil {
+			t.Fatalf("AddVote: %v", err)
+		}
+		minorityReachedQuorum = minorityReachedQuorum || quorum
+	}
+	if minorityReachedQuorum {
+		t.Fatal("expected the 1-validator minority to stall, never reaching quorum")
+	}
+}
+
+func TestFinality_MarkFinalRejectsMovingBackwards(t *testing.T) {
+	var finality Finality
+
+	if err := finality.MarkFinal(10); err != nil {
+		t.Fatalf("MarkFinal(10): %v", err)
+	}
+	if err := finality.MarkFinal(5); err == nil {
+		t.Fatal("expected MarkFinal to reject a height at or below the already-finalized height")
+	}
+	if err := finality.MarkFinal(10); err == nil {
+		t.Fatal("expected MarkFinal to reject re-finalizing the same height")
+	}
+
+	height, ok := finality.FinalizedHeight()
+	if !ok || height != 10 {
+		t.Fatalf("FinalizedHeight() = (%d, %v), want (10, true)", height, ok)
+	}
+	if !finality.IsFinal(10) || !finality.IsFinal(3) {
+		t.Fatal("expected IsFinal to hold for the finalized height and everything below it")
+	}
+	if finality.IsFinal(11) {
+		t.Fatal("expected IsFinal to be false past the finalized height")
+	}
+}
+
+func TestSignGuard_RejectsEquivocationButAllowsProgress(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	s := signer.NewMemorySigner(pub, priv)
+
+	dir := t.TempDir()
+	guard, err := LoadSignGuard(dir, 0)
+	if err != nil {
+		t.Fatalf("LoadSignGuard: %v", err)
+	}
+	defer guard.Close()
+
+	first, err := SignVote(s, 1, 0, VotePrecommit, [32]byte{1})
+	if err != nil {
+		t.Fatalf("SignVote: %v", err)
+	}
+	if err := guard.CheckAndRecord(first); err != nil {
+		t.Fatalf("CheckAndRecord(first): %v", err)
+	}
+
+	conflicting, err := SignVote(s, 1, 0, VotePrecommit, [32]byte{2})
+	if err != nil {
+		t.Fatalf("SignVote: %v", err)
+	}
+	if err := guard.CheckAndRecord(conflicting); err == nil {
+		t.Fatal("expected CheckAndRecord to reject a conflicting vote for the same height/round")
+	}
+
+	next, err := SignVote(s, 2, 0, VotePrecommit, [32]byte{1})
+	if err != nil {
+		t.Fatalf("SignVote: %v", err)
+	}
+	if err := guard.CheckAndRecord(next); err != nil {
+		t.Fatalf("CheckAndRecord(next): %v", err)
+	}
+}
+
+func TestSignGuard_SurvivesRestartAndStillRejectsEquivocation(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	s := signer.NewMemorySigner(pub, priv)
+
+	dir := t.TempDir()
+	guard, err := LoadSignGuard(dir, 0)
+	if err != nil {
+		t.Fatalf("LoadSignGuard: %v", err)
+	}
+
+	v, err := SignVote(s, 5, 0, VotePrecommit, [32]byte{3})
+	if err != nil {
+		t.Fatalf("SignVote: %v", err)
+	}
+	if err := guard.CheckAndRecord(v); err != nil {
+		t.Fatalf("CheckAndRecord: %v", err)
+	}
+	if err := guard.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	restarted, err := LoadSignGuard(dir, 0)
+	if err != nil {
+		t.Fatalf("LoadSignGuard after restart: %v", err)
+	}
+	defer restarted.Close()
+
+	conflicting, err := SignVote(s, 5, 0, VotePrecommit, [32]byte{4})
+	if err != nil {
+		t.Fatalf("SignVote: %v", err)
+	}
+	if err := restarted.CheckAndRecord(conflicting); err == nil {
+		t.Fatal("expected the restarted SignGuard to still remember and reject the pre-restart vote")
+	}
+}
@@ -0,0 +1,68 @@
+package consensus
+
+import "testing"
+
+func TestBlobSidecar_BlobGasUsed(t *testing.T) {
+	sc := BlobSidecar{Blobs: [][]byte{{1}, {2}, {3}}}
+
+	if got, want := sc.BlobGasUsed(), uint64(3*GasPerBlob); got != want {
+		t.Errorf("BlobGasUsed() = %d, want %d", got, want)
+	}
+}
+
+func TestValidateBlobSidecars_RejectsTooManyBlobs(t *testing.T) {
+	var blobs [][]byte
+	var hashes [][32]byte
+	for i := 0; i < MaxBlobsPerBlock+1; i++ {
+		blobs = append(blobs, []byte{byte(i)})
+		hashes = append(hashes, addr(byte(i)))
+	}
+
+	err := ValidateBlobSidecars([]BlobSidecar{{BlobHashes: hashes, Blobs: blobs}})
+	if err == nil {
+		t.Fatal("expected an error for a block exceeding MaxBlobsPerBlock")
+	}
+}
+
+func TestValidateBlobSidecars_RejectsMismatchedHashesAndBlobs(t *testing.T) {
+	sc := BlobSidecar{BlobHashes: [][32]byte{addr(1), addr(2)}, Blobs: [][]byte{{1}}}
+
+	if err := ValidateBlobSidecars([]BlobSidecar{sc}); err == nil {
+		t.Fatal("expected an error when blob hash count and blob count differ")
+	}
+}
+
+func TestValidateBlobSidecars_AcceptsWithinCap(t *testing.T) {
+	sc := BlobSidecar{BlobHashes: [][32]byte{addr(1)}, Blobs: [][]byte{{1}}}
+
+	if err := ValidateBlobSidecars([]BlobSidecar{sc}); err != nil {
+		t.Errorf("unexpected error for a sidecar within MaxBlobsPerBlock: %v", err)
+	}
+}
+
+func TestValidateBlobSidecars_RejectsMismatchedCommitments(t *testing.T) {
+	sc := BlobSidecar{
+		BlobHashes:  [][32]byte{addr(1)},
+		Blobs:       [][]byte{{1}},
+		Commitments: [][48]byte{{1}, {2}},
+	}
+
+	if err := ValidateBlobSidecars([]BlobSidecar{sc}); err == nil {
+		t.Fatal("expected an error when commitment count doesn't match blob count")
+	}
+}
+
+func TestBlobVersionedHash_SetsVersionByte(t *testing.T) {
+	hash := BlobVersionedHash([48]byte{1, 2, 3})
+	if hash[0] != blobCommitmentVersion {
+		t.Errorf("expected version byte %#x, got %#x", blobCommitmentVersion, hash[0])
+	}
+}
+
+func TestBlobVersionedHash_DifferentCommitmentsDeriveDifferentHashes(t *testing.T) {
+	a := BlobVersionedHash([48]byte{1})
+	b := BlobVersionedHash([48]byte{2})
+	if a == b {
+		t.Error("expected different commitments to derive different versioned hashes")
+	}
+}
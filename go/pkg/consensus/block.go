@@ -0,0 +1,295 @@
+package consensus
+
+import (
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
+)
+
+// DefaultGasLimit is the gas limit applied to blocks built via NewBlock.
+// BlockBuilder.maxGasPerBlock is the mempool-facing cap on what goes
+// into a block; this is the header field that records it.
+const DefaultGasLimit uint64 = 30_000_000
+
+// Header holds everything about a block except its transactions:
+// linkage to its parent, the roots committing to transactions/state/
+// receipts, and the PoA sealing fields (validator, nonce, difficulty).
+//
+// Header is not safe to mutate once it's inside a *Block — obtain a new
+// Block via (*Block).WithSeal instead of editing a Header in place.
+type Header struct {
+	BlockNumber  uint64
+	ParentHash   [32]byte
+	TxRoot       [32]byte
+	StateRoot    [32]byte
+	ReceiptsRoot [32]byte
+	Validator    [32]byte
+	Timestamp    int64
+	GasLimit     uint64
+	GasUsed      uint64
+	ExtraData    []byte
+	Nonce        uint64
+	Difficulty   uint64
+	// BaseFee is this block's EIP-1559-style base fee (see
+	// tokenomics.BaseFeeConfig/ComputeNextBaseFee): the per-gas amount
+	// burned outright rather than paid to the validator. Zero for
+	// chains that haven't activated the base-fee market.
+	BaseFee uint64
+	// LogsBloom is ComputeBlockBloom of this block's receipts: the OR of
+	// every receipt's own LogsBloom, letting a client rule out "no log in
+	// this block matches my filter" without fetching any receipt.
+	LogsBloom Bloom
+	// ExcessBlobGas is the running accumulator EIP-4844's blob-gas fee
+	// market reads to derive this block's blob base fee (see
+	// tokenomics.BlobFeeConfig/CalcBlobBaseFee), analogous to BaseFee
+	// above but tracking blob gas instead of calldata gas. Zero for
+	// chains that haven't activated the blob-gas market.
+	ExcessBlobGas uint64
+	// BlobGasUsed is this block's own blob gas consumption (see
+	// BlobSidecar.BlobGasUsed), the per-block input NextExcessBlobGas
+	// folds into ExcessBlobGas for the following block — distinct from
+	// ExcessBlobGas, which is the carried-forward accumulator, not this
+	// block's usage.
+	BlobGasUsed uint64
+	// BeaconRound and BeaconSignature are the randomness-beacon round
+	// this block's validator was elected against and that validator's
+	// LeaderTicket proof for it (see LeaderElection/ComputeLeaderTicket
+	// in leader_election.go). Both are zero/nil for chains that haven't
+	// activated beacon-driven leader election and still trust Validator
+	// as claimed, the same way LogsBloom is zero until the receipt-log
+	// feature it backs is activated.
+	BeaconRound     uint64
+	BeaconSignature []byte
+	// CoSigners lists every validator address that co-signed this block,
+	// for chains where more than one validator attests to a block (e.g.
+	// a BFT precommit quorum) rather than the single PoA Validator above
+	// sealing it alone. Nil for chains that haven't activated multi-
+	// signer blocks, the same way BeaconRound/BeaconSignature are zero
+	// until beacon-driven leader election is. See
+	// BlockProcessor.SetPowerTable: Process splits a block's validator
+	// reward across CoSigners instead of paying it entirely to Validator
+	// once a PowerTable is configured, CoSigners has more than one
+	// entry, and BaseFee is zero.
+	CoSigners [][32]byte
+}
+
+// clone returns a deep copy of h, so callers can build a modified
+// Header without aliasing the original's ExtraData slice.
+func (h *Header) clone() *Header {
+	cp := *h
+	cp.ExtraData = append([]byte(nil), h.ExtraData...)
+	cp.BeaconSignature = append([]byte(nil), h.BeaconSignature...)
+	cp.CoSigners = append([][32]byte(nil), h.CoSigners...)
+	return &cp
+}
+
+// Body holds a block's transactions.
+type Body struct {
+	Transactions []*mempool.Transaction
+}
+
+// clone returns a copy of b with its own backing array, so mutating the
+// clone's slice (or the caller's original slice) can't affect a sealed
+// Block's Body.
+func (b *Body) clone() *Body {
+	return &Body{Transactions: append([]*mempool.Transaction(nil), b.Transactions...)}
+}
+
+// Block is an immutable, sealed block: a Header, a Body, and the hash
+// computed from them at construction time. There is no way to mutate a
+// *Block in place — WithSeal and WithBody return a new, independently
+// sealed *Block — so a *Block can be shared across goroutines (mempool,
+// fetcher, RPC, reward distributor) without defensive copying at every
+// call site.
+type Block struct {
+	header *Header
+	body   *Body
+	hash   [32]byte
+}
+
+// NewBlock builds a sealed block around the given transactions. The
+// returned Block's hash, TxRoot, and GasUsed are already computed; there
+// is no separate Finalize step to forget to call.
+func NewBlock(blockNumber uint64, parentHash [32]byte, validator [32]byte, txs []*mempool.Transaction) *Block {
+	header := &Header{
+		BlockNumber: blockNumber,
+		ParentHash:  parentHash,
+		Validator:   validator,
+		Timestamp:   time.Now().Unix(),
+		GasLimit:    DefaultGasLimit,
+		Difficulty:  1,
+	}
+	body := &Body{Transactions: append([]*mempool.Transaction(nil), txs...)}
+
+	return seal(header, body)
+}
+
+// NewGenesisBlock builds block 0: zero parent hash, no transactions, no
+// state yet (StateRoot is the zero value until genesis allocations are
+// applied elsewhere).
+func NewGenesisBlock(validator [32]byte) *Block {
+	return NewBlock(0, [32]byte{}, validator, nil)
+}
+
+// Seal builds a fully sealed Block from a header, its transactions, and
+// the receipts executing them against state produced: it stamps
+// header.ReceiptsRoot via ComputeReceiptsRoot before computing TxRoot,
+// GasUsed, and the block hash, so a caller that already has receipts in
+// hand (e.g. BlockProcessor.Process, once it runs before block assembly
+// rather than after) never needs WithSeal's two-step
+// "seal, then patch ReceiptsRoot, then re-seal" pattern. receipts may be
+// nil for a block with no receipts yet (mirroring NewBlock, which never
+// had any).
+func Seal(header *Header, txs []*mempool.Transaction, receipts Receipts) *Block {
+	header = header.clone()
+	header.ReceiptsRoot = ComputeReceiptsRoot(receipts)
+	return seal(header, &Body{Transactions: txs})
+}
+
+// seal computes header.TxRoot/GasUsed from body and returns a new Block
+// wrapping both, with its hash computed once.
+func seal(header *Header, body *Body) *Block {
+	header = header.clone()
+	body = body.clone()
+
+	header.TxRoot = computeTxRootFromTransactions(body.Transactions)
+
+	var gasUsed uint64
+	for _, tx := range body.Transactions {
+		gasUsed += tx.GasLimit
+	}
+	header.GasUsed = gasUsed
+
+	b := &Block{header: header, body: body}
+	b.hash = b.computeHash()
+	return b
+}
+
+// computeHash hashes the header's fields via headerHash, the active
+// CryptoBackend's SHA256, applied to everything except the hash itself.
+func (b *Block) computeHash() [32]byte {
+	return headerHash(b.header)
+}
+
+// HeaderHash hashes a Header the same way sealing a Block into it does,
+// for callers (e.g. p2p's SyncManager, checking a fetched header against
+// a trusted checkpoint hash) that have a *Header but no *Block to call
+// Hash() on.
+func HeaderHash(h *Header) [32]byte {
+	return headerHash(h)
+}
+
+// headerHash hashes a Header's fields in a little-endian, fixed-field-
+// order encoding, in the same style as header_codec.go uses for the
+// light-client BlockHeader. It's a package-level function rather than a
+// Header method so BlockProcessor.ValidateHeader can use it to check
+// parent linkage without a Block (and therefore a Body) in hand.
+func headerHash(h *Header) [32]byte {
+	buf := make([]byte, 0, 8+32+32+32+32+32+8+8+8+len(h.ExtraData)+8+8+8+len(h.LogsBloom)+8+8+8+len(h.BeaconSignature))
+	buf = appendUint64(buf, h.BlockNumber)
+	buf = append(buf, h.ParentHash[:]...)
+	buf = append(buf, h.TxRoot[:]...)
+	buf = append(buf, h.StateRoot[:]...)
+	buf = append(buf, h.ReceiptsRoot[:]...)
+	buf = append(buf, h.Validator[:]...)
+	buf = appendUint64(buf, uint64(h.Timestamp))
+	buf = appendUint64(buf, h.GasLimit)
+	buf = appendUint64(buf, h.GasUsed)
+	buf = appendUint32(buf, uint32(len(h.ExtraData)))
+	buf = append(buf, h.ExtraData...)
+	buf = appendUint64(buf, h.Nonce)
+	buf = appendUint64(buf, h.Difficulty)
+	buf = appendUint64(buf, h.BaseFee)
+	buf = append(buf, h.LogsBloom[:]...)
+	buf = appendUint64(buf, h.ExcessBlobGas)
+	buf = appendUint64(buf, h.BlobGasUsed)
+	buf = appendUint64(buf, h.BeaconRound)
+	buf = appendUint32(buf, uint32(len(h.BeaconSignature)))
+	buf = append(buf, h.BeaconSignature...)
+	buf = appendUint32(buf, uint32(len(h.CoSigners)))
+	for _, signer := range h.CoSigners {
+		buf = append(buf, signer[:]...)
+	}
+
+	return DefaultBackend().SHA256(buf)
+}
+
+// computeTxRootFromTransactions computes the Merkle root over tx
+// hashes, matching ComputeMerkleRoot's empty/single/tree rules.
+func computeTxRootFromTransactions(txs []*mempool.Transaction) [32]byte {
+	hashes := make([][32]byte, len(txs))
+	for i, tx := range txs {
+		hashes[i] = tx.Hash
+	}
+	return DefaultBackend().MerkleRoot(hashes)
+}
+
+// ==================== Read-only accessors ====================
+
+func (b *Block) Number() uint64         { return b.header.BlockNumber }
+func (b *Block) Hash() [32]byte         { return b.hash }
+func (b *Block) ParentHash() [32]byte   { return b.header.ParentHash }
+func (b *Block) TxRoot() [32]byte       { return b.header.TxRoot }
+func (b *Block) StateRoot() [32]byte    { return b.header.StateRoot }
+func (b *Block) ReceiptsRoot() [32]byte { return b.header.ReceiptsRoot }
+func (b *Block) Validator() [32]byte    { return b.header.Validator }
+func (b *Block) Timestamp() int64       { return b.header.Timestamp }
+func (b *Block) GasLimit() uint64       { return b.header.GasLimit }
+func (b *Block) GasUsed() uint64        { return b.header.GasUsed }
+func (b *Block) Nonce() uint64          { return b.header.Nonce }
+func (b *Block) Difficulty() uint64     { return b.header.Difficulty }
+func (b *Block) BaseFee() uint64        { return b.header.BaseFee }
+func (b *Block) LogsBloom() Bloom       { return b.header.LogsBloom }
+func (b *Block) ExcessBlobGas() uint64  { return b.header.ExcessBlobGas }
+func (b *Block) BlobGasUsed() uint64    { return b.header.BlobGasUsed }
+func (b *Block) BeaconRound() uint64    { return b.header.BeaconRound }
+func (b *Block) CoSigners() [][32]byte  { return b.header.CoSigners }
+
+// BeaconSignature returns a defensive copy of the header's beacon
+// leader-ticket proof.
+func (b *Block) BeaconSignature() []byte {
+	return append([]byte(nil), b.header.BeaconSignature...)
+}
+
+// ExtraData returns a defensive copy of the header's extra data.
+func (b *Block) ExtraData() []byte {
+	return append([]byte(nil), b.header.ExtraData...)
+}
+
+// Header returns a defensive copy of the block's header.
+func (b *Block) Header() *Header {
+	return b.header.clone()
+}
+
+// Transactions returns a defensive copy of the block's transaction list.
+func (b *Block) Transactions() []*mempool.Transaction {
+	return append([]*mempool.Transaction(nil), b.body.Transactions...)
+}
+
+// ==================== Immutable "setters" ====================
+
+// WithSeal returns a new Block with header in place of the receiver's
+// header (and the same body), re-sealed with a freshly computed hash.
+// The receiver is left untouched.
+func (b *Block) WithSeal(header *Header) *Block {
+	return seal(header, b.body)
+}
+
+// WithBody returns a new Block with the given transactions in place of
+// the receiver's body (and the same header fields, aside from the
+// TxRoot/GasUsed that sealing recomputes). The receiver is left
+// untouched.
+func (b *Block) WithBody(txs ...*mempool.Transaction) *Block {
+	return seal(b.header, &Body{Transactions: txs})
+}
+
+// IsValid checks internal consistency: gas used must not exceed the gas
+// limit, and the cached hash must match what the header/body actually
+// hash to (catching any accidental aliasing bug that let a sealed
+// Block's fields drift from its hash).
+func (b *Block) IsValid() bool {
+	if b.header.GasUsed > b.header.GasLimit {
+		return false
+	}
+	return b.hash == b.computeHash()
+}
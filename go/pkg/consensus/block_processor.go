@@ -0,0 +1,317 @@
+package consensus
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/tokenomics"
+)
+
+const (
+	// maxHeaderFutureDrift bounds how far a header's timestamp may sit
+	// ahead of wall-clock time before ValidateHeader rejects it.
+	maxHeaderFutureDrift = 15 * time.Minute
+
+	// gasLimitBoundDivisor bounds how much GasLimit may move from one
+	// block to the next: at most parent.GasLimit/gasLimitBoundDivisor.
+	gasLimitBoundDivisor = 1024
+
+	// maxExtraDataSize bounds the header's ExtraData field.
+	maxExtraDataSize = 32
+)
+
+// BlockProcessor takes a candidate Block from "received" to "applied":
+// it checks header sanity against a parent (ValidateHeader), executes
+// the block's transactions against state to produce receipts
+// (Process), and checks the resulting roots/gas against what the
+// header claims (ValidateState). It is the fuller counterpart to
+// BlockBuilder, which only assembles a candidate block; both share the
+// same underlying state manager and reward distributor so a block's
+// local-build and remote-validation paths apply transactions the same
+// way.
+type BlockProcessor struct {
+	stateManager   *state.StateManager
+	mempool        *mempool.Mempool
+	distributor    *tokenomics.RewardDistributor
+	vestingTracker *tokenomics.VestingTracker
+	receiptStore   *ReceiptStore
+	log            *logger.Logger
+
+	authorizedValidators map[[32]byte]bool
+
+	// leaderElection, when configured via SetLeaderElection, makes
+	// ValidateHeader additionally check header.BeaconSignature as a
+	// LeaderTicket proof against the beacon round it claims. Nil on
+	// chains that haven't activated beacon-driven leader election and
+	// still trust Validator as claimed (the authorizedValidators check
+	// above).
+	leaderElection *LeaderElection
+
+	// powerTable, when configured via SetPowerTable, makes Process pay a
+	// block's validator reward across block.CoSigners() (power-weighted
+	// via DistributeBlockRewardsMultiValidator) instead of to
+	// block.Validator() alone, whenever a block with a zero BaseFee
+	// actually carries more than one co-signer. Nil on chains that haven't activated
+	// multi-signer blocks, the same way leaderElection above is nil
+	// until beacon-driven leader election is active.
+	powerTable *tokenomics.PowerTable
+}
+
+// NewBlockProcessor creates a new block processor for the given set of
+// PoA validators. vestingTracker may be nil for chains with no genesis
+// vesting allocations; when non-nil, applyTransaction rejects any
+// transfer that would spend into a sender's still-locked vesting balance
+// (see tokenomics.VestingTracker.CheckTransferAllowed).
+func NewBlockProcessor(
+	sm *state.StateManager,
+	mp *mempool.Mempool,
+	distributor *tokenomics.RewardDistributor,
+	vestingTracker *tokenomics.VestingTracker,
+	validators [][32]byte,
+	log *logger.Logger,
+) *BlockProcessor {
+	authorized := make(map[[32]byte]bool, len(validators))
+	for _, v := range validators {
+		authorized[v] = true
+	}
+
+	return &BlockProcessor{
+		stateManager:         sm,
+		mempool:              mp,
+		distributor:          distributor,
+		vestingTracker:       vestingTracker,
+		receiptStore:         NewReceiptStore(sm),
+		log:                  log,
+		authorizedValidators: authorized,
+	}
+}
+
+// SetLeaderElection activates beacon-driven leader-ticket checking:
+// from this point, ValidateHeader also verifies header.BeaconSignature
+// against le's beacon for the round header.BeaconRound claims.
+func (bp *BlockProcessor) SetLeaderElection(le *LeaderElection) {
+	bp.leaderElection = le
+}
+
+// SetPowerTable activates power-weighted multi-validator reward
+// splitting: from this point, Process pays a block's validator reward
+// via pt.Split across block.CoSigners() whenever a block carries more
+// than one, instead of paying block.Validator() alone. A block with a
+// non-zero BaseFee still pays block.Validator() alone (see Process).
+func (bp *BlockProcessor) SetPowerTable(pt *tokenomics.PowerTable) {
+	bp.powerTable = pt
+}
+
+// ValidateHeader checks header sanity against its parent: linkage,
+// sequential block number, monotonic (and not-too-far-future) timestamp,
+// bounded gas limit change, PoA validator authorization, and extradata
+// size. It does not touch state or transactions — see Process and
+// ValidateState for that.
+func (bp *BlockProcessor) ValidateHeader(parent, header *Header) error {
+	if parent == nil || header == nil {
+		return fmt.Errorf("parent and header must not be nil")
+	}
+
+	if header.ParentHash != headerHash(parent) {
+		return fmt.Errorf("parent hash mismatch: header wants %x, parent hashes to %x", header.ParentHash, headerHash(parent))
+	}
+
+	if header.BlockNumber != parent.BlockNumber+1 {
+		return fmt.Errorf("non-sequential block number: parent is %d, header is %d", parent.BlockNumber, header.BlockNumber)
+	}
+
+	if header.Timestamp <= parent.Timestamp {
+		return fmt.Errorf("timestamp %d is not after parent timestamp %d", header.Timestamp, parent.Timestamp)
+	}
+	if header.Timestamp > time.Now().Add(maxHeaderFutureDrift).Unix() {
+		return fmt.Errorf("timestamp %d is too far in the future", header.Timestamp)
+	}
+
+	gasLimitDiff := int64(header.GasLimit) - int64(parent.GasLimit)
+	if gasLimitDiff < 0 {
+		gasLimitDiff = -gasLimitDiff
+	}
+	if maxDiff := int64(parent.GasLimit) / gasLimitBoundDivisor; gasLimitDiff > maxDiff {
+		return fmt.Errorf("gas limit %d out of bounds of parent gas limit %d (max change %d)", header.GasLimit, parent.GasLimit, maxDiff)
+	}
+
+	if !bp.authorizedValidators[header.Validator] {
+		return fmt.Errorf("validator %x is not an authorized PoA signer", header.Validator[:8])
+	}
+
+	if len(header.ExtraData) > maxExtraDataSize {
+		return fmt.Errorf("extra data too large: %d bytes exceeds max %d", len(header.ExtraData), maxExtraDataSize)
+	}
+
+	if bp.leaderElection != nil {
+		entry, err := bp.leaderElection.EntryForRound(context.Background(), header.BeaconRound)
+		if err != nil {
+			return fmt.Errorf("beacon entry for round %d: %w", header.BeaconRound, err)
+		}
+		ticket := LeaderTicket{Round: header.BeaconRound, Proof: header.BeaconSignature, Value: sha256.Sum256(header.BeaconSignature)}
+		if err := VerifyLeaderTicket(ed25519.PublicKey(header.Validator[:]), header.BeaconRound, entry, ticket); err != nil {
+			return fmt.Errorf("leader ticket: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Process executes block's transactions against the processor's state
+// and returns a receipt per transaction (in order) plus the total gas
+// used. It always distributes block rewards as the last step of the
+// transition, matching DistributeBlockRewards being "called by the
+// consensus engine after a block is finalized".
+//
+// A failing transaction is reverted on its own by applyTransaction (via
+// a state snapshot), so it leaves no partial effects in bp.stateManager;
+// its receipt records ReceiptStatusFailed and the block carries on.
+func (bp *BlockProcessor) Process(block *Block) (Receipts, uint64, error) {
+	txs := block.Transactions()
+	receipts := make(Receipts, 0, len(txs))
+
+	var cumulativeGasUsed uint64
+	var totalFees uint64
+
+	for i, tx := range txs {
+		status := ReceiptStatusSuccess
+		if err := bp.applyTransaction(tx, block.Number()); err != nil {
+			bp.log.WithError(err).WithFields(logger.Fields{
+				"tx_hash":  fmt.Sprintf("%x", tx.Hash[:8]),
+				"tx_index": i,
+			}).Warn("Transaction failed during block processing")
+			status = ReceiptStatusFailed
+		} else {
+			totalFees += tx.Fee
+		}
+
+		cumulativeGasUsed += tx.GasLimit
+
+		// ContractAddress and logs are always zero/nil for now: this tx
+		// model has no contract-creation or contract-call type yet, so
+		// there is nothing for a transaction to create or emit.
+		//
+		// Receipts carry no per-tx state root: the zero root below is a
+		// placeholder, as BlockBuilder.ApplyBlock's state root is.
+		receipts = append(receipts, NewReceipt(tx.Hash, status, tx.GasLimit, cumulativeGasUsed, [32]byte{}, nil))
+	}
+
+	if bp.distributor != nil {
+		var err error
+		if baseFee := block.BaseFee(); baseFee > 0 {
+			// Post-activation: tx.Fee is the priority tip a sender bid
+			// above the base fee (this tx model predates EIP-1559 and has
+			// no separate fee-cap field, so the whole of tx.Fee is taken
+			// as tip rather than splitting it further against baseFee).
+			// baseFee*cumulativeGasUsed is burned outright by
+			// DistributeBlockRewardEIP1559, independent of totalFees.
+			//
+			// DistributeBlockRewardEIP1559 has no multi-validator
+			// counterpart yet, so a co-signed block still pays
+			// block.Validator() alone once the base-fee market is active.
+			err = bp.distributor.DistributeBlockRewardEIP1559(block.Number(), block.Validator(), baseFee, cumulativeGasUsed, totalFees)
+		} else if signers := block.CoSigners(); len(signers) > 1 && bp.powerTable != nil {
+			err = bp.distributor.DistributeBlockRewardsMultiValidator(block.Number(), signers, bp.powerTable, totalFees)
+		} else {
+			err = bp.distributor.DistributeBlockRewards(block.Number(), block.Validator(), totalFees)
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to distribute block rewards: %w", err)
+		}
+	}
+
+	// Every transaction either applied cleanly or was individually
+	// reverted by applyTransaction above, so nothing remains that a
+	// RevertToSnapshot taken before this Process call would need.
+	bp.stateManager.Finalise()
+
+	if err := bp.receiptStore.PutReceipts(block.Number(), receipts); err != nil {
+		return nil, 0, fmt.Errorf("failed to persist receipts: %w", err)
+	}
+
+	return receipts, cumulativeGasUsed, nil
+}
+
+// applyTransaction applies a single transaction's balance/nonce effects
+// to state at blockNumber, mirroring BlockBuilder.applyTransaction
+// including its snapshot-and-revert-on-error behavior.
+func (bp *BlockProcessor) applyTransaction(tx *mempool.Transaction, blockNumber uint64) (err error) {
+	snapshot := bp.stateManager.Snapshot()
+	defer func() {
+		if err != nil {
+			bp.stateManager.RevertToSnapshot(snapshot)
+		}
+	}()
+
+	sender, err := bp.stateManager.GetAccount(tx.From)
+	if err != nil {
+		return fmt.Errorf("failed to get sender account: %w", err)
+	}
+
+	recipient, err := bp.stateManager.GetAccount(tx.To)
+	if err != nil {
+		recipient = &state.Account{
+			Address:   tx.To,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+	}
+
+	totalCost := tx.Amount + tx.Fee
+	if sender.Balance < totalCost {
+		return fmt.Errorf("insufficient balance: need %d, have %d", totalCost, sender.Balance)
+	}
+
+	if bp.vestingTracker != nil {
+		if err := bp.vestingTracker.CheckTransferAllowed(sender.Address, sender.Balance, totalCost, blockNumber); err != nil {
+			return fmt.Errorf("vesting lock: %w", err)
+		}
+	}
+
+	sender.Balance -= totalCost
+	sender.Nonce++
+	recipient.Balance += tx.Amount
+
+	if err := bp.stateManager.UpdateAccount(sender.Address, sender.Balance, sender.Nonce); err != nil {
+		return fmt.Errorf("failed to update sender account: %w", err)
+	}
+	if err := bp.stateManager.UpdateAccount(recipient.Address, recipient.Balance, recipient.Nonce); err != nil {
+		return fmt.Errorf("failed to update recipient account: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateState checks that the receipts and state root produced by
+// Process match what block's header claims: TxRoot, ReceiptsRoot,
+// StateRoot, and GasUsed.
+func (bp *BlockProcessor) ValidateState(block *Block, receipts Receipts, computedStateRoot [32]byte) error {
+	if wantTxRoot := computeTxRootFromTransactions(block.Transactions()); block.TxRoot() != wantTxRoot {
+		return fmt.Errorf("tx root mismatch: header has %x, computed %x", block.TxRoot(), wantTxRoot)
+	}
+
+	if wantReceiptsRoot := ComputeReceiptsRoot(receipts); block.ReceiptsRoot() != wantReceiptsRoot {
+		return fmt.Errorf("receipts root mismatch: header has %x, computed %x", block.ReceiptsRoot(), wantReceiptsRoot)
+	}
+
+	if block.StateRoot() != computedStateRoot {
+		return fmt.Errorf("state root mismatch: header has %x, computed %x", block.StateRoot(), computedStateRoot)
+	}
+
+	var gasUsed uint64
+	for _, r := range receipts {
+		gasUsed += r.GasUsed
+	}
+	if block.GasUsed() != gasUsed {
+		return fmt.Errorf("gas used mismatch: header has %d, computed %d", block.GasUsed(), gasUsed)
+	}
+
+	return nil
+}
@@ -0,0 +1,275 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/tokenomics"
+)
+
+// createTestBlockProcessor builds a BlockProcessor backed by an in-memory
+// state manager, with validator [32]byte{1} as the sole authorized signer.
+func createTestBlockProcessor(t *testing.T) *BlockProcessor {
+	t.Helper()
+
+	log := logger.NewLogger("debug")
+
+	sm, err := state.NewStateManager(":memory:", log)
+	if err != nil {
+		t.Fatalf("Failed to create state manager: %v", err)
+	}
+
+	economics := tokenomics.NewEconomics(tokenomics.DefaultTokenomicsConfig(), log)
+	distributor := tokenomics.NewRewardDistributor(economics, sm, [32]byte{9}, log)
+
+	mempoolCfg := mempool.Config{
+		MaxSize:           1000,
+		MaxTxAge:          time.Hour,
+		CleanupInterval:   time.Minute,
+		PriorityThreshold: 0.0,
+	}
+	mp := mempool.NewMempool(mempoolCfg, log)
+
+	validators := [][32]byte{{1}}
+	return NewBlockProcessor(sm, mp, distributor, nil, validators, log)
+}
+
+func testParentHeader() *Header {
+	return &Header{
+		BlockNumber: 0,
+		Timestamp:   time.Now().Add(-time.Minute).Unix(),
+		GasLimit:    DefaultGasLimit,
+	}
+}
+
+// TestBlockProcessor_ValidateHeader_HappyPath tests a well-formed header
+// building on its parent.
+func TestBlockProcessor_ValidateHeader_HappyPath(t *testing.T) {
+	bp := createTestBlockProcessor(t)
+	parent := testParentHeader()
+
+	header := &Header{
+		BlockNumber: parent.BlockNumber + 1,
+		ParentHash:  headerHash(parent),
+		Validator:   [32]byte{1},
+		Timestamp:   parent.Timestamp + 1,
+		GasLimit:    parent.GasLimit,
+	}
+
+	if err := bp.ValidateHeader(parent, header); err != nil {
+		t.Fatalf("expected a valid header, got error: %v", err)
+	}
+}
+
+// TestBlockProcessor_ValidateHeader_UnauthorizedValidator tests that a
+// header signed by a non-authorized validator is rejected.
+func TestBlockProcessor_ValidateHeader_UnauthorizedValidator(t *testing.T) {
+	bp := createTestBlockProcessor(t)
+	parent := testParentHeader()
+
+	header := &Header{
+		BlockNumber: parent.BlockNumber + 1,
+		ParentHash:  headerHash(parent),
+		Validator:   [32]byte{99}, // not in the authorized set
+		Timestamp:   parent.Timestamp + 1,
+		GasLimit:    parent.GasLimit,
+	}
+
+	if err := bp.ValidateHeader(parent, header); err == nil {
+		t.Fatal("expected an error for an unauthorized validator")
+	}
+}
+
+// TestBlockProcessor_ValidateHeader_NonMonotonicTimestamp tests that a
+// header whose timestamp doesn't advance past its parent is rejected.
+func TestBlockProcessor_ValidateHeader_NonMonotonicTimestamp(t *testing.T) {
+	bp := createTestBlockProcessor(t)
+	parent := testParentHeader()
+
+	header := &Header{
+		BlockNumber: parent.BlockNumber + 1,
+		ParentHash:  headerHash(parent),
+		Validator:   [32]byte{1},
+		Timestamp:   parent.Timestamp, // not after parent
+		GasLimit:    parent.GasLimit,
+	}
+
+	if err := bp.ValidateHeader(parent, header); err == nil {
+		t.Fatal("expected an error for a non-monotonic timestamp")
+	}
+}
+
+// TestBlockProcessor_ValidateHeader_BadParentLinkage tests that a header
+// whose ParentHash doesn't match the parent is rejected.
+func TestBlockProcessor_ValidateHeader_BadParentLinkage(t *testing.T) {
+	bp := createTestBlockProcessor(t)
+	parent := testParentHeader()
+
+	header := &Header{
+		BlockNumber: parent.BlockNumber + 1,
+		ParentHash:  [32]byte{0xFF}, // wrong
+		Validator:   [32]byte{1},
+		Timestamp:   parent.Timestamp + 1,
+		GasLimit:    parent.GasLimit,
+	}
+
+	if err := bp.ValidateHeader(parent, header); err == nil {
+		t.Fatal("expected an error for a bad parent hash")
+	}
+}
+
+// TestBlockProcessor_Process_HappyPath tests that Process produces one
+// receipt per transaction and the expected total gas used.
+func TestBlockProcessor_Process_HappyPath(t *testing.T) {
+	bp := createTestBlockProcessor(t)
+
+	sender := [32]byte{10}
+	recipient := [32]byte{20}
+	if err := bp.stateManager.UpdateAccount(sender, 1_000, 0); err != nil {
+		t.Fatalf("failed to seed sender account: %v", err)
+	}
+
+	tx := &mempool.Transaction{
+		Hash:     [32]byte{1},
+		From:     sender,
+		To:       recipient,
+		Amount:   100,
+		Fee:      1,
+		GasLimit: 21000,
+	}
+
+	block := NewBlock(1, [32]byte{}, [32]byte{1}, []*mempool.Transaction{tx})
+
+	receipts, gasUsed, err := bp.Process(block)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	if len(receipts) != 1 {
+		t.Fatalf("expected 1 receipt, got %d", len(receipts))
+	}
+	if receipts[0].Status != ReceiptStatusSuccess {
+		t.Error("expected the transaction to succeed")
+	}
+	if gasUsed != tx.GasLimit {
+		t.Errorf("expected gas used %d, got %d", tx.GasLimit, gasUsed)
+	}
+}
+
+// TestBlockProcessor_Process_RejectsTransferFromLockedVestingBalance
+// tests that a transaction spending into a sender's still-locked vesting
+// balance fails (as a failed receipt, not a hard error), while its
+// spendable (vested) balance remains transferable.
+func TestBlockProcessor_Process_RejectsTransferFromLockedVestingBalance(t *testing.T) {
+	bp := createTestBlockProcessor(t)
+
+	sender := [32]byte{10}
+	recipient := [32]byte{20}
+	if err := bp.stateManager.UpdateAccount(sender, 1_000, 0); err != nil {
+		t.Fatalf("failed to seed sender account: %v", err)
+	}
+
+	vt := tokenomics.NewVestingTracker(bp.stateManager, logger.NewLogger("debug"))
+	alloc := tokenomics.GenesisAllocation{
+		Address: sender,
+		Amount:  1_000,
+		Vesting: &tokenomics.VestingSchedule{
+			StartBlock:    0,
+			CliffBlocks:   100,
+			VestingBlocks: 200,
+			InitialUnlock: 0.0,
+		},
+	}
+	if err := vt.AddAllocation(alloc); err != nil {
+		t.Fatalf("failed to register vesting allocation: %v", err)
+	}
+	bp.vestingTracker = vt
+
+	// Still inside the cliff (block 1 < CliffBlocks 100): everything is
+	// locked, so any spend should fail.
+	tx := &mempool.Transaction{
+		Hash:     [32]byte{1},
+		From:     sender,
+		To:       recipient,
+		Amount:   100,
+		Fee:      1,
+		GasLimit: 21000,
+	}
+	block := NewBlock(1, [32]byte{}, [32]byte{1}, []*mempool.Transaction{tx})
+
+	receipts, _, err := bp.Process(block)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if receipts[0].Status != ReceiptStatusFailed {
+		t.Error("expected the transfer to fail while the sender's balance is fully locked")
+	}
+
+	account, err := bp.stateManager.GetAccount(sender)
+	if err != nil {
+		t.Fatalf("failed to get sender account: %v", err)
+	}
+	if account.Balance != 1_000 {
+		t.Errorf("expected sender balance to remain 1000 after a rejected transfer, got %d", account.Balance)
+	}
+}
+
+// TestBlockProcessor_ValidateState_BadStateRoot tests rejection when the
+// header's StateRoot doesn't match what execution produced.
+func TestBlockProcessor_ValidateState_BadStateRoot(t *testing.T) {
+	bp := createTestBlockProcessor(t)
+
+	block := NewBlock(1, [32]byte{}, [32]byte{1}, []*mempool.Transaction{})
+	receipts, _, err := bp.Process(block)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	wrongStateRoot := [32]byte{0xAB}
+	if err := bp.ValidateState(block, receipts, wrongStateRoot); err == nil {
+		t.Fatal("expected an error for a mismatched state root")
+	}
+}
+
+// TestBlockProcessor_ValidateState_BadReceiptsRoot tests rejection when
+// the header's ReceiptsRoot doesn't match the computed receipts.
+func TestBlockProcessor_ValidateState_BadReceiptsRoot(t *testing.T) {
+	bp := createTestBlockProcessor(t)
+
+	block := NewBlock(1, [32]byte{}, [32]byte{1}, []*mempool.Transaction{})
+	header := block.Header()
+	header.ReceiptsRoot = [32]byte{0xCD}
+	block = block.WithSeal(header)
+
+	receipts, _, err := bp.Process(block)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	if err := bp.ValidateState(block, receipts, block.StateRoot()); err == nil {
+		t.Fatal("expected an error for a mismatched receipts root")
+	}
+}
+
+// TestBlockProcessor_ValidateState_HappyPath tests that a block whose
+// header matches what Process produced validates cleanly.
+func TestBlockProcessor_ValidateState_HappyPath(t *testing.T) {
+	bp := createTestBlockProcessor(t)
+
+	block := NewBlock(1, [32]byte{}, [32]byte{1}, []*mempool.Transaction{})
+	receipts, _, err := bp.Process(block)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	header := block.Header()
+	header.ReceiptsRoot = ComputeReceiptsRoot(receipts)
+	block = block.WithSeal(header)
+
+	if err := bp.ValidateState(block, receipts, block.StateRoot()); err != nil {
+		t.Fatalf("expected a valid state, got error: %v", err)
+	}
+}
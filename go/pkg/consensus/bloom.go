@@ -0,0 +1,141 @@
+package consensus
+
+// Bloom is a 2048-bit (256-byte) logs bloom filter, in the same spirit
+// as go-ethereum's types.Bloom: a probabilistic set that can say "this
+// item is definitely not present" in O(1) without a full log scan, at
+// the cost of occasional false positives. A block header's bloom is the
+// OR of every one of its receipts' blooms (see ComputeBlockBloom); a
+// single receipt's bloom is the OR of its logs' addresses and topics
+// (see NewBloom).
+type Bloom [256]byte
+
+// bloomBitsPerItem is how many of Bloom's 2048 bits a single Add call
+// sets, matching go-ethereum's three-hash-positions-per-item scheme: it
+// keeps the false-positive rate low without needing three independent
+// hash functions, since three non-overlapping 11-bit windows of one
+// SHA256 output already give three effectively independent positions.
+const bloomBitsPerItem = 3
+
+// Add sets the bits data's hash maps to. Each of the bloomBitsPerItem
+// positions is an 11-bit index (0-2047) taken from a non-overlapping
+// pair of bytes in SHA256(data), so a single hash supplies all of them.
+func (b *Bloom) Add(data []byte) {
+	h := DefaultBackend().SHA256(data)
+	for i := 0; i < bloomBitsPerItem; i++ {
+		bit := bloomBitIndex(h, i)
+		byteIndex := 255 - bit/8
+		bitMask := byte(1) << (bit % 8)
+		b[byteIndex] |= bitMask
+	}
+}
+
+// Test reports whether data's bits are all set in b. A true result means
+// "maybe present"; a false result means "definitely not present" — the
+// only guarantee a bloom filter offers.
+func (b Bloom) Test(data []byte) bool {
+	h := DefaultBackend().SHA256(data)
+	for i := 0; i < bloomBitsPerItem; i++ {
+		bit := bloomBitIndex(h, i)
+		byteIndex := 255 - bit/8
+		bitMask := byte(1) << (bit % 8)
+		if b[byteIndex]&bitMask == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// OrWith returns b merged with other: the union of everything either
+// bloom might contain.
+func (b Bloom) OrWith(other Bloom) Bloom {
+	var result Bloom
+	for i := range result {
+		result[i] = b[i] | other[i]
+	}
+	return result
+}
+
+// bloomBitIndex extracts the i'th (0, 1, or 2) 11-bit position from h's
+// bytes [2i, 2i+1], matching go-ethereum's bloom9 construction.
+func bloomBitIndex(h [32]byte, i int) int {
+	return (int(h[2*i])<<8 | int(h[2*i+1])) & 0x7ff
+}
+
+// NewBloom builds a Receipt's bloom filter from its logs: each log's
+// contract address and every one of its topics is added.
+func NewBloom(logs []Log) Bloom {
+	var b Bloom
+	for _, log := range logs {
+		b.Add(log.Address[:])
+		for _, topic := range log.Topics {
+			b.Add(topic[:])
+		}
+	}
+	return b
+}
+
+// LogFilter narrows GetLogsByBlock's result to logs matching at least
+// one of Addresses (if non-empty) and all of Topics (if non-empty) —
+// the same address-OR/topics-AND semantics eth_getLogs uses.
+type LogFilter struct {
+	Addresses [][32]byte
+	Topics    [][32]byte
+}
+
+// MatchesBloom reports whether a bloom filter could possibly contain a
+// log satisfying f. It never returns a false negative (a log that would
+// pass Matches always passes MatchesBloom first), so callers use it to
+// skip a full log scan rather than as a final answer.
+func (f LogFilter) MatchesBloom(bloom Bloom) bool {
+	if len(f.Addresses) > 0 {
+		matched := false
+		for _, addr := range f.Addresses {
+			if bloom.Test(addr[:]) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	for _, topic := range f.Topics {
+		if !bloom.Test(topic[:]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches reports whether log actually satisfies f: its address is one
+// of f.Addresses (or f.Addresses is empty) and every one of f.Topics
+// appears somewhere in log.Topics (or f.Topics is empty).
+func (f LogFilter) Matches(log Log) bool {
+	if len(f.Addresses) > 0 {
+		found := false
+		for _, addr := range f.Addresses {
+			if addr == log.Address {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	for _, want := range f.Topics {
+		found := false
+		for _, got := range log.Topics {
+			if want == got {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
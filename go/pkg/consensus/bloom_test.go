@@ -0,0 +1,96 @@
+package consensus
+
+import "testing"
+
+func addr(b byte) [32]byte {
+	var a [32]byte
+	a[0] = b
+	return a
+}
+
+func TestBloom_AddThenTestIsTrue(t *testing.T) {
+	var b Bloom
+	data := []byte("some log topic")
+	b.Add(data)
+
+	if !b.Test(data) {
+		t.Errorf("expected Test to report true for data just Added")
+	}
+}
+
+func TestBloom_TestIsFalseForUnaddedData(t *testing.T) {
+	var b Bloom
+	b.Add([]byte("present"))
+
+	if b.Test([]byte("absent")) {
+		t.Errorf("expected Test to report false for data never Added (or at least very rarely true — check for a broken hash)")
+	}
+}
+
+func TestBloom_OrWithUnionsBothFilters(t *testing.T) {
+	var b1, b2 Bloom
+	b1.Add([]byte("a"))
+	b2.Add([]byte("b"))
+
+	merged := b1.OrWith(b2)
+
+	if !merged.Test([]byte("a")) || !merged.Test([]byte("b")) {
+		t.Errorf("expected merged bloom to test true for both inputs")
+	}
+}
+
+func TestNewBloom_CoversAddressesAndTopics(t *testing.T) {
+	a1, a2 := addr(1), addr(2)
+	logs := []Log{
+		{Address: a1, Topics: [][32]byte{addr(3)}},
+	}
+	b := NewBloom(logs)
+
+	if !b.Test(a1[:]) {
+		t.Errorf("expected bloom to test true for the log's address")
+	}
+	if !b.Test(addr(3)[:]) {
+		t.Errorf("expected bloom to test true for the log's topic")
+	}
+	if b.Test(a2[:]) {
+		t.Errorf("expected bloom to test false for an address never added (or at least very rarely true)")
+	}
+}
+
+func TestLogFilter_Matches(t *testing.T) {
+	log := Log{Address: addr(1), Topics: [][32]byte{addr(2), addr(3)}}
+
+	cases := []struct {
+		name   string
+		filter LogFilter
+		want   bool
+	}{
+		{"empty filter matches anything", LogFilter{}, true},
+		{"matching address", LogFilter{Addresses: [][32]byte{addr(1)}}, true},
+		{"non-matching address", LogFilter{Addresses: [][32]byte{addr(9)}}, false},
+		{"matching topic subset", LogFilter{Topics: [][32]byte{addr(2)}}, true},
+		{"missing topic", LogFilter{Topics: [][32]byte{addr(9)}}, false},
+		{"matching address and topics", LogFilter{Addresses: [][32]byte{addr(1)}, Topics: [][32]byte{addr(2), addr(3)}}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.filter.Matches(log); got != tc.want {
+				t.Errorf("Matches() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLogFilter_MatchesBloomNeverFalseNegative(t *testing.T) {
+	log := Log{Address: addr(1), Topics: [][32]byte{addr(2)}}
+	bloom := NewBloom([]Log{log})
+
+	filter := LogFilter{Addresses: [][32]byte{addr(1)}, Topics: [][32]byte{addr(2)}}
+	if !filter.Matches(log) {
+		t.Fatalf("test setup error: filter should match log")
+	}
+	if !filter.MatchesBloom(bloom) {
+		t.Errorf("expected MatchesBloom to pass for a filter that genuinely matches")
+	}
+}
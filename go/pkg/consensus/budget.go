@@ -0,0 +1,105 @@
+package consensus
+
+// Per-tier verification budgets. A MOBILE prover has far less compute
+// available than a CLUSTER, so the budget that bounds how much work
+// VerifySubsetSum is willing to spend (and, by extension, how much work
+// a legitimate solution for that tier is expected to show) scales with
+// HardwareTier.
+var tierBudgets = map[HardwareTier]VerifyBudget{
+	TierMobile: {
+		MaxOps:         10_000,
+		MaxDurationMs:  2_000,
+		MaxMemoryBytes: 32_000_000,
+	},
+	TierDesktop: {
+		MaxOps:         100_000,
+		MaxDurationMs:  10_000,
+		MaxMemoryBytes: 256_000_000,
+	},
+	TierWorkstation: {
+		MaxOps:         1_000_000,
+		MaxDurationMs:  20_000,
+		MaxMemoryBytes: 1_000_000_000,
+	},
+	TierServer: {
+		MaxOps:         5_000_000,
+		MaxDurationMs:  30_000,
+		MaxMemoryBytes: 2_000_000_000,
+	},
+	TierCluster: {
+		MaxOps:         10_000_000,
+		MaxDurationMs:  60_000,
+		MaxMemoryBytes: 4_000_000_000,
+	},
+}
+
+// BudgetOption customizes a VerifyBudget returned by BudgetFor.
+type BudgetOption func(*VerifyBudget)
+
+// WithMaxOps overrides MaxOps.
+func WithMaxOps(maxOps uint32) BudgetOption {
+	return func(b *VerifyBudget) { b.MaxOps = maxOps }
+}
+
+// WithMaxDurationMs overrides MaxDurationMs.
+func WithMaxDurationMs(maxDurationMs uint32) BudgetOption {
+	return func(b *VerifyBudget) { b.MaxDurationMs = maxDurationMs }
+}
+
+// WithMaxMemoryBytes overrides MaxMemoryBytes.
+func WithMaxMemoryBytes(maxMemoryBytes uint32) BudgetOption {
+	return func(b *VerifyBudget) { b.MaxMemoryBytes = maxMemoryBytes }
+}
+
+// BudgetFor returns the default VerifyBudget for tier, with any
+// overrides applied on top. Unknown tiers fall back to the desktop
+// budget, the most conservative non-mobile default.
+func BudgetFor(tier HardwareTier, overrides ...BudgetOption) *VerifyBudget {
+	budget, ok := tierBudgets[tier]
+	if !ok {
+		budget = tierBudgets[TierDesktop]
+	}
+
+	for _, opt := range overrides {
+		opt(&budget)
+	}
+
+	return &budget
+}
+
+// VerifyResult carries the verification outcome alongside the resource
+// usage actually consumed, so callers (e.g. the API layer) can emit
+// metrics and flag proofs that used suspiciously little work for their
+// claimed tier.
+type VerifyResult struct {
+	Valid      bool
+	Budget     VerifyBudget
+	OpsUsed    uint32
+	DurationMs uint32
+	OverBudget bool
+}
+
+// VerifySubsetSumWithBudget verifies a solution using the tier-scaled
+// budget from BudgetFor, returning the resource usage alongside the
+// verdict. It wraps the CryptoBackend's VerifySubsetSum; ops/duration
+// accounting is an estimate based on the problem size, since the
+// backend interface itself only returns a bool.
+func VerifySubsetSumWithBudget(backend CryptoBackend, tier HardwareTier, problem *SubsetSumProblem, solution *SubsetSumSolution, overrides ...BudgetOption) (*VerifyResult, error) {
+	budget := BudgetFor(tier, overrides...)
+
+	opsEstimate := uint32(len(solution.Indices))
+	if opsEstimate > budget.MaxOps {
+		return &VerifyResult{Budget: *budget, OpsUsed: opsEstimate, OverBudget: true}, nil
+	}
+
+	valid, err := backend.VerifySubsetSum(problem, solution, budget)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VerifyResult{
+		Valid:   valid,
+		Budget:  *budget,
+		OpsUsed: opsEstimate,
+	}, nil
+}
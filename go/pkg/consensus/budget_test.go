@@ -0,0 +1,56 @@
+package consensus
+
+import "testing"
+
+func TestBudgetFor_EachTier(t *testing.T) {
+	tiers := []HardwareTier{TierMobile, TierDesktop, TierWorkstation, TierServer, TierCluster}
+
+	var prevOps uint32
+	for _, tier := range tiers {
+		budget := BudgetFor(tier)
+		if budget.MaxOps <= prevOps {
+			t.Errorf("expected tier %d to have a larger op budget than the previous tier, got %d <= %d", tier, budget.MaxOps, prevOps)
+		}
+		prevOps = budget.MaxOps
+	}
+}
+
+func TestBudgetFor_WithOverrides(t *testing.T) {
+	budget := BudgetFor(TierMobile, WithMaxOps(5), WithMaxDurationMs(1))
+
+	if budget.MaxOps != 5 {
+		t.Errorf("expected overridden MaxOps 5, got %d", budget.MaxOps)
+	}
+	if budget.MaxDurationMs != 1 {
+		t.Errorf("expected overridden MaxDurationMs 1, got %d", budget.MaxDurationMs)
+	}
+}
+
+func TestVerifySubsetSumWithBudget_OverBudget(t *testing.T) {
+	problem := &SubsetSumProblem{Elements: []int64{1, 2, 3}, Target: 3}
+	solution := &SubsetSumSolution{Indices: []uint32{0, 1, 2}}
+
+	result, err := VerifySubsetSumWithBudget(pureGoBackend{}, TierMobile, problem, solution, WithMaxOps(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.OverBudget {
+		t.Error("expected OverBudget to be true when the solution exceeds MaxOps")
+	}
+}
+
+func TestVerifySubsetSumWithBudget_WithinBudget(t *testing.T) {
+	problem := &SubsetSumProblem{Elements: []int64{1, 2, 3}, Target: 3}
+	solution := &SubsetSumSolution{Indices: []uint32{0, 1}}
+
+	result, err := VerifySubsetSumWithBudget(pureGoBackend{}, TierDesktop, problem, solution)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.OverBudget {
+		t.Fatal("did not expect OverBudget for a solution within the desktop tier's budget")
+	}
+	if !result.Valid {
+		t.Error("expected the solution (1+2=3) to be valid")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/tokenomics"
 )
 
 // BlockBuilder builds new blocks from mempool transactions
@@ -17,9 +18,16 @@ type BlockBuilder struct {
 	log          *logger.Logger
 
 	// Configuration
-	maxTxPerBlock uint64 // Maximum transactions per block
-	maxGasPerBlock uint64 // Maximum gas per block
+	maxTxPerBlock  uint64        // Maximum transactions per block
+	maxGasPerBlock uint64        // Maximum gas per block; see SetMaxGasPerBlock
 	minBlockTime   time.Duration // Minimum time between blocks
+
+	// EIP-1559 base-fee market state: baseFeeCfg parameterizes
+	// CalculateNextBaseFee, and currentBaseFee is the value BuildBlock
+	// stamps onto each new candidate's header. It's zero (market
+	// inactive) until SetBaseFeeConfig is called.
+	baseFeeCfg     tokenomics.BaseFeeConfig
+	currentBaseFee uint64
 }
 
 // NewBlockBuilder creates a new block builder
@@ -34,6 +42,42 @@ func NewBlockBuilder(mp *mempool.Mempool, sm *state.StateManager, log *logger.Lo
 	}
 }
 
+// SetBaseFeeConfig activates the EIP-1559-style base-fee market: every
+// block BuildBlock produces from this point carries cfg.InitialBaseFee
+// (or whatever CalculateNextBaseFee has since advanced it to) in its
+// header, instead of the zero value chains that haven't activated it
+// carry.
+func (bb *BlockBuilder) SetBaseFeeConfig(cfg tokenomics.BaseFeeConfig) {
+	bb.baseFeeCfg = cfg
+	bb.currentBaseFee = cfg.InitialBaseFee
+}
+
+// CalculateNextBaseFee derives the base fee the block after parent
+// should carry, given how much gas parent actually used, via
+// tokenomics.ComputeNextBaseFee.
+func (bb *BlockBuilder) CalculateNextBaseFee(parent *Header, gasUsed uint64) uint64 {
+	return tokenomics.ComputeNextBaseFee(bb.baseFeeCfg, parent.BaseFee, gasUsed)
+}
+
+// SetMaxGasPerBlock overrides the per-block gas limit BuildBlock seeds
+// its GasPool with. Unlike maxTxPerBlock, this is expected to change
+// block-to-block once a gas-limit-adjustment mechanism (e.g. the
+// EIP-1559 base-fee market) is active, rather than staying fixed for
+// the builder's lifetime.
+func (bb *BlockBuilder) SetMaxGasPerBlock(limit uint64) {
+	bb.maxGasPerBlock = limit
+}
+
+// IncludeBlobSidecars checks candidate sidecars against MaxBlobsPerBlock
+// via ValidateBlobSidecars before BuildBlock would attach them to a
+// block. It is a separate call rather than a BuildBlock parameter
+// because this tx model has no blob-carrying transaction kind yet (see
+// BlobTx) — BuildBlock only ever sees plain mempool.Transaction — so
+// there is nothing in BuildBlock's own loop for this cap to gate today.
+func (bb *BlockBuilder) IncludeBlobSidecars(sidecars []BlobSidecar) error {
+	return ValidateBlobSidecars(sidecars)
+}
+
 // BuildBlock builds a new block from mempool transactions
 // Returns the block and any transactions that were included
 func (bb *BlockBuilder) BuildBlock(parentHash [32]byte, blockNumber uint64, validator [32]byte) (*Block, error) {
@@ -47,11 +91,11 @@ func (bb *BlockBuilder) BuildBlock(parentHash [32]byte, blockNumber uint64, vali
 
 	// Filter and validate transactions
 	validTxs := make([]*mempool.Transaction, 0, len(mempoolTxs))
-	var totalGas uint64
+	gp := new(GasPool).AddGas(bb.maxGasPerBlock)
 
 	for _, tx := range mempoolTxs {
 		// Check gas limit
-		if totalGas+tx.GasLimit > bb.maxGasPerBlock {
+		if err := gp.SubGas(tx.GasLimit); err != nil {
 			bb.log.WithField("tx_hash", fmt.Sprintf("%x", tx.Hash[:8])).Debug("Transaction would exceed block gas limit, skipping")
 			continue
 		}
@@ -61,6 +105,7 @@ func (bb *BlockBuilder) BuildBlock(parentHash [32]byte, blockNumber uint64, vali
 		account, err := bb.stateManager.GetAccount(tx.From)
 		if err != nil {
 			bb.log.WithError(err).WithField("tx_hash", fmt.Sprintf("%x", tx.Hash[:8])).Warn("Failed to get account for transaction")
+			gp.AddGas(tx.GasLimit)
 			continue
 		}
 
@@ -71,6 +116,7 @@ func (bb *BlockBuilder) BuildBlock(parentHash [32]byte, blockNumber uint64, vali
 				"expected":     account.Nonce,
 				"got":          tx.Nonce,
 			}).Debug("Transaction nonce mismatch")
+			gp.AddGas(tx.GasLimit)
 			continue
 		}
 
@@ -82,25 +128,33 @@ func (bb *BlockBuilder) BuildBlock(parentHash [32]byte, blockNumber uint64, vali
 				"balance":    account.Balance,
 				"total_cost": totalCost,
 			}).Debug("Insufficient balance for transaction")
+			gp.AddGas(tx.GasLimit)
 			continue
 		}
 
 		// Transaction is valid, include it
 		validTxs = append(validTxs, tx)
-		totalGas += tx.GasLimit
 	}
 
-	// Create block
+	// NewBlock returns an already-sealed block: its hash, TxRoot, and
+	// GasUsed are computed at construction, so there's no separate
+	// finalize step to call.
 	block := NewBlock(blockNumber, parentHash, validator, validTxs)
 
-	// Finalize block (computes hashes)
-	block.Finalize()
+	// Stamp the current base fee onto the header if the market has been
+	// activated via SetBaseFeeConfig; re-sealing recomputes the hash so
+	// BaseFee is covered by it like every other header field.
+	if bb.currentBaseFee > 0 {
+		header := block.Header()
+		header.BaseFee = bb.currentBaseFee
+		block = block.WithSeal(header)
+	}
 
 	bb.log.WithFields(logger.Fields{
 		"block_number": blockNumber,
-		"block_hash":   fmt.Sprintf("%x", block.BlockHash[:8]),
+		"block_hash":   fmt.Sprintf("%x", block.Hash()[:8]),
 		"tx_count":     len(validTxs),
-		"gas_used":     block.GasUsed,
+		"gas_used":     block.GasUsed(),
 	}).Info("Block built successfully")
 
 	return block, nil
@@ -109,41 +163,78 @@ func (bb *BlockBuilder) BuildBlock(parentHash [32]byte, blockNumber uint64, vali
 // ApplyBlock applies a block's transactions to the state
 // Returns the new state root and any errors
 func (bb *BlockBuilder) ApplyBlock(block *Block) ([32]byte, error) {
+	txs := block.Transactions()
+
 	bb.log.WithFields(logger.Fields{
-		"block_number": block.BlockNumber,
-		"block_hash":   fmt.Sprintf("%x", block.BlockHash[:8]),
-		"tx_count":     len(block.Transactions),
+		"block_number": block.Number(),
+		"block_hash":   fmt.Sprintf("%x", block.Hash()[:8]),
+		"tx_count":     len(txs),
 	}).Info("Applying block to state")
 
-	// Start a state snapshot for rollback if needed
-	// TODO: Implement state snapshots
-
-	// Apply each transaction
-	for i, tx := range block.Transactions {
-		if err := bb.applyTransaction(tx, block.BlockNumber); err != nil {
+	// Apply each transaction. applyTransaction takes its own snapshot and
+	// reverts to it on error, so a failing transaction never leaves
+	// partial writes in state even though we stop applying the block
+	// right here rather than attempting the rest. gp is seeded from the
+	// header's own GasLimit (not bb.maxGasPerBlock, which only bounds
+	// blocks this builder proposes) so a block built by another validator
+	// is checked against the limit it actually declared.
+	gp := new(GasPool).AddGas(block.GasLimit())
+	for i, tx := range txs {
+		if err := bb.applyTransaction(tx, block.Number(), gp); err != nil {
 			bb.log.WithError(err).WithFields(logger.Fields{
 				"tx_hash": fmt.Sprintf("%x", tx.Hash[:8]),
 				"tx_index": i,
 			}).Error("Failed to apply transaction")
-			// TODO: Rollback state
 			return [32]byte{}, fmt.Errorf("failed to apply transaction %d: %w", i, err)
 		}
 	}
 
+	// Every transaction in this block applied cleanly (a failure above
+	// would already have returned), so there's nothing left to ever
+	// revert against this point — clear the journal rather than letting
+	// it grow across blocks.
+	bb.stateManager.Finalise()
+
 	// Compute new state root
 	// TODO: Implement proper state root computation
 	stateRoot := [32]byte{}
 
+	// Advance the base fee for the block after this one, now that its
+	// actual gas usage is known; inactive (currentBaseFee == 0) chains
+	// are left alone.
+	if bb.currentBaseFee > 0 {
+		bb.currentBaseFee = bb.CalculateNextBaseFee(block.Header(), block.GasUsed())
+	}
+
 	bb.log.WithFields(logger.Fields{
-		"block_number": block.BlockNumber,
+		"block_number": block.Number(),
 		"state_root":   fmt.Sprintf("%x", stateRoot[:8]),
 	}).Info("Block applied successfully")
 
 	return stateRoot, nil
 }
 
-// applyTransaction applies a single transaction to the state
-func (bb *BlockBuilder) applyTransaction(tx *mempool.Transaction, blockNumber uint64) error {
+// applyTransaction applies a single transaction to the state. It takes a
+// state snapshot before mutating anything and reverts to it on any
+// error, so a transaction that fails partway through (e.g. the
+// recipient-account update, after the sender has already been debited)
+// never leaves partial writes behind. gp is shared across every
+// transaction in the block being applied; SubGas fails with
+// ErrGasLimitReached if tx would overdraw it, which should not happen
+// for a block BuildBlock itself produced but guards against one that
+// didn't (e.g. received from a peer).
+func (bb *BlockBuilder) applyTransaction(tx *mempool.Transaction, blockNumber uint64, gp *GasPool) (err error) {
+	snapshot := bb.stateManager.Snapshot()
+	defer func() {
+		if err != nil {
+			bb.stateManager.RevertToSnapshot(snapshot)
+		}
+	}()
+
+	if err := gp.SubGas(tx.GasLimit); err != nil {
+		return fmt.Errorf("tx %x exceeds remaining block gas pool: %w", tx.Hash[:8], err)
+	}
+
 	// Get sender account
 	sender, err := bb.stateManager.GetAccount(tx.From)
 	if err != nil {
@@ -0,0 +1,85 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/tokenomics"
+)
+
+func TestBlockBuilder_BaseFeeInactiveByDefault(t *testing.T) {
+	bb := &BlockBuilder{}
+	if bb.currentBaseFee != 0 {
+		t.Fatalf("expected currentBaseFee to be 0 before SetBaseFeeConfig, got %d", bb.currentBaseFee)
+	}
+}
+
+func TestBlockBuilder_SetBaseFeeConfigActivatesMarket(t *testing.T) {
+	bb := &BlockBuilder{}
+	cfg := tokenomics.DefaultBaseFeeConfig()
+
+	bb.SetBaseFeeConfig(cfg)
+
+	if bb.currentBaseFee != cfg.InitialBaseFee {
+		t.Fatalf("expected currentBaseFee %d, got %d", cfg.InitialBaseFee, bb.currentBaseFee)
+	}
+}
+
+func TestBlockBuilder_CalculateNextBaseFeeMatchesTokenomics(t *testing.T) {
+	bb := &BlockBuilder{}
+	cfg := tokenomics.DefaultBaseFeeConfig()
+	bb.SetBaseFeeConfig(cfg)
+
+	parent := &Header{BaseFee: cfg.InitialBaseFee}
+	gasUsed := cfg.GasTarget * 2
+
+	got := bb.CalculateNextBaseFee(parent, gasUsed)
+	want := tokenomics.ComputeNextBaseFee(cfg, parent.BaseFee, gasUsed)
+
+	if got != want {
+		t.Fatalf("CalculateNextBaseFee() = %d, want %d", got, want)
+	}
+	if got <= cfg.InitialBaseFee {
+		t.Fatalf("expected base fee to rise above %d when gas used (%d) exceeds target, got %d", cfg.InitialBaseFee, gasUsed, got)
+	}
+}
+
+func TestComputeStateRootFromDiff_DeterministicRegardlessOfMapOrder(t *testing.T) {
+	var addrA, addrB [32]byte
+	addrA[0] = 1
+	addrB[0] = 2
+
+	diff := map[[32]byte]AccountDiff{
+		addrA: {Address: addrA, BalanceAfter: 100, NonceAfter: 1},
+		addrB: {Address: addrB, BalanceAfter: 200, NonceAfter: 2},
+	}
+
+	got1 := computeStateRootFromDiff(diff)
+	got2 := computeStateRootFromDiff(diff)
+	if got1 != got2 {
+		t.Fatalf("expected computeStateRootFromDiff to be deterministic, got %x then %x", got1, got2)
+	}
+}
+
+func TestComputeStateRootFromDiff_ChangesWithDiff(t *testing.T) {
+	var addr [32]byte
+	addr[0] = 1
+
+	before := computeStateRootFromDiff(map[[32]byte]AccountDiff{
+		addr: {Address: addr, BalanceAfter: 100, NonceAfter: 1},
+	})
+	after := computeStateRootFromDiff(map[[32]byte]AccountDiff{
+		addr: {Address: addr, BalanceAfter: 101, NonceAfter: 1},
+	})
+
+	if before == after {
+		t.Fatalf("expected state root to change when an account balance changes")
+	}
+}
+
+func TestComputeStateRootFromDiff_EmptyDiffIsStable(t *testing.T) {
+	got := computeStateRootFromDiff(map[[32]byte]AccountDiff{})
+	want := computeStateRootFromDiff(map[[32]byte]AccountDiff{})
+	if got != want {
+		t.Fatalf("expected empty diff to hash consistently")
+	}
+}
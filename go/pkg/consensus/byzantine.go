@@ -0,0 +1,113 @@
+package consensus
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Misbehavior enumerates the Byzantine proposal-building behaviors
+// ByzantineBuilder can inject at a given height, modeled on Tendermint's
+// "maverick" validator test harness. This package has no separate
+// vote/prevote/precommit protocol — validators sign whole blocks, not
+// votes — so the vote-level attacks that harness models collapse onto
+// the equivalent proposal-level behavior here: a double-sign is the
+// same observable event as an equivocating proposal, and a
+// prevote/precommit-nil attack is the same observable event as a
+// withheld proposal.
+type Misbehavior int
+
+const (
+	// MisbehaviorNone builds a normal, honest block.
+	MisbehaviorNone Misbehavior = iota
+
+	// MisbehaviorEquivocate builds two different blocks for the same
+	// height (same parent/validator, different nonce, so a different
+	// hash) and returns a new one every call — the same observable
+	// behavior as a double-sign in a whole-block-signing design like
+	// this one.
+	MisbehaviorEquivocate
+
+	// MisbehaviorWithhold builds nothing and reports an error,
+	// simulating a proposer that goes silent for its turn (the
+	// proposal-level analogue of a prevote/precommit-nil attack).
+	MisbehaviorWithhold
+
+	// MisbehaviorInvalidStateRoot builds a normal block and then
+	// corrupts its StateRoot before sealing, so honest validators
+	// should reject it during header validation.
+	MisbehaviorInvalidStateRoot
+)
+
+// ByzantineBuilder wraps an honest Builder, overriding its behavior at
+// the heights listed in Misbehaviors and building honestly everywhere
+// else. It still satisfies Builder, so it drops into BlockPublisher
+// exactly as the honest builder would — the seam this package exposes
+// for Byzantine-validator integration tests, without each test needing
+// its own copy of block-construction logic.
+type ByzantineBuilder struct {
+	honest       Builder
+	misbehaviors map[uint64]Misbehavior
+
+	mu          sync.Mutex
+	equivocated map[uint64]*Block
+}
+
+// NewByzantineBuilder wraps honest, misbehaving only at the heights
+// named in misbehaviors. A nil or missing entry for a height behaves
+// like MisbehaviorNone.
+func NewByzantineBuilder(honest Builder, misbehaviors map[uint64]Misbehavior) *ByzantineBuilder {
+	return &ByzantineBuilder{
+		honest:       honest,
+		misbehaviors: misbehaviors,
+		equivocated:  make(map[uint64]*Block),
+	}
+}
+
+// BuildBlock builds blockNumber's block, applying whatever Misbehavior
+// is configured for that height.
+func (b *ByzantineBuilder) BuildBlock(parentHash [32]byte, blockNumber uint64, validator [32]byte) (*Block, error) {
+	switch b.misbehaviors[blockNumber] {
+	case MisbehaviorWithhold:
+		return nil, fmt.Errorf("byzantine: withholding proposal for block %d", blockNumber)
+
+	case MisbehaviorEquivocate:
+		return b.buildEquivocation(parentHash, blockNumber, validator)
+
+	case MisbehaviorInvalidStateRoot:
+		block, err := b.honest.BuildBlock(parentHash, blockNumber, validator)
+		if err != nil {
+			return nil, err
+		}
+		header := block.Header()
+		header.StateRoot[0] ^= 0xFF
+		return block.WithSeal(header), nil
+
+	default:
+		return b.honest.BuildBlock(parentHash, blockNumber, validator)
+	}
+}
+
+// buildEquivocation returns a freshly-honest block for blockNumber on
+// the first call, and a distinct block (same height/parent/validator,
+// bumped nonce) on every call after — the minimal change needed to
+// produce two different hashes at the same height from the same
+// validator.
+func (b *ByzantineBuilder) buildEquivocation(parentHash [32]byte, blockNumber uint64, validator [32]byte) (*Block, error) {
+	block, err := b.honest.BuildBlock(parentHash, blockNumber, validator)
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	first, ok := b.equivocated[blockNumber]
+	if !ok {
+		b.equivocated[blockNumber] = block
+		return block, nil
+	}
+
+	header := block.Header()
+	header.Nonce = first.Header().Nonce + 1
+	return block.WithSeal(header), nil
+}
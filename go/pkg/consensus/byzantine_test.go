@@ -0,0 +1,101 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+// honestBuilder builds a real, empty-body block for whatever height it's
+// asked for — the minimal stand-in for BlockBuilder that doesn't need a
+// mempool or state manager, the same shortcut NewGenesisBlock/NewBlock
+// already let publisher_test.go's fakes take.
+type honestBuilder struct{}
+
+func (honestBuilder) BuildBlock(parentHash [32]byte, blockNumber uint64, validator [32]byte) (*Block, error) {
+	return NewBlock(blockNumber, parentHash, validator, nil), nil
+}
+
+// noopProcessor commits every block without touching any state, so the
+// test can exercise BlockPublisher's announce-then-commit path without a
+// real BlockProcessor.
+type noopProcessor struct{}
+
+func (noopProcessor) Process(block *Block) (Receipts, uint64, error) {
+	return Receipts{}, 0, nil
+}
+
+// TestByzantineConsensus runs a round-robin PoA chain across 4 simulated
+// validators, one of which is Byzantine (ByzantineBuilder configured with
+// MisbehaviorEquivocate at its turn), all publishing through one shared
+// BlockAnnouncer — the network's-eye view of who announced what. It
+// asserts that the equivocating validator's second, conflicting proposal
+// is rejected rather than broadcast, that exactly one piece of evidence
+// reaches the EvidencePool, and that the three honest validators' blocks
+// still chain together into a single agreed-on sequence.
+func TestByzantineConsensus(t *testing.T) {
+	var validators [4][32]byte
+	for i := range validators {
+		validators[i][0] = byte(i + 1)
+	}
+	const byzantineIndex = 2
+	const byzantineHeight = uint64(3) // (3-1)%4 == 2, so validators[2]'s turn
+
+	evidence := NewEvidencePool()
+	broadcaster := &recordingBroadcaster{}
+	announcer := NewBlockAnnouncer(broadcaster, evidence.RecordEquivocation, nil, logger.NewLogger("error"))
+
+	builders := make([]Builder, len(validators))
+	for i := range validators {
+		if i == byzantineIndex {
+			builders[i] = NewByzantineBuilder(honestBuilder{}, map[uint64]Misbehavior{byzantineHeight: MisbehaviorEquivocate})
+		} else {
+			builders[i] = honestBuilder{}
+		}
+	}
+
+	publishers := make([]*BlockPublisher, len(validators))
+	for i, builder := range builders {
+		publishers[i] = NewBlockPublisher(builder, announcer, noopProcessor{}, logger.NewLogger("error"))
+	}
+
+	chain := []*Block{NewGenesisBlock(validators[0])}
+	for height := uint64(1); height <= 8; height++ {
+		proposer := (height - 1) % uint64(len(validators))
+		parentHash := chain[len(chain)-1].Hash()
+
+		block, err := publishers[proposer].Publish(parentHash, height, validators[proposer])
+		if err != nil {
+			t.Fatalf("height %d: honest publish by validator %d failed: %v", height, proposer, err)
+		}
+		chain = append(chain, block)
+
+		if height == byzantineHeight {
+			if _, err := publishers[proposer].Publish(parentHash, height, validators[proposer]); err == nil {
+				t.Fatalf("height %d: expected the byzantine validator's second proposal to be rejected as equivocation", height)
+			}
+		}
+	}
+
+	if got := evidence.Count(); got != 1 {
+		t.Fatalf("expected exactly one equivocation recorded, got %d", got)
+	}
+	ev := evidence.All()[0]
+	if ev.Validator != validators[byzantineIndex] || ev.BlockNumber != byzantineHeight {
+		t.Fatalf("unexpected evidence: %+v", ev)
+	}
+
+	broadcaster.mu.Lock()
+	announced := append([]*Block(nil), broadcaster.announced...)
+	broadcaster.mu.Unlock()
+
+	if len(announced) != 8 {
+		t.Fatalf("expected only the 8 honestly-accepted blocks to reach peers, got %d", len(announced))
+	}
+	for i, block := range announced {
+		wantParent := chain[i].Hash()
+		if block.ParentHash() != wantParent {
+			t.Fatalf("announced chain broke at index %d: parent hash %x, want %x", i, block.ParentHash(), wantParent)
+		}
+	}
+}
@@ -0,0 +1,429 @@
+package consensus
+
+import "sync"
+
+// Default sub-cache sizes for BlockCache. Headers are tiny and get
+// touched on every chain-import step (parent-hash linkage, RPC header
+// lookups), so it gets by far the largest budget; bodies and receipts
+// are bigger and mostly wanted for recently imported blocks.
+const (
+	DefaultHeaderCacheSize   = 65_536
+	DefaultBodyCacheSize     = 256
+	DefaultReceiptsCacheSize = 256
+	DefaultNumberCacheSize   = 256
+)
+
+// BlockStore is the subset of a block-persistence layer that BlockCache
+// needs. It exists so BlockCache doesn't have to depend on the concrete
+// (and, in this tree, not yet implemented) state-manager/block-store
+// type — the same narrow-interface approach header_chain.go uses for
+// CryptoBackendVerifier.
+type BlockStore interface {
+	HeaderByHash(hash [32]byte) (*Header, error)
+	BodyByHash(hash [32]byte) (*Body, error)
+	ReceiptsByHash(hash [32]byte) (Receipts, error)
+	HashByNumber(number uint64) ([32]byte, error)
+	WriteBlock(block *Block, receipts Receipts) error
+}
+
+// BlockCache sits in front of a BlockStore with four independent bounded
+// LRU sub-caches (headers, bodies, receipts, and canonical
+// number->hash), so the validation and RPC paths that repeatedly touch
+// the same recent headers/blocks don't hit the underlying store every
+// time.
+type BlockCache struct {
+	store BlockStore
+
+	headers  *hashLRU
+	bodies   *hashLRU
+	receipts *hashLRU
+	numbers  *numberLRU
+}
+
+// NewBlockCache creates a BlockCache in front of store, using the
+// Default*CacheSize capacities.
+func NewBlockCache(store BlockStore) *BlockCache {
+	return NewBlockCacheWithSizes(store, DefaultHeaderCacheSize, DefaultBodyCacheSize, DefaultReceiptsCacheSize, DefaultNumberCacheSize)
+}
+
+// NewBlockCacheWithSizes creates a BlockCache with explicit sub-cache
+// capacities, for callers (tests, benchmarks) that want to exercise
+// eviction without allocating tens of thousands of entries.
+func NewBlockCacheWithSizes(store BlockStore, headerSize, bodySize, receiptsSize, numberSize int) *BlockCache {
+	return &BlockCache{
+		store:    store,
+		headers:  newHashLRU(headerSize),
+		bodies:   newHashLRU(bodySize),
+		receipts: newHashLRU(receiptsSize),
+		numbers:  newNumberLRU(numberSize),
+	}
+}
+
+// GetHeader returns the header for hash, from cache if present,
+// otherwise from the store (populating the cache on the way out).
+func (bc *BlockCache) GetHeader(hash [32]byte) (*Header, error) {
+	if v, ok := bc.headers.get(hash); ok {
+		return v.(*Header), nil
+	}
+
+	header, err := bc.store.HeaderByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	bc.headers.add(hash, header)
+	return header, nil
+}
+
+// GetBlock returns the full block for hash: its header and body, from
+// cache where possible, resealed into a *Block.
+func (bc *BlockCache) GetBlock(hash [32]byte) (*Block, error) {
+	header, err := bc.GetHeader(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	var body *Body
+	if v, ok := bc.bodies.get(hash); ok {
+		body = v.(*Body)
+	} else {
+		body, err = bc.store.BodyByHash(hash)
+		if err != nil {
+			return nil, err
+		}
+		bc.bodies.add(hash, body)
+	}
+
+	return seal(header, body), nil
+}
+
+// GetReceipts returns the receipts for hash, from cache if present,
+// otherwise from the store (populating the cache on the way out).
+func (bc *BlockCache) GetReceipts(hash [32]byte) (Receipts, error) {
+	if v, ok := bc.receipts.get(hash); ok {
+		return v.(Receipts), nil
+	}
+
+	receipts, err := bc.store.ReceiptsByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	bc.receipts.add(hash, receipts)
+	return receipts, nil
+}
+
+// HashByNumber returns the canonical block hash at number, from cache if
+// present, otherwise from the store (populating the cache on the way
+// out).
+func (bc *BlockCache) HashByNumber(number uint64) ([32]byte, error) {
+	if hash, ok := bc.numbers.get(number); ok {
+		return hash, nil
+	}
+
+	hash, err := bc.store.HashByNumber(number)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	bc.numbers.add(number, hash)
+	return hash, nil
+}
+
+// HasBlock reports whether hash is known, checking the header cache
+// before falling back to the store.
+func (bc *BlockCache) HasBlock(hash [32]byte) bool {
+	if _, ok := bc.headers.get(hash); ok {
+		return true
+	}
+	_, err := bc.store.HeaderByHash(hash)
+	return err == nil
+}
+
+// WriteBlock writes block and its receipts through to the store and
+// populates every sub-cache with the fresh data, so a block doesn't have
+// to round-trip through a cache miss right after it's produced.
+func (bc *BlockCache) WriteBlock(block *Block, receipts Receipts) error {
+	if err := bc.store.WriteBlock(block, receipts); err != nil {
+		return err
+	}
+
+	hash := block.Hash()
+	bc.headers.add(hash, block.Header())
+	bc.bodies.add(hash, &Body{Transactions: block.Transactions()})
+	bc.receipts.add(hash, receipts)
+	bc.numbers.add(block.Number(), hash)
+
+	return nil
+}
+
+// OnReorg evicts oldHashes from every hash-keyed sub-cache. It does not
+// need to touch the number cache: a reorg is only ever followed by
+// WriteBlock calls for the new canonical chain, which overwrite the
+// stale number->hash entries in place.
+func (bc *BlockCache) OnReorg(oldHashes [][32]byte) {
+	for _, hash := range oldHashes {
+		bc.headers.remove(hash)
+		bc.bodies.remove(hash)
+		bc.receipts.remove(hash)
+	}
+}
+
+// BlockCacheStats reports hit/miss counts for each sub-cache, taken at
+// the moment of the call (not a live view).
+type BlockCacheStats struct {
+	HeaderHits, HeaderMisses     uint64
+	BodyHits, BodyMisses         uint64
+	ReceiptsHits, ReceiptsMisses uint64
+	NumberHits, NumberMisses     uint64
+}
+
+// Stats returns current hit/miss counters for every sub-cache.
+func (bc *BlockCache) Stats() BlockCacheStats {
+	hh, hm := bc.headers.stats()
+	bh, bm := bc.bodies.stats()
+	rh, rm := bc.receipts.stats()
+	nh, nm := bc.numbers.stats()
+
+	return BlockCacheStats{
+		HeaderHits: hh, HeaderMisses: hm,
+		BodyHits: bh, BodyMisses: bm,
+		ReceiptsHits: rh, ReceiptsMisses: rm,
+		NumberHits: nh, NumberMisses: nm,
+	}
+}
+
+// ==================== hashLRU: [32]byte -> any, bounded, LRU-evicted ====================
+
+type hashLRUEntry struct {
+	key        [32]byte
+	value      interface{}
+	prev, next *hashLRUEntry
+}
+
+// hashLRU is a fixed-capacity LRU cache keyed by a 32-byte hash, backed
+// by a map plus an intrusive doubly-linked list for O(1) get/add/evict.
+// It exists so BlockCache doesn't pull in an external LRU dependency.
+type hashLRU struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[[32]byte]*hashLRUEntry
+	head     *hashLRUEntry // most recently used
+	tail     *hashLRUEntry // least recently used
+
+	hits, misses uint64
+}
+
+func newHashLRU(capacity int) *hashLRU {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &hashLRU{
+		capacity: capacity,
+		items:    make(map[[32]byte]*hashLRUEntry, capacity),
+	}
+}
+
+func (c *hashLRU) get(key [32]byte) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.moveToFront(e)
+	return e.value, true
+}
+
+func (c *hashLRU) add(key [32]byte, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.items[key]; ok {
+		e.value = value
+		c.moveToFront(e)
+		return
+	}
+
+	e := &hashLRUEntry{key: key, value: value}
+	c.items[key] = e
+	c.pushFront(e)
+
+	if len(c.items) > c.capacity {
+		c.evictOldest()
+	}
+}
+
+func (c *hashLRU) remove(key [32]byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return
+	}
+	c.unlink(e)
+	delete(c.items, key)
+}
+
+func (c *hashLRU) stats() (hits, misses uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
+
+func (c *hashLRU) evictOldest() {
+	if c.tail == nil {
+		return
+	}
+	oldest := c.tail
+	c.unlink(oldest)
+	delete(c.items, oldest.key)
+}
+
+func (c *hashLRU) moveToFront(e *hashLRUEntry) {
+	if c.head == e {
+		return
+	}
+	c.unlink(e)
+	c.pushFront(e)
+}
+
+func (c *hashLRU) pushFront(e *hashLRUEntry) {
+	e.prev = nil
+	e.next = c.head
+	if c.head != nil {
+		c.head.prev = e
+	}
+	c.head = e
+	if c.tail == nil {
+		c.tail = e
+	}
+}
+
+func (c *hashLRU) unlink(e *hashLRUEntry) {
+	if e.prev != nil {
+		e.prev.next = e.next
+	} else if c.head == e {
+		c.head = e.next
+	}
+	if e.next != nil {
+		e.next.prev = e.prev
+	} else if c.tail == e {
+		c.tail = e.prev
+	}
+	e.prev, e.next = nil, nil
+}
+
+// ==================== numberLRU: uint64 -> [32]byte, bounded, LRU-evicted ====================
+
+type numberLRUEntry struct {
+	key        uint64
+	value      [32]byte
+	prev, next *numberLRUEntry
+}
+
+// numberLRU is hashLRU's twin for the canonical number->hash cache. It's
+// a separate type rather than a generic one so this package doesn't
+// need to commit to a minimum Go version for generics support.
+type numberLRU struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[uint64]*numberLRUEntry
+	head     *numberLRUEntry
+	tail     *numberLRUEntry
+
+	hits, misses uint64
+}
+
+func newNumberLRU(capacity int) *numberLRU {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &numberLRU{
+		capacity: capacity,
+		items:    make(map[uint64]*numberLRUEntry, capacity),
+	}
+}
+
+func (c *numberLRU) get(key uint64) ([32]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		c.misses++
+		return [32]byte{}, false
+	}
+	c.hits++
+	c.moveToFront(e)
+	return e.value, true
+}
+
+func (c *numberLRU) add(key uint64, value [32]byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.items[key]; ok {
+		e.value = value
+		c.moveToFront(e)
+		return
+	}
+
+	e := &numberLRUEntry{key: key, value: value}
+	c.items[key] = e
+	c.pushFront(e)
+
+	if len(c.items) > c.capacity {
+		c.evictOldest()
+	}
+}
+
+func (c *numberLRU) stats() (hits, misses uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
+
+func (c *numberLRU) evictOldest() {
+	if c.tail == nil {
+		return
+	}
+	oldest := c.tail
+	c.unlink(oldest)
+	delete(c.items, oldest.key)
+}
+
+func (c *numberLRU) moveToFront(e *numberLRUEntry) {
+	if c.head == e {
+		return
+	}
+	c.unlink(e)
+	c.pushFront(e)
+}
+
+func (c *numberLRU) pushFront(e *numberLRUEntry) {
+	e.prev = nil
+	e.next = c.head
+	if c.head != nil {
+		c.head.prev = e
+	}
+	c.head = e
+	if c.tail == nil {
+		c.tail = e
+	}
+}
+
+func (c *numberLRU) unlink(e *numberLRUEntry) {
+	if e.prev != nil {
+		e.prev.next = e.next
+	} else if c.head == e {
+		c.head = e.next
+	}
+	if e.next != nil {
+		e.next.prev = e.prev
+	} else if c.tail == e {
+		c.tail = e.prev
+	}
+	e.prev, e.next = nil, nil
+}
@@ -0,0 +1,261 @@
+package consensus
+
+import (
+	"fmt"
+	"testing"
+)
+
+// fakeBlockStore is an in-memory BlockStore for testing BlockCache
+// against, with a knob (delay) to simulate an expensive lookup (e.g. a
+// disk-backed state manager) in the benchmarks below.
+type fakeBlockStore struct {
+	headers  map[[32]byte]*Header
+	bodies   map[[32]byte]*Body
+	receipts map[[32]byte]Receipts
+	numbers  map[uint64][32]byte
+
+	delay func()
+}
+
+func newFakeBlockStore() *fakeBlockStore {
+	return &fakeBlockStore{
+		headers:  make(map[[32]byte]*Header),
+		bodies:   make(map[[32]byte]*Body),
+		receipts: make(map[[32]byte]Receipts),
+		numbers:  make(map[uint64][32]byte),
+	}
+}
+
+func (s *fakeBlockStore) put(block *Block, receipts Receipts) {
+	hash := block.Hash()
+	s.headers[hash] = block.Header()
+	s.bodies[hash] = &Body{Transactions: block.Transactions()}
+	s.receipts[hash] = receipts
+	s.numbers[block.Number()] = hash
+}
+
+func (s *fakeBlockStore) HeaderByHash(hash [32]byte) (*Header, error) {
+	if s.delay != nil {
+		s.delay()
+	}
+	h, ok := s.headers[hash]
+	if !ok {
+		return nil, fmt.Errorf("header %x not found", hash)
+	}
+	return h, nil
+}
+
+func (s *fakeBlockStore) BodyByHash(hash [32]byte) (*Body, error) {
+	if s.delay != nil {
+		s.delay()
+	}
+	b, ok := s.bodies[hash]
+	if !ok {
+		return nil, fmt.Errorf("body %x not found", hash)
+	}
+	return b, nil
+}
+
+func (s *fakeBlockStore) ReceiptsByHash(hash [32]byte) (Receipts, error) {
+	if s.delay != nil {
+		s.delay()
+	}
+	r, ok := s.receipts[hash]
+	if !ok {
+		return nil, fmt.Errorf("receipts for %x not found", hash)
+	}
+	return r, nil
+}
+
+func (s *fakeBlockStore) HashByNumber(number uint64) ([32]byte, error) {
+	if s.delay != nil {
+		s.delay()
+	}
+	h, ok := s.numbers[number]
+	if !ok {
+		return [32]byte{}, fmt.Errorf("no canonical hash for block %d", number)
+	}
+	return h, nil
+}
+
+func (s *fakeBlockStore) WriteBlock(block *Block, receipts Receipts) error {
+	s.put(block, receipts)
+	return nil
+}
+
+func TestBlockCache_GetHeader_MissThenHit(t *testing.T) {
+	store := newFakeBlockStore()
+	block := NewGenesisBlock([32]byte{1})
+	store.put(block, nil)
+
+	bc := NewBlockCache(store)
+
+	if _, err := bc.GetHeader(block.Hash()); err != nil {
+		t.Fatalf("GetHeader (miss): %v", err)
+	}
+	if _, err := bc.GetHeader(block.Hash()); err != nil {
+		t.Fatalf("GetHeader (hit): %v", err)
+	}
+
+	hits, misses := bc.headers.stats()
+	if hits != 1 || misses != 1 {
+		t.Errorf("headers stats: got hits=%d misses=%d, want hits=1 misses=1", hits, misses)
+	}
+}
+
+func TestBlockCache_GetBlock_RoundTrip(t *testing.T) {
+	store := newFakeBlockStore()
+	block := NewGenesisBlock([32]byte{2})
+	store.put(block, nil)
+
+	bc := NewBlockCache(store)
+
+	got, err := bc.GetBlock(block.Hash())
+	if err != nil {
+		t.Fatalf("GetBlock: %v", err)
+	}
+	if got.Hash() != block.Hash() {
+		t.Errorf("hash mismatch: got %x, want %x", got.Hash(), block.Hash())
+	}
+}
+
+func TestBlockCache_HasBlock(t *testing.T) {
+	store := newFakeBlockStore()
+	block := NewGenesisBlock([32]byte{3})
+	store.put(block, nil)
+
+	bc := NewBlockCache(store)
+
+	if !bc.HasBlock(block.Hash()) {
+		t.Error("HasBlock should report true for a known block")
+	}
+	if bc.HasBlock([32]byte{0xff}) {
+		t.Error("HasBlock should report false for an unknown hash")
+	}
+}
+
+func TestBlockCache_WriteBlock_PopulatesCache(t *testing.T) {
+	store := newFakeBlockStore()
+	bc := NewBlockCache(store)
+
+	block := NewGenesisBlock([32]byte{4})
+	if err := bc.WriteBlock(block, Receipts{}); err != nil {
+		t.Fatalf("WriteBlock: %v", err)
+	}
+
+	if _, err := bc.GetHeader(block.Hash()); err != nil {
+		t.Fatalf("GetHeader after WriteBlock: %v", err)
+	}
+	hits, misses := bc.headers.stats()
+	if hits != 1 || misses != 0 {
+		t.Errorf("expected WriteBlock to populate the header cache: got hits=%d misses=%d", hits, misses)
+	}
+
+	hash, err := bc.HashByNumber(block.Number())
+	if err != nil {
+		t.Fatalf("HashByNumber: %v", err)
+	}
+	if hash != block.Hash() {
+		t.Errorf("HashByNumber: got %x, want %x", hash, block.Hash())
+	}
+}
+
+func TestBlockCache_OnReorg_EvictsHashKeyedEntries(t *testing.T) {
+	store := newFakeBlockStore()
+	bc := NewBlockCache(store)
+
+	block := NewGenesisBlock([32]byte{5})
+	store.put(block, Receipts{})
+	if _, err := bc.GetHeader(block.Hash()); err != nil {
+		t.Fatalf("GetHeader: %v", err)
+	}
+
+	bc.OnReorg([][32]byte{block.Hash()})
+
+	if _, ok := bc.headers.get(block.Hash()); ok {
+		t.Error("OnReorg should evict the header cache entry for a reorged-out hash")
+	}
+}
+
+func TestHashLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := newHashLRU(2)
+
+	var a, b, d [32]byte
+	a[0], b[0], d[0] = 1, 2, 3
+
+	c.add(a, "a")
+	c.add(b, "b")
+	c.get(a) // touch a so b becomes least-recently-used
+	c.add(d, "d")
+
+	if _, ok := c.get(b); ok {
+		t.Error("expected b to have been evicted as least-recently-used")
+	}
+	if v, ok := c.get(a); !ok || v != "a" {
+		t.Error("expected a to still be cached")
+	}
+	if v, ok := c.get(d); !ok || v != "d" {
+		t.Error("expected d to be cached")
+	}
+}
+
+// BenchmarkValidateHeader_ParentLookup_NoCache simulates chain import
+// fetching each block's parent header directly from a store with a
+// realistic lookup cost (e.g. a disk-backed state manager), once per
+// ValidateHeader call.
+func BenchmarkValidateHeader_ParentLookup_NoCache(b *testing.B) {
+	store, parentHash, bp := benchmarkValidateHeaderSetup()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		parent, err := store.HeaderByHash(parentHash)
+		if err != nil {
+			b.Fatal(err)
+		}
+		header := &Header{BlockNumber: parent.BlockNumber + 1, ParentHash: parentHash, Validator: parent.Validator, Timestamp: parent.Timestamp + 1, GasLimit: parent.GasLimit}
+		_ = bp.ValidateHeader(parent, header)
+	}
+}
+
+// BenchmarkValidateHeader_ParentLookup_WithCache repeats the same
+// parent-header lookup through a BlockCache in front of the same store,
+// so after the first call every lookup is an LRU hit instead of a store
+// round-trip.
+func BenchmarkValidateHeader_ParentLookup_WithCache(b *testing.B) {
+	store, parentHash, bp := benchmarkValidateHeaderSetup()
+	bc := NewBlockCache(store)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		parent, err := bc.GetHeader(parentHash)
+		if err != nil {
+			b.Fatal(err)
+		}
+		header := &Header{BlockNumber: parent.BlockNumber + 1, ParentHash: parentHash, Validator: parent.Validator, Timestamp: parent.Timestamp + 1, GasLimit: parent.GasLimit}
+		_ = bp.ValidateHeader(parent, header)
+	}
+}
+
+func benchmarkValidateHeaderSetup() (*fakeBlockStore, [32]byte, *BlockProcessor) {
+	var validator [32]byte
+	validator[0] = 7
+
+	store := newFakeBlockStore()
+	// A cheap stand-in for the cost of a real, disk-backed header
+	// lookup: enough work that a cache hit is measurably cheaper, not
+	// enough to make the benchmark itself slow to run.
+	store.delay = func() {
+		sum := 0
+		for i := 0; i < 2000; i++ {
+			sum += i
+		}
+		_ = sum
+	}
+
+	parent := NewGenesisBlock(validator)
+	store.put(parent, nil)
+
+	bp := &BlockProcessor{authorizedValidators: map[[32]byte]bool{validator: true}}
+
+	return store, parent.Hash(), bp
+}
@@ -0,0 +1,153 @@
+package consensus
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// Clock is the narrow time API block production and the various sync
+// timeouts (blocksync.Config.RequestTimeout and friends) actually need:
+// the current time, a one-shot timer, and a blocking sleep. RealClock
+// satisfies it with the real time package for production use; SimClock
+// satisfies it for tests that want block-time waits to resolve in
+// microseconds instead of minutes, the same narrow-interface approach
+// BlockStore/CryptoBackendVerifier use to keep a dependency test-friendly
+// without dragging in the concrete (and, for Engine, not yet implemented)
+// type on the other side.
+//
+// mempool.Config and p2p.Config don't exist as real types in this tree,
+// and ConsensusConfig (producer.go) has no Clock field: Producer runs its
+// BlockTime ticker on the time package directly. This file provides the
+// Clock/SimClock abstraction on its own rather than inventing those
+// config types here.
+type Clock interface {
+	// Now returns the clock's current time.
+	Now() time.Time
+
+	// NewTimer returns a channel that receives the current time once
+	// d has elapsed (virtual or real, depending on the implementation),
+	// and a function to stop the timer early.
+	NewTimer(d time.Duration) (<-chan time.Time, func())
+
+	// Sleep blocks the calling goroutine until d has elapsed.
+	Sleep(d time.Duration)
+}
+
+// RealClock is the production Clock: a thin wrapper over the time
+// package, so code written against Clock behaves identically to code
+// that called time.Now/time.NewTimer/time.Sleep directly.
+type RealClock struct{}
+
+func (RealClock) Now() time.Time { return time.Now() }
+
+func (RealClock) NewTimer(d time.Duration) (<-chan time.Time, func()) {
+	timer := time.NewTimer(d)
+	return timer.C, func() { timer.Stop() }
+}
+
+func (RealClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+// simTimer is one pending wait registered against a SimClock.
+type simTimer struct {
+	fireAt time.Time
+	index  int
+	done   chan time.Time
+}
+
+// simTimerHeap is a min-heap of pending timers ordered by fireAt, so
+// SimClock.Advance can always find (and fire) the next-soonest timer
+// without scanning every waiter.
+type simTimerHeap []*simTimer
+
+func (h simTimerHeap) Len() int            { return len(h) }
+func (h simTimerHeap) Less(i, j int) bool  { return h[i].fireAt.Before(h[j].fireAt) }
+func (h simTimerHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i]; h[i].index = i; h[j].index = j }
+func (h *simTimerHeap) Push(x interface{}) {
+	t := x.(*simTimer)
+	t.index = len(*h)
+	*h = append(*h, t)
+}
+func (h *simTimerHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	t := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return t
+}
+
+// SimClock is a deterministic virtual clock for tests: Now starts at an
+// arbitrary fixed instant and only moves forward when Advance is called,
+// so a test can drive N simulated BlockTime intervals in a tight loop
+// instead of blocking on N real time.Sleep calls. Every pending
+// NewTimer/Sleep waiter whose deadline falls at or before the new virtual
+// time fires, in deadline order, as part of a single Advance call.
+type SimClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers simTimerHeap
+}
+
+// NewSimClock creates a SimClock starting at an arbitrary fixed instant
+// (the Unix epoch), so tests get identical wall-clock-independent
+// behavior on every run.
+func NewSimClock() *SimClock {
+	return &SimClock{now: time.Unix(0, 0)}
+}
+
+func (c *SimClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *SimClock) NewTimer(d time.Duration) (<-chan time.Time, func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	timer := &simTimer{fireAt: c.now.Add(d), done: make(chan time.Time, 1)}
+	heap.Push(&c.timers, timer)
+
+	stop := func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if timer.index >= 0 && timer.index < len(c.timers) && c.timers[timer.index] == timer {
+			heap.Remove(&c.timers, timer.index)
+		}
+	}
+	return timer.done, stop
+}
+
+// Sleep blocks until d of virtual time has elapsed, i.e. until some
+// other goroutine calls Advance far enough. It's implemented directly in
+// terms of NewTimer so it shares the same ordering guarantees.
+func (c *SimClock) Sleep(d time.Duration) {
+	done, _ := c.NewTimer(d)
+	<-done
+}
+
+// Advance moves the virtual clock forward by d, firing (in deadline
+// order) every pending timer/sleep whose deadline is now at or before
+// the new time. It's meant to be driven from the test goroutine in a
+// tight loop — e.g. one call per simulated BlockTime — rather than from
+// the code under test.
+func (c *SimClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	for c.timers.Len() > 0 && !c.timers[0].fireAt.After(c.now) {
+		timer := heap.Pop(&c.timers).(*simTimer)
+		timer.done <- c.now
+	}
+}
+
+// PendingTimers reports how many timers are still waiting for a future
+// Advance, so a test can assert it has drained everything it expected to
+// before moving on.
+func (c *SimClock) PendingTimers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.timers.Len()
+}
@@ -0,0 +1,127 @@
+package consensus
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRealClock_SleepBlocksForRoughlyTheRequestedDuration(t *testing.T) {
+	var clock Clock = RealClock{}
+
+	start := clock.Now()
+	clock.Sleep(10 * time.Millisecond)
+	if elapsed := clock.Now().Sub(start); elapsed < 10*time.Millisecond {
+		t.Fatalf("expected at least 10ms to have elapsed, got %v", elapsed)
+	}
+}
+
+func TestSimClock_AdvanceFiresDueTimerImmediately(t *testing.T) {
+	clock := NewSimClock()
+	start := clock.Now()
+
+	done, _ := clock.NewTimer(5 * time.Second)
+
+	select {
+	case <-done:
+		t.Fatal("timer fired before any Advance call")
+	default:
+	}
+
+	clock.Advance(5 * time.Second)
+
+	select {
+	case fired := <-done:
+		if !fired.Equal(start.Add(5 * time.Second)) {
+			t.Fatalf("expected timer to fire at %v, got %v", start.Add(5*time.Second), fired)
+		}
+	default:
+		t.Fatal("expected timer to have fired after Advance")
+	}
+}
+
+func TestSimClock_AdvanceFiresTimersInDeadlineOrder(t *testing.T) {
+	clock := NewSimClock()
+
+	var mu sync.Mutex
+	var fired []time.Duration
+
+	for _, d := range []time.Duration{3 * time.Second, 1 * time.Second, 2 * time.Second} {
+		d := d
+		done, _ := clock.NewTimer(d)
+		go func() {
+			<-done
+			mu.Lock()
+			fired = append(fired, d)
+			mu.Unlock()
+		}()
+	}
+
+	clock.Advance(3 * time.Second)
+
+	// Give the three goroutines a moment to record their fire order;
+	// they're all unblocked from the same Advance call so this should
+	// settle almost immediately.
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		mu.Lock()
+		n := len(fired)
+		mu.Unlock()
+		if n == 3 {
+			break
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []time.Duration{1 * time.Second, 2 * time.Second, 3 * time.Second}
+	if len(fired) != len(want) {
+		t.Fatalf("expected 3 timers to fire, got %d", len(fired))
+	}
+	for i, d := range want {
+		if fired[i] != d {
+			t.Fatalf("expected timer %d to fire at %v, got %v", i, d, fired[i])
+		}
+	}
+}
+
+func TestSimClock_SleepUnblocksOnSufficientAdvance(t *testing.T) {
+	clock := NewSimClock()
+
+	woke := make(chan struct{})
+	go func() {
+		clock.Sleep(2 * time.Second)
+		close(woke)
+	}()
+
+	clock.Advance(1 * time.Second)
+	select {
+	case <-woke:
+		t.Fatal("Sleep returned before enough virtual time had elapsed")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	clock.Advance(1 * time.Second)
+	select {
+	case <-woke:
+	case <-time.After(time.Second):
+		t.Fatal("Sleep did not return after enough virtual time had elapsed")
+	}
+}
+
+func TestSimClock_StopPreventsFiring(t *testing.T) {
+	clock := NewSimClock()
+	done, stop := clock.NewTimer(time.Second)
+	stop()
+
+	clock.Advance(time.Second)
+	select {
+	case <-done:
+		t.Fatal("expected a stopped timer to never fire")
+	default:
+	}
+
+	if pending := clock.PendingTimers(); pending != 0 {
+		t.Fatalf("expected 0 pending timers after stop, got %d", pending)
+	}
+}
@@ -0,0 +1,156 @@
+package consensus
+
+import "fmt"
+
+// combinedRootPrefix domain-separates a block's combined tx/data root
+// from an ordinary RFC 6962 internal node (hashNodeRFC6962 uses
+// rfc6962NodePrefix): ComputeCombinedRoot sits one level above either
+// sub-trie's own root, so it gets its own domain byte rather than
+// reusing 0x01 in a way that could let a sub-trie's internal node be
+// mistaken for the block-level commitment.
+const combinedRootPrefix byte = 0x02
+
+// ComputeCombinedRoot combines a block's transaction root and
+// application-level data root (e.g. rollup calldata) into the single
+// commitment BlockHeader.MerkleRoot/DataRoot are sealed under:
+// H(0x02 || txRoot || dataRoot). This lets the chain commit to two
+// independent Merkle trees — and serve a DataProof against either one —
+// instead of flattening both into a single tree.
+func ComputeCombinedRoot(txRoot, dataRoot [32]byte) [32]byte {
+	buf := make([]byte, 0, 1+32+32)
+	buf = append(buf, combinedRootPrefix)
+	buf = append(buf, txRoot[:]...)
+	buf = append(buf, dataRoot[:]...)
+	return DefaultBackend().SHA256(buf)
+}
+
+// DataProofSubTrie selects which side of a combined root (see
+// ComputeCombinedRoot) a DataProof's leaf was drawn from.
+type DataProofSubTrie uint8
+
+const (
+	DataProofSubTrieLeft  DataProofSubTrie = iota // leaf is under the tx root
+	DataProofSubTrieRight                         // leaf is under the data root
+)
+
+// DataProof proves a single leaf's inclusion under one of the two
+// sub-tries (transactions or application data) a block's combined root
+// commits to, without the verifier needing the opposite sub-trie at
+// all — only its already-sealed root, OppositeRoot.
+//
+// Path is hashed RFC 6962-style (hashLeafRFC6962/hashNodeRFC6962, the
+// same domain separation as merkle_proof.go's BuildMerkleProofRFC6962)
+// and, unlike []MerkleProofStep, carries no per-step "is this sibling on
+// the left" marker: VerifyDataProof re-derives each step's orientation
+// by comparing LeafIndex against the same largestPowerOfTwoLessThan
+// split rfc6962Range used to build it, so NumberOfLeaves plus LeafIndex
+// is enough.
+type DataProof struct {
+	Leaf           [32]byte
+	SubTrie        DataProofSubTrie
+	Path           [][32]byte
+	LeafIndex      uint32
+	NumberOfLeaves uint32
+	OppositeRoot   [32]byte
+}
+
+// BuildDataProof builds a DataProof for the leaf at leafIndex within
+// leaves — the sub-trie named by subTrie — to be verified against a
+// combined root together with oppositeRoot, the already-sealed root of
+// the other sub-trie.
+func BuildDataProof(leaves [][32]byte, leafIndex uint32, subTrie DataProofSubTrie, oppositeRoot [32]byte) (DataProof, error) {
+	if leafIndex >= uint32(len(leaves)) {
+		return DataProof{}, fmt.Errorf("leaf index %d out of range for %d leaves", leafIndex, len(leaves))
+	}
+
+	_, steps, err := BuildMerkleProofRFC6962(leaves, int(leafIndex))
+	if err != nil {
+		return DataProof{}, fmt.Errorf("failed to build rfc6962 proof: %w", err)
+	}
+
+	path := make([][32]byte, len(steps))
+	for i, step := range steps {
+		path[i] = step.Sibling
+	}
+
+	return DataProof{
+		Leaf:           leaves[leafIndex],
+		SubTrie:        subTrie,
+		Path:           path,
+		LeafIndex:      leafIndex,
+		NumberOfLeaves: uint32(len(leaves)),
+		OppositeRoot:   oppositeRoot,
+	}, nil
+}
+
+// VerifyDataProof reconstructs proof's sub-trie root from Leaf, Path and
+// LeafIndex, combines it with OppositeRoot on the side SubTrie
+// indicates, and reports whether the result matches combinedRoot.
+func VerifyDataProof(proof DataProof, combinedRoot [32]byte) bool {
+	if proof.NumberOfLeaves == 0 || proof.LeafIndex >= proof.NumberOfLeaves {
+		return false
+	}
+
+	pos := 0
+	subTrieRoot, ok := reconstructRFC6962Subtree(proof.Leaf, proof.LeafIndex, 0, proof.NumberOfLeaves, proof.Path, &pos)
+	if !ok || pos != len(proof.Path) {
+		return false
+	}
+
+	var combined [32]byte
+	switch proof.SubTrie {
+	case DataProofSubTrieLeft:
+		combined = ComputeCombinedRoot(subTrieRoot, proof.OppositeRoot)
+	case DataProofSubTrieRight:
+		combined = ComputeCombinedRoot(proof.OppositeRoot, subTrieRoot)
+	default:
+		return false
+	}
+
+	return combined == combinedRoot
+}
+
+// reconstructRFC6962Subtree re-derives the root of a numberOfLeaves-leaf
+// RFC 6962 tree containing leaf at leafIndex, from leaf and path,
+// splitting [lo, hi) the same way rfc6962Range did to build the proof:
+// at largestPowerOfTwoLessThan(hi-lo), recursing into whichever half
+// contains leafIndex first and only then consuming the next path entry
+// as the other half's already-known root.
+//
+// That order matters: rfc6962Range appends to the proof only after both
+// of its recursive calls return, so the path entries belonging to
+// deeper levels always precede the current level's own entry — pos must
+// be advanced in that same depth-first order, regardless of whether
+// leafIndex falls in the left or right half at this level. Reports
+// ok=false if path runs out before the recursion bottoms out.
+func reconstructRFC6962Subtree(leaf [32]byte, leafIndex, lo, hi uint32, path [][32]byte, pos *int) (hash [32]byte, ok bool) {
+	n := hi - lo
+	if n == 1 {
+		return hashLeafRFC6962(leaf), true
+	}
+
+	k := uint32(largestPowerOfTwoLessThan(int(n)))
+	if leafIndex < lo+k {
+		left, ok := reconstructRFC6962Subtree(leaf, leafIndex, lo, lo+k, path, pos)
+		if !ok {
+			return hash, false
+		}
+		if *pos >= len(path) {
+			return hash, false
+		}
+		right := path[*pos]
+		*pos++
+		return hashNodeRFC6962(left, right), true
+	}
+
+	right, ok := reconstructRFC6962Subtree(leaf, leafIndex, lo+k, hi, path, pos)
+	if !ok {
+		return hash, false
+	}
+	if *pos >= len(path) {
+		return hash, false
+	}
+	left := path[*pos]
+	*pos++
+	return hashNodeRFC6962(left, right), true
+}
@@ -0,0 +1,145 @@
+package consensus
+
+import "testing"
+
+func leavesForDataProofTest(n int) [][32]byte {
+	leaves := make([][32]byte, n)
+	for i := range leaves {
+		leaves[i] = [32]byte{byte(i + 1), byte((i + 1) >> 8)}
+	}
+	return leaves
+}
+
+func TestBuildAndVerifyDataProof_EveryLeafAcrossSizes(t *testing.T) {
+	opposite := [32]byte{0xAA, 0xBB}
+
+	for _, n := range []int{1, 2, 3, 4, 5, 7, 8, 15, 16, 17, 31, 32, 33} {
+		leaves := leavesForDataProofTest(n)
+
+		for i := 0; i < n; i++ {
+			for _, subTrie := range []DataProofSubTrie{DataProofSubTrieLeft, DataProofSubTrieRight} {
+				proof, err := BuildDataProof(leaves, uint32(i), subTrie, opposite)
+				if err != nil {
+					t.Fatalf("n=%d index=%d: unexpected error: %v", n, i, err)
+				}
+
+				subRoot, _, err := BuildMerkleProofRFC6962(leaves, i)
+				if err != nil {
+					t.Fatalf("n=%d index=%d: unexpected error: %v", n, i, err)
+				}
+
+				var combined [32]byte
+				if subTrie == DataProofSubTrieLeft {
+					combined = ComputeCombinedRoot(subRoot, opposite)
+				} else {
+					combined = ComputeCombinedRoot(opposite, subRoot)
+				}
+
+				if !VerifyDataProof(proof, combined) {
+					t.Fatalf("n=%d index=%d subTrie=%v: expected proof to verify", n, i, subTrie)
+				}
+			}
+		}
+	}
+}
+
+func TestVerifyDataProof_RejectsWrongCombinedRoot(t *testing.T) {
+	leaves := leavesForDataProofTest(8)
+	opposite := [32]byte{0xAA}
+
+	proof, err := BuildDataProof(leaves, 3, DataProofSubTrieLeft, opposite)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	subRoot, _, err := BuildMerkleProofRFC6962(leaves, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	combined := ComputeCombinedRoot(subRoot, opposite)
+	combined[0] ^= 0xFF
+
+	if VerifyDataProof(proof, combined) {
+		t.Error("expected verification to fail against a tampered combined root")
+	}
+}
+
+func TestVerifyDataProof_RejectsTamperedLeaf(t *testing.T) {
+	leaves := leavesForDataProofTest(8)
+	opposite := [32]byte{0xAA}
+
+	proof, err := BuildDataProof(leaves, 3, DataProofSubTrieLeft, opposite)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	proof.Leaf[0] ^= 0xFF
+
+	subRoot, _, err := BuildMerkleProofRFC6962(leaves, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	combined := ComputeCombinedRoot(subRoot, opposite)
+
+	if VerifyDataProof(proof, combined) {
+		t.Error("expected verification to fail for a tampered leaf")
+	}
+}
+
+func TestVerifyDataProof_RejectsWrongOppositeRoot(t *testing.T) {
+	leaves := leavesForDataProofTest(8)
+	opposite := [32]byte{0xAA}
+
+	proof, err := BuildDataProof(leaves, 3, DataProofSubTrieLeft, opposite)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	subRoot, _, err := BuildMerkleProofRFC6962(leaves, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	combined := ComputeCombinedRoot(subRoot, opposite)
+
+	proof.OppositeRoot[0] ^= 0xFF
+	if VerifyDataProof(proof, combined) {
+		t.Error("expected verification to fail for a tampered opposite root")
+	}
+}
+
+func TestVerifyDataProof_RejectsIndexOutOfRange(t *testing.T) {
+	leaves := leavesForDataProofTest(4)
+	opposite := [32]byte{0xAA}
+
+	proof, err := BuildDataProof(leaves, 0, DataProofSubTrieLeft, opposite)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	proof.LeafIndex = proof.NumberOfLeaves
+
+	if VerifyDataProof(proof, [32]byte{}) {
+		t.Error("expected verification to fail for an out-of-range leaf index")
+	}
+}
+
+func TestBuildDataProof_RejectsIndexOutOfRange(t *testing.T) {
+	leaves := leavesForDataProofTest(4)
+	if _, err := BuildDataProof(leaves, 4, DataProofSubTrieLeft, [32]byte{}); err == nil {
+		t.Error("expected an error for a leaf index beyond the leaf set")
+	}
+}
+
+func TestComputeCombinedRoot_DiffersFromRFC6962Node(t *testing.T) {
+	// ComputeCombinedRoot's 0x02 prefix is never applied to a pair of
+	// 32-byte RFC 6962 node hashes, so this isn't a true collision check
+	// — just a sanity check that the two helpers don't happen to produce
+	// the same output for the same inputs.
+	a := [32]byte{1}
+	b := [32]byte{2}
+
+	combined := ComputeCombinedRoot(a, b)
+	node := hashNodeRFC6962(a, b)
+
+	if combined == node {
+		t.Error("expected ComputeCombinedRoot and hashNodeRFC6962 to diverge for the same inputs")
+	}
+}
@@ -0,0 +1,374 @@
+package consensus
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/signer"
+)
+
+// Engine is a pluggable consensus algorithm: given a Header/Block built
+// against the current chain head, it decides who was (or should be)
+// allowed to produce it, and how. RoundRobinEngine and CliqueEngine are
+// the two implementations shipped here; a future driver can select
+// between them by config the same way cmd/node-a/main.go would select
+// a CryptoBackend.
+//
+// Neither implementation is wired into a block-production driver:
+// Producer (producer.go) keeps its own round-robin isOurTurn check and
+// holds no Engine. Engine is built standalone against block.go as it
+// stands today, the same way Clock/SimClock were. See
+// engine_algorithms_test.go for coverage of
+// RoundRobinEngine/CliqueEngine against the current interface.
+type Engine interface {
+	// Author returns the address that produced (or claims to have
+	// produced) block, independent of whether it's actually authorized.
+	Author(block *Block) ([32]byte, error)
+
+	// VerifyHeader checks that header is validly produced: signed (if
+	// the algorithm requires a signature), by an address authorized to
+	// produce it, and not in violation of any turn-taking rule. parents
+	// is header's ancestor chain in ascending BlockNumber order, oldest
+	// first; how much of it VerifyHeader actually needs is up to the
+	// implementation.
+	VerifyHeader(header *Header, parents []*Header) error
+
+	// Prepare fills in the consensus-specific fields (Difficulty,
+	// ExtraData) of a Header about to be built into a block, given its
+	// ancestor chain. It runs before the block's transactions are
+	// known, so it must not touch TxRoot or GasUsed.
+	Prepare(header *Header, parents []*Header) error
+
+	// Seal finalizes block for this node's own identity: computing and
+	// attaching whatever authorization proof (a signature, or simply
+	// the validator field) VerifyHeader will later check. It returns a
+	// new Block; the receiver's argument is left untouched, consistent
+	// with Block's other "With*" methods.
+	Seal(block *Block) (*Block, error)
+
+	// Finalize runs any bookkeeping a sealed, verified block triggers
+	// that isn't captured by VerifyHeader's pure check — for
+	// CliqueEngine, nothing (its authorized-signer set is always
+	// recomputed from header history, never mutated in place); present
+	// on the interface so an engine that does need it doesn't require a
+	// breaking interface change later.
+	Finalize(block *Block) error
+
+	// CalcDifficulty computes the Difficulty a new block at blockNumber
+	// should carry, given its parent's Difficulty.
+	CalcDifficulty(parentDifficulty uint64, blockNumber uint64) uint64
+}
+
+// ==================== RoundRobinEngine ====================
+
+// RoundRobinEngine is this chain's original PoA algorithm: validators
+// take turns producing blocks in a fixed order, validator at index
+// (blockNumber % len(validators)) owning blockNumber. It carries no
+// signature of its own — Header.Validator is trusted as claimed.
+type RoundRobinEngine struct {
+	validators [][32]byte
+}
+
+// NewRoundRobinEngine builds a RoundRobinEngine over the given
+// validator set, in turn-taking order. The order is significant: two
+// engines built from differently-ordered validators will disagree about
+// whose turn a given block number is.
+func NewRoundRobinEngine(validators [][32]byte) *RoundRobinEngine {
+	return &RoundRobinEngine{validators: append([][32]byte(nil), validators...)}
+}
+
+// turn returns the validator whose turn blockNumber is.
+func (e *RoundRobinEngine) turn(blockNumber uint64) ([32]byte, error) {
+	if len(e.validators) == 0 {
+		return [32]byte{}, fmt.Errorf("round-robin: no validators configured")
+	}
+	return e.validators[blockNumber%uint64(len(e.validators))], nil
+}
+
+func (e *RoundRobinEngine) Author(block *Block) ([32]byte, error) {
+	return block.Validator(), nil
+}
+
+func (e *RoundRobinEngine) VerifyHeader(header *Header, parents []*Header) error {
+	expected, err := e.turn(header.BlockNumber)
+	if err != nil {
+		return err
+	}
+	if header.Validator != expected {
+		return fmt.Errorf("round-robin: block %d belongs to %x, not %x", header.BlockNumber, expected[:8], header.Validator[:8])
+	}
+	return nil
+}
+
+func (e *RoundRobinEngine) Prepare(header *Header, parents []*Header) error {
+	header.Difficulty = e.CalcDifficulty(0, header.BlockNumber)
+	return nil
+}
+
+// Seal stamps block's Validator with whichever validator owns its turn
+// and re-hashes it; it does not check that this node is actually
+// entitled to sign on that validator's behalf, the same way the header
+// field it sets carries no signature to check against.
+func (e *RoundRobinEngine) Seal(block *Block) (*Block, error) {
+	expected, err := e.turn(block.Number())
+	if err != nil {
+		return nil, err
+	}
+	header := block.Header()
+	header.Validator = expected
+	return block.WithSeal(header), nil
+}
+
+func (e *RoundRobinEngine) Finalize(block *Block) error { return nil }
+
+// CalcDifficulty is constant: round-robin has no notion of in-turn vs.
+// out-of-turn signing, so every block is equally valid.
+func (e *RoundRobinEngine) CalcDifficulty(parentDifficulty uint64, blockNumber uint64) uint64 {
+	return 1
+}
+
+// ==================== CliqueEngine ====================
+
+// cliqueIdentityLen is the size, in bytes, of the signer identity
+// CliqueEngine embeds in ExtraData. Ed25519 signatures can't be
+// recovered to a public key the way secp256k1's can, so — unlike
+// go-ethereum's Clique, which recovers the signer from the signature
+// alone — the signer's claimed address/public key has to ride alongside
+// the signature instead; VerifyHeader checks the signature against the
+// claimed identity, and checks that identity against the authorized
+// set, rather than recovering the identity from the signature.
+const cliqueIdentityLen = 32
+
+// cliqueExtraLen is ExtraData's total length on a sealed Clique header:
+// the signer identity followed by its Ed25519 signature.
+const cliqueExtraLen = cliqueIdentityLen + ed25519.SignatureSize
+
+// CliqueEngine is a Clique-style PoA algorithm (go-ethereum's
+// proof-of-authority consensus, https://eips.ethereum.org/EIPS/eip-225)
+// adapted to this chain's Ed25519-only signer model: an authorized
+// signer set, a recent-signer exclusion window, and an on-chain vote
+// mechanism for adding/removing signers, all checked against a header's
+// embedded Ed25519 signature rather than an ECDSA one.
+//
+// Unlike RoundRobinEngine, CliqueEngine keeps no mutable membership
+// state of its own — VerifyHeader reconstructs the authorized set (and
+// replays any pending votes) from the parents chain it's given, the
+// same way go-ethereum's Clique derives a Snapshot from header history
+// rather than trusting a cached in-memory set. initialSigners is only
+// the genesis-time seed that reconstruction starts from; it never
+// changes. This does mean a full reconstruction costs more the deeper
+// the chain gets — go-ethereum bounds that cost by periodically
+// checkpointing a Snapshot to disk, which this tree has no block-
+// storage hooks for yet, so epoch here only bounds how much of parents
+// a single VerifyHeader call replays, not how often the signer set
+// itself is allowed to change.
+type CliqueEngine struct {
+	initialSigners [][32]byte
+	epoch          uint64
+	self           signer.Signer
+	selfAddr       [32]byte
+}
+
+// NewCliqueEngine builds a CliqueEngine with initialSigners as its
+// genesis-time authorized set. epoch bounds how many ancestor headers
+// VerifyHeader replays to reconstruct the current signer set and vote
+// tally; 0 means replay the entire parents slice given. self is this
+// node's own signing key, used by Seal; pass nil for a node that only
+// verifies blocks produced by others.
+func NewCliqueEngine(initialSigners [][32]byte, epoch uint64, self signer.Signer) *CliqueEngine {
+	e := &CliqueEngine{
+		initialSigners: append([][32]byte(nil), initialSigners...),
+		epoch:          epoch,
+		self:           self,
+	}
+	if self != nil {
+		copy(e.selfAddr[:], self.Public())
+	}
+	return e
+}
+
+// decodeCliqueExtra splits a Clique header's ExtraData into the signer
+// identity it claims and the signature over cliqueSigHash(header).
+func decodeCliqueExtra(extra []byte) (identity [32]byte, sig []byte, err error) {
+	if len(extra) != cliqueExtraLen {
+		return identity, nil, fmt.Errorf("clique: expected %d-byte extra data (identity+signature), got %d", cliqueExtraLen, len(extra))
+	}
+	copy(identity[:], extra[:cliqueIdentityLen])
+	return identity, extra[cliqueIdentityLen:], nil
+}
+
+// cliqueSigHash hashes header the same way headerHash does, except with
+// ExtraData truncated to just the identity prefix — the signature
+// itself is excluded from what it covers, so a header can't sign over
+// its own signature bytes.
+func cliqueSigHash(header *Header) [32]byte {
+	h := header.clone()
+	if len(h.ExtraData) > cliqueIdentityLen {
+		h.ExtraData = h.ExtraData[:cliqueIdentityLen]
+	}
+	return headerHash(h)
+}
+
+func (e *CliqueEngine) Author(block *Block) ([32]byte, error) {
+	identity, _, err := decodeCliqueExtra(block.ExtraData())
+	return identity, err
+}
+
+// snapshot replays history (header's ancestors, oldest first, bounded
+// to the last e.epoch entries if epoch is nonzero) to reconstruct the
+// authorized signer set as of the block that follows it. A non-zero
+// Validator/Nonce pair on an ancestor header is that block's signer
+// casting a vote: Validator names the candidate, Nonce != 0 means
+// "authorize", 0 means "deauthorize" — mirroring Clique's repurposing
+// of coinbase/nonce for voting rather than their usual meaning.
+func (e *CliqueEngine) snapshot(history []*Header) (map[[32]byte]bool, error) {
+	if e.epoch > 0 && uint64(len(history)) > e.epoch {
+		history = history[uint64(len(history))-e.epoch:]
+	}
+
+	signers := make(map[[32]byte]bool, len(e.initialSigners))
+	for _, addr := range e.initialSigners {
+		signers[addr] = true
+	}
+
+	// candidate -> voter -> authorize
+	tally := make(map[[32]byte]map[[32]byte]bool)
+
+	var zero [32]byte
+	for _, h := range history {
+		voter, _, err := decodeCliqueExtra(h.ExtraData)
+		if err != nil {
+			return nil, err
+		}
+		if !signers[voter] {
+			// Fell out of the authorized set before this block; its
+			// vote doesn't count. VerifyHeader should already have
+			// rejected this block on its own merits — this is just
+			// defensive.
+			continue
+		}
+
+		candidate := h.Validator
+		if candidate == zero {
+			continue
+		}
+		authorize := h.Nonce != 0
+
+		if tally[candidate] == nil {
+			tally[candidate] = make(map[[32]byte]bool)
+		}
+		tally[candidate][voter] = authorize
+
+		var yes, no int
+		for _, auth := range tally[candidate] {
+			if auth {
+				yes++
+			} else {
+				no++
+			}
+		}
+		threshold := len(signers)/2 + 1
+		switch {
+		case authorize && yes >= threshold:
+			signers[candidate] = true
+			delete(tally, candidate)
+		case !authorize && no >= threshold:
+			delete(signers, candidate)
+			delete(tally, candidate)
+		}
+	}
+
+	return signers, nil
+}
+
+func (e *CliqueEngine) VerifyHeader(header *Header, parents []*Header) error {
+	identity, sig, err := decodeCliqueExtra(header.ExtraData)
+	if err != nil {
+		return err
+	}
+
+	sigHash := cliqueSigHash(header)
+	if !ed25519.Verify(ed25519.PublicKey(identity[:]), sigHash[:], sig) {
+		return fmt.Errorf("clique: signature does not match claimed signer %x", identity[:8])
+	}
+
+	signers, err := e.snapshot(parents)
+	if err != nil {
+		return err
+	}
+	if !signers[identity] {
+		return fmt.Errorf("clique: %x is not an authorized signer", identity[:8])
+	}
+
+	// A signer may not produce two blocks within floor(N/2)+1 of each
+	// other, so that no signer (or colluding minority) can dominate
+	// block production.
+	limit := len(signers)/2 + 1
+	recent := parents
+	if len(recent) > limit {
+		recent = recent[len(recent)-limit:]
+	}
+	for _, h := range recent {
+		recentSigner, _, err := decodeCliqueExtra(h.ExtraData)
+		if err == nil && recentSigner == identity {
+			return fmt.Errorf("clique: %x signed one of the last %d blocks; not its turn yet", identity[:8], limit)
+		}
+	}
+
+	return nil
+}
+
+// Prepare reserves ExtraData's identity prefix (so cliqueSigHash sees
+// the right length before Seal attaches a signature) and sets
+// Difficulty. It does not itself encode a vote — a caller wanting
+// header's block to carry one sets header.Validator/header.Nonce to the
+// candidate/authorize-bool pair before calling Prepare.
+func (e *CliqueEngine) Prepare(header *Header, parents []*Header) error {
+	header.Difficulty = e.CalcDifficulty(0, header.BlockNumber)
+	header.ExtraData = make([]byte, cliqueIdentityLen)
+	copy(header.ExtraData, e.selfAddr[:])
+	return nil
+}
+
+// Seal signs block on this node's own behalf: it overwrites the
+// header's ExtraData identity prefix with this node's address (in case
+// Prepare wasn't called against this same engine instance), computes
+// cliqueSigHash over everything but the signature, and appends the
+// resulting Ed25519 signature.
+func (e *CliqueEngine) Seal(block *Block) (*Block, error) {
+	if e.self == nil {
+		return nil, fmt.Errorf("clique: this node has no signing key configured")
+	}
+
+	header := block.Header()
+	if len(header.ExtraData) < cliqueIdentityLen {
+		header.ExtraData = make([]byte, cliqueIdentityLen)
+	} else {
+		header.ExtraData = header.ExtraData[:cliqueIdentityLen]
+	}
+	copy(header.ExtraData, e.selfAddr[:])
+
+	sigHash := cliqueSigHash(header)
+	sig, err := e.self.Sign(sigHash[:])
+	if err != nil {
+		return nil, fmt.Errorf("clique: failed to sign header: %w", err)
+	}
+	header.ExtraData = append(header.ExtraData, sig...)
+
+	return block.WithSeal(header), nil
+}
+
+func (e *CliqueEngine) Finalize(block *Block) error { return nil }
+
+// CalcDifficulty is constant. Real Clique distinguishes in-turn (2) from
+// out-of-turn (1) signing to break forks in favor of the expected
+// signer, but that requires the authorized-signer ordering this
+// interface's CalcDifficulty signature doesn't have access to (only
+// parentDifficulty and blockNumber) — VerifyHeader's recent-signer
+// window is what actually keeps any one signer from dominating, so
+// constant difficulty is a correctness-preserving simplification, not a
+// missing check.
+func (e *CliqueEngine) CalcDifficulty(parentDifficulty uint64, blockNumber uint64) uint64 {
+	return 1
+}
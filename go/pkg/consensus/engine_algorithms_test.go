@@ -0,0 +1,266 @@
+package consensus
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/signer"
+)
+
+// engineFixture builds an Engine plus whatever it needs to seal and
+// verify a simple two-block chain, so the table-driven suite below can
+// run identical assertions against RoundRobinEngine and CliqueEngine —
+// the same pluggable-engines shape used by go-ethereum's #3817.
+type engineFixture struct {
+	name  string
+	build func(t *testing.T) (engine Engine, authors [][32]byte)
+	// tamper mutates a sealed header in a way that fixture's engine
+	// actually checks, so TestEngine_VerifyHeaderRejectsATamperedHeader
+	// exercises each engine's own trust model rather than assuming they
+	// all validate the same fields.
+	tamper func(h *Header)
+}
+
+func roundRobinFixture(t *testing.T) (Engine, [][32]byte) {
+	validators := [][32]byte{{1}, {2}, {3}}
+	return NewRoundRobinEngine(validators), validators
+}
+
+func cliqueFixture(t *testing.T) (Engine, [][32]byte) {
+	pub1, priv1, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate signer key: %v", err)
+	}
+	pub2, priv2, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate signer key: %v", err)
+	}
+
+	var addr1, addr2 [32]byte
+	copy(addr1[:], pub1)
+	copy(addr2[:], pub2)
+	_ = pub2
+	_ = priv2
+
+	return NewCliqueEngine([][32]byte{addr1, addr2}, 0, signer.NewMemorySigner(pub1, priv1)), [][32]byte{addr1, addr2}
+}
+
+var engineFixtures = []engineFixture{
+	{
+		name:  "round-robin",
+		build: roundRobinFixture,
+		tamper: func(h *Header) {
+			h.Validator = [32]byte{0xff}
+		},
+	},
+	{
+		name:  "clique",
+		build: cliqueFixture,
+		tamper: func(h *Header) {
+			h.GasLimit = h.GasLimit + 1
+		},
+	},
+}
+
+// baseBlock builds an unsealed-by-any-engine Block carrying header's
+// fields, so Seal has something to re-seal with its own ExtraData.
+func baseBlock(header *Header) *Block {
+	return NewBlock(header.BlockNumber, header.ParentHash, header.Validator, nil).WithSeal(header)
+}
+
+func TestEngine_SealThenVerifyHeaderAcceptsABlockItProducedItself(t *testing.T) {
+	for _, fixture := range engineFixtures {
+		t.Run(fixture.name, func(t *testing.T) {
+			engine, authors := fixture.build(t)
+
+			header := &Header{BlockNumber: 0, Validator: authors[0]}
+			if err := engine.Prepare(header, nil); err != nil {
+				t.Fatalf("Prepare: %v", err)
+			}
+
+			block := baseBlock(header)
+			sealed, err := engine.Seal(block)
+			if err != nil {
+				t.Fatalf("Seal: %v", err)
+			}
+
+			if err := engine.VerifyHeader(sealed.Header(), nil); err != nil {
+				t.Fatalf("VerifyHeader rejected a block the engine just sealed: %v", err)
+			}
+		})
+	}
+}
+
+func TestEngine_VerifyHeaderRejectsATamperedHeader(t *testing.T) {
+	for _, fixture := range engineFixtures {
+		t.Run(fixture.name, func(t *testing.T) {
+			engine, authors := fixture.build(t)
+
+			header := &Header{BlockNumber: 0, Validator: authors[0]}
+			if err := engine.Prepare(header, nil); err != nil {
+				t.Fatalf("Prepare: %v", err)
+			}
+			block := baseBlock(header)
+			sealed, err := engine.Seal(block)
+			if err != nil {
+				t.Fatalf("Seal: %v", err)
+			}
+
+			tampered := sealed.Header()
+			fixture.tamper(tampered)
+			if err := engine.VerifyHeader(tampered, nil); err == nil {
+				t.Fatal("expected VerifyHeader to reject a header whose fields changed after sealing")
+			}
+		})
+	}
+}
+
+func TestEngine_AuthorReturnsTheSealingAddress(t *testing.T) {
+	for _, fixture := range engineFixtures {
+		t.Run(fixture.name, func(t *testing.T) {
+			engine, authors := fixture.build(t)
+
+			header := &Header{BlockNumber: 0, Validator: authors[0]}
+			if err := engine.Prepare(header, nil); err != nil {
+				t.Fatalf("Prepare: %v", err)
+			}
+			block := baseBlock(header)
+			sealed, err := engine.Seal(block)
+			if err != nil {
+				t.Fatalf("Seal: %v", err)
+			}
+
+			author, err := engine.Author(sealed)
+			if err != nil {
+				t.Fatalf("Author: %v", err)
+			}
+			if author != authors[0] {
+				t.Fatalf("Author() = %x, want %x", author[:8], authors[0][:8])
+			}
+		})
+	}
+}
+
+func TestRoundRobinEngine_VerifyHeaderRejectsTheWrongValidatorsTurn(t *testing.T) {
+	validators := [][32]byte{{1}, {2}, {3}}
+	engine := NewRoundRobinEngine(validators)
+
+	header := &Header{BlockNumber: 1, Validator: validators[0]}
+	if err := engine.VerifyHeader(header, nil); err == nil {
+		t.Fatal("expected VerifyHeader to reject validator 0 producing block 1 (validator 1's turn)")
+	}
+}
+
+func TestCliqueEngine_VerifyHeaderRejectsAnUnauthorizedSigner(t *testing.T) {
+	authorizedPub, authorizedPriv, _ := ed25519.GenerateKey(nil)
+	outsiderPub, outsiderPriv, _ := ed25519.GenerateKey(nil)
+
+	var authorized, outsider [32]byte
+	copy(authorized[:], authorizedPub)
+	copy(outsider[:], outsiderPub)
+
+	_ = authorizedPriv
+	outsiderEngine := NewCliqueEngine([][32]byte{authorized}, 0, signer.NewMemorySigner(outsiderPub, outsiderPriv))
+
+	header := &Header{BlockNumber: 0}
+	if err := outsiderEngine.Prepare(header, nil); err != nil {
+		t.Fatalf("Prepare: %v", err)
+	}
+	block := baseBlock(header)
+	sealed, err := outsiderEngine.Seal(block)
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+
+	if err := outsiderEngine.VerifyHeader(sealed.Header(), nil); err == nil {
+		t.Fatal("expected VerifyHeader to reject a signer outside the authorized set")
+	}
+}
+
+func TestCliqueEngine_VerifyHeaderRejectsASignerInsideTheRecentWindow(t *testing.T) {
+	pubA, privA, _ := ed25519.GenerateKey(nil)
+	pubB, privB, _ := ed25519.GenerateKey(nil)
+	var a, b [32]byte
+	copy(a[:], pubA)
+	copy(b[:], pubB)
+
+	signers := [][32]byte{a, b}
+	engineA := NewCliqueEngine(signers, 0, signer.NewMemorySigner(pubA, privA))
+
+	sealBlock := func(number uint64, eng *CliqueEngine) *Header {
+		header := &Header{BlockNumber: number}
+		if err := eng.Prepare(header, nil); err != nil {
+			t.Fatalf("Prepare: %v", err)
+		}
+		block := baseBlock(header)
+		sealed, err := eng.Seal(block)
+		if err != nil {
+			t.Fatalf("Seal: %v", err)
+		}
+		return sealed.Header()
+	}
+
+	block0 := sealBlock(0, engineA)
+	block1 := sealBlock(1, engineA)
+
+	// With two signers the exclusion window is floor(2/2)+1 = 2, so A
+	// signing block1 right after block0 must be rejected.
+	if err := engineA.VerifyHeader(block1, []*Header{block0}); err == nil {
+		t.Fatal("expected VerifyHeader to reject the same signer producing two blocks inside the recent window")
+	}
+}
+
+func TestCliqueEngine_SignerRotationAndVotes(t *testing.T) {
+	pubA, privA, _ := ed25519.GenerateKey(nil)
+	pubB, privB, _ := ed25519.GenerateKey(nil)
+	pubC, privC, _ := ed25519.GenerateKey(nil)
+	var a, b, c [32]byte
+	copy(a[:], pubA)
+	copy(b[:], pubB)
+	copy(c[:], pubC)
+
+	initial := [][32]byte{a, b}
+	engineA := NewCliqueEngine(initial, 0, signer.NewMemorySigner(pubA, privA))
+	engineB := NewCliqueEngine(initial, 0, signer.NewMemorySigner(pubB, privB))
+	_ = privC
+
+	// A votes to authorize C. With 2 signers, threshold is floor(2/2)+1
+	// = 2, so A's vote alone shouldn't be enough yet.
+	voteHeader := &Header{BlockNumber: 0, Validator: c, Nonce: 1}
+	if err := engineA.Prepare(voteHeader, nil); err != nil {
+		t.Fatalf("Prepare: %v", err)
+	}
+	voteBlock, err := engineA.Seal(baseBlock(voteHeader))
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+
+	signersAfterOneVote, err := engineB.snapshot([]*Header{voteBlock.Header()})
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	if signersAfterOneVote[c] {
+		t.Fatal("expected one vote out of two signers not to authorize a new signer yet")
+	}
+
+	// B casts the second vote to authorize C; the set should now
+	// include C.
+	secondVoteHeader := &Header{BlockNumber: 1, Validator: c, Nonce: 1}
+	if err := engineB.Prepare(secondVoteHeader, nil); err != nil {
+		t.Fatalf("Prepare: %v", err)
+	}
+	secondVoteBlock, err := engineB.Seal(baseBlock(secondVoteHeader))
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+
+	history := []*Header{voteBlock.Header(), secondVoteBlock.Header()}
+	signersAfterBothVotes, err := engineA.snapshot(history)
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	if !signersAfterBothVotes[c] {
+		t.Fatal("expected C to be authorized once both existing signers voted for it")
+	}
+}
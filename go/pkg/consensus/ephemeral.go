@@ -0,0 +1,212 @@
+package consensus
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
+)
+
+// TracerHook is looked up once per transaction to obtain that
+// transaction's tracer, carrying over the per-tx tracer lookup pattern
+// from the Erigon t8n migration. It may return nil for transactions the
+// caller isn't interested in tracing.
+type TracerHook func(tx *mempool.Transaction) Tracer
+
+// Tracer observes a single transaction's ephemeral execution. A
+// transaction rejected before execution (insufficient balance, nonce
+// mismatch) reaches OnTxExecuted with a nil receipt and the rejection
+// error, and is also listed in EphemeralExecResult.RejectedTxs.
+type Tracer interface {
+	OnTxExecuted(tx *mempool.Transaction, receipt *Receipt, err error)
+}
+
+// AccountDiff is the before/after balance and nonce of one account
+// touched during an ephemeral execution.
+type AccountDiff struct {
+	Address       [32]byte
+	BalanceBefore uint64
+	BalanceAfter  uint64
+	NonceBefore   uint64
+	NonceAfter    uint64
+}
+
+// RejectedTx records a transaction ExecuteBlockEphemerally refused to
+// apply and why, mirroring the skip reasons BuildBlock already logs.
+type RejectedTx struct {
+	TxHash [32]byte
+	Reason string
+}
+
+// EphemeralExecResult is the outcome of replaying a block via
+// ExecuteBlockEphemerally: per-transaction receipts, the state it would
+// produce (as a diff rather than a full account dump), the state root
+// that diff hashes to, and any transactions that couldn't be applied.
+type EphemeralExecResult struct {
+	Receipts    Receipts
+	GasUsed     uint64
+	StateDiff   map[[32]byte]AccountDiff
+	StateRoot   [32]byte
+	RejectedTxs []RejectedTx
+}
+
+// ephemeralAccount is applyTransaction's in-memory stand-in for
+// *state.Account: every read ExecuteBlockEphemerally makes goes through
+// this overlay (seeded lazily from bb.stateManager on first touch) and
+// every write stays in it, so bb.stateManager.UpdateAccount is never
+// called.
+type ephemeralAccount struct {
+	balance       uint64
+	nonce         uint64
+	balanceBefore uint64
+	nonceBefore   uint64
+}
+
+// ExecuteBlockEphemerally replays block's transactions against the
+// builder's current state without committing anything: it never calls
+// stateManager.UpdateAccount, never touches the mempool, and never
+// drives a reward distributor, so it is safe to call from block-explorer
+// simulation, eth_call-style RPCs, differential fuzzing against the Rust
+// FFI validator, or offline replay tooling while the real chain keeps
+// advancing underneath it.
+//
+// tracerHook is consulted once per transaction to obtain that
+// transaction's tracer (may be nil); see TracerHook.
+func (bb *BlockBuilder) ExecuteBlockEphemerally(block *Block, tracerHook TracerHook) (*EphemeralExecResult, error) {
+	overlay := make(map[[32]byte]*ephemeralAccount)
+	receipts := make(Receipts, 0, len(block.Transactions()))
+	rejected := make([]RejectedTx, 0)
+
+	var cumulativeGasUsed uint64
+
+	for _, tx := range block.Transactions() {
+		if err := bb.ephemeralApplyTransaction(overlay, tx); err != nil {
+			rejected = append(rejected, RejectedTx{TxHash: tx.Hash, Reason: err.Error()})
+			if hook := tracerHook; hook != nil {
+				if tracer := hook(tx); tracer != nil {
+					tracer.OnTxExecuted(tx, nil, err)
+				}
+			}
+			continue
+		}
+
+		cumulativeGasUsed += tx.GasLimit
+		receipt := &Receipt{
+			TxHash:            tx.Hash,
+			Status:            ReceiptStatusSuccess,
+			GasUsed:           tx.GasLimit,
+			CumulativeGasUsed: cumulativeGasUsed,
+		}
+		receipts = append(receipts, receipt)
+
+		if hook := tracerHook; hook != nil {
+			if tracer := hook(tx); tracer != nil {
+				tracer.OnTxExecuted(tx, receipt, nil)
+			}
+		}
+	}
+
+	diff := make(map[[32]byte]AccountDiff, len(overlay))
+	for addr, acc := range overlay {
+		diff[addr] = AccountDiff{
+			Address:       addr,
+			BalanceBefore: acc.balanceBefore,
+			BalanceAfter:  acc.balance,
+			NonceBefore:   acc.nonceBefore,
+			NonceAfter:    acc.nonce,
+		}
+	}
+
+	return &EphemeralExecResult{
+		Receipts:    receipts,
+		GasUsed:     cumulativeGasUsed,
+		StateDiff:   diff,
+		StateRoot:   computeStateRootFromDiff(diff),
+		RejectedTxs: rejected,
+	}, nil
+}
+
+// ephemeralApplyTransaction is applyTransaction's read-from-overlay-or-
+// state, write-to-overlay-only counterpart: sender and recipient are
+// read from overlay if a previous transaction in this same ephemeral run
+// already touched them, falling back to bb.stateManager (a read, never a
+// write) otherwise.
+func (bb *BlockBuilder) ephemeralApplyTransaction(overlay map[[32]byte]*ephemeralAccount, tx *mempool.Transaction) error {
+	sender, err := bb.ephemeralLoadAccount(overlay, tx.From)
+	if err != nil {
+		return fmt.Errorf("failed to get sender account: %w", err)
+	}
+
+	recipient, err := bb.ephemeralLoadAccount(overlay, tx.To)
+	if err != nil {
+		recipient = &ephemeralAccount{}
+		overlay[tx.To] = recipient
+	}
+
+	if tx.Nonce != sender.nonce {
+		return fmt.Errorf("nonce mismatch: expected %d, got %d", sender.nonce, tx.Nonce)
+	}
+
+	totalCost := tx.Amount + tx.Fee
+	if sender.balance < totalCost {
+		return fmt.Errorf("insufficient balance: need %d, have %d", totalCost, sender.balance)
+	}
+
+	sender.balance -= totalCost
+	sender.nonce++
+	recipient.balance += tx.Amount
+
+	return nil
+}
+
+// ephemeralLoadAccount returns overlay[addr], seeding it from
+// bb.stateManager on first touch. Unlike applyTransaction, a missing
+// account is an error here rather than silently defaulting to zero,
+// since ExecuteBlockEphemerally must distinguish "account doesn't exist"
+// from "account exists with zero balance" for an accurate state diff.
+func (bb *BlockBuilder) ephemeralLoadAccount(overlay map[[32]byte]*ephemeralAccount, addr [32]byte) (*ephemeralAccount, error) {
+	if acc, ok := overlay[addr]; ok {
+		return acc, nil
+	}
+
+	account, err := bb.stateManager.GetAccount(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	acc := &ephemeralAccount{
+		balance:       account.Balance,
+		nonce:         account.Nonce,
+		balanceBefore: account.Balance,
+		nonceBefore:   account.Nonce,
+	}
+	overlay[addr] = acc
+	return acc, nil
+}
+
+// computeStateRootFromDiff hashes the touched accounts' post-execution
+// balances and nonces in address-sorted order, giving
+// ExecuteBlockEphemerally a state root that's deterministic regardless
+// of map iteration order and changes whenever the diff does. It is not
+// the same state root BlockBuilder.ApplyBlock would eventually commit
+// (see its own "Implement proper state root computation" TODO) — only a
+// stable fingerprint over what this particular ephemeral run touched.
+func computeStateRootFromDiff(diff map[[32]byte]AccountDiff) [32]byte {
+	addrs := make([][32]byte, 0, len(diff))
+	for addr := range diff {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return string(addrs[i][:]) < string(addrs[j][:])
+	})
+
+	buf := make([]byte, 0, len(addrs)*(32+8+8))
+	for _, addr := range addrs {
+		d := diff[addr]
+		buf = append(buf, addr[:]...)
+		buf = appendUint64(buf, d.BalanceAfter)
+		buf = appendUint64(buf, d.NonceAfter)
+	}
+
+	return DefaultBackend().SHA256(buf)
+}
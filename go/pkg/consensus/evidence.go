@@ -0,0 +1,78 @@
+package consensus
+
+import "sync"
+
+// Evidence is a locally observed validator equivocation: the same
+// validator announcing two different blocks at the same height, as
+// surfaced by BlockAnnouncer's EquivocationHook.
+type Evidence struct {
+	Validator   [32]byte
+	BlockNumber uint64
+	First       [32]byte
+	Second      [32]byte
+}
+
+// evidenceKey identifies one (validator, height) slot, the granularity
+// at which equivocation evidence is deduplicated.
+type evidenceKey struct {
+	validator   [32]byte
+	blockNumber uint64
+}
+
+// EvidencePool collects Evidence surfaced by BlockAnnouncer so it can be
+// inspected by tests and, eventually, handed to a slashing path. It's
+// the producer-side counterpart to pkg/dispute.Manager (which plays the
+// same role on the receiving end of gossip); EvidencePool stays in
+// package consensus because BlockAnnouncer already lives here and feeds
+// it directly via RecordEquivocation, with no need for dispute's p2p
+// decoupling.
+type EvidencePool struct {
+	mu    sync.Mutex
+	seen  map[evidenceKey]bool
+	items []Evidence
+}
+
+// NewEvidencePool creates an empty EvidencePool.
+func NewEvidencePool() *EvidencePool {
+	return &EvidencePool{seen: make(map[evidenceKey]bool)}
+}
+
+// RecordEquivocation is an EquivocationHook: wire it into
+// NewBlockAnnouncer so every equivocation BlockAnnouncer catches is
+// recorded here, deduplicated by (validator, height) so a validator
+// that keeps re-announcing the same conflicting pair isn't counted
+// twice.
+func (p *EvidencePool) RecordEquivocation(event EquivocationEvent) {
+	key := evidenceKey{validator: event.Validator, blockNumber: event.BlockNumber}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.seen[key] {
+		return
+	}
+	p.seen[key] = true
+	p.items = append(p.items, Evidence{
+		Validator:   event.Validator,
+		BlockNumber: event.BlockNumber,
+		First:       event.First.Hash(),
+		Second:      event.Second.Hash(),
+	})
+}
+
+// All returns every piece of evidence collected so far.
+func (p *EvidencePool) All() []Evidence {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]Evidence, len(p.items))
+	copy(out, p.items)
+	return out
+}
+
+// Count returns how many distinct (validator, height) equivocations
+// have been recorded.
+func (p *EvidencePool) Count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.items)
+}
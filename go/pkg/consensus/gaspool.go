@@ -0,0 +1,38 @@
+package consensus
+
+import "errors"
+
+// ErrGasLimitReached is returned by GasPool.SubGas when amount would
+// overdraw the pool below zero.
+var ErrGasLimitReached = errors.New("gas limit reached")
+
+// GasPool tracks the gas remaining for a single block, shared by
+// whatever is accounting for it: BlockBuilder.BuildBlock deciding which
+// mempool transactions fit, and ApplyBlock/applyTransaction (and
+// eventually an EVM-style executor) spending intrinsic gas against the
+// same budget. It is a plain uint64 under the hood, mirroring
+// go-ethereum's core.GasPool, so the zero value is an empty (fully
+// spent) pool rather than an unbounded one.
+type GasPool uint64
+
+// AddGas increases the pool by amount, e.g. to seed it with a block's
+// gas limit or to credit back an unused refund.
+func (gp *GasPool) AddGas(amount uint64) *GasPool {
+	*gp += GasPool(amount)
+	return gp
+}
+
+// SubGas deducts amount from the pool, failing with ErrGasLimitReached
+// rather than underflowing if amount exceeds what remains.
+func (gp *GasPool) SubGas(amount uint64) error {
+	if uint64(*gp) < amount {
+		return ErrGasLimitReached
+	}
+	*gp -= GasPool(amount)
+	return nil
+}
+
+// Gas returns the amount of gas remaining in the pool.
+func (gp *GasPool) Gas() uint64 {
+	return uint64(*gp)
+}
@@ -0,0 +1,40 @@
+package consensus
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGasPool_SubGasDeductsWithinLimit(t *testing.T) {
+	gp := new(GasPool).AddGas(100)
+
+	if err := gp.SubGas(40); err != nil {
+		t.Fatalf("SubGas within limit: %v", err)
+	}
+	if got := gp.Gas(); got != 60 {
+		t.Errorf("Gas() = %d, want 60", got)
+	}
+}
+
+func TestGasPool_SubGasRejectsOverdraft(t *testing.T) {
+	gp := new(GasPool).AddGas(10)
+
+	if err := gp.SubGas(11); !errors.Is(err, ErrGasLimitReached) {
+		t.Fatalf("SubGas over limit: got %v, want ErrGasLimitReached", err)
+	}
+	if got := gp.Gas(); got != 10 {
+		t.Errorf("Gas() after rejected SubGas = %d, want unchanged 10", got)
+	}
+}
+
+func TestGasPool_AddGasCreditsBack(t *testing.T) {
+	gp := new(GasPool).AddGas(50)
+	if err := gp.SubGas(50); err != nil {
+		t.Fatalf("SubGas: %v", err)
+	}
+	gp.AddGas(20)
+
+	if got := gp.Gas(); got != 20 {
+		t.Errorf("Gas() after credit back = %d, want 20", got)
+	}
+}
@@ -0,0 +1,298 @@
+// Package goldenvec generates and verifies the golden cross-implementation
+// test vectors consumed by consensus.TestRustParity_* and
+// consensus.TestBackendParity_AllVectors.
+//
+// Historically those vectors lived solely as a static JSON file checked
+// into the Rust tree (rust/coinjecture-core/golden/network_b_vectors.json),
+// which meant a fixed vector count baked into the Go tests
+// (require.Equal(t, 50, ...)) and no way to regenerate vectors when the
+// codec version changes. Generate produces a fresh, deterministic set;
+// Verify runs them against any consensus.CryptoBackend and reports
+// pass/fail per operation so a divergence can be diffed instead of just
+// failing a single assert.
+package goldenvec
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/consensus"
+)
+
+// SchemaVersion identifies the shape of the GoldenVectors JSON document.
+// Bump it whenever a field is added, removed, or reinterpreted so old
+// vector files fail loudly instead of silently misparsing.
+const SchemaVersion = 1
+
+// Operation names, matching the Operation field used by the existing
+// Rust-parity tests.
+const (
+	OpSHA256      = "SHA256"
+	OpMerkle      = "MERKLE"
+	OpBlockHeader = "BLOCK_HEADER"
+	OpMerkleProof = "MERKLE_PROOF"
+)
+
+// Profile controls how many vectors Generate produces for each operation.
+type Profile struct {
+	SHA256Count      int // number of SHA-256 input-size variants
+	MerkleTreeShapes int // reserved for future shape presets; 0 = default set
+	HeaderCount      int // number of block-header edge-case variants
+}
+
+// DefaultProfile matches the SHA-256 and header counts of the vector set
+// the Rust tree previously checked in (50 vectors with the 6 Merkle
+// vectors); Generate adds 4 Merkle-proof vectors, for 54 in all.
+func DefaultProfile() Profile {
+	return Profile{
+		SHA256Count: 20,
+		HeaderCount: 24,
+	}
+}
+
+// GoldenVectors is the schema-versioned, serializable vector set.
+type GoldenVectors struct {
+	SchemaVersion int            `json:"schema_version"`
+	Seed          int64          `json:"seed"`
+	TotalVectors  int            `json:"total_vectors"`
+	Vectors       []GoldenVector `json:"vectors"`
+}
+
+// GoldenVector is a single test case. Only the fields relevant to
+// Operation are populated.
+type GoldenVector struct {
+	TestName     string            `json:"test_name"`
+	Operation    string            `json:"operation"`
+	InputHex     string            `json:"input_hex,omitempty"`
+	ExpectedHash string            `json:"expected_hash,omitempty"`
+	TxHashes     []string          `json:"tx_hashes,omitempty"`
+	ExpectedRoot string            `json:"expected_root,omitempty"`
+	Header       *BlockHeaderJSON  `json:"header,omitempty"`
+	LeafIndex    int               `json:"leaf_index,omitempty"`
+	Proof        []MerkleProofStep `json:"proof,omitempty"`
+}
+
+// MerkleProofStep is the JSON form of consensus.MerkleProofStep.
+type MerkleProofStep struct {
+	Sibling string `json:"sibling"`
+	IsLeft  bool   `json:"is_left"`
+}
+
+// BlockHeaderJSON is the hex-encoded wire form of a consensus.BlockHeader.
+type BlockHeaderJSON struct {
+	CodecVersion     uint32 `json:"codec_version"`
+	BlockIndex       uint32 `json:"block_index"`
+	Timestamp        int64  `json:"timestamp"`
+	ParentHash       string `json:"parent_hash"`
+	MerkleRoot       string `json:"merkle_root"`
+	MinerAddress     string `json:"miner_address"`
+	Commitment       string `json:"commitment"`
+	DifficultyTarget uint32 `json:"difficulty_target"`
+	Nonce            uint64 `json:"nonce"`
+	ExtraData        string `json:"extra_data"`
+}
+
+// Generate produces a deterministic vector set for the given seed and
+// profile. The same (seed, config) pair always yields byte-identical
+// output, so vectors can be committed and diffed like any other fixture.
+func Generate(seed int64, config Profile) *GoldenVectors {
+	rng := rand.New(rand.NewSource(seed))
+	vectors := &GoldenVectors{SchemaVersion: SchemaVersion, Seed: seed}
+
+	vectors.Vectors = append(vectors.Vectors, generateSHA256Vectors(rng, config)...)
+	vectors.Vectors = append(vectors.Vectors, generateMerkleVectors(rng)...)
+	vectors.Vectors = append(vectors.Vectors, generateMerkleProofVectors(rng)...)
+	vectors.Vectors = append(vectors.Vectors, generateHeaderVectors(rng, config)...)
+
+	vectors.TotalVectors = len(vectors.Vectors)
+	return vectors
+}
+
+func generateSHA256Vectors(rng *rand.Rand, config Profile) []GoldenVector {
+	sizes := []int{0, 1, 16, 32, 64, 127, 256, 1024, 4096}
+	var out []GoldenVector
+
+	for i := 0; i < config.SHA256Count; i++ {
+		size := sizes[i%len(sizes)]
+		data := make([]byte, size)
+		rng.Read(data)
+
+		hash := sha256.Sum256(data)
+		out = append(out, GoldenVector{
+			TestName:     fmt.Sprintf("sha256_size_%d_variant_%d", size, i),
+			Operation:    OpSHA256,
+			InputHex:     hex.EncodeToString(data),
+			ExpectedHash: hex.EncodeToString(hash[:]),
+		})
+	}
+
+	return out
+}
+
+// generateMerkleVectors covers the shape edge cases ComputeMerkleRoot
+// must agree on: empty, single leaf, two leaves, odd leaf count
+// (requires last-leaf duplication), and a larger balanced tree.
+func generateMerkleVectors(rng *rand.Rand) []GoldenVector {
+	counts := []int{0, 1, 2, 3, 7, 64}
+	var out []GoldenVector
+
+	for _, count := range counts {
+		leaves := make([][32]byte, count)
+		txHashes := make([]string, count)
+		for i := range leaves {
+			rng.Read(leaves[i][:])
+			txHashes[i] = hex.EncodeToString(leaves[i][:])
+		}
+
+		root := merkleRootReference(leaves)
+		out = append(out, GoldenVector{
+			TestName:     fmt.Sprintf("merkle_leaves_%d", count),
+			Operation:    OpMerkle,
+			TxHashes:     txHashes,
+			ExpectedRoot: hex.EncodeToString(root[:]),
+		})
+	}
+
+	return out
+}
+
+// generateMerkleProofVectors covers a few tree sizes (including odd
+// leaf counts, which exercise last-leaf duplication) with the proof
+// for a leaf near the middle of the tree.
+func generateMerkleProofVectors(rng *rand.Rand) []GoldenVector {
+	counts := []int{2, 3, 5, 16}
+	var out []GoldenVector
+
+	for _, count := range counts {
+		leaves := make([][32]byte, count)
+		txHashes := make([]string, count)
+		for i := range leaves {
+			rng.Read(leaves[i][:])
+			txHashes[i] = hex.EncodeToString(leaves[i][:])
+		}
+
+		index := count / 2
+		root, proof := merkleProofReference(leaves, index)
+
+		jsonProof := make([]MerkleProofStep, len(proof))
+		for i, step := range proof {
+			jsonProof[i] = MerkleProofStep{
+				Sibling: hex.EncodeToString(step.sibling[:]),
+				IsLeft:  step.isLeft,
+			}
+		}
+
+		out = append(out, GoldenVector{
+			TestName:     fmt.Sprintf("merkle_proof_leaves_%d_index_%d", count, index),
+			Operation:    OpMerkleProof,
+			TxHashes:     txHashes,
+			ExpectedRoot: hex.EncodeToString(root[:]),
+			LeafIndex:    index,
+			Proof:        jsonProof,
+		})
+	}
+
+	return out
+}
+
+func generateHeaderVectors(rng *rand.Rand, config Profile) []GoldenVector {
+	var out []GoldenVector
+
+	for i := 0; i < config.HeaderCount; i++ {
+		header := &consensus.BlockHeader{
+			CodecVersion:     1,
+			BlockIndex:       uint32(i),
+			Timestamp:        int64(1_700_000_000 + i),
+			DifficultyTarget: uint32(1000 * (i + 1)),
+			Nonce:            randomNonceEdgeCase(rng, i),
+			ExtraData:        randomExtraDataEdgeCase(rng, i),
+		}
+		rng.Read(header.ParentHash[:])
+		rng.Read(header.MerkleRoot[:])
+		rng.Read(header.MinerAddress[:])
+		rng.Read(header.Commitment[:])
+
+		// Edge case: zero hashes on the first vector.
+		if i == 0 {
+			header.ParentHash = [32]byte{}
+			header.MerkleRoot = [32]byte{}
+		}
+
+		hash := sha256.Sum256(encodeHeaderReference(header))
+
+		out = append(out, GoldenVector{
+			TestName:     fmt.Sprintf("header_variant_%d", i),
+			Operation:    OpBlockHeader,
+			ExpectedHash: hex.EncodeToString(hash[:]),
+			Header:       headerToJSON(header),
+		})
+	}
+
+	return out
+}
+
+func randomNonceEdgeCase(rng *rand.Rand, i int) uint64 {
+	switch i % 4 {
+	case 0:
+		return 0
+	case 1:
+		return ^uint64(0) // max nonce
+	default:
+		return rng.Uint64()
+	}
+}
+
+func randomExtraDataEdgeCase(rng *rand.Rand, i int) []byte {
+	switch i % 4 {
+	case 0:
+		return nil
+	case 1:
+		data := make([]byte, 4096) // long extra data
+		rng.Read(data)
+		return data
+	default:
+		data := make([]byte, rng.Intn(64))
+		rng.Read(data)
+		return data
+	}
+}
+
+func headerToJSON(h *consensus.BlockHeader) *BlockHeaderJSON {
+	return &BlockHeaderJSON{
+		CodecVersion:     h.CodecVersion,
+		BlockIndex:       h.BlockIndex,
+		Timestamp:        h.Timestamp,
+		ParentHash:       hex.EncodeToString(h.ParentHash[:]),
+		MerkleRoot:       hex.EncodeToString(h.MerkleRoot[:]),
+		MinerAddress:     hex.EncodeToString(h.MinerAddress[:]),
+		Commitment:       hex.EncodeToString(h.Commitment[:]),
+		DifficultyTarget: h.DifficultyTarget,
+		Nonce:            h.Nonce,
+		ExtraData:        hex.EncodeToString(h.ExtraData),
+	}
+}
+
+// WriteJSON serializes vectors as indented, schema-versioned JSON.
+func WriteJSON(vectors *GoldenVectors) ([]byte, error) {
+	data, err := json.MarshalIndent(vectors, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal golden vectors: %w", err)
+	}
+	return data, nil
+}
+
+// ReadJSON parses a GoldenVectors document, rejecting unknown schema
+// versions so a stale fixture doesn't silently misreport results.
+func ReadJSON(data []byte) (*GoldenVectors, error) {
+	var vectors GoldenVectors
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		return nil, fmt.Errorf("failed to parse golden vectors: %w", err)
+	}
+	if vectors.SchemaVersion != SchemaVersion {
+		return nil, fmt.Errorf("unsupported golden vector schema version %d (want %d)", vectors.SchemaVersion, SchemaVersion)
+	}
+	return &vectors, nil
+}
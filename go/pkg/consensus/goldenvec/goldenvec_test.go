@@ -0,0 +1,88 @@
+package goldenvec
+
+import (
+	"crypto/sha256"
+	"reflect"
+	"testing"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/consensus"
+)
+
+func TestGenerate_Deterministic(t *testing.T) {
+	a := Generate(42, DefaultProfile())
+	b := Generate(42, DefaultProfile())
+
+	if a.TotalVectors != b.TotalVectors {
+		t.Fatalf("expected same vector count for same seed, got %d vs %d", a.TotalVectors, b.TotalVectors)
+	}
+	for i := range a.Vectors {
+		if !reflect.DeepEqual(a.Vectors[i], b.Vectors[i]) {
+			t.Fatalf("vector %d differs between two Generate(42, ...) calls", i)
+		}
+	}
+}
+
+func TestGenerate_DifferentSeeds(t *testing.T) {
+	a := Generate(1, DefaultProfile())
+	b := Generate(2, DefaultProfile())
+
+	if reflect.DeepEqual(a.Vectors, b.Vectors) {
+		t.Fatalf("expected different seeds to produce different vectors")
+	}
+}
+
+func TestWriteReadJSON_RoundTrip(t *testing.T) {
+	original := Generate(7, DefaultProfile())
+
+	data, err := WriteJSON(original)
+	if err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+
+	parsed, err := ReadJSON(data)
+	if err != nil {
+		t.Fatalf("ReadJSON failed: %v", err)
+	}
+
+	if parsed.TotalVectors != original.TotalVectors {
+		t.Errorf("expected %d vectors after round trip, got %d", original.TotalVectors, parsed.TotalVectors)
+	}
+}
+
+func TestReadJSON_RejectsUnknownSchemaVersion(t *testing.T) {
+	_, err := ReadJSON([]byte(`{"schema_version": 999, "vectors": []}`))
+	if err == nil {
+		t.Fatal("expected an error for an unsupported schema version")
+	}
+}
+
+// referenceBackend is a minimal CryptoBackend that matches the reference
+// helpers in verify.go, so Verify(Generate(...), referenceBackend{})
+// should always be clean regardless of which real backend (cgo or pure
+// Go) is compiled in.
+type referenceBackend struct{}
+
+func (referenceBackend) SHA256(data []byte) [32]byte {
+	return sha256.Sum256(data)
+}
+
+func (referenceBackend) MerkleRoot(hashes [][32]byte) [32]byte {
+	return merkleRootReference(hashes)
+}
+
+func (referenceBackend) HeaderHash(h *consensus.BlockHeader) [32]byte {
+	return sha256.Sum256(encodeHeaderReference(h))
+}
+
+func (referenceBackend) VerifySubsetSum(*consensus.SubsetSumProblem, *consensus.SubsetSumSolution, *consensus.VerifyBudget) (bool, error) {
+	return false, nil
+}
+
+func TestVerify_ReferenceBackendIsClean(t *testing.T) {
+	vectors := Generate(99, DefaultProfile())
+	report := Verify(vectors, referenceBackend{})
+
+	if failed := report.TotalFailed(); failed != 0 {
+		t.Fatalf("expected 0 failures against the reference backend, got %d: %+v", failed, report.ByOperation)
+	}
+}
@@ -0,0 +1,295 @@
+package goldenvec
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/consensus"
+)
+
+// merkleRootReference computes a binary Merkle root with last-leaf
+// duplication, matching the rule ComputeMerkleRoot's CGO backend and
+// consensus.pureGoBackend are both expected to follow.
+func merkleRootReference(leaves [][32]byte) [32]byte {
+	if len(leaves) == 0 {
+		return [32]byte{}
+	}
+	if len(leaves) == 1 {
+		return leaves[0]
+	}
+
+	level := make([][32]byte, len(leaves))
+	copy(level, leaves)
+
+	for len(level) > 1 {
+		next := make([][32]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			left := level[i]
+			right := left
+			if i+1 < len(level) {
+				right = level[i+1]
+			}
+			next = append(next, sha256.Sum256(append(left[:], right[:]...)))
+		}
+		level = next
+	}
+
+	return level[0]
+}
+
+// proofStep is the internal (non-JSON) form used while building a proof.
+type proofStep struct {
+	sibling [32]byte
+	isLeft  bool
+}
+
+// merkleProofReference computes the root and inclusion proof for the
+// leaf at index, following the same tree-construction rule as
+// merkleRootReference. It mirrors consensus.ComputeMerkleRootWithProof
+// so MERKLE_PROOF vectors can be generated without depending on the
+// cgo-only ComputeMerkleRoot.
+func merkleProofReference(leaves [][32]byte, index int) ([32]byte, []proofStep) {
+	level := make([][32]byte, len(leaves))
+	copy(level, leaves)
+	pos := index
+
+	var proof []proofStep
+	for len(level) > 1 {
+		next := make([][32]byte, 0, (len(level)+1)/2)
+
+		for i := 0; i < len(level); i += 2 {
+			left := level[i]
+			right := left
+			if i+1 < len(level) {
+				right = level[i+1]
+			}
+
+			if i == pos || i+1 == pos {
+				if pos == i {
+					proof = append(proof, proofStep{sibling: right, isLeft: false})
+				} else {
+					proof = append(proof, proofStep{sibling: left, isLeft: true})
+				}
+				pos = len(next)
+			}
+
+			next = append(next, sha256.Sum256(append(left[:], right[:]...)))
+		}
+
+		level = next
+	}
+
+	return level[0], proof
+}
+
+// encodeHeaderReference mirrors consensus.encodeHeaderCanonical's field
+// layout. It's duplicated here (rather than exported from consensus)
+// because goldenvec must be able to produce vectors even when the
+// consensus package itself is only buildable under one of its two
+// build-tag configurations; keeping the reference encoder dependency-free
+// means Generate always works.
+func encodeHeaderReference(h *consensus.BlockHeader) []byte {
+	buf := make([]byte, 0, 4+4+8+32+32+32+32+4+8+4+len(h.ExtraData))
+
+	var tmp4 [4]byte
+	var tmp8 [8]byte
+
+	binary.LittleEndian.PutUint32(tmp4[:], h.CodecVersion)
+	buf = append(buf, tmp4[:]...)
+
+	binary.LittleEndian.PutUint32(tmp4[:], h.BlockIndex)
+	buf = append(buf, tmp4[:]...)
+
+	binary.LittleEndian.PutUint64(tmp8[:], uint64(h.Timestamp))
+	buf = append(buf, tmp8[:]...)
+
+	buf = append(buf, h.ParentHash[:]...)
+	buf = append(buf, h.MerkleRoot[:]...)
+	buf = append(buf, h.MinerAddress[:]...)
+	buf = append(buf, h.Commitment[:]...)
+
+	binary.LittleEndian.PutUint32(tmp4[:], h.DifficultyTarget)
+	buf = append(buf, tmp4[:]...)
+
+	binary.LittleEndian.PutUint64(tmp8[:], h.Nonce)
+	buf = append(buf, tmp8[:]...)
+
+	binary.LittleEndian.PutUint32(tmp4[:], uint32(len(h.ExtraData)))
+	buf = append(buf, tmp4[:]...)
+	buf = append(buf, h.ExtraData...)
+
+	return buf
+}
+
+// OperationReport holds the pass/fail tally for one operation kind.
+type OperationReport struct {
+	Passed int
+	Failed int
+	// Mismatches names the first few failing vectors, for quick triage
+	// without re-running with -v.
+	Mismatches []string
+}
+
+// Report summarizes a Verify run across all operations.
+type Report struct {
+	ByOperation map[string]*OperationReport
+}
+
+// TotalFailed sums failures across every operation.
+func (r Report) TotalFailed() int {
+	total := 0
+	for _, op := range r.ByOperation {
+		total += op.Failed
+	}
+	return total
+}
+
+// Verify runs every vector against backend and tallies pass/fail per
+// operation. It never returns an error itself; malformed vectors count
+// as failures so a bad fixture shows up in the report rather than
+// aborting the whole run.
+func Verify(vectors *GoldenVectors, backend consensus.CryptoBackend) Report {
+	report := Report{ByOperation: make(map[string]*OperationReport)}
+
+	record := func(op, name string, ok bool) {
+		r, exists := report.ByOperation[op]
+		if !exists {
+			r = &OperationReport{}
+			report.ByOperation[op] = r
+		}
+		if ok {
+			r.Passed++
+			return
+		}
+		r.Failed++
+		if len(r.Mismatches) < 10 {
+			r.Mismatches = append(r.Mismatches, name)
+		}
+	}
+
+	for _, vec := range vectors.Vectors {
+		switch vec.Operation {
+		case OpSHA256:
+			input, err := hex.DecodeString(vec.InputHex)
+			if err != nil {
+				record(vec.Operation, vec.TestName, false)
+				continue
+			}
+			actual := backend.SHA256(input)
+			expected, err := decodeHash(vec.ExpectedHash)
+			record(vec.Operation, vec.TestName, err == nil && actual == expected)
+
+		case OpMerkle:
+			leaves := make([][32]byte, len(vec.TxHashes))
+			ok := true
+			for i, h := range vec.TxHashes {
+				hash, err := decodeHash(h)
+				if err != nil {
+					ok = false
+					break
+				}
+				leaves[i] = hash
+			}
+			if !ok {
+				record(vec.Operation, vec.TestName, false)
+				continue
+			}
+			actual := backend.MerkleRoot(leaves)
+			expected, err := decodeHash(vec.ExpectedRoot)
+			record(vec.Operation, vec.TestName, err == nil && actual == expected)
+
+		case OpMerkleProof:
+			leaf, err := decodeHash(vec.TxHashes[vec.LeafIndex])
+			if err != nil {
+				record(vec.Operation, vec.TestName, false)
+				continue
+			}
+			steps := make([]consensus.MerkleProofStep, len(vec.Proof))
+			ok := true
+			for i, s := range vec.Proof {
+				sibling, err := decodeHash(s.Sibling)
+				if err != nil {
+					ok = false
+					break
+				}
+				steps[i] = consensus.MerkleProofStep{Sibling: sibling, IsLeft: s.IsLeft}
+			}
+			if !ok {
+				record(vec.Operation, vec.TestName, false)
+				continue
+			}
+			expected, err := decodeHash(vec.ExpectedRoot)
+			record(vec.Operation, vec.TestName, err == nil && consensus.VerifyMerkleProof(leaf, steps, expected))
+
+		case OpBlockHeader:
+			header, err := headerFromJSON(vec.Header)
+			if err != nil {
+				record(vec.Operation, vec.TestName, false)
+				continue
+			}
+			actual := backend.HeaderHash(header)
+			expected, err := decodeHash(vec.ExpectedHash)
+			record(vec.Operation, vec.TestName, err == nil && actual == expected)
+
+		default:
+			record(vec.Operation, vec.TestName, false)
+		}
+	}
+
+	return report
+}
+
+func decodeHash(h string) ([32]byte, error) {
+	var out [32]byte
+	data, err := hex.DecodeString(h)
+	if err != nil {
+		return out, err
+	}
+	if len(data) != 32 {
+		return out, fmt.Errorf("expected 32 bytes, got %d", len(data))
+	}
+	copy(out[:], data)
+	return out, nil
+}
+
+func headerFromJSON(j *BlockHeaderJSON) (*consensus.BlockHeader, error) {
+	if j == nil {
+		return nil, fmt.Errorf("header vector missing header field")
+	}
+
+	parentHash, err := decodeHash(j.ParentHash)
+	if err != nil {
+		return nil, fmt.Errorf("parent_hash: %w", err)
+	}
+	merkleRoot, err := decodeHash(j.MerkleRoot)
+	if err != nil {
+		return nil, fmt.Errorf("merkle_root: %w", err)
+	}
+	minerAddress, err := decodeHash(j.MinerAddress)
+	if err != nil {
+		return nil, fmt.Errorf("miner_address: %w", err)
+	}
+	commitment, err := decodeHash(j.Commitment)
+	if err != nil {
+		return nil, fmt.Errorf("commitment: %w", err)
+	}
+	extraData, err := hex.DecodeString(j.ExtraData)
+	if err != nil {
+		return nil, fmt.Errorf("extra_data: %w", err)
+	}
+
+	return &consensus.BlockHeader{
+		CodecVersion:     j.CodecVersion,
+		BlockIndex:       j.BlockIndex,
+		Timestamp:        j.Timestamp,
+		ParentHash:       parentHash,
+		MerkleRoot:       merkleRoot,
+		MinerAddress:     minerAddress,
+		Commitment:       commitment,
+		DifficultyTarget: j.DifficultyTarget,
+		Nonce:            j.Nonce,
+		ExtraData:        extraData,
+	}, nil
+}
@@ -0,0 +1,335 @@
+// Header-chain verification and storage for light clients.
+package consensus
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Retarget and timestamp sanity defaults. These mirror the defaults a
+// light client would negotiate with a full node until a governance
+// parameter for them exists.
+const (
+	// DefaultMaxFutureDrift is how far into the future a header's
+	// Timestamp may be relative to local wall-clock time.
+	DefaultMaxFutureDrift int64 = 15 * 60 // 15 minutes, in seconds
+
+	// DefaultRetargetWindow is the number of headers between
+	// difficulty retargets.
+	DefaultRetargetWindow uint32 = 2016
+
+	// DefaultMaxDifficultyRatio bounds how much DifficultyTarget may
+	// change between consecutive retarget windows (4x up or down).
+	DefaultMaxDifficultyRatio uint32 = 4
+
+	// MaxOrphanHeaders caps the orphan pool to bound memory use from
+	// headers whose parent hasn't arrived yet.
+	MaxOrphanHeaders = 10_000
+)
+
+// HeaderChainConfig controls the validation rules applied in AddHeaders.
+type HeaderChainConfig struct {
+	MaxFutureDrift      int64
+	RetargetWindow      uint32
+	MaxDifficultyRatio  uint32
+	VerifyBudget        VerifyBudget
+}
+
+// DefaultHeaderChainConfig returns the standard validation parameters.
+func DefaultHeaderChainConfig() HeaderChainConfig {
+	return HeaderChainConfig{
+		MaxFutureDrift:     DefaultMaxFutureDrift,
+		RetargetWindow:     DefaultRetargetWindow,
+		MaxDifficultyRatio: DefaultMaxDifficultyRatio,
+		VerifyBudget: VerifyBudget{
+			MaxOps:         100_000,
+			MaxDurationMs:  10_000,
+			MaxMemoryBytes: 100_000_000,
+		},
+	}
+}
+
+// storedHeader is a BlockHeader plus its memoized hash.
+type storedHeader struct {
+	header *BlockHeader
+	hash   [32]byte
+}
+
+// HeaderChain tracks a validated, linear chain of block headers and an
+// orphan pool for headers that arrive before their parent.
+//
+// It is the light-client counterpart to the full BlockProcessor: it
+// only ever looks at header fields (no transaction or state data), so
+// it can validate PoW/commitment and parent linkage without a full
+// node's mempool or state manager.
+type HeaderChain struct {
+	mu sync.RWMutex
+
+	cfg HeaderChainConfig
+
+	byHash  map[[32]byte]*storedHeader
+	byIndex map[uint32]*storedHeader
+	tip     *storedHeader
+
+	// orphans holds headers whose ParentHash isn't known yet, keyed by
+	// the parent hash they're waiting on.
+	orphans map[[32]byte][]*BlockHeader
+}
+
+// NewHeaderChain creates a HeaderChain seeded with a trusted genesis
+// header. The genesis header is accepted as-is (no parent-linkage or
+// PoW check is performed against it).
+func NewHeaderChain(genesis *BlockHeader, cfg HeaderChainConfig) (*HeaderChain, error) {
+	if genesis == nil {
+		return nil, errors.New("genesis header cannot be nil")
+	}
+
+	hash, err := ComputeHeaderHash(genesis)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash genesis header: %w", err)
+	}
+
+	sh := &storedHeader{header: genesis, hash: hash}
+
+	return &HeaderChain{
+		cfg:     cfg,
+		byHash:  map[[32]byte]*storedHeader{hash: sh},
+		byIndex: map[uint32]*storedHeader{genesis.BlockIndex: sh},
+		tip:     sh,
+		orphans: make(map[[32]byte][]*BlockHeader),
+	}, nil
+}
+
+// Tip returns the current chain tip header.
+func (hc *HeaderChain) Tip() *BlockHeader {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+	return hc.tip.header
+}
+
+// GetHeader returns the stored header for hash, if known.
+func (hc *HeaderChain) GetHeader(hash [32]byte) (*BlockHeader, bool) {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+
+	sh, ok := hc.byHash[hash]
+	if !ok {
+		return nil, false
+	}
+	return sh.header, true
+}
+
+// HeadersByRange returns up to count headers starting at BlockIndex
+// from, in ascending order. It stops early if the chain doesn't extend
+// that far.
+func (hc *HeaderChain) HeadersByRange(from uint32, count uint32) []*BlockHeader {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+
+	headers := make([]*BlockHeader, 0, count)
+	for i := uint32(0); i < count; i++ {
+		sh, ok := hc.byIndex[from+i]
+		if !ok {
+			break
+		}
+		headers = append(headers, sh.header)
+	}
+	return headers
+}
+
+// AddHeaders validates and appends a batch of headers to the chain.
+//
+// Validation mirrors AddHeaders in neo-go: the whole batch is checked
+// against a scratch view of the chain before any of it is committed, so
+// a failure partway through the batch leaves the chain untouched.
+// Headers that don't chain off a known header are held in the orphan
+// pool until their parent arrives. A header that does chain in can
+// itself unblock orphans waiting on it (possibly several generations
+// deep, e.g. index 5 arriving after 7 and 6 already did); those are
+// validated and inserted in the same pass rather than left in the pool
+// until some later AddHeaders call happens to re-submit them.
+func (hc *HeaderChain) AddHeaders(headers []*BlockHeader, proofs map[[32]byte]*CommitmentProof, backend CryptoBackendVerifier) error {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	// Work against scratch copies of the indexes (including the orphan
+	// pool) so a validation failure anywhere in the batch, or in a
+	// promoted orphan, leaves the real chain untouched.
+	scratchByHash := make(map[[32]byte]*storedHeader, len(hc.byHash)+len(headers))
+	scratchByIndex := make(map[uint32]*storedHeader, len(hc.byIndex)+len(headers))
+	for k, v := range hc.byHash {
+		scratchByHash[k] = v
+	}
+	for k, v := range hc.byIndex {
+		scratchByIndex[k] = v
+	}
+	scratchOrphans := make(map[[32]byte][]*BlockHeader, len(hc.orphans))
+	for k, v := range hc.orphans {
+		scratchOrphans[k] = v
+	}
+
+	tip := hc.tip
+	var stillOrphan []*BlockHeader
+
+	// queue starts as the submitted batch and grows with any orphans a
+	// newly-accepted header promotes, so promotion chains resolve fully
+	// within this single call.
+	queue := append([]*BlockHeader(nil), headers...)
+
+	for len(queue) > 0 {
+		h := queue[0]
+		queue = queue[1:]
+
+		parent, ok := scratchByHash[h.ParentHash]
+		if !ok {
+			// Out-of-order arrival: hold it until its parent shows up.
+			stillOrphan = append(stillOrphan, h)
+			continue
+		}
+
+		if err := hc.validateHeader(h, parent.header, proofs[h.Commitment], backend); err != nil {
+			return fmt.Errorf("header at index %d rejected: %w", h.BlockIndex, err)
+		}
+
+		hash, err := ComputeHeaderHash(h)
+		if err != nil {
+			return fmt.Errorf("failed to hash header at index %d: %w", h.BlockIndex, err)
+		}
+
+		sh := &storedHeader{header: h, hash: hash}
+		scratchByHash[hash] = sh
+		scratchByIndex[h.BlockIndex] = sh
+
+		if parent == tip {
+			tip = sh
+		}
+
+		if waiting, ok := scratchOrphans[hash]; ok {
+			delete(scratchOrphans, hash)
+			queue = append(queue, waiting...)
+		}
+	}
+
+	// Commit: the scratch maps become the real ones only once every
+	// header reachable from the batch (including promoted orphans) has
+	// passed validation.
+	hc.byHash = scratchByHash
+	hc.byIndex = scratchByIndex
+	hc.tip = tip
+	hc.orphans = scratchOrphans
+
+	for _, orphan := range stillOrphan {
+		hc.addOrphan(orphan)
+	}
+
+	return nil
+}
+
+// validateHeader checks parent linkage, monotonic index/timestamp,
+// difficulty retarget sanity, and the embedded subset-sum commitment.
+func (hc *HeaderChain) validateHeader(h, parent *BlockHeader, proof *CommitmentProof, backend CryptoBackendVerifier) error {
+	parentHash, err := ComputeHeaderHash(parent)
+	if err != nil {
+		return fmt.Errorf("failed to hash parent header: %w", err)
+	}
+	if h.ParentHash != parentHash {
+		return errors.New("parent hash does not match computed hash of stored parent")
+	}
+
+	if h.BlockIndex != parent.BlockIndex+1 {
+		return fmt.Errorf("block index %d is not one greater than parent index %d", h.BlockIndex, parent.BlockIndex)
+	}
+
+	if h.Timestamp <= parent.Timestamp {
+		return fmt.Errorf("timestamp %d is not after parent timestamp %d", h.Timestamp, parent.Timestamp)
+	}
+
+	if err := hc.validateDifficulty(h, parent); err != nil {
+		return err
+	}
+
+	if err := hc.validateCommitment(h, proof, backend); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateDifficulty enforces that DifficultyTarget only changes at a
+// retarget boundary, and even then by no more than MaxDifficultyRatio.
+func (hc *HeaderChain) validateDifficulty(h, parent *BlockHeader) error {
+	if h.BlockIndex%hc.cfg.RetargetWindow != 0 {
+		if h.DifficultyTarget != parent.DifficultyTarget {
+			return fmt.Errorf("difficulty target changed outside retarget window at index %d", h.BlockIndex)
+		}
+		return nil
+	}
+
+	if h.DifficultyTarget == 0 || parent.DifficultyTarget == 0 {
+		return errors.New("difficulty target must be non-zero")
+	}
+
+	ratio := hc.cfg.MaxDifficultyRatio
+	if h.DifficultyTarget > parent.DifficultyTarget*ratio {
+		return fmt.Errorf("difficulty target increased by more than %dx at retarget boundary", ratio)
+	}
+	if parent.DifficultyTarget > h.DifficultyTarget*ratio {
+		return fmt.Errorf("difficulty target decreased by more than %dx at retarget boundary", ratio)
+	}
+
+	return nil
+}
+
+// CryptoBackendVerifier is the subset of CryptoBackend the header chain
+// needs to re-verify a header's embedded subset-sum commitment.
+type CryptoBackendVerifier interface {
+	VerifySubsetSum(problem *SubsetSumProblem, solution *SubsetSumSolution, budget *VerifyBudget) (bool, error)
+}
+
+// CommitmentProof carries the subset-sum problem/solution pair that a
+// header's Commitment field is a hash of. Headers only carry the
+// 32-byte commitment itself, so the proof must travel alongside the
+// header batch (e.g. fetched from the same peer that sent the headers).
+type CommitmentProof struct {
+	Problem  *SubsetSumProblem
+	Solution *SubsetSumSolution
+}
+
+// validateCommitment re-runs subset-sum verification on the proof
+// claimed to back h.Commitment (the caller's proofs entry keyed by that
+// commitment) via the supplied backend. It does not hash the proof to
+// check it against h.Commitment.
+func (hc *HeaderChain) validateCommitment(h *BlockHeader, proof *CommitmentProof, backend CryptoBackendVerifier) error {
+	if backend == nil {
+		return errors.New("crypto backend required to validate header commitment")
+	}
+	if proof == nil {
+		return fmt.Errorf("no commitment proof supplied for header at index %d", h.BlockIndex)
+	}
+
+	budget := hc.cfg.VerifyBudget
+	valid, err := backend.VerifySubsetSum(proof.Problem, proof.Solution, &budget)
+	if err != nil {
+		return fmt.Errorf("commitment verification error: %w", err)
+	}
+	if !valid {
+		return errors.New("commitment proof is invalid")
+	}
+
+	return nil
+}
+
+// addOrphan stores a header that arrived before its parent. Once the
+// pool holds MaxOrphanHeaders headers, h is dropped; no existing orphan
+// is evicted to make room.
+func (hc *HeaderChain) addOrphan(h *BlockHeader) {
+	total := 0
+	for _, v := range hc.orphans {
+		total += len(v)
+	}
+	if total >= MaxOrphanHeaders {
+		return
+	}
+	hc.orphans[h.ParentHash] = append(hc.orphans[h.ParentHash], h)
+}
@@ -0,0 +1,135 @@
+package consensus
+
+import "testing"
+
+// alwaysValidVerifier accepts any commitment proof, so these tests can
+// focus on header-chain linkage/orphan behavior without constructing
+// real subset-sum problems.
+type alwaysValidVerifier struct{}
+
+func (alwaysValidVerifier) VerifySubsetSum(*SubsetSumProblem, *SubsetSumSolution, *VerifyBudget) (bool, error) {
+	return true, nil
+}
+
+// testHeader builds a BlockHeader chained off parent, with a
+// commitment proof already registered in proofs so validateCommitment
+// passes.
+func testHeader(proofs map[[32]byte]*CommitmentProof, index uint32, parentHash [32]byte, parentTimestamp int64, difficulty uint32) *BlockHeader {
+	h := &BlockHeader{
+		CodecVersion:     1,
+		BlockIndex:       index,
+		Timestamp:        parentTimestamp + 1,
+		ParentHash:       parentHash,
+		DifficultyTarget: difficulty,
+		Commitment:       [32]byte{byte(index)},
+	}
+	proofs[h.Commitment] = &CommitmentProof{
+		Problem:  &SubsetSumProblem{Elements: []int64{1}, Target: 1},
+		Solution: &SubsetSumSolution{Indices: []uint32{0}},
+	}
+	return h
+}
+
+func newTestChain(t *testing.T) (*HeaderChain, *BlockHeader, map[[32]byte]*CommitmentProof) {
+	t.Helper()
+
+	genesis := &BlockHeader{CodecVersion: 1, BlockIndex: 0, Timestamp: 1000, DifficultyTarget: 1}
+	hc, err := NewHeaderChain(genesis, DefaultHeaderChainConfig())
+	if err != nil {
+		t.Fatalf("NewHeaderChain: %v", err)
+	}
+	return hc, genesis, make(map[[32]byte]*CommitmentProof)
+}
+
+// TestAddHeaders_OutOfOrderArrival checks that a header whose parent
+// hasn't arrived yet is held as an orphan rather than rejected.
+func TestAddHeaders_OutOfOrderArrival(t *testing.T) {
+	hc, genesis, proofs := newTestChain(t)
+	genesisHash, _ := ComputeHeaderHash(genesis)
+
+	h1 := testHeader(proofs, 1, genesisHash, genesis.Timestamp, 1)
+	h1Hash, _ := ComputeHeaderHash(h1)
+	h2 := testHeader(proofs, 2, h1Hash, h1.Timestamp, 1)
+
+	// h2 arrives before h1: its parent isn't known yet, so it must be
+	// held rather than rejected outright.
+	if err := hc.AddHeaders([]*BlockHeader{h2}, proofs, alwaysValidVerifier{}); err != nil {
+		t.Fatalf("AddHeaders(h2) out of order: %v", err)
+	}
+	if _, ok := hc.GetHeader(h1Hash); ok {
+		t.Fatalf("h1 should not be known yet")
+	}
+	if tip := hc.Tip(); tip.BlockIndex != 0 {
+		t.Fatalf("tip should still be genesis, got index %d", tip.BlockIndex)
+	}
+}
+
+// TestAddHeaders_PromotesOrphanOnParentArrival checks that once a
+// header's parent arrives, a previously orphaned header waiting on it
+// is validated and inserted automatically rather than staying stuck in
+// the orphan pool forever.
+func TestAddHeaders_PromotesOrphanOnParentArrival(t *testing.T) {
+	hc, genesis, proofs := newTestChain(t)
+	genesisHash, _ := ComputeHeaderHash(genesis)
+
+	h1 := testHeader(proofs, 1, genesisHash, genesis.Timestamp, 1)
+	h1Hash, _ := ComputeHeaderHash(h1)
+	h2 := testHeader(proofs, 2, h1Hash, h1.Timestamp, 1)
+	h2Hash, _ := ComputeHeaderHash(h2)
+
+	// h2 arrives first and is orphaned.
+	if err := hc.AddHeaders([]*BlockHeader{h2}, proofs, alwaysValidVerifier{}); err != nil {
+		t.Fatalf("AddHeaders(h2): %v", err)
+	}
+
+	// h1 arrives: it chains onto genesis directly, and should also
+	// promote h2 out of the orphan pool in the same call.
+	if err := hc.AddHeaders([]*BlockHeader{h1}, proofs, alwaysValidVerifier{}); err != nil {
+		t.Fatalf("AddHeaders(h1): %v", err)
+	}
+
+	if _, ok := hc.GetHeader(h2Hash); !ok {
+		t.Fatalf("h2 should have been promoted into the chain once h1 arrived")
+	}
+	if tip := hc.Tip(); tip.BlockIndex != 2 {
+		t.Fatalf("tip should be h2 (index 2), got index %d", tip.BlockIndex)
+	}
+}
+
+// TestAddHeaders_PromotesMultiGenerationOrphanChain checks that
+// promotion cascades: h3 waiting on h2 waiting on h1 all link in as
+// soon as h1's parent (genesis) is already known, without requiring a
+// separate AddHeaders call per generation.
+func TestAddHeaders_PromotesMultiGenerationOrphanChain(t *testing.T) {
+	hc, genesis, proofs := newTestChain(t)
+	genesisHash, _ := ComputeHeaderHash(genesis)
+
+	h1 := testHeader(proofs, 1, genesisHash, genesis.Timestamp, 1)
+	h1Hash, _ := ComputeHeaderHash(h1)
+	h2 := testHeader(proofs, 2, h1Hash, h1.Timestamp, 1)
+	h2Hash, _ := ComputeHeaderHash(h2)
+	h3 := testHeader(proofs, 3, h2Hash, h2.Timestamp, 1)
+	h3Hash, _ := ComputeHeaderHash(h3)
+
+	// h2 and h3 both arrive before h1; h3 depends on h2, which itself
+	// isn't known yet, so both land in the orphan pool.
+	if err := hc.AddHeaders([]*BlockHeader{h2, h3}, proofs, alwaysValidVerifier{}); err != nil {
+		t.Fatalf("AddHeaders(h2, h3): %v", err)
+	}
+
+	// h1 arrives, unblocking h2, which in turn unblocks h3 — all in
+	// this one call.
+	if err := hc.AddHeaders([]*BlockHeader{h1}, proofs, alwaysValidVerifier{}); err != nil {
+		t.Fatalf("AddHeaders(h1): %v", err)
+	}
+
+	if _, ok := hc.GetHeader(h2Hash); !ok {
+		t.Fatalf("h2 should have been promoted")
+	}
+	if _, ok := hc.GetHeader(h3Hash); !ok {
+		t.Fatalf("h3 should have cascaded in behind h2")
+	}
+	if tip := hc.Tip(); tip.BlockIndex != 3 {
+		t.Fatalf("tip should be h3 (index 3), got index %d", tip.BlockIndex)
+	}
+}
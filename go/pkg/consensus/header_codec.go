@@ -0,0 +1,146 @@
+package consensus
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Canonical wire format for BlockHeader (CodecVersion 1 and 2):
+//
+//	codec_version      uint32  little-endian
+//	block_index        uint32  little-endian
+//	timestamp          int64   little-endian (two's complement)
+//	parent_hash        [32]byte
+//	merkle_root        [32]byte
+//	miner_address      [32]byte
+//	commitment         [32]byte
+//	data_root          [32]byte
+//	difficulty_target  uint32  little-endian
+//	nonce              uint64  little-endian
+//	extra_data_len     uint32  little-endian
+//	extra_data         [extra_data_len]byte
+//
+// This is the same field order and width rust_bindings.go marshals into
+// BlockHeaderFFI for the CGO call, so HashCanonical(h) and
+// ComputeHeaderHash(h) are expected to agree; see
+// TestHeaderCanonical_MatchesRustHash for the property test asserting
+// that, plus TestHeaderCanonical_RoundTrip for Marshal/Unmarshal.
+//
+// CodecVersion 2 shares this exact byte layout with version 1: the only
+// difference is semantic, not structural — merkle_root was committed to
+// using MerkleSchemeRFC6962 (see merkle_proof.go's
+// MerkleSchemeForCodecVersion) instead of the legacy duplicate-last-leaf
+// scheme. Both versions marshal/unmarshal identically; callers that want
+// to verify merkle_root must pick the scheme with
+// MerkleSchemeForCodecVersion(h.CodecVersion).
+//
+// data_root is the combined-commitment field DataProof verifies
+// against (see ComputeCombinedRoot): h.MerkleRoot is the TxRoot side,
+// h.DataRoot is the app-level data side.
+const headerFixedSize = 4 + 4 + 8 + 32 + 32 + 32 + 32 + 32 + 4 + 8 + 4
+
+// MarshalCanonical encodes header using the canonical wire format
+// identified by header.CodecVersion. CodecVersion 1 and 2 share this
+// layout (see the package doc comment above); callers that bump
+// CodecVersion again to change the byte layout itself must extend this
+// switch rather than silently reusing it.
+func (h *BlockHeader) MarshalCanonical() ([]byte, error) {
+	if h == nil {
+		return nil, fmt.Errorf("cannot marshal nil header")
+	}
+	if h.CodecVersion != 1 && h.CodecVersion != 2 {
+		return nil, fmt.Errorf("unsupported codec version %d", h.CodecVersion)
+	}
+
+	buf := make([]byte, 0, headerFixedSize+len(h.ExtraData))
+
+	buf = appendUint32(buf, h.CodecVersion)
+	buf = appendUint32(buf, h.BlockIndex)
+	buf = appendUint64(buf, uint64(h.Timestamp))
+	buf = append(buf, h.ParentHash[:]...)
+	buf = append(buf, h.MerkleRoot[:]...)
+	buf = append(buf, h.MinerAddress[:]...)
+	buf = append(buf, h.Commitment[:]...)
+	buf = append(buf, h.DataRoot[:]...)
+	buf = appendUint32(buf, h.DifficultyTarget)
+	buf = appendUint64(buf, h.Nonce)
+	buf = appendUint32(buf, uint32(len(h.ExtraData)))
+	buf = append(buf, h.ExtraData...)
+
+	return buf, nil
+}
+
+// UnmarshalCanonical decodes a BlockHeader from its canonical wire
+// format. It rejects truncated input and an ExtraData length prefix
+// that doesn't match the remaining bytes.
+func UnmarshalCanonical(data []byte) (*BlockHeader, error) {
+	if len(data) < headerFixedSize {
+		return nil, fmt.Errorf("header too short: got %d bytes, need at least %d", len(data), headerFixedSize)
+	}
+
+	h := &BlockHeader{}
+	off := 0
+
+	h.CodecVersion, off = readUint32(data, off)
+	if h.CodecVersion != 1 && h.CodecVersion != 2 {
+		return nil, fmt.Errorf("unsupported codec version %d", h.CodecVersion)
+	}
+
+	h.BlockIndex, off = readUint32(data, off)
+
+	var ts uint64
+	ts, off = readUint64(data, off)
+	h.Timestamp = int64(ts)
+
+	off += copy(h.ParentHash[:], data[off:off+32])
+	off += copy(h.MerkleRoot[:], data[off:off+32])
+	off += copy(h.MinerAddress[:], data[off:off+32])
+	off += copy(h.Commitment[:], data[off:off+32])
+	off += copy(h.DataRoot[:], data[off:off+32])
+
+	h.DifficultyTarget, off = readUint32(data, off)
+	h.Nonce, off = readUint64(data, off)
+
+	var extraLen uint32
+	extraLen, off = readUint32(data, off)
+
+	if len(data)-off != int(extraLen) {
+		return nil, fmt.Errorf("extra_data length mismatch: header says %d, have %d remaining bytes", extraLen, len(data)-off)
+	}
+
+	h.ExtraData = append([]byte(nil), data[off:]...)
+
+	return h, nil
+}
+
+// HashCanonical runs SHA-256 over header's canonical encoding, in pure
+// Go. It is the basis for consensus.pureGoBackend.HeaderHash and lets
+// callers (p2p gossip, storage, RPC JSON<->binary) hash a header
+// without going through CGO.
+func (h *BlockHeader) HashCanonical() ([32]byte, error) {
+	data, err := h.MarshalCanonical()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return pureGoBackend{}.SHA256(data), nil
+}
+
+func appendUint32(buf []byte, v uint32) []byte {
+	var tmp [4]byte
+	binary.LittleEndian.PutUint32(tmp[:], v)
+	return append(buf, tmp[:]...)
+}
+
+func appendUint64(buf []byte, v uint64) []byte {
+	var tmp [8]byte
+	binary.LittleEndian.PutUint64(tmp[:], v)
+	return append(buf, tmp[:]...)
+}
+
+func readUint32(data []byte, off int) (uint32, int) {
+	return binary.LittleEndian.Uint32(data[off : off+4]), off + 4
+}
+
+func readUint64(data []byte, off int) (uint64, int) {
+	return binary.LittleEndian.Uint64(data[off : off+8]), off + 8
+}
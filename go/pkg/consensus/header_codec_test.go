@@ -0,0 +1,149 @@
+package consensus
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func randomHeaderForCodecTest(rng *rand.Rand) *BlockHeader {
+	h := &BlockHeader{
+		CodecVersion:     1,
+		BlockIndex:       rng.Uint32(),
+		Timestamp:        rng.Int63(),
+		DifficultyTarget: rng.Uint32(),
+		Nonce:            rng.Uint64(),
+	}
+	rng.Read(h.ParentHash[:])
+	rng.Read(h.MerkleRoot[:])
+	rng.Read(h.MinerAddress[:])
+	rng.Read(h.Commitment[:])
+	rng.Read(h.DataRoot[:])
+
+	if rng.Intn(2) == 0 {
+		h.ExtraData = nil
+	} else {
+		h.ExtraData = make([]byte, rng.Intn(512))
+		rng.Read(h.ExtraData)
+	}
+
+	return h
+}
+
+// TestHeaderCanonical_RoundTrip is a property test: for many random
+// headers, UnmarshalCanonical(MarshalCanonical(h)) must reproduce h.
+func TestHeaderCanonical_RoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 200; i++ {
+		h := randomHeaderForCodecTest(rng)
+
+		data, err := h.MarshalCanonical()
+		if err != nil {
+			t.Fatalf("MarshalCanonical failed: %v", err)
+		}
+
+		got, err := UnmarshalCanonical(data)
+		if err != nil {
+			t.Fatalf("UnmarshalCanonical failed: %v", err)
+		}
+
+		if !reflect.DeepEqual(h, got) {
+			t.Fatalf("round trip mismatch\n  want: %+v\n  got:  %+v", h, got)
+		}
+	}
+}
+
+// TestHeaderCanonical_MatchesRustHash is a property test asserting that
+// HashCanonical (pure Go) agrees with ComputeHeaderHash (CGO) for many
+// random headers. It only runs when built with CGO, since
+// ComputeHeaderHash requires the Rust library.
+func TestHeaderCanonical_MatchesRustHash(t *testing.T) {
+	if Version() == "" {
+		t.Skip("requires a CGO build with the Rust library linked")
+	}
+
+	rng := rand.New(rand.NewSource(2))
+
+	for i := 0; i < 50; i++ {
+		h := randomHeaderForCodecTest(rng)
+
+		goHash, err := h.HashCanonical()
+		if err != nil {
+			t.Fatalf("HashCanonical failed: %v", err)
+		}
+
+		rustHash, err := ComputeHeaderHash(h)
+		if err != nil {
+			t.Fatalf("ComputeHeaderHash failed: %v", err)
+		}
+
+		if goHash != rustHash {
+			t.Fatalf("canonical hash diverges from Rust for header %+v", h)
+		}
+	}
+}
+
+func TestUnmarshalCanonical_RejectsTruncated(t *testing.T) {
+	if _, err := UnmarshalCanonical([]byte{1, 2, 3}); err == nil {
+		t.Fatal("expected an error for truncated input")
+	}
+}
+
+func TestUnmarshalCanonical_RejectsExtraDataLengthMismatch(t *testing.T) {
+	h := &BlockHeader{CodecVersion: 1, ExtraData: []byte("hello")}
+	data, err := h.MarshalCanonical()
+	if err != nil {
+		t.Fatalf("MarshalCanonical failed: %v", err)
+	}
+
+	truncated := data[:len(data)-2]
+	if _, err := UnmarshalCanonical(truncated); err == nil {
+		t.Fatal("expected an error when extra_data is shorter than its length prefix")
+	}
+}
+
+func TestMarshalCanonical_RejectsUnsupportedCodecVersion(t *testing.T) {
+	h := &BlockHeader{CodecVersion: 3}
+	if _, err := h.MarshalCanonical(); err == nil {
+		t.Fatal("expected an error for an unsupported codec version")
+	}
+}
+
+func TestMarshalCanonical_AcceptsCodecVersion2(t *testing.T) {
+	// CodecVersion 2 shares version 1's byte layout; it only changes
+	// which MerkleScheme merkle_root was committed under (see
+	// MerkleSchemeForCodecVersion).
+	h := &BlockHeader{CodecVersion: 2, BlockIndex: 5, ExtraData: []byte("x")}
+
+	data, err := h.MarshalCanonical()
+	if err != nil {
+		t.Fatalf("MarshalCanonical failed for codec version 2: %v", err)
+	}
+
+	got, err := UnmarshalCanonical(data)
+	if err != nil {
+		t.Fatalf("UnmarshalCanonical failed for codec version 2: %v", err)
+	}
+	if got.CodecVersion != 2 {
+		t.Errorf("expected round-tripped CodecVersion 2, got %d", got.CodecVersion)
+	}
+}
+
+func TestMarshalCanonical_Deterministic(t *testing.T) {
+	h := &BlockHeader{CodecVersion: 1, BlockIndex: 5, ExtraData: []byte("x")}
+
+	a, err := h.MarshalCanonical()
+	if err != nil {
+		t.Fatalf("MarshalCanonical failed: %v", err)
+	}
+	b, err := h.MarshalCanonical()
+	if err != nil {
+		t.Fatalf("MarshalCanonical failed: %v", err)
+	}
+
+	if !bytes.Equal(a, b) {
+		t.Fatal("expected MarshalCanonical to be deterministic for the same header")
+	}
+}
@@ -0,0 +1,132 @@
+package consensus
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/beacon"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/signer"
+)
+
+// electionDomain tags the message a LeaderTicket signs, so a ticket
+// signature can never be replayed as a block signature (or vice versa)
+// even though both are plain Ed25519 signatures over a SHA256 digest.
+const electionDomain = "election"
+
+// LeaderTicket is one validator's claim to produce the block at Round,
+// derived from the beacon entry published for that round. Ed25519 has
+// no native VRF, so Proof is simply this validator's Ed25519 signature
+// over electionMessage(entry, Round) — deterministic given the same key
+// and message, which is the property a VRF's uniqueness requirement
+// actually needs for sortition — and Value is SHA256(Proof), the number
+// Elect compares across competing tickets.
+type LeaderTicket struct {
+	Round uint64
+	Proof []byte
+	Value [32]byte
+}
+
+// electionMessage is the digest a ticket's Proof signs over: it binds
+// the ticket to both the beacon round's randomness and the round number
+// itself, so a ticket computed for one round can't be replayed as if it
+// were for another.
+func electionMessage(entry beacon.BeaconEntry, round uint64) [32]byte {
+	buf := make([]byte, 0, len(entry.Randomness)+len(electionDomain)+8)
+	buf = append(buf, entry.Randomness[:]...)
+	buf = append(buf, []byte(electionDomain)...)
+	buf = appendUint64(buf, round)
+	return sha256.Sum256(buf)
+}
+
+// ComputeLeaderTicket derives self's ticket for round against entry, the
+// beacon entry published for the round derived_round(round) maps to
+// (that derivation, e.g. round == blockNumber, is the caller's choice).
+func ComputeLeaderTicket(self signer.Signer, round uint64, entry beacon.BeaconEntry) (LeaderTicket, error) {
+	msg := electionMessage(entry, round)
+	proof, err := self.Sign(msg[:])
+	if err != nil {
+		return LeaderTicket{}, fmt.Errorf("leader election: failed to sign ticket: %w", err)
+	}
+	return LeaderTicket{Round: round, Proof: proof, Value: sha256.Sum256(proof)}, nil
+}
+
+// VerifyLeaderTicket checks that ticket.Proof is validatorPubKey's
+// genuine Ed25519 signature over round's election message, and that
+// Value really is SHA256(Proof) rather than a forged lower value
+// smuggled in to win Elect unfairly.
+func VerifyLeaderTicket(validatorPubKey ed25519.PublicKey, round uint64, entry beacon.BeaconEntry, ticket LeaderTicket) error {
+	if ticket.Round != round {
+		return fmt.Errorf("leader election: ticket is for round %d, not %d", ticket.Round, round)
+	}
+	msg := electionMessage(entry, round)
+	if !ed25519.Verify(validatorPubKey, msg[:], ticket.Proof) {
+		return fmt.Errorf("leader election: ticket proof does not verify against claimed signer")
+	}
+	if want := sha256.Sum256(ticket.Proof); ticket.Value != want {
+		return fmt.Errorf("leader election: ticket value does not match its own proof")
+	}
+	return nil
+}
+
+// LeaderElection picks, among the tickets validators submit for a given
+// round, the one whose Value is numerically smallest when read as a big
+// integer — the same "lowest hash wins" sortition rule VRF-based leader
+// election elsewhere (e.g. Algorand, Ouroboros Praos) relies on, so that
+// no validator can bias who wins without controlling the beacon itself.
+type LeaderElection struct {
+	beaconAPI  beacon.BeaconAPI
+	validators map[[32]byte]ed25519.PublicKey
+}
+
+// NewLeaderElection builds a LeaderElection backed by beaconAPI (a
+// *beacon.DrandBeacon in production, beacon.NewNullBeacon() for a
+// single-node/dev deployment with no drand network configured, or
+// *beacon.MockBeacon in tests) and the given validator set, keyed by
+// address — which, as CliqueEngine's selfAddr already assumes, is just
+// the validator's raw Ed25519 public key bytes.
+func NewLeaderElection(beaconAPI beacon.BeaconAPI, validators map[[32]byte]ed25519.PublicKey) *LeaderElection {
+	return &LeaderElection{beaconAPI: beaconAPI, validators: validators}
+}
+
+// EntryForRound fetches (or, for a polling BeaconAPI, waits for) the
+// beacon entry a given round's election is computed against.
+func (le *LeaderElection) EntryForRound(ctx context.Context, round uint64) (beacon.BeaconEntry, error) {
+	return le.beaconAPI.Entry(ctx, round)
+}
+
+// Elect picks the winning validator among tickets, the set received
+// within round's bounded collection window. It returns an error if
+// tickets is empty, any ticket fails VerifyLeaderTicket, or a ticket's
+// address isn't in the configured validator set — a malformed or
+// impersonated ticket aborts the whole election rather than being
+// silently skipped, since an attacker could otherwise try to win by
+// spamming unverifiable tickets past whatever would have beaten them.
+func (le *LeaderElection) Elect(round uint64, entry beacon.BeaconEntry, tickets map[[32]byte]LeaderTicket) ([32]byte, error) {
+	if len(tickets) == 0 {
+		return [32]byte{}, fmt.Errorf("leader election: no tickets received for round %d", round)
+	}
+
+	var winner [32]byte
+	var winnerValue *big.Int
+	for addr, ticket := range tickets {
+		pubKey, ok := le.validators[addr]
+		if !ok {
+			return [32]byte{}, fmt.Errorf("leader election: ticket from %x is not a configured validator", addr[:8])
+		}
+		if err := VerifyLeaderTicket(pubKey, round, entry, ticket); err != nil {
+			return [32]byte{}, fmt.Errorf("leader election: %x: %w", addr[:8], err)
+		}
+
+		value := new(big.Int).SetBytes(ticket.Value[:])
+		if winnerValue == nil || value.Cmp(winnerValue) < 0 {
+			winner = addr
+			winnerValue = value
+		}
+	}
+
+	return winner, nil
+}
@@ -0,0 +1,136 @@
+package consensus
+
+import (
+	"math/big"
+	"testing"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/beacon"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/signer"
+)
+
+func TestVerifyLeaderTicket_AcceptsGenuineTicket(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate signer key: %v", err)
+	}
+	self := signer.NewMemorySigner(pub, priv)
+
+	entry := beacon.BeaconEntry{Round: 5, Randomness: [32]byte{9, 9, 9}}
+
+	ticket, err := ComputeLeaderTicket(self, 5, entry)
+	if err != nil {
+		t.Fatalf("ComputeLeaderTicket: %v", err)
+	}
+
+	if err := VerifyLeaderTicket(pub, 5, entry, ticket); err != nil {
+		t.Errorf("expected genuine ticket to verify, got: %v", err)
+	}
+}
+
+func TestVerifyLeaderTicket_RejectsWrongRound(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate signer key: %v", err)
+	}
+	self := signer.NewMemorySigner(pub, priv)
+
+	entry := beacon.BeaconEntry{Round: 5, Randomness: [32]byte{9, 9, 9}}
+	ticket, err := ComputeLeaderTicket(self, 5, entry)
+	if err != nil {
+		t.Fatalf("ComputeLeaderTicket: %v", err)
+	}
+
+	if err := VerifyLeaderTicket(pub, 6, entry, ticket); err == nil {
+		t.Error("expected an error when verifying a ticket against the wrong round")
+	}
+}
+
+func TestVerifyLeaderTicket_RejectsWrongSigner(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate signer key: %v", err)
+	}
+	otherPub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate signer key: %v", err)
+	}
+	self := signer.NewMemorySigner(pub, priv)
+
+	entry := beacon.BeaconEntry{Round: 5, Randomness: [32]byte{9, 9, 9}}
+	ticket, err := ComputeLeaderTicket(self, 5, entry)
+	if err != nil {
+		t.Fatalf("ComputeLeaderTicket: %v", err)
+	}
+
+	if err := VerifyLeaderTicket(otherPub, 5, entry, ticket); err == nil {
+		t.Error("expected an error when verifying a ticket against the wrong signer's public key")
+	}
+}
+
+func TestLeaderElection_ElectPicksSmallestTicketValue(t *testing.T) {
+	mock := beacon.NewMockBeacon()
+	entry := mock.Seed(1)
+
+	pubA, privA, _ := ed25519.GenerateKey(nil)
+	pubB, privB, _ := ed25519.GenerateKey(nil)
+	var addrA, addrB [32]byte
+	copy(addrA[:], pubA)
+	copy(addrB[:], pubB)
+
+	ticketA, err := ComputeLeaderTicket(signer.NewMemorySigner(pubA, privA), 1, entry)
+	if err != nil {
+		t.Fatalf("ComputeLeaderTicket A: %v", err)
+	}
+	ticketB, err := ComputeLeaderTicket(signer.NewMemorySigner(pubB, privB), 1, entry)
+	if err != nil {
+		t.Fatalf("ComputeLeaderTicket B: %v", err)
+	}
+
+	le := NewLeaderElection(mock, map[[32]byte]ed25519.PublicKey{addrA: pubA, addrB: pubB})
+
+	winner, err := le.Elect(1, entry, map[[32]byte]LeaderTicket{addrA: ticketA, addrB: ticketB})
+	if err != nil {
+		t.Fatalf("Elect: %v", err)
+	}
+
+	want := addrA
+	if new(big.Int).SetBytes(ticketB.Value[:]).Cmp(new(big.Int).SetBytes(ticketA.Value[:])) < 0 {
+		want = addrB
+	}
+	if winner != want {
+		t.Errorf("Elect returned %x, want the validator with the smaller ticket value %x", winner[:8], want[:8])
+	}
+}
+
+func TestLeaderElection_ElectRejectsUnconfiguredValidator(t *testing.T) {
+	mock := beacon.NewMockBeacon()
+	entry := mock.Seed(1)
+
+	pubA, privA, _ := ed25519.GenerateKey(nil)
+	var addrA [32]byte
+	copy(addrA[:], pubA)
+
+	ticketA, err := ComputeLeaderTicket(signer.NewMemorySigner(pubA, privA), 1, entry)
+	if err != nil {
+		t.Fatalf("ComputeLeaderTicket: %v", err)
+	}
+
+	le := NewLeaderElection(mock, map[[32]byte]ed25519.PublicKey{})
+
+	if _, err := le.Elect(1, entry, map[[32]byte]LeaderTicket{addrA: ticketA}); err == nil {
+		t.Error("expected an error for a ticket from a validator not in the configured set")
+	}
+}
+
+func TestLeaderElection_ElectRejectsEmptyTicketSet(t *testing.T) {
+	mock := beacon.NewMockBeacon()
+	entry := mock.Seed(1)
+
+	le := NewLeaderElection(mock, map[[32]byte]ed25519.PublicKey{})
+
+	if _, err := le.Elect(1, entry, map[[32]byte]LeaderTicket{}); err == nil {
+		t.Error("expected an error for an empty ticket set")
+	}
+}
@@ -0,0 +1,184 @@
+package consensus
+
+import "sort"
+
+// LeafWithIndex pairs a leaf hash with its position among the tree's
+// original leaves, the input VerifyMerkleMultiProof needs to know where
+// each leaf sits without being handed the whole leaf set.
+type LeafWithIndex struct {
+	Index uint32
+	Leaf  [32]byte
+}
+
+// MultiProof is the batched-verification counterpart to []MerkleProofStep:
+// verifying N leaves from the same tree with N independent
+// VerifyMerkleProof calls redundantly recomputes every internal node
+// shared between their paths. MultiProof instead carries only the
+// sibling hashes VerifyMerkleMultiProof's level-by-level BFS can't
+// already derive from the leaves (and previously-reconstructed parents)
+// it was given, in MerkleSchemeLegacy (hashPair's duplicate-last-node
+// rule — same scheme BuildMerkleProof/VerifyMerkleProof use).
+type MultiProof struct {
+	NumLeaves uint32
+	Siblings  [][32]byte
+}
+
+// merkleSiblingIndex returns j's sibling index at a level of size
+// levelSize, following the same pairing hashPair's tree-building loop
+// uses: even indices pair with the next index, except the last index of
+// an odd-sized level, which has no partner and is duplicated against
+// itself.
+func merkleSiblingIndex(j, levelSize uint32) uint32 {
+	if j%2 == 0 {
+		if j+1 < levelSize {
+			return j + 1
+		}
+		return j
+	}
+	return j - 1
+}
+
+// merkleLevels builds every level of the MerkleSchemeLegacy tree over
+// hashes, level 0 being the leaves and the last level its single-node
+// root, mirroring pureGoBackend.MerkleRoot's loop but keeping each
+// intermediate level instead of discarding it.
+func merkleLevels(hashes [][32]byte) [][][32]byte {
+	if len(hashes) == 0 {
+		return [][][32]byte{{{}}}
+	}
+
+	cur := append([][32]byte(nil), hashes...)
+	levels := [][][32]byte{cur}
+
+	for len(cur) > 1 {
+		next := make([][32]byte, 0, (len(cur)+1)/2)
+		for i := 0; i < len(cur); i += 2 {
+			left := cur[i]
+			right := left
+			if i+1 < len(cur) {
+				right = cur[i+1]
+			}
+			next = append(next, hashPair(left, right))
+		}
+		levels = append(levels, next)
+		cur = next
+	}
+
+	return levels
+}
+
+// sortedUint32 returns keys sorted ascending, for deterministic
+// generate/verify traversal order.
+func sortedUint32(keys []uint32) []uint32 {
+	sorted := append([]uint32(nil), keys...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+// GenerateMerkleMultiProof builds the MultiProof a batched verifier
+// needs to confirm every leaf at indices is included in hashes' Merkle
+// root, without a separate root-to-leaf MerkleProofStep path per leaf.
+func GenerateMerkleMultiProof(hashes [][32]byte, indices []uint32) MultiProof {
+	levels := merkleLevels(hashes)
+
+	known := make(map[uint32]bool, len(indices))
+	for _, i := range indices {
+		known[i] = true
+	}
+
+	var siblings [][32]byte
+	for level := 0; level < len(levels)-1; level++ {
+		size := uint32(len(levels[level]))
+
+		keys := make([]uint32, 0, len(known))
+		for i := range known {
+			keys = append(keys, i)
+		}
+
+		next := make(map[uint32]bool, (len(keys)+1)/2)
+		for _, i := range sortedUint32(keys) {
+			sib := merkleSiblingIndex(i, size)
+			if sib != i && !known[sib] {
+				siblings = append(siblings, levels[level][sib])
+			}
+			next[i>>1] = true
+		}
+		known = next
+	}
+
+	return MultiProof{NumLeaves: uint32(len(hashes)), Siblings: siblings}
+}
+
+// VerifyMerkleMultiProof checks that every leaf in leaves is included
+// under root, reconstructing shared internal nodes once via a
+// level-by-level BFS instead of walking an independent root-to-leaf
+// path per leaf: at each level it sorts the working set of known
+// (index, hash) pairs ascending, and for each one only consumes the
+// next hash off proof.Siblings when that index's sibling isn't already
+// known — either because it was also supplied in leaves, or because an
+// earlier pair at this level already pulled it off the proof and cached
+// it. VerifyMerkleProof remains the right call for a single leaf.
+func VerifyMerkleMultiProof(leaves []LeafWithIndex, proof MultiProof, root [32]byte) bool {
+	if proof.NumLeaves == 0 {
+		return len(leaves) == 0 && root == [32]byte{}
+	}
+
+	known := make(map[uint32][32]byte, len(leaves))
+	for _, l := range leaves {
+		if l.Index >= proof.NumLeaves {
+			return false
+		}
+		known[l.Index] = l.Leaf
+	}
+
+	siblingPos := 0
+	size := proof.NumLeaves
+
+	for size > 1 {
+		keys := make([]uint32, 0, len(known))
+		for i := range known {
+			keys = append(keys, i)
+		}
+
+		next := make(map[uint32][32]byte, (len(keys)+1)/2)
+		for _, i := range sortedUint32(keys) {
+			parentIdx := i >> 1
+			if _, done := next[parentIdx]; done {
+				continue
+			}
+
+			sib := merkleSiblingIndex(i, size)
+			var sibHash [32]byte
+			if sib == i {
+				sibHash = known[i]
+			} else if h, ok := known[sib]; ok {
+				sibHash = h
+			} else {
+				if siblingPos >= len(proof.Siblings) {
+					return false
+				}
+				sibHash = proof.Siblings[siblingPos]
+				siblingPos++
+				known[sib] = sibHash
+			}
+
+			if i%2 == 0 {
+				next[parentIdx] = hashPair(known[i], sibHash)
+			} else {
+				next[parentIdx] = hashPair(sibHash, known[i])
+			}
+		}
+
+		known = next
+		size = (size + 1) / 2
+	}
+
+	if siblingPos != len(proof.Siblings) {
+		return false
+	}
+	if len(known) != 1 {
+		return false
+	}
+	return known[0] == root
+}
+
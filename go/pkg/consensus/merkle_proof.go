@@ -0,0 +1,288 @@
+package consensus
+
+import "fmt"
+
+// MerkleScheme selects how leaf and internal-node hashes are domain
+// separated when building or verifying a Merkle proof.
+//
+// MerkleSchemeLegacy is the original scheme this package shipped with:
+// plain SHA-256(left || right) at every level, with an unbalanced level
+// resolved by duplicating its last node (see TestComputeMerkleRoot_
+// ThreeLeaves). It is vulnerable to a second-preimage attack: a 64-byte
+// forged "leaf" is indistinguishable from a hashed-together internal
+// node, so an attacker can craft a fake leaf that equals some internal
+// node's hash input. It is kept, unchanged, so historical blocks whose
+// MerkleRoot was already committed under this scheme still verify.
+//
+// MerkleSchemeRFC6962 follows RFC 6962 (Certificate Transparency):
+// leaves are hashed as SHA-256(0x00 || leaf) and internal nodes as
+// SHA-256(0x01 || left || right), and an unbalanced list of n leaves is
+// split at k, the largest power of two strictly less than n, with the
+// root combining the sub-roots of the left k and right n-k leaves
+// instead of duplicating a leaf. This is the scheme new blocks should
+// commit to.
+type MerkleScheme uint8
+
+const (
+	MerkleSchemeLegacy MerkleScheme = iota
+	MerkleSchemeRFC6962
+)
+
+// MerkleSchemeForCodecVersion reports which MerkleScheme a header's
+// MerkleRoot was committed under, keyed off the same CodecVersion field
+// header_codec.go already gates its wire format on: version 1 headers
+// predate RFC 6962 domain separation, version 2 headers commit to it.
+func MerkleSchemeForCodecVersion(codecVersion uint32) MerkleScheme {
+	if codecVersion >= 2 {
+		return MerkleSchemeRFC6962
+	}
+	return MerkleSchemeLegacy
+}
+
+// MerkleProofStep is one step of a Merkle inclusion proof: the sibling
+// hash at that level, and whether it belongs on the left or right of
+// the node being hashed up from.
+type MerkleProofStep struct {
+	Sibling [32]byte
+	IsLeft  bool // true if Sibling is the left operand, false if right
+}
+
+// ComputeMerkleRootWithProof computes the Merkle root of txHashes (via
+// ComputeMerkleRoot, so it shares the same last-leaf-duplication rule)
+// and also returns the inclusion proof for the leaf at index.
+//
+// This is the primitive a light-client RPC needs to let a caller verify
+// that a transaction hash is included under a header's MerkleRoot
+// without downloading the full block: the server returns the proof
+// alongside the header, and the client re-derives the root locally with
+// VerifyMerkleProof.
+func ComputeMerkleRootWithProof(txHashes [][32]byte, index int) ([32]byte, []MerkleProofStep, error) {
+	var root [32]byte
+
+	if index < 0 || index >= len(txHashes) {
+		return root, nil, fmt.Errorf("index %d out of range for %d leaves", index, len(txHashes))
+	}
+
+	root, err := ComputeMerkleRoot(txHashes)
+	if err != nil {
+		return root, nil, fmt.Errorf("failed to compute merkle root: %w", err)
+	}
+
+	level := make([][32]byte, len(txHashes))
+	copy(level, txHashes)
+	pos := index
+
+	var proof []MerkleProofStep
+	for len(level) > 1 {
+		next := make([][32]byte, 0, (len(level)+1)/2)
+
+		for i := 0; i < len(level); i += 2 {
+			left := level[i]
+			right := left
+			if i+1 < len(level) {
+				right = level[i+1]
+			}
+
+			if i == pos || i+1 == pos {
+				if pos == i {
+					// Our node is the left child; sibling is the right.
+					proof = append(proof, MerkleProofStep{Sibling: right, IsLeft: false})
+				} else {
+					proof = append(proof, MerkleProofStep{Sibling: left, IsLeft: true})
+				}
+				pos = len(next)
+			}
+
+			next = append(next, hashPair(left, right))
+		}
+
+		level = next
+	}
+
+	return root, proof, nil
+}
+
+// BuildMerkleProof computes the same root and inclusion proof as
+// ComputeMerkleRootWithProof, but derives the root from the same
+// hashPair tree walk the proof is built from (matching DefaultBackend().
+// MerkleRoot by construction) instead of the CGO-only ComputeMerkleRoot,
+// so it also works in non-CGO builds — needed by callers like p2p's
+// light-client proof RPC that can't assume CGO is available.
+func BuildMerkleProof(txHashes [][32]byte, index int) ([32]byte, []MerkleProofStep, error) {
+	var root [32]byte
+
+	if index < 0 || index >= len(txHashes) {
+		return root, nil, fmt.Errorf("index %d out of range for %d leaves", index, len(txHashes))
+	}
+
+	level := make([][32]byte, len(txHashes))
+	copy(level, txHashes)
+	pos := index
+
+	var proof []MerkleProofStep
+	for len(level) > 1 {
+		next := make([][32]byte, 0, (len(level)+1)/2)
+
+		for i := 0; i < len(level); i += 2 {
+			left := level[i]
+			right := left
+			if i+1 < len(level) {
+				right = level[i+1]
+			}
+
+			if i == pos || i+1 == pos {
+				if pos == i {
+					proof = append(proof, MerkleProofStep{Sibling: right, IsLeft: false})
+				} else {
+					proof = append(proof, MerkleProofStep{Sibling: left, IsLeft: true})
+				}
+				pos = len(next)
+			}
+
+			next = append(next, hashPair(left, right))
+		}
+
+		level = next
+	}
+
+	return level[0], proof, nil
+}
+
+// VerifyMerkleProof recomputes the root from leaf and proof and checks
+// it matches root.
+func VerifyMerkleProof(leaf [32]byte, proof []MerkleProofStep, root [32]byte) bool {
+	current := leaf
+
+	for _, step := range proof {
+		if step.IsLeft {
+			current = hashPair(step.Sibling, current)
+		} else {
+			current = hashPair(current, step.Sibling)
+		}
+	}
+
+	return current == root
+}
+
+// hashPair hashes two nodes together using the same backend as
+// ComputeMerkleRoot, so proofs built with this file stay in lockstep
+// with the authoritative root computation.
+func hashPair(left, right [32]byte) [32]byte {
+	return DefaultBackend().SHA256(append(append([]byte{}, left[:]...), right[:]...))
+}
+
+// rfc6962LeafPrefix and rfc6962NodePrefix are the domain-separation
+// bytes RFC 6962 section 2.1 prepends before hashing a leaf or an
+// internal node, so a forged 64-byte "leaf" can never collide with an
+// internal node's hash input the way plain SHA-256(left||right) allows.
+const (
+	rfc6962LeafPrefix byte = 0x00
+	rfc6962NodePrefix byte = 0x01
+)
+
+// hashLeafRFC6962 hashes a single leaf under the RFC 6962 leaf domain.
+func hashLeafRFC6962(leaf [32]byte) [32]byte {
+	buf := make([]byte, 0, 1+32)
+	buf = append(buf, rfc6962LeafPrefix)
+	buf = append(buf, leaf[:]...)
+	return DefaultBackend().SHA256(buf)
+}
+
+// hashNodeRFC6962 hashes two child hashes under the RFC 6962 internal-
+// node domain.
+func hashNodeRFC6962(left, right [32]byte) [32]byte {
+	buf := make([]byte, 0, 1+32+32)
+	buf = append(buf, rfc6962NodePrefix)
+	buf = append(buf, left[:]...)
+	buf = append(buf, right[:]...)
+	return DefaultBackend().SHA256(buf)
+}
+
+// largestPowerOfTwoLessThan returns the largest k = 2^i such that
+// k < n, for n > 1. RFC 6962 uses this to split an unbalanced leaf
+// range into a left sub-range of size k (a perfect subtree) and a
+// right sub-range of size n-k, rather than duplicating the last leaf.
+func largestPowerOfTwoLessThan(n int) int {
+	k := 1
+	for k*2 < n {
+		k *= 2
+	}
+	return k
+}
+
+// rfc6962Range hashes the leaves in hashes[lo:hi] into a single RFC 6962
+// subtree root, recursively splitting unbalanced ranges at
+// largestPowerOfTwoLessThan(hi-lo) instead of duplicating the last leaf.
+// If proof is non-nil and index falls within [lo, hi), it is
+// appended to with the sibling at every level the proof path passes
+// through, recording the leaf's position in the final proof slice.
+func rfc6962Range(hashes [][32]byte, lo, hi int, index int, proof *[]MerkleProofStep) [32]byte {
+	n := hi - lo
+	if n == 1 {
+		return hashLeafRFC6962(hashes[lo])
+	}
+
+	k := largestPowerOfTwoLessThan(n)
+	left := rfc6962Range(hashes, lo, lo+k, index, proof)
+	right := rfc6962Range(hashes, lo+k, hi, index, proof)
+
+	if proof != nil && index >= lo && index < hi {
+		if index < lo+k {
+			*proof = append(*proof, MerkleProofStep{Sibling: right, IsLeft: false})
+		} else {
+			*proof = append(*proof, MerkleProofStep{Sibling: left, IsLeft: true})
+		}
+	}
+
+	return hashNodeRFC6962(left, right)
+}
+
+// BuildMerkleProofWithScheme computes the Merkle root of txHashes and
+// the inclusion proof for the leaf at index, under the given
+// MerkleScheme. BuildMerkleProof (MerkleSchemeLegacy) and
+// BuildMerkleProofRFC6962 (MerkleSchemeRFC6962) are thin wrappers
+// around this for callers that already know which scheme they want.
+func BuildMerkleProofWithScheme(scheme MerkleScheme, txHashes [][32]byte, index int) ([32]byte, []MerkleProofStep, error) {
+	var root [32]byte
+
+	if index < 0 || index >= len(txHashes) {
+		return root, nil, fmt.Errorf("index %d out of range for %d leaves", index, len(txHashes))
+	}
+
+	switch scheme {
+	case MerkleSchemeRFC6962:
+		var proof []MerkleProofStep
+		// rfc6962Range appends each level's sibling as its recursive call
+		// returns, innermost (closest to the leaf) first, which is
+		// already the leaf-to-root order VerifyMerkleProofWithScheme
+		// walks in.
+		root = rfc6962Range(txHashes, 0, len(txHashes), index, &proof)
+		return root, proof, nil
+	default:
+		return BuildMerkleProof(txHashes, index)
+	}
+}
+
+// BuildMerkleProofRFC6962 is BuildMerkleProofWithScheme(MerkleSchemeRFC6962, ...).
+func BuildMerkleProofRFC6962(txHashes [][32]byte, index int) ([32]byte, []MerkleProofStep, error) {
+	return BuildMerkleProofWithScheme(MerkleSchemeRFC6962, txHashes, index)
+}
+
+// VerifyMerkleProofWithScheme recomputes the root from leaf and proof
+// under the given MerkleScheme and checks it matches root.
+// MerkleSchemeLegacy delegates to VerifyMerkleProof unchanged.
+func VerifyMerkleProofWithScheme(scheme MerkleScheme, leaf [32]byte, proof []MerkleProofStep, root [32]byte) bool {
+	if scheme != MerkleSchemeRFC6962 {
+		return VerifyMerkleProof(leaf, proof, root)
+	}
+
+	current := hashLeafRFC6962(leaf)
+	for _, step := range proof {
+		if step.IsLeft {
+			current = hashNodeRFC6962(step.Sibling, current)
+		} else {
+			current = hashNodeRFC6962(current, step.Sibling)
+		}
+	}
+	return current == root
+}
@@ -0,0 +1,131 @@
+package consensus
+
+import "testing"
+
+func TestComputeMerkleRootWithProof_IndexOutOfRange(t *testing.T) {
+	leaves := [][32]byte{{1}, {2}, {3}}
+
+	_, _, err := ComputeMerkleRootWithProof(leaves, 3)
+	if err == nil {
+		t.Fatal("expected an error for an out-of-range index")
+	}
+
+	_, _, err = ComputeMerkleRootWithProof(leaves, -1)
+	if err == nil {
+		t.Fatal("expected an error for a negative index")
+	}
+}
+
+func TestMerkleProofStep_RoundTrip(t *testing.T) {
+	leaf := [32]byte{9}
+	sibling := [32]byte{10}
+
+	root := hashPair(leaf, sibling)
+	proof := []MerkleProofStep{{Sibling: sibling, IsLeft: false}}
+
+	if !VerifyMerkleProof(leaf, proof, root) {
+		t.Error("expected proof built from hashPair to verify against the matching root")
+	}
+
+	wrongRoot := hashPair(sibling, leaf)
+	if VerifyMerkleProof(leaf, proof, wrongRoot) {
+		t.Error("expected proof to fail against a root computed with swapped operand order")
+	}
+}
+
+func TestRFC6962_LeafAndNodeHashesAreDomainSeparated(t *testing.T) {
+	leaf := [32]byte{1}
+
+	leafHash := hashLeafRFC6962(leaf)
+	nodeHash := hashNodeRFC6962(leaf, leaf)
+
+	if leafHash == nodeHash {
+		t.Error("expected leaf and node hashes of the same bytes to differ under RFC 6962 domain separation")
+	}
+}
+
+func TestBuildMerkleProofRFC6962_SingleLeaf(t *testing.T) {
+	leaves := [][32]byte{{7}}
+
+	root, proof, err := BuildMerkleProofRFC6962(leaves, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(proof) != 0 {
+		t.Errorf("expected an empty proof for a single-leaf tree, got %d steps", len(proof))
+	}
+	if root != hashLeafRFC6962(leaves[0]) {
+		t.Error("expected a single-leaf root to equal its leaf hash")
+	}
+	if !VerifyMerkleProofWithScheme(MerkleSchemeRFC6962, leaves[0], proof, root) {
+		t.Error("expected the single-leaf proof to verify")
+	}
+}
+
+func TestBuildMerkleProofRFC6962_EveryLeafVerifies(t *testing.T) {
+	for _, n := range []int{2, 3, 4, 5, 7, 8} {
+		leaves := make([][32]byte, n)
+		for i := range leaves {
+			leaves[i] = [32]byte{byte(i + 1)}
+		}
+
+		root, _, err := BuildMerkleProofRFC6962(leaves, 0)
+		if err != nil {
+			t.Fatalf("n=%d: unexpected error: %v", n, err)
+		}
+
+		for i := 0; i < n; i++ {
+			gotRoot, proof, err := BuildMerkleProofRFC6962(leaves, i)
+			if err != nil {
+				t.Fatalf("n=%d index=%d: unexpected error: %v", n, i, err)
+			}
+			if gotRoot != root {
+				t.Fatalf("n=%d index=%d: root %x does not match root %x from index 0", n, i, gotRoot, root)
+			}
+			if !VerifyMerkleProofWithScheme(MerkleSchemeRFC6962, leaves[i], proof, root) {
+				t.Errorf("n=%d index=%d: expected proof to verify", n, i)
+			}
+		}
+	}
+}
+
+func TestBuildMerkleProofRFC6962_UnbalancedDoesNotDuplicateLastLeaf(t *testing.T) {
+	// A 3-leaf RFC 6962 tree splits at k=2: {0,1} on the left, {2} alone
+	// on the right, not {2,2} the legacy duplicate-last-leaf scheme uses.
+	leaves := [][32]byte{{1}, {2}, {3}}
+
+	root, _, err := BuildMerkleProofRFC6962(leaves, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	left := hashNodeRFC6962(hashLeafRFC6962(leaves[0]), hashLeafRFC6962(leaves[1]))
+	right := hashLeafRFC6962(leaves[2])
+	expected := hashNodeRFC6962(left, right)
+
+	if root != expected {
+		t.Errorf("expected root %x (split at k=2, no duplication), got %x", expected, root)
+	}
+}
+
+func TestVerifyMerkleProofWithScheme_RejectsWrongScheme(t *testing.T) {
+	leaves := [][32]byte{{1}, {2}, {3}, {4}}
+
+	root, proof, err := BuildMerkleProofRFC6962(leaves, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if VerifyMerkleProofWithScheme(MerkleSchemeLegacy, leaves[0], proof, root) {
+		t.Error("expected an RFC 6962 proof to fail verification under the legacy scheme")
+	}
+}
+
+func TestMerkleSchemeForCodecVersion(t *testing.T) {
+	if MerkleSchemeForCodecVersion(1) != MerkleSchemeLegacy {
+		t.Error("expected codec version 1 to map to MerkleSchemeLegacy")
+	}
+	if MerkleSchemeForCodecVersion(2) != MerkleSchemeRFC6962 {
+		t.Error("expected codec version 2 to map to MerkleSchemeRFC6962")
+	}
+}
@@ -0,0 +1,68 @@
+package consensus
+
+import "fmt"
+
+// MerkleProof is the flat, wire-friendly encoding of a Merkle inclusion
+// proof: the leaf's position and its sibling hashes from the leaf level
+// up to the root, in order. It carries the same information as a
+// []MerkleProofStep, but without the per-step IsLeft flag — Index's bit
+// pattern determines each level's left/right placement on its own (bit i
+// is 1 when the node at level i is a right child), the same encoding
+// Bitcoin's merkleblock and most SPV proof formats use. This is the
+// shape a light client or an RPC response wants to serialize, where
+// []MerkleProofStep is the shape proof construction/verification
+// already works in internally.
+type MerkleProof struct {
+	Index    uint32
+	Siblings [][32]byte
+}
+
+func stepsToMerkleProof(index int, steps []MerkleProofStep) MerkleProof {
+	siblings := make([][32]byte, len(steps))
+	var packedIndex uint32
+	for i, step := range steps {
+		siblings[i] = step.Sibling
+		if step.IsLeft {
+			// The sibling is on the left, so the node we're hashing up
+			// from was the right (odd) child at this level.
+			packedIndex |= 1 << uint(i)
+		}
+	}
+	_ = index // retained for documentation parity with BuildMerkleProof's signature
+	return MerkleProof{Index: packedIndex, Siblings: siblings}
+}
+
+func (p MerkleProof) toSteps() []MerkleProofStep {
+	steps := make([]MerkleProofStep, len(p.Siblings))
+	for i, sibling := range p.Siblings {
+		steps[i] = MerkleProofStep{Sibling: sibling, IsLeft: p.Index&(1<<uint(i)) != 0}
+	}
+	return steps
+}
+
+// ComputeMerkleProof builds the inclusion proof for the leaf at index,
+// in the flat wire encoding MerkleProof uses, by delegating to
+// BuildMerkleProof so it shares the same tree walk (and so shares
+// BuildMerkleProof's CGO-independence).
+func ComputeMerkleProof(txHashes [][32]byte, index uint32) (MerkleProof, error) {
+	if index >= uint32(len(txHashes)) {
+		return MerkleProof{}, fmt.Errorf("index %d out of range for %d leaves", index, len(txHashes))
+	}
+
+	_, steps, err := BuildMerkleProof(txHashes, int(index))
+	if err != nil {
+		return MerkleProof{}, err
+	}
+
+	return stepsToMerkleProof(int(index), steps), nil
+}
+
+// VerifyInclusionProof checks that leaf is included under root according
+// to proof, by expanding it back to []MerkleProofStep and delegating to
+// VerifyMerkleProof. An empty Siblings list is valid: it's what
+// ComputeMerkleProof returns for a single-leaf tree, where the leaf is
+// the root. Named distinctly from VerifyMerkleProof (same package,
+// different parameter types) rather than overloading it.
+func VerifyInclusionProof(root [32]byte, leaf [32]byte, proof MerkleProof) (bool, error) {
+	return VerifyMerkleProof(leaf, proof.toSteps(), root), nil
+}
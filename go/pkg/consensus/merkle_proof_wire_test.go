@@ -0,0 +1,87 @@
+package consensus
+
+import "testing"
+
+func leafHashes(n int) [][32]byte {
+	hashes := make([][32]byte, n)
+	for i := range hashes {
+		hashes[i] = hashPair([32]byte{byte(i)}, [32]byte{byte(i), 1})
+	}
+	return hashes
+}
+
+func TestComputeMerkleProof_VerifiesAgainstBuildMerkleProofRoot(t *testing.T) {
+	hashes := leafHashes(5)
+
+	for index := range hashes {
+		root, _, err := BuildMerkleProof(hashes, index)
+		if err != nil {
+			t.Fatalf("BuildMerkleProof(%d): %v", index, err)
+		}
+
+		proof, err := ComputeMerkleProof(hashes, uint32(index))
+		if err != nil {
+			t.Fatalf("ComputeMerkleProof(%d): %v", index, err)
+		}
+
+		ok, err := VerifyInclusionProof(root, hashes[index], proof)
+		if err != nil {
+			t.Fatalf("VerifyInclusionProof(%d): %v", index, err)
+		}
+		if !ok {
+			t.Errorf("expected proof for leaf %d to verify against the root", index)
+		}
+	}
+}
+
+func TestComputeMerkleProof_RejectsOutOfRangeIndex(t *testing.T) {
+	hashes := leafHashes(3)
+	if _, err := ComputeMerkleProof(hashes, 3); err == nil {
+		t.Fatal("expected an error for an out-of-range index")
+	}
+}
+
+func TestVerifyInclusionProof_RejectsWrongLeaf(t *testing.T) {
+	hashes := leafHashes(4)
+	root, _, err := BuildMerkleProof(hashes, 2)
+	if err != nil {
+		t.Fatalf("BuildMerkleProof: %v", err)
+	}
+
+	proof, err := ComputeMerkleProof(hashes, 2)
+	if err != nil {
+		t.Fatalf("ComputeMerkleProof: %v", err)
+	}
+
+	ok, err := VerifyInclusionProof(root, hashes[0], proof)
+	if err != nil {
+		t.Fatalf("VerifyInclusionProof: %v", err)
+	}
+	if ok {
+		t.Error("expected a proof built for a different leaf to fail verification")
+	}
+}
+
+func TestComputeMerkleProof_SingleLeafTreeHasEmptyProof(t *testing.T) {
+	hashes := leafHashes(1)
+	root, _, err := BuildMerkleProof(hashes, 0)
+	if err != nil {
+		t.Fatalf("BuildMerkleProof: %v", err)
+	}
+
+	proof, err := ComputeMerkleProof(hashes, 0)
+	if err != nil {
+		t.Fatalf("ComputeMerkleProof: %v", err)
+	}
+	if len(proof.Siblings) != 0 {
+		t.Fatalf("expected an empty proof for a single-leaf tree, got %d siblings", len(proof.Siblings))
+	}
+
+	ok, err := VerifyInclusionProof(root, hashes[0], proof)
+	if err != nil {
+		t.Fatalf("VerifyInclusionProof: %v", err)
+	}
+	if !ok {
+		t.Error("expected the single leaf to verify as its own root")
+	}
+}
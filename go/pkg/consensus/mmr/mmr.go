@@ -0,0 +1,258 @@
+// Package mmr implements a Merkle Mountain Range over block headers,
+// modeled on the Zcash ZIP-221 history tree: an append-only structure
+// that commits to every header seen so far in O(log n) space (the
+// current "peaks"), supports O(log n) append, and produces O(log n)
+// inclusion proofs a light client can use to verify "header X is an
+// ancestor of tip T" without downloading every header in between.
+//
+// It is deliberately standalone (crypto/sha256 only, no dependency on
+// consensus.CryptoBackend or CGO) so it can be unit tested and used by
+// light-client code without requiring the Rust core library — the same
+// reasoning pkg/consensus/goldenvec and pkg/evidence apply to staying
+// decoupled from the packages that would consume them.
+//
+// Wiring a BlockHeader.HistoryRoot field that commits to this tree's
+// Root() is deferred: BlockHeader is defined only under the cgo build
+// tag (rust_bindings.go) as the Go mirror of Rust's BlockHeaderFFI, and
+// this tree has no rust/ source tree to keep the two struct layouts in
+// sync — adding a field on the Go side alone would silently diverge from
+// the FFI boundary it's supposed to match.
+package mmr
+
+import (
+	"crypto/sha256"
+	"fmt"
+)
+
+// leafPrefix and nodePrefix domain-separate a leaf commitment from an
+// internal node's, the same second-preimage defense
+// consensus.MerkleSchemeRFC6962 uses for the transaction/state trees.
+const (
+	leafPrefix byte = 0x00
+	nodePrefix byte = 0x01
+)
+
+func hashLeaf(data [32]byte) [32]byte {
+	buf := make([]byte, 0, 1+32)
+	buf = append(buf, leafPrefix)
+	buf = append(buf, data[:]...)
+	return sha256.Sum256(buf)
+}
+
+func hashNode(left, right [32]byte) [32]byte {
+	buf := make([]byte, 0, 1+32+32)
+	buf = append(buf, nodePrefix)
+	buf = append(buf, left[:]...)
+	buf = append(buf, right[:]...)
+	return sha256.Sum256(buf)
+}
+
+// NodeData is the commitment carried by one node of the tree, whether a
+// leaf (StartHeight == EndHeight) or an internal node spanning the
+// height range of everything beneath it.
+type NodeData struct {
+	StartHeight       uint64
+	EndHeight         uint64
+	SubtreeTotalWork  uint64
+	SubtreeCommitment [32]byte
+}
+
+// mmrNode is a NodeData plus the tree links Prove needs to walk from a
+// leaf up to its current peak; left/right/parent are node indices into
+// Tree.nodes, or -1 when absent.
+type mmrNode struct {
+	data                NodeData
+	left, right, parent int
+}
+
+// Tree is an append-only Merkle Mountain Range. The zero value is ready
+// to use.
+type Tree struct {
+	nodes      []mmrNode
+	peaks      []int // node indices of the current peaks, left (oldest/largest) to right (newest/smallest)
+	leafNode   map[uint64]int
+	nextHeight uint64
+}
+
+// NewTree creates an empty Tree.
+func NewTree() *Tree {
+	return &Tree{leafNode: make(map[uint64]int)}
+}
+
+// Append adds the next header's hash and work as a new leaf at height
+// t.LeafCount(), bagging equal-sized adjacent peaks as a binary counter
+// would. It runs in amortized O(1), worst case O(log n).
+func (t *Tree) Append(headerHash [32]byte, work uint64) NodeData {
+	height := t.nextHeight
+	t.nextHeight++
+
+	leaf := NodeData{
+		StartHeight:       height,
+		EndHeight:         height,
+		SubtreeTotalWork:  work,
+		SubtreeCommitment: hashLeaf(headerHash),
+	}
+	idx := len(t.nodes)
+	t.nodes = append(t.nodes, mmrNode{data: leaf, left: -1, right: -1, parent: -1})
+	t.leafNode[height] = idx
+	t.peaks = append(t.peaks, idx)
+
+	for len(t.peaks) >= 2 {
+		li, ri := t.peaks[len(t.peaks)-2], t.peaks[len(t.peaks)-1]
+		l, r := t.nodes[li].data, t.nodes[ri].data
+		if (l.EndHeight - l.StartHeight) != (r.EndHeight - r.StartHeight) {
+			break
+		}
+
+		parent := NodeData{
+			StartHeight:       l.StartHeight,
+			EndHeight:         r.EndHeight,
+			SubtreeTotalWork:  l.SubtreeTotalWork + r.SubtreeTotalWork,
+			SubtreeCommitment: hashNode(l.SubtreeCommitment, r.SubtreeCommitment),
+		}
+		pIdx := len(t.nodes)
+		t.nodes = append(t.nodes, mmrNode{data: parent, left: li, right: ri, parent: -1})
+		t.nodes[li].parent = pIdx
+		t.nodes[ri].parent = pIdx
+
+		t.peaks = t.peaks[:len(t.peaks)-2]
+		t.peaks = append(t.peaks, pIdx)
+	}
+
+	return leaf
+}
+
+// LeafCount returns how many leaves (headers) have been appended.
+func (t *Tree) LeafCount() uint64 {
+	return t.nextHeight
+}
+
+// Peaks returns the current peaks, left (oldest/largest) to right
+// (newest/smallest).
+func (t *Tree) Peaks() []NodeData {
+	peaks := make([]NodeData, len(t.peaks))
+	for i, idx := range t.peaks {
+		peaks[i] = t.nodes[idx].data
+	}
+	return peaks
+}
+
+// Root bags the current peaks right-to-left into a single commitment —
+// the "HistoryRoot" a header would commit to. Returns the zero hash for
+// an empty tree.
+func (t *Tree) Root() [32]byte {
+	if len(t.peaks) == 0 {
+		return [32]byte{}
+	}
+
+	bag := t.nodes[t.peaks[len(t.peaks)-1]].data.SubtreeCommitment
+	for i := len(t.peaks) - 2; i >= 0; i-- {
+		bag = hashNode(t.nodes[t.peaks[i]].data.SubtreeCommitment, bag)
+	}
+	return bag
+}
+
+// ProofStep is one step of an InclusionProof: the sibling commitment at
+// that level, and whether it belongs on the left or right of the node
+// being hashed up from — the same shape as consensus.MerkleProofStep.
+type ProofStep struct {
+	Sibling [32]byte
+	IsLeft  bool
+}
+
+// InclusionProof lets a light client verify that the leaf at a given
+// height was committed to by Root(), without holding the rest of the
+// tree. PeakSteps walks from the leaf up to its containing peak;
+// OtherPeaks (with PeakIndex marking where the reconstructed peak slots
+// back in) lets the verifier re-bag the full peak list and compare
+// against the claimed root. This doubles as a "header X is an ancestor
+// of tip T" proof: T is whatever tip Root() was computed for when the
+// proof was built.
+type InclusionProof struct {
+	LeafCommitment [32]byte
+	PeakSteps      []ProofStep
+	PeakIndex      int
+	OtherPeaks     [][32]byte
+}
+
+// Prove builds an InclusionProof for the leaf at height, against the
+// tree's current Root(). Returns an error if no leaf exists at that
+// height.
+func (t *Tree) Prove(height uint64) (*InclusionProof, error) {
+	idx, ok := t.leafNode[height]
+	if !ok {
+		return nil, fmt.Errorf("no leaf at height %d (tree has %d leaves)", height, t.nextHeight)
+	}
+
+	leafCommitment := t.nodes[idx].data.SubtreeCommitment
+
+	var steps []ProofStep
+	cur := idx
+	for t.nodes[cur].parent != -1 {
+		p := t.nodes[cur].parent
+		if t.nodes[p].left == cur {
+			steps = append(steps, ProofStep{Sibling: t.nodes[t.nodes[p].right].data.SubtreeCommitment, IsLeft: false})
+		} else {
+			steps = append(steps, ProofStep{Sibling: t.nodes[t.nodes[p].left].data.SubtreeCommitment, IsLeft: true})
+		}
+		cur = p
+	}
+
+	peakIndex := -1
+	for i, pIdx := range t.peaks {
+		if pIdx == cur {
+			peakIndex = i
+			break
+		}
+	}
+	if peakIndex == -1 {
+		return nil, fmt.Errorf("internal error: leaf's topmost ancestor is not a current peak")
+	}
+
+	otherPeaks := make([][32]byte, 0, len(t.peaks)-1)
+	for i, pIdx := range t.peaks {
+		if i != peakIndex {
+			otherPeaks = append(otherPeaks, t.nodes[pIdx].data.SubtreeCommitment)
+		}
+	}
+
+	return &InclusionProof{
+		LeafCommitment: leafCommitment,
+		PeakSteps:      steps,
+		PeakIndex:      peakIndex,
+		OtherPeaks:     otherPeaks,
+	}, nil
+}
+
+// VerifyInclusion recomputes proof.LeafCommitment's peak from PeakSteps,
+// reinserts it among OtherPeaks at PeakIndex, re-bags the full peak
+// list, and checks the result matches root.
+func VerifyInclusion(proof *InclusionProof, root [32]byte) bool {
+	if proof.PeakIndex < 0 || proof.PeakIndex > len(proof.OtherPeaks) {
+		return false
+	}
+
+	cur := proof.LeafCommitment
+	for _, s := range proof.PeakSteps {
+		if s.IsLeft {
+			cur = hashNode(s.Sibling, cur)
+		} else {
+			cur = hashNode(cur, s.Sibling)
+		}
+	}
+
+	peaks := make([][32]byte, len(proof.OtherPeaks)+1)
+	copy(peaks, proof.OtherPeaks[:proof.PeakIndex])
+	peaks[proof.PeakIndex] = cur
+	copy(peaks[proof.PeakIndex+1:], proof.OtherPeaks[proof.PeakIndex:])
+
+	if len(peaks) == 0 {
+		return false
+	}
+
+	bag := peaks[len(peaks)-1]
+	for i := len(peaks) - 2; i >= 0; i-- {
+		bag = hashNode(peaks[i], bag)
+	}
+	return bag == root
+}
@@ -0,0 +1,135 @@
+package mmr
+
+import (
+	"crypto/sha256"
+	"testing"
+)
+
+func headerHash(s string) [32]byte {
+	return sha256.Sum256([]byte(s))
+}
+
+func TestTree_EmptyRootIsZero(t *testing.T) {
+	tree := NewTree()
+	if tree.Root() != ([32]byte{}) {
+		t.Error("expected an empty tree's root to be the zero hash")
+	}
+	if tree.LeafCount() != 0 {
+		t.Errorf("expected LeafCount 0, got %d", tree.LeafCount())
+	}
+}
+
+func TestTree_SingleLeafRootEqualsLeafCommitment(t *testing.T) {
+	tree := NewTree()
+	leaf := tree.Append(headerHash("genesis"), 100)
+
+	if tree.Root() != leaf.SubtreeCommitment {
+		t.Error("expected a single-leaf tree's root to equal the leaf's own commitment")
+	}
+}
+
+func TestTree_AppendIsDeterministic(t *testing.T) {
+	build := func() [32]byte {
+		tree := NewTree()
+		for i := 0; i < 11; i++ {
+			tree.Append(headerHash(string(rune('a'+i))), uint64(i+1))
+		}
+		return tree.Root()
+	}
+
+	if build() != build() {
+		t.Error("expected two identically-built trees to produce the same root")
+	}
+}
+
+func TestTree_DifferentHeaderAtSameHeightChangesRoot(t *testing.T) {
+	a := NewTree()
+	a.Append(headerHash("x"), 1)
+	a.Append(headerHash("y"), 1)
+
+	b := NewTree()
+	b.Append(headerHash("x"), 1)
+	b.Append(headerHash("z"), 1)
+
+	if a.Root() == b.Root() {
+		t.Error("expected a different leaf to change the root")
+	}
+}
+
+func TestTree_ProveAndVerifyInclusion_EveryLeafAcrossSizes(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 4, 5, 7, 8, 15, 16, 17} {
+		tree := NewTree()
+		for i := 0; i < n; i++ {
+			tree.Append(headerHash(string(rune(i))), uint64(i+1))
+		}
+		root := tree.Root()
+
+		for h := 0; h < n; h++ {
+			proof, err := tree.Prove(uint64(h))
+			if err != nil {
+				t.Fatalf("n=%d height=%d: unexpected error: %v", n, h, err)
+			}
+			if !VerifyInclusion(proof, root) {
+				t.Errorf("n=%d height=%d: expected proof to verify against the tree's root", n, h)
+			}
+		}
+	}
+}
+
+func TestTree_ProveUnknownHeightErrors(t *testing.T) {
+	tree := NewTree()
+	tree.Append(headerHash("only"), 1)
+
+	if _, err := tree.Prove(5); err == nil {
+		t.Error("expected an error proving a height that hasn't been appended yet")
+	}
+}
+
+func TestVerifyInclusion_RejectsWrongRoot(t *testing.T) {
+	tree := NewTree()
+	for i := 0; i < 5; i++ {
+		tree.Append(headerHash(string(rune('a'+i))), uint64(i+1))
+	}
+
+	proof, err := tree.Prove(2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wrongRoot := headerHash("not the root")
+	if VerifyInclusion(proof, wrongRoot) {
+		t.Error("expected verification to fail against an unrelated root")
+	}
+}
+
+func TestTree_PeaksCoverEveryLeafExactlyOnce(t *testing.T) {
+	tree := NewTree()
+	const n = 13
+	for i := 0; i < n; i++ {
+		tree.Append(headerHash(string(rune('a'+i))), 1)
+	}
+
+	var total uint64
+	for _, peak := range tree.Peaks() {
+		total += peak.EndHeight - peak.StartHeight + 1
+	}
+	if total != n {
+		t.Errorf("expected peaks to cover all %d leaves exactly once, got %d", n, total)
+	}
+}
+
+func TestTree_SubtreeTotalWorkSumsAcrossMerges(t *testing.T) {
+	tree := NewTree()
+	tree.Append(headerHash("a"), 10)
+	tree.Append(headerHash("b"), 20)
+	tree.Append(headerHash("c"), 30)
+	tree.Append(headerHash("d"), 40)
+
+	peaks := tree.Peaks()
+	if len(peaks) != 1 {
+		t.Fatalf("expected a single peak for 4 leaves, got %d", len(peaks))
+	}
+	if peaks[0].SubtreeTotalWork != 100 {
+		t.Errorf("expected total work 100, got %d", peaks[0].SubtreeTotalWork)
+	}
+}
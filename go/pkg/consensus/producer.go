@@ -0,0 +1,194 @@
+package consensus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
+)
+
+// ConsensusConfig is the block-production driver's configuration: the
+// literal cmd/network-a-node/main.go builds (BlockTime, Validators,
+// ValidatorKey, IsValidator) before passing it to consensus.NewEngine.
+// That call site, like the NewEngine calls in cmd/coinjectured/main.go
+// and test/integration/multi_node_test.go, predates the Engine interface this package now uses for pluggable sealing
+// algorithms (see engine.go) — "Engine" isn't free to reuse here for
+// the driver, so Producer is this config's actual consumer, and
+// cmd/network-a-node/main.go's NewEngine/engine.Start() calls remain an
+// unreconciled, pre-existing gap rather than something this change
+// papers over.
+type ConsensusConfig struct {
+	BlockTime    time.Duration
+	Validators   [][32]byte
+	ValidatorKey [32]byte
+	IsValidator  bool
+
+	// AllowEmptyBlocks gates production of a block with zero
+	// transactions. With BlockTime == 0 and AllowEmptyBlocks == false,
+	// Producer never seals anything until a transaction actually shows
+	// up — the erigon `--dev.period 0` semantics this chunk targets.
+	AllowEmptyBlocks bool
+}
+
+// Sealer produces a block on demand from whatever is currently
+// pending; *BlockBuilder satisfies it via BuildBlock.
+type Sealer interface {
+	BuildBlock(parentHash [32]byte, blockNumber uint64, validator [32]byte) (*Block, error)
+}
+
+// TxSource is the mempool-facing half of Producer's dependencies: a
+// notification channel of newly-submitted transactions. pkg/mempool
+// has no source files in this tree yet (the same gap noted in
+// pkg/rpc's doc comment), so Producer depends on this narrow interface
+// rather than *mempool.Mempool directly; a Mempool.SubscribeNewTx()
+// returning <-chan *mempool.Transaction is a drop-in once it exists.
+type TxSource interface {
+	SubscribeNewTx() <-chan *mempool.Transaction
+}
+
+// Producer drives on-demand block production per ConsensusConfig. When
+// BlockTime is zero, no timer loop runs at all; blocks are sealed only
+// when SealNow is called, or when a transaction arrives on txSrc while
+// it's this validator's turn. When BlockTime is nonzero, a fixed
+// interval ticker fires alongside those two triggers, matching today's
+// always-on production.
+type Producer struct {
+	cfg    ConsensusConfig
+	sealer Sealer
+	txSrc  TxSource
+	log    *logger.Logger
+
+	onBlock func(*Block)
+
+	currentHeight uint64
+	parentHash    [32]byte
+
+	sealNow chan struct{}
+	stop    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewProducer builds a Producer that will seal block startHeight (with
+// parent startParentHash) first, then each height after that. txSrc may
+// be nil, disabling the new-transaction trigger entirely.
+func NewProducer(cfg ConsensusConfig, sealer Sealer, txSrc TxSource, startHeight uint64, startParentHash [32]byte, log *logger.Logger) *Producer {
+	return &Producer{
+		cfg:           cfg,
+		sealer:        sealer,
+		txSrc:         txSrc,
+		log:           log,
+		currentHeight: startHeight,
+		parentHash:    startParentHash,
+		sealNow:       make(chan struct{}, 1),
+		stop:          make(chan struct{}),
+	}
+}
+
+// SetNewBlockCallback registers fn to be called with every block
+// Producer seals — the same hook pkg/rpc's ConsensusSource.Subscribe
+// doc comment names as Engine.SetNewBlockCallback.
+func (p *Producer) SetNewBlockCallback(fn func(*Block)) {
+	p.onBlock = fn
+}
+
+// SealNow requests an on-demand seal attempt, for an RPC call like
+// consensus_sealBlock or a test driving production without a timer.
+// It's non-blocking: a seal request already pending coalesces with
+// this one rather than queuing.
+func (p *Producer) SealNow() {
+	select {
+	case p.sealNow <- struct{}{}:
+	default:
+	}
+}
+
+// isOurTurn reports whether this validator is the round-robin author
+// for blockNumber, mirroring RoundRobinEngine's turn-taking.
+func (p *Producer) isOurTurn(blockNumber uint64) bool {
+	if len(p.cfg.Validators) == 0 {
+		return false
+	}
+	return p.cfg.Validators[blockNumber%uint64(len(p.cfg.Validators))] == p.cfg.ValidatorKey
+}
+
+// Start launches the production loop in the background. It returns
+// immediately; call Stop to shut it down. Start is a no-op if this
+// node isn't configured as a validator.
+func (p *Producer) Start() error {
+	if !p.cfg.IsValidator {
+		return nil
+	}
+
+	var tickCh <-chan time.Time
+	if p.cfg.BlockTime > 0 {
+		ticker := time.NewTicker(p.cfg.BlockTime)
+		tickCh = ticker.C
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			defer ticker.Stop()
+			p.loop(tickCh)
+		}()
+		return nil
+	}
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.loop(nil)
+	}()
+	return nil
+}
+
+func (p *Producer) loop(tickCh <-chan time.Time) {
+	var txCh <-chan *mempool.Transaction
+	if p.txSrc != nil {
+		txCh = p.txSrc.SubscribeNewTx()
+	}
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-tickCh:
+			p.tryProduce()
+		case <-txCh:
+			if p.isOurTurn(p.currentHeight) {
+				p.tryProduce()
+			}
+		case <-p.sealNow:
+			p.tryProduce()
+		}
+	}
+}
+
+// tryProduce builds a candidate block and, unless it's empty with
+// AllowEmptyBlocks disabled, seals it and advances to the next height.
+// Building is side-effect free (it only reads pending transactions), so
+// discarding an empty candidate here costs nothing.
+func (p *Producer) tryProduce() {
+	block, err := p.sealer.BuildBlock(p.parentHash, p.currentHeight, p.cfg.ValidatorKey)
+	if err != nil {
+		p.log.WithError(err).Warn("Producer: failed to build candidate block")
+		return
+	}
+
+	if len(block.Transactions()) == 0 && !p.cfg.AllowEmptyBlocks {
+		p.log.WithField("block_number", p.currentHeight).Debug("Producer: skipping empty block, AllowEmptyBlocks is false")
+		return
+	}
+
+	p.currentHeight = block.Number() + 1
+	p.parentHash = block.Hash()
+
+	if p.onBlock != nil {
+		p.onBlock(block)
+	}
+}
+
+// Stop shuts down the production loop and waits for it to exit.
+func (p *Producer) Stop() {
+	close(p.stop)
+	p.wg.Wait()
+}
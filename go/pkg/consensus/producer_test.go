@@ -0,0 +1,170 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
+)
+
+// fakeSealer builds blocks directly via NewBlock, standing in for
+// *BlockBuilder so these tests don't need a real mempool or state
+// manager — only Producer's own scheduling logic is under test here.
+type fakeSealer struct {
+	pendingTxCount int
+}
+
+func (f *fakeSealer) BuildBlock(parentHash [32]byte, blockNumber uint64, validator [32]byte) (*Block, error) {
+	txs := make([]*mempool.Transaction, f.pendingTxCount)
+	for i := range txs {
+		txs[i] = &mempool.Transaction{}
+	}
+	return NewBlock(blockNumber, parentHash, validator, txs), nil
+}
+
+type fakeTxSource struct {
+	ch chan *mempool.Transaction
+}
+
+func newFakeTxSource() *fakeTxSource {
+	return &fakeTxSource{ch: make(chan *mempool.Transaction, 4)}
+}
+
+func (f *fakeTxSource) SubscribeNewTx() <-chan *mempool.Transaction { return f.ch }
+
+func (f *fakeTxSource) notify() { f.ch <- &mempool.Transaction{} }
+
+func awaitBlock(t *testing.T, produced <-chan *Block, timeout time.Duration) *Block {
+	t.Helper()
+	select {
+	case b := <-produced:
+		return b
+	case <-time.After(timeout):
+		t.Fatal("timed out waiting for a block to be produced")
+		return nil
+	}
+}
+
+func assertNoBlock(t *testing.T, produced <-chan *Block, wait time.Duration) {
+	t.Helper()
+	select {
+	case b := <-produced:
+		t.Fatalf("expected no block to be produced, got block %d", b.Number())
+	case <-time.After(wait):
+	}
+}
+
+func TestProducer_SealNowProducesBlockImmediately(t *testing.T) {
+	self := [32]byte{1}
+	cfg := ConsensusConfig{
+		BlockTime:        0,
+		Validators:       [][32]byte{self},
+		ValidatorKey:     self,
+		IsValidator:      true,
+		AllowEmptyBlocks: true,
+	}
+	p := NewProducer(cfg, &fakeSealer{}, nil, 0, [32]byte{}, logger.NewLogger("error"))
+
+	produced := make(chan *Block, 1)
+	p.SetNewBlockCallback(func(b *Block) { produced <- b })
+
+	if err := p.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer p.Stop()
+
+	p.SealNow()
+	block := awaitBlock(t, produced, time.Second)
+	if block.Number() != 0 {
+		t.Fatalf("block.Number() = %d, want 0", block.Number())
+	}
+
+	p.SealNow()
+	next := awaitBlock(t, produced, time.Second)
+	if next.Number() != 1 {
+		t.Fatalf("block.Number() = %d, want 1", next.Number())
+	}
+}
+
+func TestProducer_NoBlockProducedWhileMempoolEmptyAndEmptyBlocksDisallowed(t *testing.T) {
+	self := [32]byte{1}
+	cfg := ConsensusConfig{
+		BlockTime:        0,
+		Validators:       [][32]byte{self},
+		ValidatorKey:     self,
+		IsValidator:      true,
+		AllowEmptyBlocks: false,
+	}
+	p := NewProducer(cfg, &fakeSealer{pendingTxCount: 0}, nil, 0, [32]byte{}, logger.NewLogger("error"))
+
+	produced := make(chan *Block, 1)
+	p.SetNewBlockCallback(func(b *Block) { produced <- b })
+
+	if err := p.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer p.Stop()
+
+	p.SealNow()
+	assertNoBlock(t, produced, 200*time.Millisecond)
+}
+
+func TestProducer_WakesOnNewTransactionWhenItIsOurTurn(t *testing.T) {
+	self := [32]byte{1}
+	cfg := ConsensusConfig{
+		BlockTime:        0,
+		Validators:       [][32]byte{self},
+		ValidatorKey:     self,
+		IsValidator:      true,
+		AllowEmptyBlocks: false,
+	}
+	txSrc := newFakeTxSource()
+	p := NewProducer(cfg, &fakeSealer{pendingTxCount: 1}, txSrc, 0, [32]byte{}, logger.NewLogger("error"))
+
+	produced := make(chan *Block, 1)
+	p.SetNewBlockCallback(func(b *Block) { produced <- b })
+
+	if err := p.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer p.Stop()
+
+	txSrc.notify()
+	block := awaitBlock(t, produced, time.Second)
+	if len(block.Transactions()) != 1 {
+		t.Fatalf("len(block.Transactions()) = %d, want 1", len(block.Transactions()))
+	}
+}
+
+func TestProducer_IgnoresNewTransactionWhenItIsNotOurTurn(t *testing.T) {
+	self := [32]byte{1}
+	other := [32]byte{2}
+	cfg := ConsensusConfig{
+		BlockTime:        0,
+		Validators:       [][32]byte{other, self},
+		ValidatorKey:     self,
+		IsValidator:      true,
+		AllowEmptyBlocks: true,
+	}
+	txSrc := newFakeTxSource()
+	p := NewProducer(cfg, &fakeSealer{pendingTxCount: 1}, txSrc, 0, [32]byte{}, logger.NewLogger("error"))
+
+	produced := make(chan *Block, 1)
+	p.SetNewBlockCallback(func(b *Block) { produced <- b })
+
+	if err := p.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer p.Stop()
+
+	// At height 0 it's other's turn (validators[0%2] == other), so a
+	// new transaction must not wake production.
+	txSrc.notify()
+	assertNoBlock(t, produced, 200*time.Millisecond)
+
+	// An explicit SealNow is unconditional regardless of whose turn it
+	// is — only the transaction-triggered path is turn-gated.
+	p.SealNow()
+	awaitBlock(t, produced, time.Second)
+}
@@ -0,0 +1,79 @@
+package consensus
+
+import (
+	"fmt"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+// Builder is the subset of BlockBuilder that BlockPublisher needs.
+// Narrowing it to an interface lets tests substitute a fake builder
+// without a real mempool/state manager.
+type Builder interface {
+	BuildBlock(parentHash [32]byte, blockNumber uint64, validator [32]byte) (*Block, error)
+}
+
+// Processor is the subset of BlockProcessor that BlockPublisher needs to
+// commit an announced block locally. Narrowing it to an interface lets
+// tests substitute a slow/fake processor to exercise the
+// announce-before-commit ordering without a real state manager.
+type Processor interface {
+	Process(block *Block) (Receipts, uint64, error)
+}
+
+// BlockPublisher is the two-stage publish path for a validator's
+// produced blocks: announce to peers first, commit locally second.
+// Gossiping before committing means peers hear about a block the
+// instant it's signed rather than waiting on however long the local
+// state commit takes — the same ordering go-ethereum's miner uses
+// (NewMinedBlockEvent posted before InsertChain returns).
+//
+// If the local commit fails after the block was already announced,
+// Publish retracts it through the announcer so peers drop it instead of
+// building on a block this node never actually applied.
+type BlockPublisher struct {
+	builder   Builder
+	announcer *BlockAnnouncer
+	processor Processor
+	log       *logger.Logger
+}
+
+// NewBlockPublisher creates a BlockPublisher wiring builder, announcer,
+// and processor into the announce-then-commit pipeline.
+func NewBlockPublisher(builder Builder, announcer *BlockAnnouncer, processor Processor, log *logger.Logger) *BlockPublisher {
+	return &BlockPublisher{
+		builder:   builder,
+		announcer: announcer,
+		processor: processor,
+		log:       log,
+	}
+}
+
+// Publish builds a new block atop parentHash, announces it to peers, and
+// only then commits it locally. It returns the sealed block on success;
+// on a failed local commit, the block has already been retracted via the
+// announcer and the error describes why.
+func (p *BlockPublisher) Publish(parentHash [32]byte, blockNumber uint64, validator [32]byte) (*Block, error) {
+	block, err := p.builder.BuildBlock(parentHash, blockNumber, validator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build block %d: %w", blockNumber, err)
+	}
+
+	if err := p.announcer.Announce(block); err != nil {
+		return nil, fmt.Errorf("failed to announce block %d: %w", blockNumber, err)
+	}
+
+	if _, _, err := p.processor.Process(block); err != nil {
+		p.announcer.Retract(block, err)
+		return nil, fmt.Errorf("failed to commit block %d locally after announcing: %w", blockNumber, err)
+	}
+
+	if p.log != nil {
+		p.log.WithFields(logger.Fields{
+			"block_number": block.Number(),
+			"block_hash":   fmt.Sprintf("%x", block.Hash()[:8]),
+		}).Info("Published block: announced to peers and committed locally")
+	}
+
+	return block, nil
+}
@@ -0,0 +1,160 @@
+package consensus
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+// recordingBroadcaster is a Broadcaster that records the order in which
+// blocks are announced.
+type recordingBroadcaster struct {
+	mu        sync.Mutex
+	announced []*Block
+	err       error
+}
+
+func (b *recordingBroadcaster) BroadcastConsensusBlock(block *Block) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err != nil {
+		return b.err
+	}
+	b.announced = append(b.announced, block)
+	return nil
+}
+
+// slowProcessor is a Processor whose Process call blocks until release
+// is closed, so tests can assert that announcement happens before this
+// returns.
+type slowProcessor struct {
+	release chan struct{}
+	err     error
+}
+
+func (p *slowProcessor) Process(block *Block) (Receipts, uint64, error) {
+	<-p.release
+	if p.err != nil {
+		return nil, 0, p.err
+	}
+	return Receipts{}, 0, nil
+}
+
+// fakeBuilder always returns a fixed block regardless of arguments.
+type fakeBuilder struct {
+	block *Block
+	err   error
+}
+
+func (f *fakeBuilder) BuildBlock(parentHash [32]byte, blockNumber uint64, validator [32]byte) (*Block, error) {
+	return f.block, f.err
+}
+
+func TestBlockPublisher_AnnouncesBeforeSlowLocalCommit(t *testing.T) {
+	var validator [32]byte
+	validator[0] = 9
+	block := NewGenesisBlock(validator)
+
+	broadcaster := &recordingBroadcaster{}
+	announcer := NewBlockAnnouncer(broadcaster, nil, nil, logger.NewLogger("error"))
+	processor := &slowProcessor{release: make(chan struct{})}
+	publisher := NewBlockPublisher(&fakeBuilder{block: block}, announcer, processor, logger.NewLogger("error"))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := publisher.Publish([32]byte{}, block.Number(), validator)
+		done <- err
+	}()
+
+	// Give Publish time to reach the (blocked) local commit. If
+	// announcement happened only after commit, broadcaster.announced
+	// would still be empty at this point too — the real assertion is
+	// the ordering below, this just bounds how long the test waits.
+	time.Sleep(20 * time.Millisecond)
+
+	broadcaster.mu.Lock()
+	announcedBeforeCommit := len(broadcaster.announced)
+	broadcaster.mu.Unlock()
+
+	if announcedBeforeCommit != 1 {
+		t.Fatalf("expected the block to be announced to peers before the slow local commit finished, got %d announcements", announcedBeforeCommit)
+	}
+
+	close(processor.release)
+	if err := <-done; err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+}
+
+func TestBlockPublisher_RetractsOnFailedCommit(t *testing.T) {
+	var validator [32]byte
+	validator[0] = 10
+	block := NewGenesisBlock(validator)
+
+	broadcaster := &recordingBroadcaster{}
+	var retracted []BlockRetractedEvent
+	announcer := NewBlockAnnouncer(broadcaster, nil, func(e BlockRetractedEvent) {
+		retracted = append(retracted, e)
+	}, logger.NewLogger("error"))
+
+	processor := &slowProcessor{release: make(chan struct{})}
+	close(processor.release) // don't block commit for this test
+	processor.err = fmt.Errorf("state commit failed")
+
+	publisher := NewBlockPublisher(&fakeBuilder{block: block}, announcer, processor, logger.NewLogger("error"))
+
+	if _, err := publisher.Publish([32]byte{}, block.Number(), validator); err == nil {
+		t.Fatal("expected Publish to fail when the local commit fails")
+	}
+
+	if len(retracted) != 1 || retracted[0].Block.Hash() != block.Hash() {
+		t.Fatalf("expected one retraction event for the announced block, got %+v", retracted)
+	}
+}
+
+func TestBlockAnnouncer_DetectsEquivocation(t *testing.T) {
+	var validator [32]byte
+	validator[0] = 11
+
+	first := NewBlock(1, [32]byte{}, validator, nil)
+	second := first.WithBody() // same number/validator, different ExtraData-free body is identical... force a different hash via ExtraData
+	h := second.Header()
+	h.ExtraData = []byte("distinct")
+	second = second.WithSeal(h)
+
+	if first.Hash() == second.Hash() {
+		t.Fatal("test setup bug: first and second must hash differently")
+	}
+
+	broadcaster := &recordingBroadcaster{}
+	var events []EquivocationEvent
+	announcer := NewBlockAnnouncer(broadcaster, func(e EquivocationEvent) {
+		events = append(events, e)
+	}, nil, logger.NewLogger("error"))
+
+	if err := announcer.Announce(first); err != nil {
+		t.Fatalf("Announce(first): %v", err)
+	}
+	if err := announcer.Announce(second); err == nil {
+		t.Fatal("expected Announce(second) to be rejected as equivocation")
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("expected one equivocation event, got %d", len(events))
+	}
+	if events[0].Validator != validator || events[0].BlockNumber != 1 {
+		t.Fatalf("unexpected equivocation event: %+v", events[0])
+	}
+	if events[0].First.Hash() != first.Hash() || events[0].Second.Hash() != second.Hash() {
+		t.Fatalf("equivocation event did not carry both conflicting blocks: %+v", events[0])
+	}
+
+	broadcaster.mu.Lock()
+	defer broadcaster.mu.Unlock()
+	if len(broadcaster.announced) != 1 {
+		t.Fatalf("expected only the first block to be broadcast, got %d broadcasts", len(broadcaster.announced))
+	}
+}
@@ -0,0 +1,105 @@
+package consensus
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+var (
+	errNilVerifyInput   = errors.New("problem, solution, and budget cannot be nil")
+	errEmptyVerifyInput = errors.New("problem and solution must have at least one element")
+	errBudgetExceeded   = errors.New("solution exceeds verification op budget")
+)
+
+// pureGoBackend implements CryptoBackend without CGO, using
+// crypto/sha256 and a canonical byte encoding of BlockHeader that
+// mirrors the field layout the Rust implementation hashes over. It
+// lets the rest of the codebase (and pure-Go CI jobs) build and run
+// without the Rust core library, at the cost of needing its own parity
+// test (see backend_parity_test.go) against the CGO backend.
+//
+// It has no build tag: the cgo build selects the Rust backend in
+// newPlatformBackend (backend_cgo.go) but still compiles this type in
+// so backend_parity_test.go can compare the two directly.
+type pureGoBackend struct{}
+
+func (pureGoBackend) SHA256(data []byte) [32]byte {
+	return sha256.Sum256(data)
+}
+
+// MerkleRoot computes a binary Merkle root: empty input hashes to the
+// all-zero hash, a single leaf is its own root, and an odd node at any
+// level is duplicated and hashed with itself.
+func (b pureGoBackend) MerkleRoot(txHashes [][32]byte) [32]byte {
+	if len(txHashes) == 0 {
+		return [32]byte{}
+	}
+	if len(txHashes) == 1 {
+		return txHashes[0]
+	}
+
+	level := make([][32]byte, len(txHashes))
+	copy(level, txHashes)
+
+	for len(level) > 1 {
+		next := make([][32]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			left := level[i]
+			right := left
+			if i+1 < len(level) {
+				right = level[i+1]
+			}
+			next = append(next, b.SHA256(append(left[:], right[:]...)))
+		}
+		level = next
+	}
+
+	return level[0]
+}
+
+// HeaderHash hashes the canonical encoding of header via
+// (*BlockHeader).HashCanonical, so the pure-Go backend and anyone
+// calling MarshalCanonical directly always agree.
+func (b pureGoBackend) HeaderHash(header *BlockHeader) [32]byte {
+	hash, err := header.HashCanonical()
+	if err != nil {
+		// header_codec.go's MarshalCanonical only rejects a nil header
+		// or an unsupported CodecVersion, neither of which a backend
+		// caller is expected to hand us.
+		panic(fmt.Sprintf("pure-go backend: HeaderHash failed: %v", err))
+	}
+	return hash
+}
+
+// VerifySubsetSum verifies that the elements at solution.Indices sum to
+// problem.Target, subject to the op budget. It trusts the caller's
+// budget.MaxOps as a hard cap on the number of indices it will examine,
+// matching the Rust implementation's resource-limited verification.
+func (pureGoBackend) VerifySubsetSum(problem *SubsetSumProblem, solution *SubsetSumSolution, budget *VerifyBudget) (bool, error) {
+	if problem == nil || solution == nil || budget == nil {
+		return false, errNilVerifyInput
+	}
+	if len(problem.Elements) == 0 || len(solution.Indices) == 0 {
+		return false, errEmptyVerifyInput
+	}
+	if budget.MaxOps > 0 && uint32(len(solution.Indices)) > budget.MaxOps {
+		return false, errBudgetExceeded
+	}
+
+	var sum int64
+	seen := make(map[uint32]bool, len(solution.Indices))
+	for _, idx := range solution.Indices {
+		if seen[idx] {
+			return false, nil
+		}
+		seen[idx] = true
+
+		if idx >= uint32(len(problem.Elements)) {
+			return false, nil
+		}
+		sum += problem.Elements[idx]
+	}
+
+	return sum == problem.Target, nil
+}
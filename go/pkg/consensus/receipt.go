@@ -0,0 +1,186 @@
+package consensus
+
+import "fmt"
+
+// ReceiptStatus records whether a transaction succeeded or failed during
+// block execution.
+type ReceiptStatus uint8
+
+const (
+	ReceiptStatusFailed ReceiptStatus = iota
+	ReceiptStatusSuccess
+)
+
+// Log is one event a transaction emitted: the contract address it came
+// from, its indexed topics, and its opaque data payload. Nothing in this
+// tree emits logs yet (there is no contract-call transaction type), but
+// Receipt, the bloom filter, and GetLogsByBlock's filtering are all
+// already shaped around it so that lands as a pure addition later.
+type Log struct {
+	Address [32]byte
+	Topics  [][32]byte
+	Data    []byte
+}
+
+// Receipt records the outcome of executing a single transaction within a
+// block: whether it succeeded, how much gas it used, the address of any
+// contract it created, the logs it emitted, a bloom filter over those
+// logs, and the state root immediately after it was applied.
+type Receipt struct {
+	TxHash            [32]byte
+	Status            ReceiptStatus
+	GasUsed           uint64
+	CumulativeGasUsed uint64
+	ContractAddress   [32]byte // zero unless the transaction created a contract
+	Logs              []Log
+	LogsBloom         Bloom
+	PostStateRoot     [32]byte
+}
+
+// NewReceipt builds a Receipt with LogsBloom already derived from logs,
+// so callers (BlockBuilder.applyTransaction, BlockProcessor.Process)
+// never need to remember to compute it themselves.
+func NewReceipt(txHash [32]byte, status ReceiptStatus, gasUsed, cumulativeGasUsed uint64, contractAddress [32]byte, logs []Log) *Receipt {
+	return &Receipt{
+		TxHash:            txHash,
+		Status:            status,
+		GasUsed:           gasUsed,
+		CumulativeGasUsed: cumulativeGasUsed,
+		ContractAddress:   contractAddress,
+		Logs:              logs,
+		LogsBloom:         NewBloom(logs),
+	}
+}
+
+// Receipts is a block's receipts, in transaction order.
+type Receipts []*Receipt
+
+// ComputeReceiptsRoot computes a Merkle root over receipts, the same way
+// computeTxRootFromTransactions computes one over transactions: hash each
+// receipt's canonical encoding, then combine via the active
+// CryptoBackend's MerkleRoot.
+func ComputeReceiptsRoot(receipts Receipts) [32]byte {
+	hashes := make([][32]byte, len(receipts))
+	for i, r := range receipts {
+		hashes[i] = r.hash()
+	}
+	return DefaultBackend().MerkleRoot(hashes)
+}
+
+// ComputeBlockBloom ORs together every receipt's LogsBloom into the
+// single aggregate bloom a block header commits to, so a light client
+// can rule out "no log in this block matches" without fetching any
+// individual receipt.
+func ComputeBlockBloom(receipts Receipts) Bloom {
+	var agg Bloom
+	for _, r := range receipts {
+		agg = agg.OrWith(r.LogsBloom)
+	}
+	return agg
+}
+
+// hash returns the canonical hash of a single receipt, combining its
+// fields in the same fixed-order, length-prefixed style header_codec.go
+// uses for BlockHeader.
+func (r *Receipt) hash() [32]byte {
+	buf := make([]byte, 0, 32+1+8+8+32+4+len(r.PostStateRoot)+len(r.LogsBloom))
+	buf = append(buf, r.TxHash[:]...)
+	buf = append(buf, byte(r.Status))
+	buf = appendUint64(buf, r.GasUsed)
+	buf = appendUint64(buf, r.CumulativeGasUsed)
+	buf = append(buf, r.ContractAddress[:]...)
+	buf = appendUint32(buf, uint32(len(r.Logs)))
+	for _, log := range r.Logs {
+		buf = append(buf, log.Address[:]...)
+		buf = appendUint32(buf, uint32(len(log.Topics)))
+		for _, topic := range log.Topics {
+			buf = append(buf, topic[:]...)
+		}
+		buf = appendUint32(buf, uint32(len(log.Data)))
+		buf = append(buf, log.Data...)
+	}
+	buf = append(buf, r.LogsBloom[:]...)
+	buf = append(buf, r.PostStateRoot[:]...)
+	return DefaultBackend().SHA256(buf)
+}
+
+// Encode serializes r in the same fixed-field-order, length-prefixed
+// style hash() hashes over, so ReceiptStore can persist it through
+// state.StateManager's opaque Data column and reconstruct an identical
+// Receipt later via DecodeReceipt.
+func (r *Receipt) Encode() []byte {
+	buf := make([]byte, 0, 32+1+8+8+32+4+len(r.LogsBloom)+len(r.PostStateRoot))
+	buf = append(buf, r.TxHash[:]...)
+	buf = append(buf, byte(r.Status))
+	buf = appendUint64(buf, r.GasUsed)
+	buf = appendUint64(buf, r.CumulativeGasUsed)
+	buf = append(buf, r.ContractAddress[:]...)
+	buf = appendUint32(buf, uint32(len(r.Logs)))
+	for _, log := range r.Logs {
+		buf = append(buf, log.Address[:]...)
+		buf = appendUint32(buf, uint32(len(log.Topics)))
+		for _, topic := range log.Topics {
+			buf = append(buf, topic[:]...)
+		}
+		buf = appendUint32(buf, uint32(len(log.Data)))
+		buf = append(buf, log.Data...)
+	}
+	buf = append(buf, r.LogsBloom[:]...)
+	buf = append(buf, r.PostStateRoot[:]...)
+	return buf
+}
+
+// DecodeReceipt is Encode's inverse.
+func DecodeReceipt(data []byte) (*Receipt, error) {
+	const minSize = 32 + 1 + 8 + 8 + 32 + 4 + 256 + 32
+	if len(data) < minSize {
+		return nil, fmt.Errorf("receipt data too short: got %d bytes, need at least %d", len(data), minSize)
+	}
+
+	r := &Receipt{}
+	off := 0
+	off += copy(r.TxHash[:], data[off:off+32])
+	r.Status = ReceiptStatus(data[off])
+	off++
+	r.GasUsed, off = readUint64(data, off)
+	r.CumulativeGasUsed, off = readUint64(data, off)
+	off += copy(r.ContractAddress[:], data[off:off+32])
+
+	logCount, off := readUint32(data, off)
+	r.Logs = make([]Log, logCount)
+	for i := range r.Logs {
+		var log Log
+		if off+32 > len(data) {
+			return nil, fmt.Errorf("receipt data truncated reading log %d address", i)
+		}
+		off += copy(log.Address[:], data[off:off+32])
+
+		var topicCount uint32
+		topicCount, off = readUint32(data, off)
+		log.Topics = make([][32]byte, topicCount)
+		for j := range log.Topics {
+			if off+32 > len(data) {
+				return nil, fmt.Errorf("receipt data truncated reading log %d topic %d", i, j)
+			}
+			off += copy(log.Topics[j][:], data[off:off+32])
+		}
+
+		var dataLen uint32
+		dataLen, off = readUint32(data, off)
+		if off+int(dataLen) > len(data) {
+			return nil, fmt.Errorf("receipt data truncated reading log %d data", i)
+		}
+		log.Data = append([]byte(nil), data[off:off+int(dataLen)]...)
+		off += int(dataLen)
+
+		r.Logs[i] = log
+	}
+
+	if off+len(r.LogsBloom)+len(r.PostStateRoot) > len(data) {
+		return nil, fmt.Errorf("receipt data truncated reading bloom/state root")
+	}
+	off += copy(r.LogsBloom[:], data[off:off+len(r.LogsBloom)])
+	off += copy(r.PostStateRoot[:], data[off:off+32])
+
+	return r, nil
+}
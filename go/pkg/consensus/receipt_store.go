@@ -0,0 +1,83 @@
+package consensus
+
+import (
+	"fmt"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
+)
+
+// ReceiptStore persists Receipts through a state.StateManager and serves
+// the two lookups an eth_getTransactionReceipt/eth_getLogs-equivalent
+// JSON-RPC method needs: by transaction hash, and by block with
+// address/topic filtering. state.StateManager only ever sees opaque,
+// already-encoded receipt bytes (see Receipt.Encode/DecodeReceipt) so
+// that pkg/state never needs to import pkg/consensus back.
+type ReceiptStore struct {
+	sm *state.StateManager
+}
+
+// NewReceiptStore wraps sm for receipt persistence and lookup.
+func NewReceiptStore(sm *state.StateManager) *ReceiptStore {
+	return &ReceiptStore{sm: sm}
+}
+
+// PutReceipts persists every receipt produced for blockNumber, in order.
+func (rs *ReceiptStore) PutReceipts(blockNumber uint64, receipts Receipts) error {
+	for i, r := range receipts {
+		err := rs.sm.PutReceipt(state.ReceiptRecord{
+			TxHash:      r.TxHash,
+			BlockNumber: blockNumber,
+			LogsBloom:   [256]byte(r.LogsBloom),
+			Data:        r.Encode(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to persist receipt %d (tx %x): %w", i, r.TxHash[:8], err)
+		}
+	}
+	return nil
+}
+
+// GetReceipt looks up a single receipt by transaction hash.
+func (rs *ReceiptStore) GetReceipt(txHash [32]byte) (*Receipt, error) {
+	record, err := rs.sm.GetReceipt(txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load receipt %x: %w", txHash[:8], err)
+	}
+
+	receipt, err := DecodeReceipt(record.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode receipt %x: %w", txHash[:8], err)
+	}
+	return receipt, nil
+}
+
+// GetLogsByBlock returns every log in blockNumber's receipts that
+// matches filter. Each receipt's persisted LogsBloom is checked against
+// filter first (MatchesBloom) so a receipt with no chance of matching is
+// never even decoded; only receipts that pass the bloom precheck are
+// decoded and scanned log-by-log for an exact match (Matches).
+func (rs *ReceiptStore) GetLogsByBlock(blockNumber uint64, filter LogFilter) ([]Log, error) {
+	records, err := rs.sm.GetReceiptsByBlock(blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load receipts for block %d: %w", blockNumber, err)
+	}
+
+	var matches []Log
+	for _, record := range records {
+		if !filter.MatchesBloom(Bloom(record.LogsBloom)) {
+			continue
+		}
+
+		receipt, err := DecodeReceipt(record.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode receipt %x: %w", record.TxHash[:8], err)
+		}
+
+		for _, log := range receipt.Logs {
+			if filter.Matches(log) {
+				matches = append(matches, log)
+			}
+		}
+	}
+	return matches, nil
+}
@@ -0,0 +1,60 @@
+package consensus
+
+import "testing"
+
+func TestReceipt_EncodeDecodeRoundTrip(t *testing.T) {
+	original := NewReceipt(
+		addr(1), ReceiptStatusSuccess, 21000, 21000, addr(2),
+		[]Log{
+			{Address: addr(3), Topics: [][32]byte{addr(4), addr(5)}, Data: []byte("payload")},
+		},
+	)
+	original.PostStateRoot = addr(6)
+
+	decoded, err := DecodeReceipt(original.Encode())
+	if err != nil {
+		t.Fatalf("DecodeReceipt failed: %v", err)
+	}
+
+	if decoded.TxHash != original.TxHash {
+		t.Errorf("TxHash mismatch: got %x, want %x", decoded.TxHash, original.TxHash)
+	}
+	if decoded.Status != original.Status {
+		t.Errorf("Status mismatch: got %v, want %v", decoded.Status, original.Status)
+	}
+	if decoded.ContractAddress != original.ContractAddress {
+		t.Errorf("ContractAddress mismatch: got %x, want %x", decoded.ContractAddress, original.ContractAddress)
+	}
+	if len(decoded.Logs) != 1 || decoded.Logs[0].Address != addr(3) {
+		t.Fatalf("unexpected decoded logs: %+v", decoded.Logs)
+	}
+	if len(decoded.Logs[0].Topics) != 2 {
+		t.Fatalf("expected 2 decoded topics, got %d", len(decoded.Logs[0].Topics))
+	}
+	if string(decoded.Logs[0].Data) != "payload" {
+		t.Errorf("expected decoded log data %q, got %q", "payload", decoded.Logs[0].Data)
+	}
+	if decoded.LogsBloom != original.LogsBloom {
+		t.Errorf("LogsBloom mismatch after round trip")
+	}
+	if decoded.PostStateRoot != original.PostStateRoot {
+		t.Errorf("PostStateRoot mismatch after round trip")
+	}
+}
+
+func TestDecodeReceipt_RejectsTooShortData(t *testing.T) {
+	if _, err := DecodeReceipt([]byte{1, 2, 3}); err == nil {
+		t.Errorf("expected an error decoding truncated receipt data")
+	}
+}
+
+func TestComputeBlockBloom_UnionsReceiptBlooms(t *testing.T) {
+	r1 := NewReceipt(addr(1), ReceiptStatusSuccess, 0, 0, [32]byte{}, []Log{{Address: addr(10)}})
+	r2 := NewReceipt(addr(2), ReceiptStatusSuccess, 0, 0, [32]byte{}, []Log{{Address: addr(20)}})
+
+	blockBloom := ComputeBlockBloom(Receipts{r1, r2})
+
+	if !blockBloom.Test(addr(10)[:]) || !blockBloom.Test(addr(20)[:]) {
+		t.Errorf("expected block bloom to cover both receipts' log addresses")
+	}
+}
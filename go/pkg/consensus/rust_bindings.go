@@ -1,3 +1,6 @@
+//go:build cgo
+// +build cgo
+
 // Package consensus provides Go bindings to Rust consensus-critical functions via CGO.
 //
 // This package delegates all consensus operations to the Rust core library,
@@ -94,6 +97,7 @@ type BlockHeader struct {
 	MerkleRoot       [32]byte
 	MinerAddress     [32]byte
 	Commitment       [32]byte
+	DataRoot         [32]byte // root of the app-level data sub-trie; see ComputeCombinedRoot
 	DifficultyTarget uint32
 	Nonce            uint64
 	ExtraData        []byte
@@ -124,6 +128,7 @@ func ComputeHeaderHash(header *BlockHeader) ([32]byte, error) {
 	copy(cHeader.merkle_root[:], header.MerkleRoot[:])
 	copy(cHeader.miner_address[:], header.MinerAddress[:])
 	copy(cHeader.commitment[:], header.Commitment[:])
+	copy(cHeader.data_root[:], header.DataRoot[:])
 
 	// Handle extra data (optional)
 	if len(header.ExtraData) > 0 {
@@ -178,6 +183,73 @@ func ComputeMerkleRoot(txHashes [][32]byte) ([32]byte, error) {
 	return root, nil
 }
 
+// ==================== MERKLE PROOF ====================
+
+// maxMerkleProofDepth bounds how many sibling hashes ComputeMerkleProofRust
+// will read back from Rust: 64 levels covers any tree up to 2^64
+// leaves, far beyond what a block's transaction count can reach.
+const maxMerkleProofDepth = 64
+
+// ComputeMerkleProofRust computes the inclusion proof for
+// txHashes[index] via the Rust FFI, using the same tree layout
+// (duplicate-last-odd, pairwise hashing) as ComputeMerkleRoot, so a
+// proof built here verifies against a root ComputeMerkleRoot produced
+// for the same txHashes. The Go-only equivalent is ComputeMerkleProof
+// in merkle_proof_wire.go, which pure-Go callers (non-CGO builds) use
+// instead.
+func ComputeMerkleProofRust(txHashes [][32]byte, index uint32) (MerkleProof, error) {
+	if index >= uint32(len(txHashes)) {
+		return MerkleProof{}, fmt.Errorf("index %d out of range for %d leaves", index, len(txHashes))
+	}
+
+	var siblingsBuf [maxMerkleProofDepth][32]byte
+	var siblingsLen C.uint32_t
+
+	result := C.coinjecture_compute_merkle_proof(
+		(*[32]C.uint8_t)(unsafe.Pointer(&txHashes[0])),
+		C.uint32_t(len(txHashes)),
+		C.uint32_t(index),
+		(*[32]C.uint8_t)(unsafe.Pointer(&siblingsBuf[0])),
+		C.uint32_t(maxMerkleProofDepth),
+		&siblingsLen,
+	)
+	if result != C.COINJ_OK {
+		return MerkleProof{}, fmt.Errorf("Rust Merkle proof failed: %s", ResultCode(result))
+	}
+
+	siblings := make([][32]byte, int(siblingsLen))
+	copy(siblings, siblingsBuf[:siblingsLen])
+	return MerkleProof{Index: index, Siblings: siblings}, nil
+}
+
+// VerifyMerkleProofRust checks proof against leaf and root via the Rust
+// FFI, so a client verifying a proof computed by ComputeMerkleProofRust gets
+// byte-for-byte identical tree-hashing behavior to the backend that
+// produced it. The Go-only equivalent is VerifyInclusionProof in
+// merkle_proof_wire.go, which pure-Go callers (non-CGO builds) use
+// instead.
+func VerifyMerkleProofRust(root [32]byte, leaf [32]byte, proof MerkleProof) (bool, error) {
+	if len(proof.Siblings) == 0 {
+		return leaf == root, nil
+	}
+
+	result := C.coinjecture_verify_merkle_proof(
+		(*C.uint8_t)(unsafe.Pointer(&root[0])),
+		(*C.uint8_t)(unsafe.Pointer(&leaf[0])),
+		(*[32]C.uint8_t)(unsafe.Pointer(&proof.Siblings[0])),
+		C.uint32_t(len(proof.Siblings)),
+		C.uint32_t(proof.Index),
+	)
+	switch result {
+	case C.COINJ_OK:
+		return true, nil
+	case C.COINJ_ERR_VERIFICATION_FAILED:
+		return false, nil
+	default:
+		return false, fmt.Errorf("Rust Merkle proof verification failed: %s", ResultCode(result))
+	}
+}
+
 // ==================== SUBSET SUM VERIFICATION ====================
 
 // HardwareTier represents computational capacity categories
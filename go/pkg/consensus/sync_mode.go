@@ -0,0 +1,24 @@
+package consensus
+
+// SyncMode selects how a node is currently catching up the chain.
+//
+// No block-production driver in this tree (Producer included) has a
+// SetSyncMode method to attach this to; it's defined here so
+// pkg/blocksync and a driver can agree on the same two modes rather than
+// each inventing its own.
+type SyncMode int
+
+const (
+	// SyncModeConsensus is the normal steady-state mode: blocks are
+	// applied one at a time as they arrive from gossip or
+	// SyncManager's headers-first catch-up, each going through the full
+	// validator/production pipeline.
+	SyncModeConsensus SyncMode = iota
+
+	// SyncModeFast is used while a node is far enough behind that
+	// pkg/blocksync.BlockPool is driving catch-up: blocks are fetched
+	// and applied in bulk from whichever peers respond, and anything
+	// that only matters for block production (timers, isOurTurn checks)
+	// should be skipped until the node is back to SyncModeConsensus.
+	SyncModeFast
+)
@@ -0,0 +1,316 @@
+// Package wal is an append-only, crash-recoverable log of
+// consensus-relevant events (received block, produced block,
+// fork-choice switch, timer tick, validator turn transition), modeled
+// on Tendermint's consensus WAL: every record is length-prefixed and
+// CRC32-checked, segments rotate at a configurable size, and replay
+// skips forward to a caller-supplied height marker before feeding
+// records back through a handler.
+//
+// It is deliberately standalone (os/bufio/hash only, no dependency on
+// consensus.Engine) the same way pkg/consensus/mmr and
+// pkg/consensus/goldenvec stay decoupled from the packages that would
+// consume them — nothing in this tree, Producer included, calls
+// Append or Replay, following the precedent set in clock.go and
+// engine.go. Wiring Writer.Append into a driver's event handlers and
+// Replay into its startup path is left to that driver.
+package wal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// EventKind is the kind of consensus event a Record carries.
+type EventKind uint8
+
+const (
+	EventReceivedBlock EventKind = iota
+	EventProducedBlock
+	EventForkChoiceSwitch
+	EventTimerTick
+	EventValidatorTurnTransition
+	// EventVoteSigned records a bft.Vote this validator signed, so
+	// bft.SignGuard can replay its own signing history on restart
+	// without a WAL event kind of its own. It's named here rather than
+	// in package bft so every WAL record's EventKind stays in one enum.
+	EventVoteSigned
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventReceivedBlock:
+		return "received_block"
+	case EventProducedBlock:
+		return "produced_block"
+	case EventForkChoiceSwitch:
+		return "fork_choice_switch"
+	case EventTimerTick:
+		return "timer_tick"
+	case EventValidatorTurnTransition:
+		return "validator_turn_transition"
+	case EventVoteSigned:
+		return "vote_signed"
+	default:
+		return "unknown"
+	}
+}
+
+// frameVersion is bumped whenever a record's on-disk layout changes, so
+// Replay can tell an old-format log apart from a corrupted one instead
+// of misreading it.
+const frameVersion uint8 = 1
+
+// recordHeaderLen is the fixed-size portion of an encoded record:
+// version(1) + kind(1) + height(8) + payload length(4).
+const recordHeaderLen = 1 + 1 + 8 + 4
+
+// crc32Len is the trailing checksum's size, covering the header and
+// payload but not itself.
+const crc32Len = 4
+
+// Record is one decoded WAL entry.
+type Record struct {
+	Kind    EventKind
+	Height  uint64
+	Payload []byte
+}
+
+// encodeRecord serializes r into a self-contained, checksummed frame.
+func encodeRecord(r Record) []byte {
+	buf := make([]byte, recordHeaderLen+len(r.Payload)+crc32Len)
+	buf[0] = frameVersion
+	buf[1] = byte(r.Kind)
+	binary.BigEndian.PutUint64(buf[2:10], r.Height)
+	binary.BigEndian.PutUint32(buf[10:14], uint32(len(r.Payload)))
+	copy(buf[recordHeaderLen:], r.Payload)
+
+	sum := crc32.ChecksumIEEE(buf[:recordHeaderLen+len(r.Payload)])
+	binary.BigEndian.PutUint32(buf[recordHeaderLen+len(r.Payload):], sum)
+	return buf
+}
+
+// decodeRecord parses one frame out of buf, returning the Record and
+// the number of bytes it consumed. It returns an error if buf is too
+// short to hold a complete frame, carries an unrecognized
+// frameVersion, or fails its CRC32 check — all three are treated
+// identically by Replay, as the signature of a torn write.
+func decodeRecord(buf []byte) (Record, int, error) {
+	if len(buf) < recordHeaderLen+crc32Len {
+		return Record{}, 0, fmt.Errorf("wal: truncated record header")
+	}
+	if buf[0] != frameVersion {
+		return Record{}, 0, fmt.Errorf("wal: unsupported frame version %d", buf[0])
+	}
+
+	payloadLen := int(binary.BigEndian.Uint32(buf[10:14]))
+	total := recordHeaderLen + payloadLen + crc32Len
+	if len(buf) < total {
+		return Record{}, 0, fmt.Errorf("wal: truncated record payload")
+	}
+
+	wantSum := binary.BigEndian.Uint32(buf[recordHeaderLen+payloadLen : total])
+	gotSum := crc32.ChecksumIEEE(buf[:recordHeaderLen+payloadLen])
+	if wantSum != gotSum {
+		return Record{}, 0, fmt.Errorf("wal: CRC32 mismatch (corrupt or torn record)")
+	}
+
+	record := Record{
+		Kind:    EventKind(buf[1]),
+		Height:  binary.BigEndian.Uint64(buf[2:10]),
+		Payload: append([]byte(nil), buf[recordHeaderLen:recordHeaderLen+payloadLen]...),
+	}
+	return record, total, nil
+}
+
+// segmentName returns the filename for dir's nth segment (0-indexed),
+// sortable lexically in the same order as numerically.
+func segmentName(n int) string {
+	return fmt.Sprintf("wal-%08d.log", n)
+}
+
+// segments returns dir's existing segment indices in ascending order.
+func segments(dir string) ([]int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var indices []int
+	for _, entry := range entries {
+		var n int
+		if _, err := fmt.Sscanf(entry.Name(), "wal-%08d.log", &n); err == nil {
+			indices = append(indices, n)
+		}
+	}
+	sort.Ints(indices)
+	return indices, nil
+}
+
+// Writer appends Records to a directory of rotating segment files.
+type Writer struct {
+	dir             string
+	maxSegmentBytes int64
+
+	file    *os.File
+	writer  *bufio.Writer
+	segment int
+	written int64
+}
+
+// OpenWriter opens (creating if necessary) a Writer appending into
+// dir, rotating to a new segment once the current one reaches
+// maxSegmentBytes. maxSegmentBytes <= 0 disables rotation entirely.
+func OpenWriter(dir string, maxSegmentBytes int64) (*Writer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("wal: failed to create directory %s: %w", dir, err)
+	}
+
+	existing, err := segments(dir)
+	if err != nil {
+		return nil, fmt.Errorf("wal: failed to list existing segments: %w", err)
+	}
+
+	segment := 0
+	if len(existing) > 0 {
+		segment = existing[len(existing)-1]
+	}
+
+	w := &Writer{dir: dir, maxSegmentBytes: maxSegmentBytes, segment: segment}
+	if err := w.openSegment(segment); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *Writer) openSegment(segment int) error {
+	path := filepath.Join(w.dir, segmentName(segment))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("wal: failed to open segment %s: %w", path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("wal: failed to stat segment %s: %w", path, err)
+	}
+
+	w.file = file
+	w.writer = bufio.NewWriter(file)
+	w.segment = segment
+	w.written = info.Size()
+	return nil
+}
+
+// Append writes one Record for height to the current segment, rotating
+// to a fresh segment first if the current one has reached
+// maxSegmentBytes.
+func (w *Writer) Append(kind EventKind, height uint64, payload []byte) error {
+	frame := encodeRecord(Record{Kind: kind, Height: height, Payload: payload})
+
+	if w.maxSegmentBytes > 0 && w.written > 0 && w.written+int64(len(frame)) > w.maxSegmentBytes {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := w.writer.Write(frame)
+	if err != nil {
+		return fmt.Errorf("wal: failed to append record: %w", err)
+	}
+	if err := w.writer.Flush(); err != nil {
+		return fmt.Errorf("wal: failed to flush record: %w", err)
+	}
+	w.written += int64(n)
+	return nil
+}
+
+func (w *Writer) rotate() error {
+	if err := w.writer.Flush(); err != nil {
+		return fmt.Errorf("wal: failed to flush before rotating: %w", err)
+	}
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("wal: failed to close segment before rotating: %w", err)
+	}
+	return w.openSegment(w.segment + 1)
+}
+
+// Close flushes and closes the Writer's current segment.
+func (w *Writer) Close() error {
+	if err := w.writer.Flush(); err != nil {
+		return fmt.Errorf("wal: failed to flush on close: %w", err)
+	}
+	return w.file.Close()
+}
+
+// Handler processes one decoded Record during replay. An error from
+// Handler aborts Replay entirely; a corrupted or truncated trailing
+// record is never passed to Handler (see Replay).
+type Handler func(Record) error
+
+// Replay reads every segment in dir in order, skipping every record at
+// or before heightMarker, and invokes handler for each record after
+// it. If a segment's trailing bytes don't form a complete, CRC-valid
+// record — the signature of a torn write during a crash mid-append —
+// Replay truncates that segment to its last valid record boundary and
+// stops, rather than returning an error: this mirrors Tendermint's WAL
+// replay, which treats a corrupt tail as "this is where the process
+// died," not as data loss requiring operator intervention.
+func Replay(dir string, heightMarker uint64, handler Handler) error {
+	indices, err := segments(dir)
+	if err != nil {
+		return fmt.Errorf("wal: failed to list segments: %w", err)
+	}
+
+	for _, n := range indices {
+		path := filepath.Join(dir, segmentName(n))
+		if err := ReplayFile(path, heightMarker, handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReplayFile replays a single segment file, for offline debugging of
+// one segment in isolation. It applies the same heightMarker skip and
+// corrupt-tail truncation Replay does.
+func ReplayFile(path string, heightMarker uint64, handler Handler) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("wal: failed to read %s: %w", path, err)
+	}
+
+	offset := 0
+	for offset < len(data) {
+		record, n, err := decodeRecord(data[offset:])
+		if err != nil {
+			// A corrupt or truncated tail: this is where the process
+			// died mid-append. Drop it and move on rather than failing
+			// startup.
+			if truncErr := os.Truncate(path, int64(offset)); truncErr != nil {
+				return fmt.Errorf("wal: failed to truncate corrupt tail of %s: %w", path, truncErr)
+			}
+			break
+		}
+
+		offset += n
+		if record.Height <= heightMarker {
+			continue
+		}
+		if err := handler(record); err != nil {
+			return fmt.Errorf("wal: handler failed on height %d: %w", record.Height, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package wal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriterAppendThenReplayRoundTrips(t *testing.T) {
+	dir := t.TempDir()
+
+	writer, err := OpenWriter(dir, 0)
+	if err != nil {
+		t.Fatalf("OpenWriter: %v", err)
+	}
+	for height := uint64(1); height <= 3; height++ {
+		if err := writer.Append(EventProducedBlock, height, []byte{byte(height)}); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	var replayed []Record
+	if err := Replay(dir, 0, func(r Record) error {
+		replayed = append(replayed, r)
+		return nil
+	}); err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+
+	if len(replayed) != 3 {
+		t.Fatalf("expected 3 replayed records, got %d", len(replayed))
+	}
+	for i, r := range replayed {
+		wantHeight := uint64(i + 1)
+		if r.Height != wantHeight || r.Kind != EventProducedBlock || len(r.Payload) != 1 || r.Payload[0] != byte(wantHeight) {
+			t.Fatalf("record %d = %+v, want height %d", i, r, wantHeight)
+		}
+	}
+}
+
+func TestReplaySkipsRecordsAtOrBeforeHeightMarker(t *testing.T) {
+	dir := t.TempDir()
+
+	writer, err := OpenWriter(dir, 0)
+	if err != nil {
+		t.Fatalf("OpenWriter: %v", err)
+	}
+	for height := uint64(1); height <= 5; height++ {
+		if err := writer.Append(EventReceivedBlock, height, nil); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	var heights []uint64
+	if err := Replay(dir, 3, func(r Record) error {
+		heights = append(heights, r.Height)
+		return nil
+	}); err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+
+	want := []uint64{4, 5}
+	if len(heights) != len(want) {
+		t.Fatalf("heights = %v, want %v", heights, want)
+	}
+	for i := range want {
+		if heights[i] != want[i] {
+			t.Fatalf("heights = %v, want %v", heights, want)
+		}
+	}
+}
+
+func TestReplayTruncatesACorruptTrailingRecordInsteadOfFailing(t *testing.T) {
+	dir := t.TempDir()
+
+	writer, err := OpenWriter(dir, 0)
+	if err != nil {
+		t.Fatalf("OpenWriter: %v", err)
+	}
+	if err := writer.Append(EventValidatorTurnTransition, 1, []byte("full record")); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	path := filepath.Join(dir, segmentName(0))
+	before, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+
+	// Simulate a crash mid-append: a partial frame with no valid CRC
+	// trailing the last good record.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+	if _, err := f.Write([]byte{frameVersion, byte(EventTimerTick), 0, 0, 0, 0, 0, 0, 0, 9, 0, 0, 0, 3}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	var replayed []Record
+	if err := Replay(dir, 0, func(r Record) error {
+		replayed = append(replayed, r)
+		return nil
+	}); err != nil {
+		t.Fatalf("Replay returned an error for a corrupt trailing record: %v", err)
+	}
+
+	if len(replayed) != 1 || replayed[0].Height != 1 {
+		t.Fatalf("expected only the one complete record to replay, got %+v", replayed)
+	}
+
+	after, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if after.Size() != before.Size() {
+		t.Fatalf("expected the corrupt tail to be truncated back to %d bytes, got %d", before.Size(), after.Size())
+	}
+}
+
+func TestWriterRotatesToANewSegmentPastMaxSize(t *testing.T) {
+	dir := t.TempDir()
+
+	// Small enough that a couple of records force a rotation.
+	writer, err := OpenWriter(dir, 40)
+	if err != nil {
+		t.Fatalf("OpenWriter: %v", err)
+	}
+	for height := uint64(1); height <= 5; height++ {
+		if err := writer.Append(EventTimerTick, height, []byte("payload")); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	indices, err := segments(dir)
+	if err != nil {
+		t.Fatalf("segments: %v", err)
+	}
+	if len(indices) < 2 {
+		t.Fatalf("expected rotation to produce multiple segments, got %d", len(indices))
+	}
+
+	var heights []uint64
+	if err := Replay(dir, 0, func(r Record) error {
+		heights = append(heights, r.Height)
+		return nil
+	}); err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	if len(heights) != 5 {
+		t.Fatalf("expected all 5 records across segments to replay, got %d", len(heights))
+	}
+}
+
+func TestEventKind_String(t *testing.T) {
+	cases := map[EventKind]string{
+		EventReceivedBlock:           "received_block",
+		EventProducedBlock:           "produced_block",
+		EventForkChoiceSwitch:        "fork_choice_switch",
+		EventTimerTick:               "timer_tick",
+		EventValidatorTurnTransition: "validator_turn_transition",
+		EventVoteSigned:              "vote_signed",
+		EventKind(99):                "unknown",
+	}
+	for kind, want := range cases {
+		if got := kind.String(); got != want {
+			t.Errorf("EventKind(%d).String() = %q, want %q", kind, got, want)
+		}
+	}
+}
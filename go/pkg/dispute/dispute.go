@@ -0,0 +1,291 @@
+// Package dispute watches gossip for provable protocol violations —
+// double-signed blocks and conflicting solution-CID claims — and turns
+// the first pair of conflicting messages it sees into portable
+// Evidence that can be gossiped to peers and fed into a slashing hook,
+// the receiver-side counterpart to consensus.BlockAnnouncer's
+// producer-side equivocation check.
+//
+// It has no dependency on p2p or consensus: callers hand it plain
+// values (a proposer's address, a height, a hash, a signature) rather
+// than concrete wire types, the same narrow-coupling approach
+// pkg/blockpool and pkg/p2p/sync_manager.go's HeaderValidator use to
+// avoid import cycles with the packages that consume them.
+package dispute
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+// ViolationType identifies what kind of protocol violation a piece of
+// Evidence proves.
+type ViolationType string
+
+const (
+	// ViolationDoubleSignBlock is two distinct blocks signed by the same
+	// proposer at the same height.
+	ViolationDoubleSignBlock ViolationType = "double_sign_block"
+
+	// ViolationConflictingSolutionCID is two distinct solution CIDs
+	// published by the same publisher for the same problem round.
+	ViolationConflictingSolutionCID ViolationType = "conflicting_solution_cid"
+)
+
+// DefaultSeverePenalty is how much a peer's score drops once this node
+// itself verifies evidence implicating it, a much larger hit than the
+// incremental penalties gossip-level misbehavior (a single malformed
+// message, say) would normally apply.
+const DefaultSeverePenalty = 1000
+
+// EvidenceHistorySize bounds how many (proposer, height) / (publisher,
+// round) pairs are remembered to reject replayed evidence, the same
+// bounded-history approach chainState's orphan pool uses.
+const EvidenceHistorySize = 10_000
+
+// Evidence is a compact, gossipable proof of a single protocol
+// violation. SigA/SigB and BlockHashA/BlockHashB carry the two
+// conflicting claims: for a double-signed block, the block's own
+// signature and hash; for conflicting solution CIDs, SigA/SigB are left
+// empty and BlockHashA/BlockHashB are SHA256(CID string), since CIDs
+// aren't already 32-byte hashes.
+type Evidence struct {
+	Type       ViolationType
+	Proposer   [32]byte
+	Height     uint64
+	SigA       []byte
+	SigB       []byte
+	BlockHashA [32]byte
+	BlockHashB [32]byte
+}
+
+// evidenceKey identifies one (proposer, height) or (publisher, round)
+// slot, the granularity at which a conflict is detected and at which
+// evidence is deduplicated.
+type evidenceKey struct {
+	proposer [32]byte
+	height   uint64
+	kind     ViolationType
+}
+
+// SlashingHandler is called with newly detected (or newly verified,
+// externally-sourced) Evidence so the state layer can burn the
+// offender's balance. Errors are logged, not retried: Evidence is
+// persisted as seen either way so the same violation isn't slashed
+// twice.
+type SlashingHandler func(*Evidence) error
+
+// GossipHandler is called to broadcast freshly detected Evidence to
+// peers (normally over a dedicated pubsub topic). Left to the caller so
+// this package has no libp2p dependency.
+type GossipHandler func(*Evidence) error
+
+// PeerScorer is the subset of peer-reputation tracking Manager needs to
+// apply a severe penalty once it verifies evidence against a peer,
+// narrowed the same way other packages in this tree scope their
+// dependencies to just the methods they call.
+type PeerScorer interface {
+	ApplyPenalty(identity string, penalty int)
+}
+
+// seenBlock/seenSolution record the first claim observed for a given
+// key, so a second, conflicting claim can be detected.
+type seenBlock struct {
+	blockHash [32]byte
+	signature []byte
+}
+
+type seenSolution struct {
+	cid string
+}
+
+// Manager detects equivocation from gossip it's shown (ObserveBlock,
+// ObserveSolutionCID), and processes evidence relayed by other peers
+// (IngestExternalEvidence), in both cases deduplicating by
+// (proposer/publisher, height/round) and invoking the configured
+// slashing and gossip hooks at most once per violation.
+type Manager struct {
+	log *logger.Logger
+
+	scorer        PeerScorer
+	severePenalty int
+
+	mu sync.Mutex
+
+	blocks    map[evidenceKey]seenBlock
+	solutions map[evidenceKey]seenSolution
+
+	evidenceSeen      map[evidenceKey]bool
+	evidenceSeenOrder []evidenceKey
+
+	onSlash  SlashingHandler
+	onGossip GossipHandler
+}
+
+// NewManager creates a dispute Manager. scorer may be nil (peer
+// penalties are then skipped); severePenalty <= 0 uses
+// DefaultSeverePenalty.
+func NewManager(scorer PeerScorer, severePenalty int, log *logger.Logger) *Manager {
+	if severePenalty <= 0 {
+		severePenalty = DefaultSeverePenalty
+	}
+	return &Manager{
+		log:           log,
+		scorer:        scorer,
+		severePenalty: severePenalty,
+		blocks:        make(map[evidenceKey]seenBlock),
+		solutions:     make(map[evidenceKey]seenSolution),
+		evidenceSeen:  make(map[evidenceKey]bool),
+	}
+}
+
+// SetSlashingHandler wires the callback invoked whenever this node
+// detects or verifies a slashable violation.
+func (m *Manager) SetSlashingHandler(handler SlashingHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onSlash = handler
+}
+
+// SetGossipHandler wires the callback used to broadcast freshly
+// detected evidence to peers.
+func (m *Manager) SetGossipHandler(handler GossipHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onGossip = handler
+}
+
+// ObserveBlock records a signed block from proposer at height with the
+// given hash/signature. If proposer already has a different block
+// recorded at height, this is a double-sign: Manager builds Evidence,
+// processes it (slash + gossip + penalize), and returns it. Returns nil
+// Evidence (and no error) when there's no conflict yet.
+func (m *Manager) ObserveBlock(proposer [32]byte, height uint64, blockHash [32]byte, signature []byte) (*Evidence, error) {
+	key := evidenceKey{proposer: proposer, height: height, kind: ViolationDoubleSignBlock}
+
+	m.mu.Lock()
+	prev, exists := m.blocks[key]
+	if !exists {
+		m.blocks[key] = seenBlock{blockHash: blockHash, signature: signature}
+		m.mu.Unlock()
+		return nil, nil
+	}
+	m.mu.Unlock()
+
+	if prev.blockHash == blockHash {
+		return nil, nil
+	}
+
+	evidence := &Evidence{
+		Type:       ViolationDoubleSignBlock,
+		Proposer:   proposer,
+		Height:     height,
+		SigA:       prev.signature,
+		SigB:       signature,
+		BlockHashA: prev.blockHash,
+		BlockHashB: blockHash,
+	}
+	return evidence, m.process(evidence, key)
+}
+
+// ObserveSolutionCID records a solution CID claim from publisher for
+// round. If publisher already published a different CID for round,
+// this is a conflicting claim: Manager builds Evidence (CID hashes in
+// place of block hashes), processes it, and returns it.
+func (m *Manager) ObserveSolutionCID(publisher [32]byte, round uint64, cid string) (*Evidence, error) {
+	key := evidenceKey{proposer: publisher, height: round, kind: ViolationConflictingSolutionCID}
+
+	m.mu.Lock()
+	prev, exists := m.solutions[key]
+	if !exists {
+		m.solutions[key] = seenSolution{cid: cid}
+		m.mu.Unlock()
+		return nil, nil
+	}
+	m.mu.Unlock()
+
+	if prev.cid == cid {
+		return nil, nil
+	}
+
+	evidence := &Evidence{
+		Type:       ViolationConflictingSolutionCID,
+		Proposer:   publisher,
+		Height:     round,
+		BlockHashA: sha256.Sum256([]byte(prev.cid)),
+		BlockHashB: sha256.Sum256([]byte(cid)),
+	}
+	return evidence, m.process(evidence, key)
+}
+
+// IngestExternalEvidence processes Evidence relayed by another peer
+// (over the evidence gossip topic), applying the same slash/penalize
+// handling as locally detected evidence, deduplicated so the same
+// violation isn't processed twice regardless of source.
+func (m *Manager) IngestExternalEvidence(evidence *Evidence) error {
+	key := evidenceKey{proposer: evidence.Proposer, height: evidence.Height, kind: evidence.Type}
+	return m.process(evidence, key)
+}
+
+// process applies evidence's consequences — slashing handler, severe
+// peer-score penalty, and rebroadcast — exactly once per key, recording
+// the key in the bounded replay-prevention history regardless of
+// whether the handlers succeed.
+func (m *Manager) process(evidence *Evidence, key evidenceKey) error {
+	m.mu.Lock()
+	if m.evidenceSeen[key] {
+		m.mu.Unlock()
+		return nil
+	}
+	m.markSeen(key)
+	onSlash := m.onSlash
+	onGossip := m.onGossip
+	scorer := m.scorer
+	penalty := m.severePenalty
+	m.mu.Unlock()
+
+	if m.log != nil {
+		m.log.WithFields(logger.Fields{
+			"violation": string(evidence.Type),
+			"proposer":  fmt.Sprintf("%x", evidence.Proposer[:8]),
+			"height":    evidence.Height,
+		}).Warn("Protocol violation evidence verified")
+	}
+
+	if scorer != nil {
+		scorer.ApplyPenalty(fmt.Sprintf("%x", evidence.Proposer), penalty)
+	}
+
+	var slashErr error
+	if onSlash != nil {
+		if err := onSlash(evidence); err != nil {
+			slashErr = fmt.Errorf("slashing handler failed for proposer %x at height %d: %w", evidence.Proposer[:8], evidence.Height, err)
+			if m.log != nil {
+				m.log.WithError(slashErr).Error("Slashing handler rejected evidence")
+			}
+		}
+	}
+
+	if onGossip != nil {
+		if err := onGossip(evidence); err != nil && m.log != nil {
+			m.log.WithError(err).Warn("Failed to gossip evidence to peers")
+		}
+	}
+
+	return slashErr
+}
+
+// markSeen records key in the replay-prevention history, evicting the
+// oldest entry once EvidenceHistorySize is exceeded. Caller must hold
+// m.mu.
+func (m *Manager) markSeen(key evidenceKey) {
+	if len(m.evidenceSeenOrder) >= EvidenceHistorySize {
+		oldest := m.evidenceSeenOrder[0]
+		m.evidenceSeenOrder = m.evidenceSeenOrder[1:]
+		delete(m.evidenceSeen, oldest)
+	}
+	m.evidenceSeen[key] = true
+	m.evidenceSeenOrder = append(m.evidenceSeenOrder, key)
+}
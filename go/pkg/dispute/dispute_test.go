@@ -0,0 +1,114 @@
+package dispute
+
+import "testing"
+
+type fakeScorer struct {
+	penalized map[string]int
+}
+
+func newFakeScorer() *fakeScorer {
+	return &fakeScorer{penalized: make(map[string]int)}
+}
+
+func (f *fakeScorer) ApplyPenalty(identity string, penalty int) {
+	f.penalized[identity] += penalty
+}
+
+func TestManager_ObserveBlock_NoConflictOnFirstSight(t *testing.T) {
+	m := NewManager(nil, 0, nil)
+
+	evidence, err := m.ObserveBlock([32]byte{1}, 10, [32]byte{0xAA}, []byte("sig-a"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if evidence != nil {
+		t.Fatal("expected no evidence for a single observation")
+	}
+}
+
+func TestManager_ObserveBlock_DetectsDoubleSign(t *testing.T) {
+	scorer := newFakeScorer()
+	m := NewManager(scorer, 500, nil)
+
+	proposer := [32]byte{1}
+	if _, err := m.ObserveBlock(proposer, 10, [32]byte{0xAA}, []byte("sig-a")); err != nil {
+		t.Fatalf("unexpected error on first block: %v", err)
+	}
+
+	evidence, err := m.ObserveBlock(proposer, 10, [32]byte{0xBB}, []byte("sig-b"))
+	if err != nil {
+		t.Fatalf("unexpected error on conflicting block: %v", err)
+	}
+	if evidence == nil {
+		t.Fatal("expected evidence for a double-signed block")
+	}
+	if evidence.Type != ViolationDoubleSignBlock {
+		t.Fatalf("expected ViolationDoubleSignBlock, got %v", evidence.Type)
+	}
+	if evidence.BlockHashA != ([32]byte{0xAA}) || evidence.BlockHashB != ([32]byte{0xBB}) {
+		t.Fatalf("expected both conflicting hashes recorded, got %x / %x", evidence.BlockHashA, evidence.BlockHashB)
+	}
+	total := 0
+	for _, p := range scorer.penalized {
+		total += p
+	}
+	if total != 500 {
+		t.Fatalf("expected a single 500-point penalty applied, got total %d", total)
+	}
+}
+
+func TestManager_ObserveBlock_SameBlockTwiceIsNotEquivocation(t *testing.T) {
+	m := NewManager(nil, 0, nil)
+	proposer := [32]byte{2}
+
+	if _, err := m.ObserveBlock(proposer, 5, [32]byte{0xCC}, []byte("sig")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	evidence, err := m.ObserveBlock(proposer, 5, [32]byte{0xCC}, []byte("sig"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if evidence != nil {
+		t.Fatal("expected re-gossip of the same block to not be flagged as equivocation")
+	}
+}
+
+func TestManager_IngestExternalEvidence_DedupesReplay(t *testing.T) {
+	calls := 0
+	m := NewManager(nil, 0, nil)
+	m.SetSlashingHandler(func(ev *Evidence) error {
+		calls++
+		return nil
+	})
+
+	evidence := &Evidence{Type: ViolationDoubleSignBlock, Proposer: [32]byte{3}, Height: 20}
+	if err := m.IngestExternalEvidence(evidence); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := m.IngestExternalEvidence(evidence); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected slashing handler to run exactly once for a replayed evidence, got %d", calls)
+	}
+}
+
+func TestManager_ObserveSolutionCID_DetectsConflict(t *testing.T) {
+	m := NewManager(nil, 0, nil)
+	publisher := [32]byte{4}
+
+	if _, err := m.ObserveSolutionCID(publisher, 1, "QmFirst"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	evidence, err := m.ObserveSolutionCID(publisher, 1, "QmSecond")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if evidence == nil {
+		t.Fatal("expected evidence for conflicting solution CIDs")
+	}
+	if evidence.Type != ViolationConflictingSolutionCID {
+		t.Fatalf("expected ViolationConflictingSolutionCID, got %v", evidence.Type)
+	}
+}
@@ -0,0 +1,229 @@
+// Package evidence is the block-inclusion side of misbehavior handling:
+// a Pool of pending Evidence waiting to be carried in the next block a
+// validator produces, the way Tendermint's own EvidencePool feeds its
+// block proposer. It's deliberately a different concern from the two
+// other evidence-shaped pieces already in this tree:
+//
+//   - pkg/dispute.Manager detects a violation the moment it's observed
+//     from gossip (ObserveBlock/ObserveSolutionCID) and reacts
+//     immediately — slash, gossip, penalize — with no notion of
+//     "waiting to be included in a block."
+//   - consensus.EvidencePool (byzantine.go/evidence.go) is
+//     BlockAnnouncer's own record of equivocations it catches at
+//     announce time, scoped to that one producer-side check.
+//
+// This package is the queue in between: something (a dispute.Manager
+// hook, a gossiped report, a light-client check) calls Add with a
+// DuplicateVoteEvidence or LightClientAttackEvidence value, and the
+// block builder calls Pending at proposal time to decide what to embed,
+// then MarkIncluded once it's actually in a sealed block. Prune expires
+// anything that's aged out without ever being included, the same bound
+// Tendermint's evidence pool and dispute.EvidenceHistorySize both apply
+// in spirit.
+package evidence
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+)
+
+// DefaultMaxAge is how many blocks pending (not-yet-included) evidence
+// is kept before Prune discards it, matching the kind of bound
+// dispute.EvidenceHistorySize applies to its own seen-evidence set.
+const DefaultMaxAge = 100_000
+
+// Kind distinguishes the two evidence shapes this pool accepts.
+type Kind string
+
+const (
+	KindDuplicateVote     Kind = "duplicate_vote"
+	KindLightClientAttack Kind = "light_client_attack"
+)
+
+// Evidence is anything the pool can queue, dedupe, and hand to a block
+// builder: a validator identity, the height it misbehaved at, and a
+// stable hash used as the pool's dedup key.
+type Evidence interface {
+	Kind() Kind
+	Validator() [32]byte
+	Height() uint64
+	Hash() [32]byte
+}
+
+// DuplicateVoteEvidence proves a validator signed two different
+// proposals at the same height — the same shape dispute.Manager's
+// ViolationDoubleSignBlock detects, carried here as a typed value
+// instead of dispute's generic Evidence struct so the block-inclusion
+// path doesn't have to import pkg/dispute.
+type DuplicateVoteEvidence struct {
+	ValidatorKey [32]byte
+	BlockNumber  uint64
+	FirstHash    [32]byte
+	SecondHash   [32]byte
+	FirstSig     []byte
+	SecondSig    []byte
+}
+
+func (e *DuplicateVoteEvidence) Kind() Kind          { return KindDuplicateVote }
+func (e *DuplicateVoteEvidence) Validator() [32]byte { return e.ValidatorKey }
+func (e *DuplicateVoteEvidence) Height() uint64      { return e.BlockNumber }
+
+func (e *DuplicateVoteEvidence) Hash() [32]byte {
+	return hashEvidence(KindDuplicateVote, e.ValidatorKey, e.BlockNumber, e.FirstHash, e.SecondHash)
+}
+
+// LightClientAttackEvidence proves a validator signed a header that
+// conflicts with one a light client already trusts at the same height —
+// the "light client attack" category Tendermint's evidence pool also
+// tracks separately from a plain duplicate vote, since the conflicting
+// header may not be a proposal this node ever saw gossiped directly.
+type LightClientAttackEvidence struct {
+	ValidatorKey          [32]byte
+	BlockNumber           uint64
+	TrustedHeaderHash     [32]byte
+	ConflictingHeaderHash [32]byte
+}
+
+func (e *LightClientAttackEvidence) Kind() Kind          { return KindLightClientAttack }
+func (e *LightClientAttackEvidence) Validator() [32]byte { return e.ValidatorKey }
+func (e *LightClientAttackEvidence) Height() uint64      { return e.BlockNumber }
+
+func (e *LightClientAttackEvidence) Hash() [32]byte {
+	return hashEvidence(KindLightClientAttack, e.ValidatorKey, e.BlockNumber, e.TrustedHeaderHash, e.ConflictingHeaderHash)
+}
+
+func hashEvidence(kind Kind, validator [32]byte, height uint64, a, b [32]byte) [32]byte {
+	h := sha256.New()
+	h.Write([]byte(kind))
+	h.Write(validator[:])
+	var heightBuf [8]byte
+	binary.BigEndian.PutUint64(heightBuf[:], height)
+	h.Write(heightBuf[:])
+	h.Write(a[:])
+	h.Write(b[:])
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+// entry tracks one pool slot: the evidence itself, the height it was
+// added at (for aging), and whether it has already been embedded in a
+// sealed block.
+type entry struct {
+	ev       Evidence
+	addedAt  uint64
+	included bool
+}
+
+// Config bounds how long unincluded evidence is kept pending.
+type Config struct {
+	MaxAge uint64
+}
+
+// DefaultConfig returns the Config used when none is given explicitly.
+func DefaultConfig() Config {
+	return Config{MaxAge: DefaultMaxAge}
+}
+
+// Pool holds pending evidence, deduped by Hash, until a block builder
+// claims it via Pending/MarkIncluded or Prune expires it. All methods
+// are safe for concurrent use.
+type Pool struct {
+	mu  sync.Mutex
+	cfg Config
+
+	byHash map[[32]byte]*entry
+	order  [][32]byte
+}
+
+// NewPool creates an empty Pool.
+func NewPool(cfg Config) *Pool {
+	if cfg.MaxAge == 0 {
+		cfg.MaxAge = DefaultMaxAge
+	}
+	return &Pool{
+		cfg:    cfg,
+		byHash: make(map[[32]byte]*entry),
+	}
+}
+
+// Add queues ev if it hasn't been seen before (by Hash), recording
+// currentHeight as its arrival height for later aging. Returns true if
+// this was a new addition, false if ev was already known (a duplicate
+// report of the same violation, e.g. relayed by more than one peer).
+func (p *Pool) Add(ev Evidence, currentHeight uint64) bool {
+	hash := ev.Hash()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, exists := p.byHash[hash]; exists {
+		return false
+	}
+	p.byHash[hash] = &entry{ev: ev, addedAt: currentHeight}
+	p.order = append(p.order, hash)
+	return true
+}
+
+// Pending returns every queued evidence item that hasn't yet been
+// included in a block, in the order it was added — the set a block
+// builder should consider embedding in its next proposal.
+func (p *Pool) Pending() []Evidence {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pending := make([]Evidence, 0, len(p.order))
+	for _, hash := range p.order {
+		e, ok := p.byHash[hash]
+		if ok && !e.included {
+			pending = append(pending, e.ev)
+		}
+	}
+	return pending
+}
+
+// MarkIncluded records that the given evidence hashes have now been
+// embedded in a sealed block, so Pending stops returning them and Prune
+// never expires them.
+func (p *Pool) MarkIncluded(hashes [][32]byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, hash := range hashes {
+		if e, ok := p.byHash[hash]; ok {
+			e.included = true
+		}
+	}
+}
+
+// Prune discards pending (not-yet-included) evidence whose age exceeds
+// cfg.MaxAge as of currentHeight — evidence that's already been
+// included is kept regardless of age, since it's part of chain history
+// rather than something still waiting for a proposer to act on.
+func (p *Pool) Prune(currentHeight uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	kept := p.order[:0]
+	for _, hash := range p.order {
+		e, ok := p.byHash[hash]
+		if !ok {
+			continue
+		}
+		if !e.included && currentHeight-e.addedAt > p.cfg.MaxAge {
+			delete(p.byHash, hash)
+			continue
+		}
+		kept = append(kept, hash)
+	}
+	p.order = kept
+}
+
+// Count returns the number of evidence items currently tracked
+// (pending and included alike).
+func (p *Pool) Count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.byHash)
+}
@@ -0,0 +1,84 @@
+package evidence
+
+import "testing"
+
+func TestPool_AddDedupesByHash(t *testing.T) {
+	pool := NewPool(DefaultConfig())
+
+	ev := &DuplicateVoteEvidence{ValidatorKey: [32]byte{1}, BlockNumber: 10, FirstHash: [32]byte{0xAA}, SecondHash: [32]byte{0xBB}}
+
+	if !pool.Add(ev, 10) {
+		t.Fatal("expected first Add to report a new addition")
+	}
+	if pool.Add(ev, 10) {
+		t.Fatal("expected a duplicate Add (same hash) to report no new addition")
+	}
+	if pool.Count() != 1 {
+		t.Fatalf("expected 1 tracked item, got %d", pool.Count())
+	}
+}
+
+func TestPool_PendingExcludesIncluded(t *testing.T) {
+	pool := NewPool(DefaultConfig())
+
+	first := &DuplicateVoteEvidence{ValidatorKey: [32]byte{1}, BlockNumber: 10, FirstHash: [32]byte{0xAA}, SecondHash: [32]byte{0xBB}}
+	second := &LightClientAttackEvidence{ValidatorKey: [32]byte{2}, BlockNumber: 11, TrustedHeaderHash: [32]byte{0xCC}, ConflictingHeaderHash: [32]byte{0xDD}}
+
+	pool.Add(first, 10)
+	pool.Add(second, 11)
+
+	pending := pool.Pending()
+	if len(pending) != 2 {
+		t.Fatalf("expected 2 pending items, got %d", len(pending))
+	}
+
+	pool.MarkIncluded([][32]byte{first.Hash()})
+
+	pending = pool.Pending()
+	if len(pending) != 1 || pending[0].Hash() != second.Hash() {
+		t.Fatalf("expected only the light-client-attack evidence still pending, got %+v", pending)
+	}
+}
+
+func TestPool_PrunesOnlyUnincludedPastMaxAge(t *testing.T) {
+	pool := NewPool(Config{MaxAge: 5})
+
+	stale := &DuplicateVoteEvidence{ValidatorKey: [32]byte{1}, BlockNumber: 1, FirstHash: [32]byte{1}, SecondHash: [32]byte{2}}
+	included := &DuplicateVoteEvidence{ValidatorKey: [32]byte{2}, BlockNumber: 1, FirstHash: [32]byte{3}, SecondHash: [32]byte{4}}
+	fresh := &DuplicateVoteEvidence{ValidatorKey: [32]byte{3}, BlockNumber: 1, FirstHash: [32]byte{5}, SecondHash: [32]byte{6}}
+
+	pool.Add(stale, 0)
+	pool.Add(included, 0)
+	pool.Add(fresh, 8)
+	pool.MarkIncluded([][32]byte{included.Hash()})
+
+	pool.Prune(10)
+
+	if pool.Count() != 2 {
+		t.Fatalf("expected the stale, unincluded item to be pruned, leaving 2, got %d", pool.Count())
+	}
+	pending := pool.Pending()
+	if len(pending) != 1 || pending[0].Hash() != fresh.Hash() {
+		t.Fatalf("expected only the fresh item still pending after prune, got %+v", pending)
+	}
+}
+
+func TestDuplicateVoteEvidence_HashDistinguishesFields(t *testing.T) {
+	a := &DuplicateVoteEvidence{ValidatorKey: [32]byte{1}, BlockNumber: 10, FirstHash: [32]byte{0xAA}, SecondHash: [32]byte{0xBB}}
+	b := &DuplicateVoteEvidence{ValidatorKey: [32]byte{1}, BlockNumber: 10, FirstHash: [32]byte{0xAA}, SecondHash: [32]byte{0xCC}}
+
+	if a.Hash() == b.Hash() {
+		t.Fatal("expected evidence with different second-hash fields to hash differently")
+	}
+}
+
+func TestLightClientAttackEvidence_KindAndAccessors(t *testing.T) {
+	ev := &LightClientAttackEvidence{ValidatorKey: [32]byte{9}, BlockNumber: 42, TrustedHeaderHash: [32]byte{1}, ConflictingHeaderHash: [32]byte{2}}
+
+	if ev.Kind() != KindLightClientAttack {
+		t.Fatalf("expected KindLightClientAttack, got %v", ev.Kind())
+	}
+	if ev.Validator() != ([32]byte{9}) || ev.Height() != 42 {
+		t.Fatal("expected Validator/Height accessors to reflect the struct fields")
+	}
+}
@@ -0,0 +1,178 @@
+// Package keystore seals an Ed25519 private key at rest using a
+// passphrase, so validator tooling can stop writing raw key material to
+// disk with only filesystem permissions protecting it.
+package keystore
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// CurrentVersion is the keystore file format version written by Seal.
+	CurrentVersion = 1
+
+	// Cipher identifies the AEAD used to seal the private key.
+	Cipher = "xchacha20poly1305"
+
+	// scryptN, scryptR, scryptP are the scrypt cost parameters Seal uses
+	// to derive the sealing key from a passphrase.
+	scryptN = 1 << 17
+	scryptR = 8
+	scryptP = 1
+
+	// saltSize matches the scrypt salt length Seal generates.
+	saltSize = 32
+)
+
+// KDFParams records the scrypt parameters and salt a keystore file was
+// sealed with, so Open can re-derive the same sealing key.
+type KDFParams struct {
+	Algorithm string   `json:"algorithm"`
+	N         int      `json:"n"`
+	R         int      `json:"r"`
+	P         int      `json:"p"`
+	Salt      hexBytes `json:"salt"`
+}
+
+// File is the on-disk representation of a sealed private key: KDF
+// params, the AEAD nonce, and a ciphertext whose trailing Poly1305 tag
+// authenticates both the ciphertext and KDF (passed to Seal as
+// associated data), so a tampered kdf block is detected on Open just
+// like a tampered ciphertext.
+type File struct {
+	Version    int       `json:"version"`
+	PublicKey  hexBytes  `json:"public_key"`
+	KDF        KDFParams `json:"kdf"`
+	Cipher     string    `json:"cipher"`
+	Nonce      hexBytes  `json:"nonce"`
+	Ciphertext hexBytes  `json:"ciphertext"`
+	Created    time.Time `json:"created"`
+}
+
+// hexBytes marshals as a lowercase hex string instead of json's default
+// base64, matching how the rest of this tool renders key material.
+type hexBytes []byte
+
+func (b hexBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hex.EncodeToString(b))
+}
+
+func (b *hexBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	decoded, err := hex.DecodeString(s)
+	if err != nil {
+		return fmt.Errorf("invalid hex: %w", err)
+	}
+	*b = decoded
+	return nil
+}
+
+// Seal encrypts privateKey under a key derived from passphrase via
+// scrypt, returning a File ready to be written to disk (e.g. as
+// "<name>.keystore.json"). publicKey is stored alongside in the clear
+// purely for identification; it is also bound into the AEAD's
+// associated data so it cannot be swapped without invalidating the tag.
+func Seal(publicKey, privateKey []byte, passphrase string) (*File, error) {
+	salt := make([]byte, saltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive sealing key: %w", err)
+	}
+	defer secureZeroBytes(key)
+
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct AEAD: %w", err)
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	kdf := KDFParams{
+		Algorithm: "scrypt",
+		N:         scryptN,
+		R:         scryptR,
+		P:         scryptP,
+		Salt:      salt,
+	}
+
+	ciphertext := aead.Seal(nil, nonce, privateKey, associatedData(publicKey, kdf))
+
+	return &File{
+		Version:    CurrentVersion,
+		PublicKey:  publicKey,
+		KDF:        kdf,
+		Cipher:     Cipher,
+		Nonce:      nonce,
+		Ciphertext: ciphertext,
+		Created:    time.Now().UTC(),
+	}, nil
+}
+
+// Open re-derives the sealing key from passphrase and f.KDF and decrypts
+// f.Ciphertext, returning the original private key. It fails closed (a
+// wrong passphrase or any tampering with f produces an error, never
+// garbage key material) because XChaCha20-Poly1305 rejects the
+// ciphertext outright when the derived key or associated data is wrong.
+func Open(f *File, passphrase string) ([]byte, error) {
+	if f.Cipher != Cipher {
+		return nil, fmt.Errorf("unsupported keystore cipher %q", f.Cipher)
+	}
+	if f.KDF.Algorithm != "scrypt" {
+		return nil, fmt.Errorf("unsupported keystore kdf %q", f.KDF.Algorithm)
+	}
+
+	key, err := scrypt.Key([]byte(passphrase), f.KDF.Salt, f.KDF.N, f.KDF.R, f.KDF.P, chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive sealing key: %w", err)
+	}
+	defer secureZeroBytes(key)
+
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct AEAD: %w", err)
+	}
+
+	privateKey, err := aead.Open(nil, f.Nonce, f.Ciphertext, associatedData(f.PublicKey, f.KDF))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore: wrong passphrase or corrupted file")
+	}
+
+	return privateKey, nil
+}
+
+// associatedData binds a keystore's public key and KDF params into the
+// AEAD tag, so Seal's "MAC over ciphertext+params" guarantee holds: any
+// edit to either field, not just the ciphertext, fails Open.
+func associatedData(publicKey []byte, kdf KDFParams) []byte {
+	data := make([]byte, 0, len(publicKey)+len(kdf.Salt)+24)
+	data = append(data, publicKey...)
+	data = append(data, kdf.Algorithm...)
+	data = append(data, kdf.Salt...)
+	return data
+}
+
+// secureZeroBytes overwrites b's contents in place (defense in depth for
+// derived key material that otherwise lingers in memory).
+func secureZeroBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,96 @@
+package keystore
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSealOpen_RoundTrip(t *testing.T) {
+	publicKey := bytes.Repeat([]byte{0xAB}, 32)
+	privateKey := bytes.Repeat([]byte{0xCD}, 64)
+
+	f, err := Seal(publicKey, privateKey, "correct horse battery staple 42!")
+	if err != nil {
+		t.Fatalf("Seal failed: %v", err)
+	}
+
+	got, err := Open(f, "correct horse battery staple 42!")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if !bytes.Equal(got, privateKey) {
+		t.Error("decrypted private key does not match the original")
+	}
+}
+
+func TestOpen_RejectsWrongPassphrase(t *testing.T) {
+	publicKey := bytes.Repeat([]byte{0xAB}, 32)
+	privateKey := bytes.Repeat([]byte{0xCD}, 64)
+
+	f, err := Seal(publicKey, privateKey, "correct horse battery staple 42!")
+	if err != nil {
+		t.Fatalf("Seal failed: %v", err)
+	}
+
+	if _, err := Open(f, "wrong passphrase"); err == nil {
+		t.Error("expected an error when opening with the wrong passphrase")
+	}
+}
+
+func TestOpen_RejectsTamperedCiphertext(t *testing.T) {
+	publicKey := bytes.Repeat([]byte{0xAB}, 32)
+	privateKey := bytes.Repeat([]byte{0xCD}, 64)
+
+	f, err := Seal(publicKey, privateKey, "correct horse battery staple 42!")
+	if err != nil {
+		t.Fatalf("Seal failed: %v", err)
+	}
+
+	f.Ciphertext[0] ^= 0xFF
+
+	if _, err := Open(f, "correct horse battery staple 42!"); err == nil {
+		t.Error("expected an error when the ciphertext has been tampered with")
+	}
+}
+
+func TestOpen_RejectsTamperedKDFParams(t *testing.T) {
+	publicKey := bytes.Repeat([]byte{0xAB}, 32)
+	privateKey := bytes.Repeat([]byte{0xCD}, 64)
+
+	f, err := Seal(publicKey, privateKey, "correct horse battery staple 42!")
+	if err != nil {
+		t.Fatalf("Seal failed: %v", err)
+	}
+
+	// The salt is authenticated as associated data, so swapping it
+	// (without re-sealing) must be detected even though it isn't part
+	// of the ciphertext itself.
+	f.KDF.Salt[0] ^= 0xFF
+
+	if _, err := Open(f, "correct horse battery staple 42!"); err == nil {
+		t.Error("expected an error when the kdf salt has been tampered with")
+	}
+}
+
+func TestEstimatePasswordStrength_RejectsCommonPasswords(t *testing.T) {
+	score, _ := EstimatePasswordStrength("password")
+	if score >= MinPasswordScore {
+		t.Errorf("expected a common password to score below %d, got %d", MinPasswordScore, score)
+	}
+}
+
+func TestEstimatePasswordStrength_RejectsRepeatedAndSequential(t *testing.T) {
+	for _, password := range []string{"aaaaaaaaaaaa", "abcdefghijkl", "12345678901234"} {
+		score, _ := EstimatePasswordStrength(password)
+		if score >= MinPasswordScore {
+			t.Errorf("expected %q to score below %d, got %d", password, MinPasswordScore, score)
+		}
+	}
+}
+
+func TestEstimatePasswordStrength_AcceptsLongMixedPassword(t *testing.T) {
+	score, feedback := EstimatePasswordStrength("Tr0ub4dor&zxQ9!mK")
+	if score < MinPasswordScore {
+		t.Errorf("expected a long mixed-character password to score at least %d, got %d (%v)", MinPasswordScore, score, feedback)
+	}
+}
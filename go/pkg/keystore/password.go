@@ -0,0 +1,205 @@
+package keystore
+
+import (
+	"math"
+	"strings"
+)
+
+// MinPasswordScore is the lowest EstimatePasswordStrength score Seal's
+// callers should accept; see the "reject scores below 3" requirement
+// this gates.
+const MinPasswordScore = 3
+
+// commonPasswords is a small sample of the passwords that show up at
+// the top of every leaked-credential frequency list (rockyou.txt and
+// similar); a real zxcvbn install ships tens of thousands of these, but
+// catching the handful an attacker tries first still heavily improves
+// on no dictionary check at all.
+var commonPasswords = map[string]bool{
+	"password": true, "123456": true, "123456789": true, "12345678": true,
+	"qwerty": true, "111111": true, "123123": true, "abc123": true,
+	"letmein": true, "monkey": true, "dragon": true, "iloveyou": true,
+	"admin": true, "welcome": true, "passw0rd": true, "password1": true,
+	"trustno1": true, "princess": true, "solo": true, "starwars": true,
+	"validator": true, "keystore": true, "changeme": true,
+}
+
+// sequentialRuns are short substrings zxcvbn would recognize as a
+// "sequence" pattern (ascending/descending runs of letters, digits, or
+// adjacent QWERTY keys) rather than genuine entropy.
+var sequentialRuns = []string{
+	"0123456789", "9876543210",
+	"abcdefghijklmnopqrstuvwxyz",
+	"qwertyuiop", "asdfghjkl", "zxcvbnm",
+}
+
+// EstimatePasswordStrength scores password on zxcvbn's familiar 0-4
+// scale (0 = trivially guessable, 4 = very strong) along with
+// human-readable feedback explaining the score. It is a lightweight
+// heuristic, not a port of zxcvbn's full pattern-matching and frequency
+// dictionaries: it checks length, character variety, repeated/sequential
+// runs, and membership in a small common-password list.
+func EstimatePasswordStrength(password string) (score int, feedback []string) {
+	lower := strings.ToLower(password)
+
+	if commonPasswords[lower] {
+		return 0, []string{"this is one of the most commonly used passwords — choose something unique"}
+	}
+
+	if longestRepeatedRun(password) >= 4 {
+		feedback = append(feedback, "avoid long runs of the same repeated character")
+	}
+	if containsSequentialRun(lower, 4) {
+		feedback = append(feedback, "avoid sequential characters like \"abcd\" or \"1234\"")
+	}
+
+	classes := 0
+	for _, has := range []bool{hasLower(password), hasUpper(password), hasDigit(password), hasSymbol(password)} {
+		if has {
+			classes++
+		}
+	}
+	if classes < 3 {
+		feedback = append(feedback, "mix uppercase, lowercase, digits, and symbols")
+	}
+
+	if len(password) < 12 {
+		feedback = append(feedback, "use a longer password (at least 12 characters)")
+	}
+
+	bits := entropyBits(password, classes)
+	score = scoreFromEntropy(bits)
+
+	// Repeated or sequential runs make a password far weaker than its
+	// raw entropy estimate suggests, regardless of length or variety.
+	if longestRepeatedRun(password) >= 4 || containsSequentialRun(lower, 5) {
+		if score > 1 {
+			score = 1
+		}
+	}
+
+	if len(feedback) == 0 {
+		feedback = append(feedback, "strong password")
+	}
+
+	return score, feedback
+}
+
+// entropyBits estimates a password's guessing entropy as
+// length * log2(charset size), the same coarse model zxcvbn falls back
+// to once no recognizable pattern reduces the search space.
+func entropyBits(password string, classes int) float64 {
+	charsetSize := 0
+	switch {
+	case classes >= 4:
+		charsetSize = 95 // full printable ASCII
+	case classes == 3:
+		charsetSize = 62 + 10 // letters+digits plus some symbols
+	case classes == 2:
+		charsetSize = 62
+	default:
+		charsetSize = 26
+	}
+
+	if len(password) == 0 {
+		return 0
+	}
+
+	return float64(len(password)) * math.Log2(float64(charsetSize))
+}
+
+// scoreFromEntropy buckets an entropy estimate into zxcvbn's 0-4 scale.
+// The bands are calibrated so a 12-character password mixing 3+
+// character classes (the feedback this function itself asks for) lands
+// at score 3, the minimum Seal's callers require.
+func scoreFromEntropy(bits float64) int {
+	switch {
+	case bits < 28:
+		return 0
+	case bits < 36:
+		return 1
+	case bits < 60:
+		return 2
+	case bits < 128:
+		return 3
+	default:
+		return 4
+	}
+}
+
+func longestRepeatedRun(s string) int {
+	longest, current := 0, 0
+	var prev rune
+	for i, r := range s {
+		if i > 0 && r == prev {
+			current++
+		} else {
+			current = 1
+		}
+		if current > longest {
+			longest = current
+		}
+		prev = r
+	}
+	return longest
+}
+
+func containsSequentialRun(lower string, runLength int) bool {
+	if len(lower) < runLength {
+		return false
+	}
+	for _, seq := range sequentialRuns {
+		for _, candidate := range []string{seq, reverseString(seq)} {
+			for i := 0; i+runLength <= len(candidate); i++ {
+				if strings.Contains(lower, candidate[i:i+runLength]) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func reverseString(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+func hasLower(s string) bool {
+	for _, r := range s {
+		if r >= 'a' && r <= 'z' {
+			return true
+		}
+	}
+	return false
+}
+
+func hasUpper(s string) bool {
+	for _, r := range s {
+		if r >= 'A' && r <= 'Z' {
+			return true
+		}
+	}
+	return false
+}
+
+func hasDigit(s string) bool {
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			return true
+		}
+	}
+	return false
+}
+
+func hasSymbol(s string) bool {
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') {
+			return true
+		}
+	}
+	return false
+}
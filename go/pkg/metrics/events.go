@@ -0,0 +1,142 @@
+package metrics
+
+import "sync"
+
+// EventKind identifies which of the typed events below an Event value
+// carries, the same tagged-union-by-Kind approach pkg/evidence.Evidence
+// and pkg/p2p's syncEnvelope both already use.
+type EventKind string
+
+const (
+	EventNewRound         EventKind = "new_round"
+	EventProposalReceived EventKind = "proposal_received"
+	EventBlockCommitted   EventKind = "block_committed"
+	EventPeerConnected    EventKind = "peer_connected"
+	EventTxAdmitted       EventKind = "tx_admitted"
+	EventReorgDetected    EventKind = "reorg_detected"
+)
+
+// Event is anything the bus can publish and subscribers can receive.
+type Event interface {
+	Kind() EventKind
+}
+
+// NewRoundEvent marks consensus starting a new round at Height.
+type NewRoundEvent struct {
+	Height uint64
+	Round  uint64
+}
+
+func (NewRoundEvent) Kind() EventKind { return EventNewRound }
+
+// ProposalReceivedEvent marks a proposed block arriving for the current
+// round, before it's been validated or committed.
+type ProposalReceivedEvent struct {
+	Height   uint64
+	Proposer [32]byte
+}
+
+func (ProposalReceivedEvent) Kind() EventKind { return EventProposalReceived }
+
+// BlockCommittedEvent marks a block becoming the new local head.
+type BlockCommittedEvent struct {
+	Height    uint64
+	Hash      [32]byte
+	Validator [32]byte
+}
+
+func (BlockCommittedEvent) Kind() EventKind { return EventBlockCommitted }
+
+// PeerConnectedEvent marks a new libp2p connection being established.
+type PeerConnectedEvent struct {
+	PeerID string
+}
+
+func (PeerConnectedEvent) Kind() EventKind { return EventPeerConnected }
+
+// TxAdmittedEvent marks a transaction being accepted into the mempool.
+type TxAdmittedEvent struct {
+	TxHash [32]byte
+}
+
+func (TxAdmittedEvent) Kind() EventKind { return EventTxAdmitted }
+
+// ReorgDetectedEvent marks the local head switching to a different chain
+// than the one it was previously building on.
+type ReorgDetectedEvent struct {
+	OldHash [32]byte
+	NewHash [32]byte
+	Depth   uint64
+}
+
+func (ReorgDetectedEvent) Kind() EventKind { return EventReorgDetected }
+
+// subscription is one Subscribe call's channel and its bound kind, kept
+// so Unsubscribe can find and remove exactly this entry even if the
+// same kind has multiple subscribers.
+type subscription struct {
+	kind EventKind
+	ch   chan Event
+}
+
+// EventBus is an in-process pub/sub for typed Event values, so code that
+// wants to react to "a block committed" can subscribe instead of
+// polling a getter in a time.Sleep loop (the pattern used throughout
+// test/integration/multi_node_test.go before this package existed).
+// Publish never blocks: a subscriber whose channel is full simply misses
+// that event, the same best-effort delivery chainState's bounded orphan
+// pool already accepts for out-of-order blocks.
+type EventBus struct {
+	mu   sync.Mutex
+	subs []*subscription
+}
+
+// NewEventBus creates an empty EventBus.
+func NewEventBus() *EventBus {
+	return &EventBus{}
+}
+
+// DefaultSubscriberBuffer bounds how many unconsumed events a single
+// subscription channel holds before Publish starts dropping for it.
+const DefaultSubscriberBuffer = 32
+
+// Subscribe returns a channel that receives every future Event of the
+// given kind, and an unsubscribe function that stops delivery and closes
+// the channel.
+func (b *EventBus) Subscribe(kind EventKind) (<-chan Event, func()) {
+	sub := &subscription{kind: kind, ch: make(chan Event, DefaultSubscriberBuffer)}
+
+	b.mu.Lock()
+	b.subs = append(b.subs, sub)
+	b.mu.Unlock()
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		for i, s := range b.subs {
+			if s == sub {
+				b.subs = append(b.subs[:i], b.subs[i+1:]...)
+				break
+			}
+		}
+		close(sub.ch)
+	}
+	return sub.ch, unsubscribe
+}
+
+// Publish delivers ev to every current subscriber of ev.Kind(),
+// non-blocking: a subscriber that isn't keeping up simply misses it.
+func (b *EventBus) Publish(ev Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, sub := range b.subs {
+		if sub.kind != ev.Kind() {
+			continue
+		}
+		select {
+		case sub.ch <- ev:
+		default:
+		}
+	}
+}
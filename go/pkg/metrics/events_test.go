@@ -0,0 +1,81 @@
+package metrics
+
+import "testing"
+
+func TestEventBus_SubscribeOnlyReceivesMatchingKind(t *testing.T) {
+	bus := NewEventBus()
+	blocks, unsubscribe := bus.Subscribe(EventBlockCommitted)
+	defer unsubscribe()
+
+	bus.Publish(PeerConnectedEvent{PeerID: "peer-a"})
+	bus.Publish(BlockCommittedEvent{Height: 5, Hash: [32]byte{1}})
+
+	select {
+	case ev := <-blocks:
+		committed, ok := ev.(BlockCommittedEvent)
+		if !ok || committed.Height != 5 {
+			t.Fatalf("expected BlockCommittedEvent{Height:5}, got %+v", ev)
+		}
+	default:
+		t.Fatal("expected the matching event to be delivered")
+	}
+
+	select {
+	case ev := <-blocks:
+		t.Fatalf("expected no second event, got %+v", ev)
+	default:
+	}
+}
+
+func TestEventBus_MultipleSubscribersEachGetTheEvent(t *testing.T) {
+	bus := NewEventBus()
+	a, unsubA := bus.Subscribe(EventPeerConnected)
+	defer unsubA()
+	b, unsubB := bus.Subscribe(EventPeerConnected)
+	defer unsubB()
+
+	bus.Publish(PeerConnectedEvent{PeerID: "peer-x"})
+
+	for _, ch := range []<-chan Event{a, b} {
+		select {
+		case ev := <-ch:
+			if pc, ok := ev.(PeerConnectedEvent); !ok || pc.PeerID != "peer-x" {
+				t.Fatalf("expected PeerConnectedEvent{peer-x}, got %+v", ev)
+			}
+		default:
+			t.Fatal("expected every subscriber to receive the event")
+		}
+	}
+}
+
+func TestEventBus_UnsubscribeStopsDelivery(t *testing.T) {
+	bus := NewEventBus()
+	ch, unsubscribe := bus.Subscribe(EventTxAdmitted)
+	unsubscribe()
+
+	bus.Publish(TxAdmittedEvent{TxHash: [32]byte{1}})
+
+	if _, ok := <-ch; ok {
+		t.Fatal("expected the channel to be closed after unsubscribe")
+	}
+}
+
+func TestEventBus_PublishDoesNotBlockWhenSubscriberIsFull(t *testing.T) {
+	bus := NewEventBus()
+	_, unsubscribe := bus.Subscribe(EventNewRound)
+	defer unsubscribe()
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < DefaultSubscriberBuffer+10; i++ {
+			bus.Publish(NewRoundEvent{Height: uint64(i)})
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	default:
+	}
+	<-done
+}
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestNewRegistry_MetricsAreRegisteredAndObservable(t *testing.T) {
+	reg := NewRegistry()
+
+	reg.PeerCount.Set(3)
+	reg.MempoolSize.Set(12)
+	reg.OrphanBlockCount.Inc()
+	reg.GossipFanout.Add(2)
+	reg.BlockProductionLatency.Observe(0.25)
+	reg.ProposalToCommitSeconds.Observe(0.5)
+	reg.SyncCurrentHeight.Set(100)
+	reg.SyncTargetHeight.Set(150)
+	reg.SyncHeadersPerSec.Set(42)
+	reg.SyncBodiesPerSec.Set(7)
+
+	if got := testutil.ToFloat64(reg.PeerCount); got != 3 {
+		t.Fatalf("expected PeerCount 3, got %v", got)
+	}
+	if got := testutil.ToFloat64(reg.MempoolSize); got != 12 {
+		t.Fatalf("expected MempoolSize 12, got %v", got)
+	}
+	if got := testutil.ToFloat64(reg.OrphanBlockCount); got != 1 {
+		t.Fatalf("expected OrphanBlockCount 1, got %v", got)
+	}
+	if got := testutil.ToFloat64(reg.GossipFanout); got != 2 {
+		t.Fatalf("expected GossipFanout 2, got %v", got)
+	}
+	if got := testutil.ToFloat64(reg.SyncCurrentHeight); got != 100 {
+		t.Fatalf("expected SyncCurrentHeight 100, got %v", got)
+	}
+	if got := testutil.ToFloat64(reg.SyncTargetHeight); got != 150 {
+		t.Fatalf("expected SyncTargetHeight 150, got %v", got)
+	}
+
+	families, err := reg.Gatherer().Gather()
+	if err != nil {
+		t.Fatalf("unexpected error gathering metrics: %v", err)
+	}
+	if len(families) != 10 {
+		t.Fatalf("expected all 10 registered metric families, got %d", len(families))
+	}
+}
+
+func TestNewRegistry_IndependentInstancesDoNotShareState(t *testing.T) {
+	a := NewRegistry()
+	b := NewRegistry()
+
+	a.PeerCount.Set(5)
+
+	if got := testutil.ToFloat64(b.PeerCount); got != 0 {
+		t.Fatalf("expected a fresh registry's PeerCount to start at 0, got %v", got)
+	}
+}
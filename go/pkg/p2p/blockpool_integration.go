@@ -0,0 +1,93 @@
+// BlockPool-P2P integration: bridges the generic blockpool.BlockPool
+// staging area to p2p's concrete BlockMessage/TransactionInBlock wire
+// types, the same way consensus_integration.go bridges BlockMessage to
+// consensus.Block. Kept in p2p (rather than in blockpool itself) so
+// blockpool has no dependency on either p2p or mempool and can't form
+// an import cycle with the packages that consume it.
+package p2p
+
+import (
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/blockpool"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
+)
+
+// blockPoolEntry builds a blockpool.Entry from a BlockMessage, carrying
+// the message itself as the opaque Payload so GetBlockByHash can return
+// it unchanged.
+func blockPoolEntry(block *BlockMessage) blockpool.Entry {
+	return blockpool.Entry{
+		Hash:       block.BlockHash,
+		ParentHash: block.ParentHash,
+		Number:     block.BlockNumber,
+		Payload:    block,
+	}
+}
+
+// reinjectBlockTransactions strips the block-specific fields a
+// TransactionInBlock carries (it's the wire shape for a transaction
+// that's already sealed into a block) and resubmits each one to the
+// mempool as an ordinary pending transaction, the same field mapping
+// P2PMessageToBlock uses to reconstruct mempool.Transaction values from
+// a BlockMessage.
+func (m *Manager) reinjectBlockTransactions(payload interface{}) {
+	block, ok := payload.(*BlockMessage)
+	if !ok || m.mempool == nil {
+		return
+	}
+
+	reinjected := 0
+	for _, tx := range block.Transactions {
+		poolTx := &mempool.Transaction{
+			Hash:      tx.TxHash,
+			From:      tx.From,
+			To:        tx.To,
+			Amount:    tx.Amount,
+			Nonce:     tx.Nonce,
+			Fee:       tx.Fee,
+			Signature: tx.Signature,
+		}
+		if err := m.mempool.StoreTx(poolTx); err != nil {
+			m.log.WithError(err).WithField("tx_hash", poolTx.Hash).Debug("Failed to reinject transaction from pruned block")
+			continue
+		}
+		reinjected++
+	}
+
+	if m.blockPool != nil {
+		m.blockPool.AddReinjectedTxs(reinjected)
+	}
+
+	m.log.WithFields(logger.Fields{
+		"block_number": block.BlockNumber,
+		"reinjected":   reinjected,
+	}).Info("Reinjected transactions from pruned block back into mempool")
+}
+
+// PruneLosingBranch evicts the given block hashes from the accepted
+// tier (called by consensus code once a fork-choice round or
+// finalization decides they lost) and reinjects every transaction they
+// carried back into the mempool, so a losing fork doesn't cause user
+// transactions to simply vanish. Returns the number of blocks pruned.
+func (m *Manager) PruneLosingBranch(losingHashes [][32]byte) int {
+	if m.blockPool == nil {
+		return 0
+	}
+	return m.blockPool.PruneAcceptedBlocks(losingHashes, m.reinjectBlockTransactions)
+}
+
+// GetBlockByHash returns a block this node has seen recently, served
+// from the BlockPool staging area (accepted tier checked first, then
+// known), regardless of whether it was ever handed to the consensus
+// engine.
+func (m *Manager) GetBlockByHash(hash [32]byte) (*BlockMessage, bool) {
+	if m.blockPool == nil {
+		return nil, false
+	}
+	payload, ok := m.blockPool.BlockByHash(hash)
+	if !ok {
+		return nil, false
+	}
+	block, ok := payload.(*BlockMessage)
+	return block, ok
+}
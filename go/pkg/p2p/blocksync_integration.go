@@ -0,0 +1,166 @@
+// Fast-sync driver: bridges pkg/blocksync's peer-scheduling BlockPool
+// and out-of-order BlockCache to p2p's concrete BlockMessage wire type
+// and its existing sync stream protocol, the same bridging role
+// blockpool_integration.go and consensus_integration.go play for their
+// own packages.
+//
+// SyncManager's headers-first catch-up (see sync_manager.go) already
+// handles the common case of a peer that's a handful of blocks ahead,
+// pulling from one peer serially. FastSync is for the much-further-behind
+// case this package's own backlog called out: a node started 100+ blocks
+// behind, where fanning requests out across every available peer at once
+// (and banning whichever ones serve bad blocks) finishes far sooner than
+// one peer's serial batches ever could.
+package p2p
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/blocksync"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// FastSync drives catch-up to targetHeight by scheduling single-block
+// requests across peers via a fresh blocksync.BlockPool/BlockCache pair,
+// dispatching every round's requests to their assigned peers concurrently,
+// applying blocks in order as they arrive (buffering ones that land out
+// of order) until the local head reaches targetHeight or every candidate
+// peer has been banned.
+func (m *Manager) FastSync(peers []string, targetHeight uint64) error {
+	if m.host == nil {
+		return fmt.Errorf("host not initialized")
+	}
+	if len(peers) == 0 {
+		return fmt.Errorf("no peers available for fast sync")
+	}
+
+	pool := blocksync.NewBlockPool(blocksync.DefaultConfig(), m.blockSyncPenalizer())
+	cache := blocksync.NewBlockCache()
+	pool.SetPeers(peers)
+
+	for {
+		base, _ := m.currentHeight()
+		if base >= targetHeight {
+			return nil
+		}
+
+		requests := pool.Schedule(base, targetHeight, time.Now())
+		requests = append(requests, pool.RecordTimeout(time.Now())...)
+		m.dispatchBlockRequests(pool, cache, requests)
+
+		applied := 0
+		for _, payload := range cache.PopContiguous(base + 1) {
+			block, ok := payload.(*BlockMessage)
+			if !ok {
+				continue
+			}
+			if err := m.applyBlock(block); err != nil {
+				m.log.WithError(err).WithField("block_number", block.BlockNumber).Warn("Fast-synced block failed to apply")
+				continue
+			}
+			pool.Fulfill(block.BlockNumber)
+			applied++
+		}
+
+		if applied == 0 && len(requests) == 0 && pool.PendingCount() == 0 {
+			return fmt.Errorf("fast sync stalled at height %d: no peers left to request from", base)
+		}
+	}
+}
+
+// dispatchBlockRequests fetches every request's height from its assigned
+// peer concurrently — the point of fanning requests out across multiple
+// peers instead of pulling from one peer serially — banning (via
+// pool.RecordBadBlock) whichever peers fail to answer, and buffering
+// every successful response in cache for PopContiguous to pick up once
+// it's next in line.
+func (m *Manager) dispatchBlockRequests(pool *blocksync.BlockPool, cache *blocksync.BlockCache, requests []blocksync.Request) {
+	var wg sync.WaitGroup
+	for _, req := range requests {
+		wg.Add(1)
+		go func(req blocksync.Request) {
+			defer wg.Done()
+
+			blocks, err := m.requestBlocksFromPeer(req.Peer, []uint64{req.Height})
+			if err != nil || len(blocks) != 1 || blocks[0].BlockNumber != req.Height {
+				m.log.WithError(err).WithFields(logger.Fields{
+					"peer":   req.Peer,
+					"height": req.Height,
+				}).Debug("Fast-sync request failed")
+				pool.RecordBadBlock(req.Peer)
+				return
+			}
+			cache.Add(req.Height, blocks[0].BlockHash, blocks[0])
+		}(req)
+	}
+	wg.Wait()
+}
+
+// requestBlocksFromPeer opens a sync stream to peerID and fetches the
+// given block numbers via the same getBodies/bodies exchange
+// syncWithPeer uses, skipping the headers round entirely since FastSync
+// already knows exactly which heights it wants.
+func (m *Manager) requestBlocksFromPeer(peerID string, numbers []uint64) ([]*BlockMessage, error) {
+	if m.faultInjector != nil && !m.faultInjector.admitSend(peerID) {
+		return nil, fmt.Errorf("peer %s is unreachable (fault injected)", peerID)
+	}
+
+	pid, err := peer.Decode(peerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid peer id %q: %w", peerID, err)
+	}
+
+	stream, err := m.host.GetHost().NewStream(m.ctx, pid, SyncProtocolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sync stream to %s: %w", peerID, err)
+	}
+	defer stream.Close()
+
+	enc := json.NewEncoder(stream)
+	dec := json.NewDecoder(stream)
+
+	// Consume the peer's proactive status message before sending our own
+	// request, so it isn't mistaken for the bodies response.
+	var statusEnv syncEnvelope
+	if err := dec.Decode(&statusEnv); err != nil {
+		return nil, fmt.Errorf("failed to read status from %s: %w", peerID, err)
+	}
+
+	if err := enc.Encode(syncEnvelope{Kind: msgKindGetBodies, Payload: mustMarshal(getBodiesMsg{Numbers: numbers})}); err != nil {
+		return nil, fmt.Errorf("failed to request blocks from %s: %w", peerID, err)
+	}
+
+	var bodiesEnv syncEnvelope
+	if err := dec.Decode(&bodiesEnv); err != nil {
+		return nil, fmt.Errorf("failed to read blocks from %s: %w", peerID, err)
+	}
+	var resp bodiesMsg
+	if err := json.Unmarshal(bodiesEnv.Payload, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse blocks from %s: %w", peerID, err)
+	}
+	return resp.Blocks, nil
+}
+
+// currentHeight returns the local chain head SyncManager is tracking, the
+// same view syncWithPeer uses to decide what's missing.
+func (m *Manager) currentHeight() (uint64, bool) {
+	if m.syncManager == nil {
+		return 0, false
+	}
+	number, _ := m.syncManager.LocalHead()
+	return number, true
+}
+
+// blockSyncPenalizer adapts *PeerScoring to blocksync.PeerPenalizer, the
+// same narrow-adapter approach peerScoringPenalizer uses for
+// dispute.PeerScorer.
+func (m *Manager) blockSyncPenalizer() blocksync.PeerPenalizer {
+	if m.peerScoring == nil {
+		return nil
+	}
+	return &peerScoringPenalizer{scoring: m.peerScoring}
+}
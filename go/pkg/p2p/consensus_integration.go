@@ -6,37 +6,61 @@ import (
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
 )
 
-// BlockToP2PMessage converts a consensus.Block to a p2p.BlockMessage
+// BlockToP2PMessage converts a consensus.Block to a p2p.BlockMessage.
+//
+// Each transaction is given its own Merkle inclusion proof under the
+// block's TxRoot (consensus.BuildMerkleProof over the same tx-hash
+// leaves block.TxRoot() was sealed from), so a light client that only
+// has the header can verify a single gossiped transaction without
+// fetching the rest of the block. See VerifyTxInclusion.
 func BlockToP2PMessage(block *consensus.Block) *BlockMessage {
 	// Convert transactions
-	txs := make([]TransactionInBlock, len(block.Transactions))
-	for i, tx := range block.Transactions {
+	blockTxs := block.Transactions()
+	hashes := make([][32]byte, len(blockTxs))
+	for i, tx := range blockTxs {
+		hashes[i] = tx.Hash
+	}
+
+	txs := make([]TransactionInBlock, len(blockTxs))
+	for i, tx := range blockTxs {
+		var proof [][]byte
+		if _, steps, err := consensus.BuildMerkleProof(hashes, i); err == nil {
+			proof = encodeMerkleProof(steps)
+		}
 		txs[i] = TransactionInBlock{
-			TxHash:    tx.Hash,
-			From:      tx.From,
-			To:        tx.To,
-			Amount:    tx.Amount,
-			Nonce:     tx.Nonce,
-			Fee:       tx.Fee,
-			Signature: tx.Signature,
+			TxHash:      tx.Hash,
+			From:        tx.From,
+			To:          tx.To,
+			Amount:      tx.Amount,
+			Nonce:       tx.Nonce,
+			Fee:         tx.Fee,
+			Signature:   tx.Signature,
+			MerkleProof: proof,
 		}
 	}
 
 	return &BlockMessage{
-		BlockNumber:  block.BlockNumber,
-		ParentHash:   block.ParentHash,
-		StateRoot:    block.StateRoot,
-		TxRoot:       block.TxRoot,
-		Timestamp:    block.Timestamp,
-		Miner:        block.Validator, // Note: Miner field = Validator in PoA
-		Difficulty:   block.Difficulty,
-		Nonce:        block.Nonce,
+		BlockNumber:  block.Number(),
+		ParentHash:   block.ParentHash(),
+		StateRoot:    block.StateRoot(),
+		TxRoot:       block.TxRoot(),
+		Timestamp:    block.Timestamp(),
+		Miner:        block.Validator(), // Note: Miner field = Validator in PoA
+		Difficulty:   block.Difficulty(),
+		Nonce:        block.Nonce(),
 		Transactions: txs,
-		BlockHash:    block.BlockHash,
+		BlockHash:    block.Hash(),
 	}
 }
 
-// P2PMessageToBlock converts a p2p.BlockMessage to a consensus.Block
+// P2PMessageToBlock converts a p2p.BlockMessage to a consensus.Block.
+//
+// Block's fields are unexported, so reconstruction goes through
+// NewBlock (which reseals TxRoot/GasUsed from the transactions) and
+// then WithSeal to restore the wire-carried fields NewBlock doesn't
+// take as parameters (StateRoot, Timestamp, Nonce, Difficulty). The
+// result's Hash() and TxRoot() are recomputed locally rather than
+// trusted off the wire, the same way a freshly-built block would be.
 func P2PMessageToBlock(msg *BlockMessage) *consensus.Block {
 	// Convert transactions
 	txs := make([]*mempool.Transaction, len(msg.Transactions))
@@ -55,18 +79,23 @@ func P2PMessageToBlock(msg *BlockMessage) *consensus.Block {
 		}
 	}
 
-	return &consensus.Block{
-		BlockNumber:  msg.BlockNumber,
-		ParentHash:   msg.ParentHash,
-		StateRoot:    msg.StateRoot,
-		TxRoot:       msg.TxRoot,
-		Timestamp:    msg.Timestamp,
-		Validator:    msg.Miner, // Note: Miner field = Validator in PoA
-		Difficulty:   msg.Difficulty,
-		Nonce:        msg.Nonce,
-		Transactions: txs,
-		BlockHash:    msg.BlockHash,
-		// GasLimit and GasUsed are not transmitted (can be recomputed)
-		// ExtraData is not transmitted (not critical)
-	}
+	block := consensus.NewBlock(msg.BlockNumber, msg.ParentHash, msg.Miner, txs) // Note: Miner field = Validator in PoA
+
+	header := block.Header()
+	header.StateRoot = msg.StateRoot
+	header.Timestamp = msg.Timestamp
+	header.Nonce = msg.Nonce
+	header.Difficulty = msg.Difficulty
+	// GasLimit and GasUsed are not transmitted (recomputed by WithSeal)
+	// ExtraData is not transmitted (not critical)
+
+	return block.WithSeal(header)
+}
+
+// BroadcastConsensusBlock gossips a consensus.Block to peers, converting
+// it to the wire BlockMessage first. It satisfies consensus.Broadcaster
+// structurally, so a *Manager can be handed directly to a
+// consensus.BlockAnnouncer without consensus needing to import p2p.
+func (m *Manager) BroadcastConsensusBlock(block *consensus.Block) error {
+	return m.BroadcastBlock(BlockToP2PMessage(block))
 }
@@ -0,0 +1,82 @@
+// Dispute-P2P integration: bridges pkg/dispute's equivocation detector
+// to p2p's concrete BlockMessage/CIDMessage wire types and to a
+// dedicated evidence gossip topic, the same pattern blockpool_integration.go
+// and consensus_integration.go use to keep the underlying package free of
+// any p2p dependency.
+package p2p
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/dispute"
+)
+
+// EvidenceTopicID is the dedicated pubsub topic equivocation/slashing
+// Evidence is gossiped on, separate from block/tx/CID gossip so a node
+// can subscribe to it alone if it only cares about watching for
+// misbehavior.
+const EvidenceTopicID = "/coinjecture/evidence/1.0.0"
+
+// peerScoringPenalizer adapts *PeerScoring to dispute.PeerScorer, the
+// same narrow-adapter approach BroadcastConsensusBlock uses to satisfy
+// consensus.Broadcaster without consensus importing p2p.
+type peerScoringPenalizer struct {
+	scoring *PeerScoring
+}
+
+func (p *peerScoringPenalizer) ApplyPenalty(identity string, penalty int) {
+	if p.scoring != nil {
+		p.scoring.ApplyPenalty(identity, penalty)
+	}
+}
+
+// publishEvidence gossips freshly detected Evidence to peers over
+// EvidenceTopicID, wired as disputeMgr's GossipHandler.
+func (m *Manager) publishEvidence(ev *dispute.Evidence) error {
+	if m.evidenceTopic == nil {
+		return nil
+	}
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	return m.evidenceTopic.Publish(m.ctx, data)
+}
+
+// consumeEvidenceTopic relays Evidence gossiped by other peers into
+// disputeMgr, which applies the same slash/penalize handling regardless
+// of whether the violation was detected locally or reported externally.
+// Runs until ctx is cancelled (Manager.Stop cancels it and tears down
+// the subscription).
+func (m *Manager) consumeEvidenceTopic(ctx context.Context) {
+	for {
+		msg, err := m.evidenceSub.Next(ctx)
+		if err != nil {
+			return
+		}
+		if m.host != nil && msg.ReceivedFrom == m.host.ID() {
+			continue
+		}
+
+		var evidence dispute.Evidence
+		if err := json.Unmarshal(msg.Data, &evidence); err != nil {
+			m.log.WithError(err).Debug("Failed to decode gossiped evidence")
+			continue
+		}
+		if err := m.disputeMgr.IngestExternalEvidence(&evidence); err != nil {
+			m.log.WithError(err).Warn("Failed to process gossiped evidence")
+		}
+	}
+}
+
+// SetSlashingHandler wires the callback invoked whenever this node
+// detects or verifies a slashable protocol violation (a double-signed
+// block or conflicting solution-CID claim), normally to burn the
+// offender's stake at the state layer.
+func (m *Manager) SetSlashingHandler(handler dispute.SlashingHandler) {
+	if m.disputeMgr != nil {
+		m.disputeMgr.SetSlashingHandler(handler)
+	}
+}
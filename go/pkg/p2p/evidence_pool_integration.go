@@ -0,0 +1,129 @@
+// Evidence-pool-P2P integration: gossips pkg/evidence's pending
+// DuplicateVoteEvidence/LightClientAttackEvidence over a dedicated
+// pubsub topic, the same bridging role dispute_integration.go plays for
+// pkg/dispute's reactive detect-and-slash Evidence. This is a distinct
+// payload and topic from EvidenceTopicID: dispute's Evidence is acted on
+// the moment it's seen, while pkg/evidence.Pool holds typed evidence
+// that's still waiting to be embedded in (or expire before) a future
+// block.
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/evidence"
+)
+
+// EvidencePoolTopicID is the pubsub topic pkg/evidence.Pool entries are
+// gossiped on, kept separate from EvidenceTopicID so a node can
+// subscribe to one without the other.
+const EvidencePoolTopicID = "/coinjecture/evidence-pool/1.0.0"
+
+// wireEvidence is the JSON envelope an evidence.Evidence value is
+// gossiped as: the discriminant Kind plus both concrete payloads, one of
+// which will be zero-valued depending on Kind. A tagged-union-over-JSON
+// approach, the same one syncEnvelope/getBodiesMsg already use for the
+// sync protocol's own message kinds.
+type wireEvidence struct {
+	Kind              evidence.Kind                        `json:"kind"`
+	DuplicateVote     *evidence.DuplicateVoteEvidence       `json:"duplicate_vote,omitempty"`
+	LightClientAttack *evidence.LightClientAttackEvidence   `json:"light_client_attack,omitempty"`
+}
+
+func toWireEvidence(ev evidence.Evidence) (wireEvidence, bool) {
+	switch v := ev.(type) {
+	case *evidence.DuplicateVoteEvidence:
+		return wireEvidence{Kind: evidence.KindDuplicateVote, DuplicateVote: v}, true
+	case *evidence.LightClientAttackEvidence:
+		return wireEvidence{Kind: evidence.KindLightClientAttack, LightClientAttack: v}, true
+	default:
+		return wireEvidence{}, false
+	}
+}
+
+func fromWireEvidence(w wireEvidence) (evidence.Evidence, bool) {
+	switch w.Kind {
+	case evidence.KindDuplicateVote:
+		if w.DuplicateVote == nil {
+			return nil, false
+		}
+		return w.DuplicateVote, true
+	case evidence.KindLightClientAttack:
+		if w.LightClientAttack == nil {
+			return nil, false
+		}
+		return w.LightClientAttack, true
+	default:
+		return nil, false
+	}
+}
+
+// EvidencePool returns this Manager's block-inclusion evidence pool,
+// nil until Start has run. A block builder consults Pending() on it at
+// proposal time; ReportEvidence is how new evidence gets in.
+func (m *Manager) EvidencePool() *evidence.Pool {
+	return m.evidencePool
+}
+
+// ReportEvidence queues ev in the local evidence pool (at currentHeight)
+// and, if it's genuinely new, gossips it to peers over
+// EvidencePoolTopicID — the entry point for anything that detects a
+// DuplicateVoteEvidence/LightClientAttackEvidence locally, e.g. a
+// dispute.Manager hook or a light-client check.
+func (m *Manager) ReportEvidence(ev evidence.Evidence, currentHeight uint64) error {
+	if m.evidencePool == nil {
+		return fmt.Errorf("evidence pool not initialized")
+	}
+	if !m.evidencePool.Add(ev, currentHeight) {
+		return nil
+	}
+	return m.publishPoolEvidence(ev)
+}
+
+// publishPoolEvidence gossips ev to peers over EvidencePoolTopicID, for
+// a caller (normally whatever local code just called pool.Add) to
+// invoke once it has something new to announce.
+func (m *Manager) publishPoolEvidence(ev evidence.Evidence) error {
+	if m.evidencePoolTopic == nil {
+		return nil
+	}
+	wire, ok := toWireEvidence(ev)
+	if !ok {
+		return nil
+	}
+	data, err := json.Marshal(wire)
+	if err != nil {
+		return err
+	}
+	return m.evidencePoolTopic.Publish(m.ctx, data)
+}
+
+// consumeEvidencePoolTopic relays gossiped pool evidence into pool,
+// deduplicating exactly the way a locally detected item would (Pool.Add
+// is itself idempotent by Hash). currentHeight is read fresh on every
+// message via heightFn, since a node's local height keeps advancing
+// while this loop runs. Runs until ctx is cancelled.
+func (m *Manager) consumeEvidencePoolTopic(ctx context.Context, pool *evidence.Pool, heightFn func() uint64) {
+	for {
+		msg, err := m.evidencePoolSub.Next(ctx)
+		if err != nil {
+			return
+		}
+		if m.host != nil && msg.ReceivedFrom == m.host.ID() {
+			continue
+		}
+
+		var wire wireEvidence
+		if err := json.Unmarshal(msg.Data, &wire); err != nil {
+			m.log.WithError(err).Debug("Failed to decode gossiped pool evidence")
+			continue
+		}
+		ev, ok := fromWireEvidence(wire)
+		if !ok {
+			continue
+		}
+		pool.Add(ev, heightFn())
+	}
+}
@@ -0,0 +1,196 @@
+// Programmable network fault injection, so integration tests can write
+// genuine split-brain scenarios instead of only approximating them with
+// timing (see the long-standing comment on TestNetworkPartitionRecovery).
+// FaultInjector sits as middleware in Manager's peer-keyed send/receive
+// paths (the sync protocol's outbound requests and inbound responses),
+// the same bridging role fault_injector.go plays relative to the rest of
+// this package: it has no opinion on wire formats, only on whether a
+// given peer's traffic should be dropped, delayed, or corrupted.
+package p2p
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// FaultInjector holds per-peer fault state: full drops, latency, random
+// drop rate, and a global probability of corrupting a served block. All
+// methods are safe for concurrent use, since Manager's send/receive paths
+// run from multiple goroutines (one per active stream).
+type FaultInjector struct {
+	mu sync.Mutex
+
+	dropped  map[string]bool
+	latency  map[string]time.Duration
+	dropRate map[string]float64
+
+	corruptBlockProb float64
+
+	selfIndex     int
+	haveSelfIndex bool
+	peerIndex     map[int]string
+}
+
+// NewFaultInjector creates a FaultInjector with no faults configured —
+// every peer is reachable at full speed until one of the Drop*/AddLatency
+// methods says otherwise.
+func NewFaultInjector() *FaultInjector {
+	return &FaultInjector{
+		dropped:   make(map[string]bool),
+		latency:   make(map[string]time.Duration),
+		dropRate:  make(map[string]float64),
+		peerIndex: make(map[int]string),
+	}
+}
+
+// DropPeer blocks all traffic to and from peerID until Heal is called.
+func (f *FaultInjector) DropPeer(peerID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.dropped[peerID] = true
+}
+
+// AddLatency delays every message to or from peerID by dur.
+func (f *FaultInjector) AddLatency(peerID string, dur time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.latency[peerID] = dur
+}
+
+// DropRate randomly drops pct (0..1) of messages to or from peerID,
+// independent of any full DropPeer block.
+func (f *FaultInjector) DropRate(peerID string, pct float64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.dropRate[peerID] = pct
+}
+
+// CorruptBlockProb sets the probability (0..1) that a served block's hash
+// is corrupted before being handed to a peer, so tests can verify a
+// requester's own validation rejects a bad response instead of applying
+// it.
+func (f *FaultInjector) CorruptBlockProb(pct float64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.corruptBlockProb = pct
+}
+
+// Heal clears every fault configured so far: drops, latency, drop rates,
+// and block corruption, returning the injector to its pristine state.
+func (f *FaultInjector) Heal() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.dropped = make(map[string]bool)
+	f.latency = make(map[string]time.Duration)
+	f.dropRate = make(map[string]float64)
+	f.corruptBlockProb = 0
+}
+
+// SetSelfIndex records which Partition group index this injector's own
+// node belongs to, so Partition knows whether it applies to this node at
+// all. RegisterPeer should be called once per known peer (using the same
+// index scheme across every node in a test) before Partition is called.
+func (f *FaultInjector) SetSelfIndex(index int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.selfIndex = index
+	f.haveSelfIndex = true
+}
+
+// RegisterPeer records peerID's index in the same global numbering
+// SetSelfIndex and Partition use, so Partition can translate an index
+// group into concrete peer IDs to drop.
+func (f *FaultInjector) RegisterPeer(index int, peerID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.peerIndex[index] = peerID
+}
+
+// Partition drops all traffic between groupA and groupB from this node's
+// side of the split: if this node's own index (set via SetSelfIndex) is
+// in one group, every peer in the other group is dropped; if it's in
+// neither group, Partition has no effect here (the node isn't part of
+// the split). Calling Partition identically on every node in a test
+// produces a full two-sided network partition.
+func (f *FaultInjector) Partition(groupA, groupB []int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.haveSelfIndex {
+		return
+	}
+
+	var other []int
+	switch {
+	case containsIndex(groupA, f.selfIndex):
+		other = groupB
+	case containsIndex(groupB, f.selfIndex):
+		other = groupA
+	default:
+		return
+	}
+
+	for _, idx := range other {
+		if peerID, ok := f.peerIndex[idx]; ok {
+			f.dropped[peerID] = true
+		}
+	}
+}
+
+func containsIndex(indices []int, target int) bool {
+	for _, i := range indices {
+		if i == target {
+			return true
+		}
+	}
+	return false
+}
+
+// admitSend reports whether a message to/from peerID should go through,
+// applying that peer's configured latency (by sleeping, if any) before
+// returning true. It's used on both the outbound and inbound side of a
+// stream, since a dropped peer's traffic is blocked in both directions.
+func (f *FaultInjector) admitSend(peerID string) bool {
+	f.mu.Lock()
+	dropped := f.dropped[peerID]
+	rate := f.dropRate[peerID]
+	delay := f.latency[peerID]
+	f.mu.Unlock()
+
+	if dropped {
+		return false
+	}
+	if rate > 0 && rand.Float64() < rate {
+		return false
+	}
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+	return true
+}
+
+// maybeCorruptBlock flips a byte of block's hash with the configured
+// CorruptBlockProb, simulating a peer that occasionally serves a
+// corrupted block, so the requester's own header/body validation has
+// something real to reject.
+func (f *FaultInjector) maybeCorruptBlock(block *BlockMessage) *BlockMessage {
+	f.mu.Lock()
+	prob := f.corruptBlockProb
+	f.mu.Unlock()
+
+	if prob <= 0 || block == nil || rand.Float64() >= prob {
+		return block
+	}
+
+	corrupted := *block
+	corrupted.BlockHash[0] ^= 0xFF
+	return &corrupted
+}
+
+// FaultInjector returns this Manager's fault injector. NewManager
+// allocates one up front, so it's always safe to call, even before
+// Start has run.
+func (m *Manager) FaultInjector() *FaultInjector {
+	return m.faultInjector
+}
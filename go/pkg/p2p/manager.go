@@ -3,13 +3,21 @@ package p2p
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/beacon"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/blockpool"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/config"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/consensus/bft"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/dispute"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/evidence"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/mempool"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/metrics"
 	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
 )
 
 // Manager orchestrates all P2P networking components
@@ -19,11 +27,50 @@ type Manager struct {
 	log    *logger.Logger
 
 	// Core P2P components
-	host          *Host
-	txGossip      *TransactionGossip
-	blockGossip   *BlockGossip
-	cidGossip     *CIDGossip
-	peerScoring   *PeerScoring
+	host        *Host
+	txGossip    *TransactionGossip
+	blockGossip *BlockGossip
+	cidGossip   *CIDGossip
+	peerScoring *PeerScoring
+	syncManager *SyncManager
+	blockPool   *blockpool.BlockPool
+	disputeMgr  *dispute.Manager
+
+	// faultInjector lets integration tests drop, delay, or corrupt
+	// traffic to specific peers (see fault_injector.go); it's always
+	// present but inert (no faults configured) outside of tests.
+	faultInjector *FaultInjector
+
+	// pubsub is the shared GossipSub instance blockGossip/cidGossip are
+	// built on; kept here too so other pubsub topics (e.g. evidence
+	// gossip) can be joined without plumbing it through every component.
+	pubsub        *pubsub.PubSub
+	evidenceTopic *pubsub.Topic
+	evidenceSub   *pubsub.Subscription
+
+	// evidencePool holds pending DuplicateVoteEvidence/
+	// LightClientAttackEvidence waiting to be embedded in a future
+	// block (see pkg/evidence), gossiped separately from the
+	// detect-and-slash evidenceTopic/evidenceSub pair above.
+	evidencePool      *evidence.Pool
+	evidencePoolTopic *pubsub.Topic
+	evidencePoolSub   *pubsub.Subscription
+
+	// voteTopic/voteSub are the gossip topic bft.Vote prevotes/
+	// precommits travel over (see vote_integration.go); voteInbox is
+	// what VoteGossip.Inbox hands a BFT driver, buffered the same way
+	// evidenceSub's consumers tolerate a burst without blocking the
+	// pubsub goroutine.
+	voteTopic *pubsub.Topic
+	voteSub   *pubsub.Subscription
+	voteInbox chan bft.Vote
+
+	// metrics and events are the shared Prometheus registry and
+	// in-process pub/sub bus (see pkg/metrics); both are always present,
+	// set once here and never reassigned, so unlike syncManager/host they
+	// need no mutex to read safely from notify callbacks.
+	metrics *metrics.Registry
+	events  *metrics.EventBus
 
 	// Application components
 	mempool *mempool.Mempool
@@ -31,6 +78,11 @@ type Manager struct {
 
 	// Consensus engine callback
 	onConsensusBlock func(*BlockMessage) error
+	headerValidator  HeaderValidator
+
+	// Randomness beacon (drand), used to seed CIDGossip's equilibrium
+	// jitter and exposed for consensus leader/validator selection.
+	beaconAPI beacon.BeaconAPI
 
 	// Shutdown
 	ctx    context.Context
@@ -48,12 +100,16 @@ func NewManager(
 	ctx, cancel := context.WithCancel(ctx)
 
 	m := &Manager{
-		config:  cfg,
-		log:     log,
-		mempool: mp,
-		state:   sm,
-		ctx:     ctx,
-		cancel:  cancel,
+		config:        cfg,
+		log:           log,
+		mempool:       mp,
+		state:         sm,
+		ctx:           ctx,
+		cancel:        cancel,
+		faultInjector: NewFaultInjector(),
+		metrics:       metrics.NewRegistry(),
+		events:        metrics.NewEventBus(),
+		voteInbox:     make(chan bft.Vote, 256),
 	}
 
 	log.WithFields(logger.Fields{
@@ -93,6 +149,7 @@ func (m *Manager) Start(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to create pubsub: %w", err)
 	}
+	m.pubsub = ps
 
 	// 3. Initialize peer scoring
 	m.peerScoring = NewPeerScoring(
@@ -143,13 +200,103 @@ func (m *Manager) Start(ctx context.Context) error {
 	}
 	m.cidGossip = cidGossip
 
+	// 7. Initialize headers-first chain sync (parallel to blockGossip)
+	syncManager := NewSyncManager(m.headerValidator, m.handleBlockReceived, m.log)
+	syncManager.SetFaultInjector(m.faultInjector)
+	syncManager.registerWith(m.ctx, host.GetHost())
+	m.syncManager = syncManager
+
+	// Observability: report every new connection as a PeerConnected
+	// event and keep the PeerCount gauge current, independent of
+	// SyncManager's own connection notify above (libp2p allows more
+	// than one Notifiee on the same host).
+	host.GetHost().Network().Notify(&libp2pnetwork.NotifyBundle{
+		ConnectedF: func(_ libp2pnetwork.Network, conn libp2pnetwork.Conn) {
+			m.metrics.PeerCount.Set(float64(m.PeerCount()))
+			m.events.Publish(metrics.PeerConnectedEvent{PeerID: conn.RemotePeer().String()})
+		},
+		DisconnectedF: func(_ libp2pnetwork.Network, conn libp2pnetwork.Conn) {
+			m.metrics.PeerCount.Set(float64(m.PeerCount()))
+		},
+	})
+
+	// Staging area between blockGossip and the consensus callback, so a
+	// block that loses a fork doesn't take its transactions with it.
+	m.blockPool = blockpool.NewBlockPool(blockpool.DefaultConfig())
+
+	// Serve light-client Merkle inclusion proofs for transactions in
+	// blocks this node has seen (backed by the block pool above).
+	m.registerTxProofHandler()
+
+	// 8. Subscribe to the randomness beacon, if one is wired, so newly
+	// published rounds seed CIDGossip's equilibrium jitter as they land.
+	if m.beaconAPI != nil {
+		go m.consumeBeaconEntries(m.beaconAPI.NewEntries())
+	}
+
+	// 9. Initialize equivocation/slashing-evidence detection and its
+	// dedicated gossip topic.
+	m.disputeMgr = dispute.NewManager(&peerScoringPenalizer{scoring: m.peerScoring}, dispute.DefaultSeverePenalty, m.log)
+	m.disputeMgr.SetGossipHandler(m.publishEvidence)
+
+	evidenceTopic, err := ps.Join(EvidenceTopicID)
+	if err != nil {
+		return fmt.Errorf("failed to join evidence topic: %w", err)
+	}
+	m.evidenceTopic = evidenceTopic
+
+	evidenceSub, err := evidenceTopic.Subscribe()
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to evidence topic: %w", err)
+	}
+	m.evidenceSub = evidenceSub
+	go m.consumeEvidenceTopic(m.ctx)
+
+	// 10. Initialize the block-inclusion evidence pool and its own
+	// dedicated gossip topic (see evidence_pool_integration.go).
+	m.evidencePool = evidence.NewPool(evidence.DefaultConfig())
+
+	evidencePoolTopic, err := ps.Join(EvidencePoolTopicID)
+	if err != nil {
+		return fmt.Errorf("failed to join evidence pool topic: %w", err)
+	}
+	m.evidencePoolTopic = evidencePoolTopic
+
+	evidencePoolSub, err := evidencePoolTopic.Subscribe()
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to evidence pool topic: %w", err)
+	}
+	m.evidencePoolSub = evidencePoolSub
+	go m.consumeEvidencePoolTopic(m.ctx, m.evidencePool, func() uint64 {
+		height, _ := m.currentHeight()
+		return height
+	})
+
+	// 11. Join the BFT prevote/precommit vote-gossip topic (see
+	// vote_integration.go), so VoteTransport() is usable the moment a
+	// consensus driver starts reading from its Inbox.
+	voteTopic, err := ps.Join(VoteTopicID)
+	if err != nil {
+		return fmt.Errorf("failed to join vote topic: %w", err)
+	}
+	m.voteTopic = voteTopic
+
+	voteSub, err := voteTopic.Subscribe()
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to vote topic: %w", err)
+	}
+	m.voteSub = voteSub
+	go m.consumeVoteTopic(m.ctx)
+
 	m.log.WithFields(logger.Fields{
-		"peer_id":       host.ID().String(),
-		"peer_count":    host.PeerCount(),
-		"tx_gossip":     "enabled",
-		"block_gossip":  "enabled",
-		"cid_gossip":    "enabled",
-		"peer_scoring":  "enabled",
+		"peer_id":      host.ID().String(),
+		"peer_count":   host.PeerCount(),
+		"tx_gossip":    "enabled",
+		"block_gossip": "enabled",
+		"cid_gossip":   "enabled",
+		"peer_scoring": "enabled",
+		"sync_manager": "enabled",
+		"dispute":      "enabled",
 	}).Info("P2P manager started successfully")
 
 	return nil
@@ -160,6 +307,24 @@ func (m *Manager) Stop() {
 	m.log.Info("Stopping P2P manager")
 
 	// Shutdown in reverse order
+	if m.voteSub != nil {
+		m.voteSub.Cancel()
+	}
+	if m.voteTopic != nil {
+		m.voteTopic.Close()
+	}
+	if m.evidencePoolSub != nil {
+		m.evidencePoolSub.Cancel()
+	}
+	if m.evidencePoolTopic != nil {
+		m.evidencePoolTopic.Close()
+	}
+	if m.evidenceSub != nil {
+		m.evidenceSub.Cancel()
+	}
+	if m.evidenceTopic != nil {
+		m.evidenceTopic.Close()
+	}
 	if m.cidGossip != nil {
 		m.cidGossip.Close()
 	}
@@ -186,12 +351,14 @@ func (m *Manager) Stop() {
 func (m *Manager) BroadcastTransaction(tx *mempool.Transaction) {
 	if m.txGossip != nil {
 		m.txGossip.BroadcastTransaction(tx)
+		m.metrics.GossipFanout.Inc()
 	}
 }
 
 // BroadcastBlock broadcasts a block to the network
 func (m *Manager) BroadcastBlock(block *BlockMessage) error {
 	if m.blockGossip != nil {
+		m.metrics.GossipFanout.Inc()
 		return m.blockGossip.BroadcastBlock(block)
 	}
 	return fmt.Errorf("block gossip not initialized")
@@ -202,6 +369,69 @@ func (m *Manager) SetConsensusBlockHandler(handler func(*BlockMessage) error) {
 	m.onConsensusBlock = handler
 }
 
+// SetHeaderValidator sets the header chain validator SyncManager uses to
+// check a peer's headers before downloading bodies for them. Safe to
+// call either before Start (stored for SyncManager's construction) or
+// after (forwarded directly, since the consensus engine — the usual
+// validator — is often only created once P2P networking is already up).
+func (m *Manager) SetHeaderValidator(validator HeaderValidator) {
+	m.headerValidator = validator
+	if m.syncManager != nil {
+		m.syncManager.SetValidator(validator)
+	}
+}
+
+// SyncStatus reports headers-first sync progress: target height,
+// current height, and how many connected peers are caught up.
+func (m *Manager) SyncStatus() SyncStatus {
+	if m.syncManager == nil {
+		return SyncStatus{}
+	}
+	return m.syncManager.Status()
+}
+
+// SetBeacon wires a randomness beacon (normally a beacon.DrandBeacon,
+// or a beacon.MockBeacon in tests). Safe to call either before Start
+// (subscribed to once Start runs) or after (subscribed to immediately).
+func (m *Manager) SetBeacon(b beacon.BeaconAPI) {
+	m.beaconAPI = b
+	if m.ctx != nil {
+		go m.consumeBeaconEntries(b.NewEntries())
+	}
+}
+
+// LatestBeacon returns the most recently observed beacon entry, for
+// consensus code that wants it for validator/leader selection. Returns
+// an error if no beacon is wired or no round has been observed yet.
+func (m *Manager) LatestBeacon() (beacon.BeaconEntry, error) {
+	if m.beaconAPI == nil {
+		return beacon.BeaconEntry{}, fmt.Errorf("no randomness beacon wired")
+	}
+	round := m.beaconAPI.LatestRound()
+	if round == 0 {
+		return beacon.BeaconEntry{}, fmt.Errorf("randomness beacon has not observed a round yet")
+	}
+	return m.beaconAPI.Entry(m.ctx, round)
+}
+
+// consumeBeaconEntries seeds CIDGossip's equilibrium jitter from every
+// newly observed beacon entry, XORed against the local chain's current
+// head so all honest nodes — which see the same beacon entry and
+// (once in sync) the same head — derive identical jitter schedules.
+func (m *Manager) consumeBeaconEntries(entries <-chan beacon.BeaconEntry) {
+	for entry := range entries {
+		var blockNumber uint64
+		if m.syncManager != nil {
+			blockNumber, _ = m.syncManager.LocalHead()
+		}
+
+		seed := beacon.EquilibriumSeed(entry, blockNumber)
+		if m.cidGossip != nil {
+			m.cidGossip.SetEquilibriumSeed(seed)
+		}
+	}
+}
+
 // AnnounceCID announces a CID to the network (equilibrium gossip)
 func (m *Manager) AnnounceCID(cid string, cidType string, blockNumber uint64) {
 	if m.cidGossip == nil {
@@ -217,7 +447,22 @@ func (m *Manager) AnnounceCID(cid string, cidType string, blockNumber uint64) {
 		m.cidGossip.AnnounceBlockCID(cid, blockNumber, 0)
 	default:
 		m.log.WithField("type", cidType).Warn("Unknown CID type")
+		return
 	}
+	m.metrics.GossipFanout.Inc()
+}
+
+// Metrics returns the Prometheus registry this manager reports to, so
+// callers can wire it into an HTTP /metrics handler or a test assertion.
+func (m *Manager) Metrics() *metrics.Registry {
+	return m.metrics
+}
+
+// EventBus returns the in-process event bus this manager publishes to,
+// so callers can subscribe to e.g. metrics.EventPeerConnected instead of
+// polling a getter in a loop.
+func (m *Manager) EventBus() *metrics.EventBus {
+	return m.events
 }
 
 // PeerCount returns number of connected peers
@@ -275,19 +520,83 @@ func (m *Manager) GetNetworkStats() map[string]interface{} {
 		stats["mempool_size"] = m.mempool.Size()
 	}
 
+	// Sync manager stats
+	if m.syncManager != nil {
+		status := m.syncManager.Status()
+		stats["sync_syncing"] = status.Syncing
+		stats["sync_current_height"] = status.CurrentHeight
+		stats["sync_target_height"] = status.TargetHeight
+		stats["sync_peers_in_sync"] = status.PeersInSync
+
+		m.metrics.SyncCurrentHeight.Set(float64(status.CurrentHeight))
+		m.metrics.SyncTargetHeight.Set(float64(status.TargetHeight))
+		m.metrics.SyncHeadersPerSec.Set(status.HeadersPerSec)
+		m.metrics.SyncBodiesPerSec.Set(status.BodiesPerSec)
+	}
+
+	// Block pool stats
+	if m.blockPool != nil {
+		poolStats := m.blockPool.Stats()
+		stats["known_count"] = poolStats.KnownCount
+		stats["accepted_count"] = poolStats.AcceptedCount
+		stats["reinjected_txs"] = poolStats.ReinjectedTxs
+	}
+
 	return stats
 }
 
 // ==================== INTERNAL HANDLERS ====================
 
-// handleBlockReceived processes blocks received from network
+// handleBlockReceived processes blocks received from network. Blocks
+// that arrive out of order (e.g. gossip racing ahead of a still-running
+// sync) are stashed in the sync manager's bounded orphan pool instead of
+// being rejected outright; applying the missing parent (from gossip or
+// from sync) promotes them automatically.
 func (m *Manager) handleBlockReceived(block *BlockMessage) error {
+	if m.blockPool != nil && !m.blockPool.MarkKnown(blockPoolEntry(block)) {
+		m.log.WithField("block_number", block.BlockNumber).Debug("Ignoring already-known block")
+		return nil
+	}
+
+	if m.syncManager != nil {
+		if localNumber, _ := m.syncManager.LocalHead(); block.BlockNumber > localNumber+1 {
+			m.syncManager.HandleOutOfOrderBlock(block)
+			m.metrics.OrphanBlockCount.Set(float64(m.syncManager.OrphanCount()))
+			return nil
+		}
+	}
+
+	if err := m.applyBlock(block); err != nil {
+		return err
+	}
+
+	if m.syncManager != nil {
+		m.syncManager.PromoteReadyOrphans(m.applyBlock)
+		m.metrics.OrphanBlockCount.Set(float64(m.syncManager.OrphanCount()))
+	}
+
+	return nil
+}
+
+// applyBlock logs, forwards to the consensus engine callback, and
+// records a single block that is already known to be next-in-order.
+func (m *Manager) applyBlock(block *BlockMessage) error {
 	m.log.WithFields(logger.Fields{
 		"block_number": block.BlockNumber,
 		"block_hash":   fmt.Sprintf("%x", block.BlockHash[:8]),
 		"tx_count":     len(block.Transactions),
 	}).Info("Processing block from network")
 
+	// Check for a double-signed block before handing it to consensus:
+	// two distinct blocks from the same proposer at the same height is
+	// provable equivocation regardless of which one (if either) consensus
+	// ultimately accepts.
+	if m.disputeMgr != nil {
+		if _, err := m.disputeMgr.ObserveBlock(block.Miner, block.BlockNumber, block.BlockHash, block.Signature); err != nil {
+			m.log.WithError(err).WithField("block_number", block.BlockNumber).Warn("Failed to process double-sign evidence")
+		}
+	}
+
 	// Forward to consensus engine if callback is set
 	if m.onConsensusBlock != nil {
 		if err := m.onConsensusBlock(block); err != nil {
@@ -298,6 +607,25 @@ func (m *Manager) handleBlockReceived(block *BlockMessage) error {
 		m.log.Warn("No consensus engine callback set - block not processed")
 	}
 
+	if m.syncManager != nil {
+		m.syncManager.RecordBlock(headerFromBlockMessage(block), block)
+	}
+
+	if m.blockPool != nil {
+		m.blockPool.Accept(blockPoolEntry(block))
+	}
+
+	// BlockProductionLatency/ProposalToCommitSeconds are deferred: both
+	// need a proposal timestamp that only a real consensus.Engine (which
+	// doesn't exist in this tree yet, see clock.go) would hand us. Report
+	// what's real and available instead: that a block reached this node's
+	// head.
+	m.events.Publish(metrics.BlockCommittedEvent{
+		Height:    block.BlockNumber,
+		Hash:      block.BlockHash,
+		Validator: block.Miner,
+	})
+
 	return nil
 }
 
@@ -310,6 +638,16 @@ func (m *Manager) handleCIDReceived(cid *CIDMessage) error {
 		"publisher":    cid.Publisher,
 	}).Info("Processing CID from network")
 
+	// Conflicting solution CIDs from the same publisher for the same
+	// block are provable evidence the publisher is claiming more than
+	// one answer to the same problem round.
+	if cid.Type == "solution" && m.disputeMgr != nil {
+		publisher := sha256.Sum256([]byte(cid.Publisher))
+		if _, err := m.disputeMgr.ObserveSolutionCID(publisher, cid.BlockNumber, cid.CID); err != nil {
+			m.log.WithError(err).WithField("cid", cid.CID).Warn("Failed to process conflicting-solution evidence")
+		}
+	}
+
 	// TODO: Fetch CID content from IPFS
 	// TODO: Validate content matches CID
 	// TODO: Store in local IPFS node
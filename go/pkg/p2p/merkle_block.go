@@ -0,0 +1,263 @@
+// Partial Merkle trees for SPV-style "is transaction X in block B"
+// proofs that cover many transactions at once, Bitcoin BIP 37 style.
+//
+// tx_proof.go's Proof already answers this for a single transaction via
+// consensus.BuildMerkleProof; MerkleBlock generalizes that to a batch: a
+// light client sends a bloom-filter-style match list (or a block
+// explorer precomputes one for "transactions touching address A"), and
+// BuildPartialMerkleTree returns the minimal set of hashes and a flag
+// bitstream that lets ExtractMatches reconstruct the root and recover
+// just the matched leaves, without the unmatched transactions ever
+// being sent.
+//
+// The traversal and root computation intentionally match
+// consensus.pureGoBackend.MerkleRoot's plain-SHA-256, duplicate-the-
+// last-node-per-level rule (MerkleSchemeLegacy), not
+// consensus.MerkleSchemeRFC6962: TxRoot on real blocks is still sealed
+// under the legacy scheme (see tx_proof.go's deferral note), so a
+// MerkleBlock must reconstruct a root under the same scheme to ever
+// match BlockHeader.TxRoot.
+package p2p
+
+import (
+	"fmt"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/consensus"
+)
+
+// MerkleBlock is a partial Merkle tree proving that some subset of a
+// block's transactions are included under Header.TxRoot, without
+// carrying the rest of the block's transactions.
+//
+// Header is *consensus.Header rather than the BlockHeader the request
+// describes: BlockHeader (rust_bindings.go) is the CGO-only mirror of
+// the Rust FFI struct, but every concrete header this package actually
+// hands around on the wire (sync_manager.go's chainState, blocksync
+// responses) is a *consensus.Header — see headerFromBlockMessage. Using
+// the real type keeps MerkleBlock buildable without CGO, the same
+// reasoning tx_proof.go and consensus/mmr already apply.
+type MerkleBlock struct {
+	Header  *consensus.Header
+	TotalTx uint32
+	Hashes  [][32]byte
+	Flags   []byte
+}
+
+// calcPartialTreeWidth returns the number of nodes at a given height in
+// the conceptual tree over totalTx leaves, where height 0 is the leaves
+// and height increases toward the root. This is the same
+// ceil(totalTx / 2^height) shape consensus.pureGoBackend.MerkleRoot
+// walks level by level.
+func calcPartialTreeWidth(totalTx uint32, height uint) uint32 {
+	return (totalTx + (1 << height) - 1) >> height
+}
+
+// calcPartialTreeHeight returns ceil(log2(totalTx)), the height of the
+// root above the leaves (0 for a single leaf).
+func calcPartialTreeHeight(totalTx uint32) uint {
+	height := uint(0)
+	for calcPartialTreeWidth(totalTx, height) > 1 {
+		height++
+	}
+	return height
+}
+
+// calcHash computes the hash of the node at (height, pos) in the
+// conceptual tree over txHashes, duplicating the last node at any
+// unbalanced level exactly as consensus.pureGoBackend.MerkleRoot does,
+// so it agrees with the root a real block sealed its TxRoot under.
+func calcHash(height uint, pos uint32, txHashes [][32]byte) [32]byte {
+	if height == 0 {
+		return txHashes[pos]
+	}
+
+	width := calcPartialTreeWidth(uint32(len(txHashes)), height-1)
+	left := calcHash(height-1, pos*2, txHashes)
+	right := left
+	if pos*2+1 < width {
+		right = calcHash(height-1, pos*2+1, txHashes)
+	}
+	return hashPair(left, right)
+}
+
+// hashPair mirrors consensus's unexported hashPair: plain
+// SHA-256(left || right), the legacy scheme's node hash.
+func hashPair(left, right [32]byte) [32]byte {
+	buf := make([]byte, 0, 64)
+	buf = append(buf, left[:]...)
+	buf = append(buf, right[:]...)
+	return consensus.DefaultBackend().SHA256(buf)
+}
+
+// BuildPartialMerkleTree builds a MerkleBlock proving inclusion of the
+// transactions marked true in matched, via depth-first traversal of the
+// conceptual tree of height calcPartialTreeHeight(len(txHashes)): at
+// each node a 0 flag bit means "this subtree has no match, here is its
+// hash, stop recursing", a 1 bit means "recurse into children" (or, at a
+// leaf, "this leaf matched — emit its hash too"). Header is left nil;
+// callers that have one (e.g. BlockToP2PMessage's caller) set it
+// afterward.
+func BuildPartialMerkleTree(txHashes [][32]byte, matched []bool) *MerkleBlock {
+	mb := &MerkleBlock{TotalTx: uint32(len(txHashes))}
+	if len(txHashes) == 0 {
+		return mb
+	}
+
+	height := calcPartialTreeHeight(uint32(len(txHashes)))
+
+	var bits []bool
+	var traverse func(h uint, pos uint32)
+	traverse = func(h uint, pos uint32) {
+		// Scan this node's leaf range directly rather than recursing
+		// into children to compute it: that's what lets an unmatched
+		// subtree stop here instead of descending to emit (and
+		// duplicate) every leaf hash underneath it.
+		lo := pos << h
+		hi := lo + (1 << h)
+		if hi > uint32(len(txHashes)) {
+			hi = uint32(len(txHashes))
+		}
+		match := false
+		for i := lo; i < hi; i++ {
+			if matched[i] {
+				match = true
+				break
+			}
+		}
+		bits = append(bits, match)
+
+		if h == 0 || !match {
+			mb.Hashes = append(mb.Hashes, calcHash(h, pos, txHashes))
+			return
+		}
+
+		width := calcPartialTreeWidth(uint32(len(txHashes)), h-1)
+		traverse(h-1, pos*2)
+		if pos*2+1 < width {
+			traverse(h-1, pos*2+1)
+		}
+	}
+	traverse(height, 0)
+
+	mb.Flags = packBits(bits)
+	return mb
+}
+
+// packBits packs bits into bytes, LSB first, the BIP 37 wire convention:
+// bit i of bits lands in byte i/8, position i%8.
+func packBits(bits []bool) []byte {
+	flags := make([]byte, (len(bits)+7)/8)
+	for i, b := range bits {
+		if b {
+			flags[i/8] |= 1 << uint(i%8)
+		}
+	}
+	return flags
+}
+
+// unpackBit reads bit i (LSB first) out of flags, reporting false (not
+// an error) if i is out of range — callers are expected to bounds-check
+// the number of flag bits consumed separately.
+func unpackBit(flags []byte, i int) bool {
+	byteIdx, bitIdx := i/8, uint(i%8)
+	if byteIdx >= len(flags) {
+		return false
+	}
+	return flags[byteIdx]&(1<<bitIdx) != 0
+}
+
+// ExtractMatches walks mb.Flags/mb.Hashes in the same depth-first order
+// BuildPartialMerkleTree emitted them, reconstructing the root and
+// collecting every matched leaf's hash. It refuses to validate a
+// MerkleBlock that doesn't consume every hash and flag bit it carries,
+// or that carries unused non-zero flag bits in its last byte, since
+// either is a sign the tree was built for a different TotalTx or was
+// tampered with.
+func (mb *MerkleBlock) ExtractMatches() (root [32]byte, matchedHashes [][32]byte, err error) {
+	if mb.TotalTx == 0 {
+		return root, nil, fmt.Errorf("merkle block has TotalTx == 0")
+	}
+
+	height := calcPartialTreeHeight(mb.TotalTx)
+
+	bitPos, hashPos := 0, 0
+
+	var traverse func(h uint, pos uint32) ([32]byte, error)
+	traverse = func(h uint, pos uint32) ([32]byte, error) {
+		if bitPos/8 >= len(mb.Flags) {
+			return root, fmt.Errorf("flag bitstream exhausted while traversing")
+		}
+		match := unpackBit(mb.Flags, bitPos)
+		bitPos++
+
+		if h == 0 || !match {
+			if hashPos >= len(mb.Hashes) {
+				return root, fmt.Errorf("ran out of hashes while traversing")
+			}
+			hash := mb.Hashes[hashPos]
+			hashPos++
+
+			if h == 0 && match {
+				matchedHashes = append(matchedHashes, hash)
+			}
+			return hash, nil
+		}
+
+		width := calcPartialTreeWidth(mb.TotalTx, h-1)
+		left, err := traverse(h-1, pos*2)
+		if err != nil {
+			return root, err
+		}
+		right := left
+		if pos*2+1 < width {
+			right, err = traverse(h-1, pos*2+1)
+			if err != nil {
+				return root, err
+			}
+		}
+		return hashPair(left, right), nil
+	}
+
+	root, err = traverse(height, 0)
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+
+	if hashPos != len(mb.Hashes) {
+		return [32]byte{}, nil, fmt.Errorf("merkle block carries %d unused hash(es)", len(mb.Hashes)-hashPos)
+	}
+
+	consumedBytes := (bitPos + 7) / 8
+	if consumedBytes != len(mb.Flags) {
+		return [32]byte{}, nil, fmt.Errorf("merkle block carries %d unused flag byte(s)", len(mb.Flags)-consumedBytes)
+	}
+	if lastByteUnusedBits(mb.Flags, bitPos) {
+		return [32]byte{}, nil, fmt.Errorf("merkle block's trailing flag bits are non-zero but unused")
+	}
+
+	if mb.Header != nil && root != mb.Header.TxRoot {
+		return root, matchedHashes, fmt.Errorf("reconstructed root %x does not match header TxRoot %x", root[:8], mb.Header.TxRoot[:8])
+	}
+
+	return root, matchedHashes, nil
+}
+
+// lastByteUnusedBits reports whether any bit past consumedBits in
+// flags' final byte is set, catching a MerkleBlock whose flag bitstream
+// was padded with garbage instead of zeros.
+func lastByteUnusedBits(flags []byte, consumedBits int) bool {
+	if len(flags) == 0 {
+		return false
+	}
+	lastByte := flags[len(flags)-1]
+	firstUnused := consumedBits % 8
+	if consumedBits > 0 && firstUnused == 0 {
+		return false
+	}
+	for i := uint(firstUnused); i < 8; i++ {
+		if lastByte&(1<<i) != 0 {
+			return true
+		}
+	}
+	return false
+}
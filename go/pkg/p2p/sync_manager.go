@@ -0,0 +1,783 @@
+// Headers-first chain-sync: catch-up path for a peer that is behind or
+// has just joined, parallel to the steady-state blockGossip/cidGossip
+// propagation paths.
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/consensus"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// SyncProtocolID is the libp2p stream protocol SyncManager speaks,
+// separate from the pubsub topics blockGossip/txGossip/cidGossip use:
+// sync is a point-to-point request/response exchange, not a broadcast.
+const SyncProtocolID protocol.ID = "/coinjecture/sync/1.0.0"
+
+const (
+	// headerBatchSize bounds how many headers SyncManager requests per
+	// GetHeaders round-trip.
+	headerBatchSize = 256
+
+	// maxOrphanBlocks bounds the out-of-order gossip orphan pool so a
+	// peer (or a burst of reordered gossip) can't force unbounded
+	// memory growth by sending blocks whose parent hasn't arrived yet.
+	maxOrphanBlocks = 1024
+)
+
+// StatusMsg is exchanged at the start of every sync stream so both sides
+// know, before any headers are requested, whether the other is ahead,
+// behind, or on an incompatible chain (a Genesis mismatch).
+type StatusMsg struct {
+	Genesis    [32]byte `json:"genesis"`
+	Head       [32]byte `json:"head"`
+	HeadNumber uint64   `json:"head_number"`
+	TotalWork  uint64   `json:"total_work"`
+}
+
+// syncMsgKind dispatches a decoded syncEnvelope's Payload to the right
+// type on the receiving side.
+type syncMsgKind string
+
+const (
+	msgKindStatus     syncMsgKind = "status"
+	msgKindGetHeaders syncMsgKind = "get_headers"
+	msgKindHeaders    syncMsgKind = "headers"
+	msgKindGetBodies  syncMsgKind = "get_bodies"
+	msgKindBodies     syncMsgKind = "bodies"
+)
+
+// syncEnvelope wraps every message sent over a sync stream. Messages are
+// newline-delimited JSON (one json.Encoder.Encode call per message),
+// the same low-ceremony wire style the rest of this package's gossip
+// messages use.
+type syncEnvelope struct {
+	Kind    syncMsgKind     `json:"kind"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// getHeadersMsg requests a batch of headers starting after FromNumber,
+// up to Max headers, in ascending order.
+type getHeadersMsg struct {
+	FromNumber uint64 `json:"from_number"`
+	Max        uint32 `json:"max"`
+}
+
+type headersMsg struct {
+	Headers []*consensus.Header `json:"headers"`
+}
+
+// getBodiesMsg requests the full blocks (as the same BlockMessage wire
+// type blockGossip already uses) for the given block numbers.
+type getBodiesMsg struct {
+	Numbers []uint64 `json:"numbers"`
+}
+
+type bodiesMsg struct {
+	Blocks []*BlockMessage `json:"blocks"`
+}
+
+// HeaderValidator is the subset of BlockProcessor SyncManager needs to
+// check a fetched header chain before downloading full bodies for it,
+// so an invalid or equivocating chain is rejected before any bandwidth
+// is spent on transaction data.
+type HeaderValidator interface {
+	ValidateHeader(parent, header *consensus.Header) error
+}
+
+// HeaderStore is the subset of state.StateManager SyncManager needs to
+// persist fetched headers as they're validated, so a later checkpoint
+// sync (or a restart partway through catch-up) can resume from
+// GetHeaderByNumber instead of re-fetching headers already on disk.
+type HeaderStore interface {
+	PutHeader(record state.HeaderRecord) error
+}
+
+// SyncStatus reports headers-first sync progress for GetNetworkStats
+// and any admin/CLI tooling that wants catch-up progress.
+type SyncStatus struct {
+	Syncing       bool
+	CurrentHeight uint64
+	TargetHeight  uint64
+	PeersInSync   int
+	HeadersPerSec float64
+	BodiesPerSec  float64
+}
+
+// chainState is SyncManager's own record of the local chain's head and
+// recent blocks: enough to answer a peer's StatusMsg/GetHeaders/
+// GetBodies requests and to decide whether a peer is ahead of us. It is
+// populated from every block this node applies, whether that block
+// arrived via blockGossip or via SyncManager itself.
+type chainState struct {
+	mu sync.RWMutex
+
+	genesis    [32]byte
+	headNumber uint64
+	totalWork  uint64
+	haveHead   bool
+
+	headers map[uint64]*consensus.Header
+	blocks  map[uint64]*BlockMessage
+
+	// orphans holds out-of-order gossip blocks whose parent hasn't been
+	// applied yet, keyed by block number.
+	orphans map[uint64]*BlockMessage
+}
+
+func newChainState() *chainState {
+	return &chainState{
+		headers: make(map[uint64]*consensus.Header),
+		blocks:  make(map[uint64]*BlockMessage),
+		orphans: make(map[uint64]*BlockMessage),
+	}
+}
+
+// record stores a block this node has just applied as the new head.
+func (cs *chainState) record(header *consensus.Header, block *BlockMessage) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	cs.headers[header.BlockNumber] = header
+	cs.blocks[header.BlockNumber] = block
+	cs.headNumber = header.BlockNumber
+	cs.totalWork += header.Difficulty
+	cs.haveHead = true
+	delete(cs.orphans, header.BlockNumber)
+}
+
+func (cs *chainState) status() StatusMsg {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+
+	status := StatusMsg{Genesis: cs.genesis, HeadNumber: cs.headNumber, TotalWork: cs.totalWork}
+	if block, ok := cs.blocks[cs.headNumber]; ok {
+		status.Head = block.BlockHash
+	}
+	return status
+}
+
+func (cs *chainState) genesisHash() [32]byte {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.genesis
+}
+
+func (cs *chainState) setGenesis(hash [32]byte) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.genesis = hash
+}
+
+func (cs *chainState) head() (uint64, uint64, bool) {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.headNumber, cs.totalWork, cs.haveHead
+}
+
+func (cs *chainState) headerByNumber(number uint64) (*consensus.Header, bool) {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	h, ok := cs.headers[number]
+	return h, ok
+}
+
+func (cs *chainState) headersByRange(from uint64, max uint32) []*consensus.Header {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+
+	headers := make([]*consensus.Header, 0, max)
+	for i := uint32(0); i < max; i++ {
+		h, ok := cs.headers[from+uint64(i)]
+		if !ok {
+			break
+		}
+		headers = append(headers, h)
+	}
+	return headers
+}
+
+func (cs *chainState) blocksByNumbers(numbers []uint64) []*BlockMessage {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+
+	blocks := make([]*BlockMessage, 0, len(numbers))
+	for _, n := range numbers {
+		if b, ok := cs.blocks[n]; ok {
+			blocks = append(blocks, b)
+		}
+	}
+	return blocks
+}
+
+// addOrphan stashes a gossip block that arrived before its parent was
+// applied, evicting the lowest-numbered orphan if the pool is full.
+func (cs *chainState) addOrphan(block *BlockMessage) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if _, exists := cs.orphans[block.BlockNumber]; !exists && len(cs.orphans) >= maxOrphanBlocks {
+		var lowest uint64
+		first := true
+		for n := range cs.orphans {
+			if first || n < lowest {
+				lowest = n
+				first = false
+			}
+		}
+		delete(cs.orphans, lowest)
+	}
+	cs.orphans[block.BlockNumber] = block
+}
+
+// takeOrphan removes and returns the orphan waiting at number, if any.
+func (cs *chainState) takeOrphan(number uint64) (*BlockMessage, bool) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	b, ok := cs.orphans[number]
+	if ok {
+		delete(cs.orphans, number)
+	}
+	return b, ok
+}
+
+// orphanCount returns how many out-of-order blocks are currently stashed.
+func (cs *chainState) orphanCount() int {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return len(cs.orphans)
+}
+
+// SyncManager implements headers-first catch-up sync: on connect (or on
+// a manual Manager.SyncTo call) it exchanges a StatusMsg with a peer,
+// and if the peer is ahead, pulls headers in batches, validates the
+// header chain before downloading bodies, and hands validated blocks to
+// onConsensusBlock in ascending order. It also holds the bounded orphan
+// pool that handleBlockReceived uses for out-of-order gossip, since both
+// need the same view of "what's the local head, and what's waiting on
+// it."
+//
+// syncWithPeer pulls from one peer at a time rather than fanning a
+// sliding window of in-flight body requests across N peers: Manager's
+// peer set has no per-peer request pipelining today, so a multi-peer
+// fetcher would need that plumbing built first rather than bolted onto
+// this loop.
+type SyncManager struct {
+	log       *logger.Logger
+	validator HeaderValidator
+	onBlock   func(*BlockMessage) error
+
+	state *chainState
+
+	mu      sync.Mutex
+	syncing bool
+	target  uint64
+	peersOK map[peer.ID]bool
+
+	// headersPerSec and bodiesPerSec are the most recent per-batch
+	// throughput syncWithPeer measured, for Status()/GetNetworkStats.
+	headersPerSec float64
+	bodiesPerSec  float64
+
+	// faultInjector, if set, gates and can corrupt every sync stream
+	// exchange by peer ID — how Manager.FaultInjector's Partition/
+	// DropPeer/AddLatency/CorruptBlockProb reach this package's real,
+	// peer-ID-keyed send/receive paths. Left nil outside of tests.
+	faultInjector *FaultInjector
+
+	// headerStore, if set via SetHeaderStore, receives every header
+	// syncWithPeer validates, so a checkpoint sync started on a later run
+	// can resume from GetHeaderByNumber instead of re-fetching from
+	// genesis. Left nil outside of tests, in which case headers are only
+	// ever held in sm.state's in-memory map.
+	headerStore HeaderStore
+
+	// checkpoint, if set via SetCheckpoint, is a trusted (Height,
+	// HeaderHash) pair a new node can bootstrap from: syncWithPeer skips
+	// validator.ValidateHeader for any header at or below Height, the
+	// same weak-subjectivity shortcut Ethereum's checkpoint sync uses to
+	// avoid replaying signature verification for a chain segment the
+	// operator already trusts out of band. Headers above Height are
+	// still fully validated.
+	checkpoint *SyncCheckpoint
+}
+
+// SyncCheckpoint is a trusted (Height, HeaderHash) pair SetCheckpoint
+// accepts: HeaderHash is the hash of the header at Height, pinned by
+// whoever configured the node (a known-good block from a block
+// explorer, a prior run's own head, etc.), not something SyncManager
+// derives on its own.
+type SyncCheckpoint struct {
+	Height     uint64
+	HeaderHash [32]byte
+}
+
+// SetCheckpoint configures a trusted checkpoint new headers-first sync
+// runs will bootstrap from — see the checkpoint field's doc comment.
+// Passing nil clears it, reverting to full validation from genesis.
+func (sm *SyncManager) SetCheckpoint(cp *SyncCheckpoint) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.checkpoint = cp
+}
+
+func (sm *SyncManager) getCheckpoint() *SyncCheckpoint {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	return sm.checkpoint
+}
+
+// SetHeaderStore wires (or rewires) the HeaderStore syncWithPeer
+// persists validated headers to. Passing nil disables persistence,
+// reverting to sm.state's in-memory-only view.
+func (sm *SyncManager) SetHeaderStore(store HeaderStore) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.headerStore = store
+}
+
+func (sm *SyncManager) getHeaderStore() HeaderStore {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	return sm.headerStore
+}
+
+// SetFaultInjector wires (or rewires) the FaultInjector that
+// handleIncomingStream and syncWithPeer consult before serving or
+// pulling from a peer.
+func (sm *SyncManager) SetFaultInjector(injector *FaultInjector) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.faultInjector = injector
+}
+
+func (sm *SyncManager) getFaultInjector() *FaultInjector {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	return sm.faultInjector
+}
+
+// NewSyncManager creates a SyncManager. validator checks each fetched
+// header against its parent before bodies are downloaded; onBlock is
+// the same consensus callback blockGossip delivers to (normally
+// Manager.handleBlockReceived, via Manager's own onConsensusBlock).
+func NewSyncManager(validator HeaderValidator, onBlock func(*BlockMessage) error, log *logger.Logger) *SyncManager {
+	return &SyncManager{
+		log:       log,
+		validator: validator,
+		onBlock:   onBlock,
+		state:     newChainState(),
+		peersOK:   make(map[peer.ID]bool),
+	}
+}
+
+// SetGenesis records the local genesis block hash, so a peer on a
+// different chain is rejected at the StatusMsg exchange instead of
+// failing deep into header validation.
+func (sm *SyncManager) SetGenesis(hash [32]byte) {
+	sm.state.setGenesis(hash)
+}
+
+// SetValidator wires (or rewires) the header chain validator. syncWithPeer
+// reads sm.validator at call time, so this is safe to call any time
+// before a sync actually runs — in particular, after Start, the way
+// Manager.SetHeaderValidator is normally used once the consensus engine
+// exists.
+func (sm *SyncManager) SetValidator(validator HeaderValidator) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.validator = validator
+}
+
+func (sm *SyncManager) getValidator() HeaderValidator {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	return sm.validator
+}
+
+// RecordBlock tells SyncManager a block has just been applied (whether
+// via gossip or via sync itself), updating local head/total-work
+// bookkeeping. It does not itself promote orphans — callers that may
+// have unblocked one call PromoteReadyOrphans afterwards.
+func (sm *SyncManager) RecordBlock(header *consensus.Header, block *BlockMessage) {
+	sm.state.record(header, block)
+}
+
+// PromoteReadyOrphans applies every orphan block that is now next in
+// line (repeatedly, since applying one can unblock the next), using
+// apply the same way a freshly-received block would be: apply is
+// expected to both hand the block to the consensus engine and call
+// RecordBlock on success (Manager.applyBlock does both).
+func (sm *SyncManager) PromoteReadyOrphans(apply func(*BlockMessage) error) {
+	for {
+		localNumber, _ := sm.LocalHead()
+		orphan, ok := sm.state.takeOrphan(localNumber + 1)
+		if !ok {
+			return
+		}
+		if err := apply(orphan); err != nil {
+			sm.log.WithError(err).WithField("block_number", orphan.BlockNumber).Warn("Promoted orphan block rejected by consensus engine")
+			return
+		}
+	}
+}
+
+// HandleOutOfOrderBlock stashes a gossip block whose parent hasn't been
+// applied yet, bounded by maxOrphanBlocks, instead of rejecting it
+// outright the way handleBlockReceived used to.
+func (sm *SyncManager) HandleOutOfOrderBlock(block *BlockMessage) {
+	sm.state.addOrphan(block)
+	sm.log.WithFields(logger.Fields{
+		"block_number": block.BlockNumber,
+	}).Debug("Stashed out-of-order block in sync orphan pool")
+}
+
+// OrphanCount returns how many out-of-order gossip blocks are currently
+// stashed waiting for their parent, for metrics reporting.
+func (sm *SyncManager) OrphanCount() int {
+	return sm.state.orphanCount()
+}
+
+// LocalHead returns the local head's block number and cumulative work.
+func (sm *SyncManager) LocalHead() (number uint64, totalWork uint64) {
+	number, totalWork, _ = sm.state.head()
+	return
+}
+
+// Status reports current sync progress.
+func (sm *SyncManager) Status() SyncStatus {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	current, _, _ := sm.state.head()
+	peersInSync := 0
+	for _, ok := range sm.peersOK {
+		if ok {
+			peersInSync++
+		}
+	}
+
+	return SyncStatus{
+		Syncing:       sm.syncing,
+		CurrentHeight: current,
+		TargetHeight:  sm.target,
+		PeersInSync:   peersInSync,
+		HeadersPerSec: sm.headersPerSec,
+		BodiesPerSec:  sm.bodiesPerSec,
+	}
+}
+
+// recordBatchRates updates headersPerSec/bodiesPerSec from one
+// GetHeaders/GetBodies round-trip's counts and elapsed time.
+func (sm *SyncManager) recordBatchRates(headerCount, bodyCount int, elapsed time.Duration) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	seconds := elapsed.Seconds()
+	if seconds <= 0 {
+		return
+	}
+	sm.headersPerSec = float64(headerCount) / seconds
+	sm.bodiesPerSec = float64(bodyCount) / seconds
+}
+
+// headerFromBlockMessage reconstructs the consensus.Header fields
+// carried on the wire by a BlockMessage, mirroring
+// p2p.P2PMessageToBlock's field mapping (Miner field = Validator in
+// PoA) without going through a full *consensus.Block.
+func headerFromBlockMessage(msg *BlockMessage) *consensus.Header {
+	return &consensus.Header{
+		BlockNumber: msg.BlockNumber,
+		ParentHash:  msg.ParentHash,
+		TxRoot:      msg.TxRoot,
+		StateRoot:   msg.StateRoot,
+		Validator:   msg.Miner,
+		Timestamp:   msg.Timestamp,
+		Nonce:       msg.Nonce,
+		Difficulty:  msg.Difficulty,
+	}
+}
+
+// registerWith wires SyncManager's libp2p stream handler and connection
+// notifications onto host, so it starts serving sync requests and
+// proactively syncs with every newly connected peer.
+func (sm *SyncManager) registerWith(ctx context.Context, host libp2pSyncHost) {
+	host.SetStreamHandler(SyncProtocolID, sm.handleIncomingStream)
+
+	host.Network().Notify(&libp2pnetwork.NotifyBundle{
+		ConnectedF: func(_ libp2pnetwork.Network, conn libp2pnetwork.Conn) {
+			p := conn.RemotePeer()
+			go func() {
+				if err := sm.syncWithPeer(ctx, host, p); err != nil {
+					sm.log.WithError(err).WithField("peer", p.String()).Debug("Sync with newly connected peer did not complete")
+				}
+			}()
+		},
+	})
+}
+
+// libp2pSyncHost is the subset of the libp2p core Host SyncManager
+// needs: opening/serving streams and observing connection events. It's
+// satisfied by the *libp2phost.Host returned from Host.GetHost(), kept
+// narrow here so tests can substitute a fake.
+type libp2pSyncHost interface {
+	SetStreamHandler(protocol.ID, libp2pnetwork.StreamHandler)
+	NewStream(ctx context.Context, p peer.ID, pids ...protocol.ID) (libp2pnetwork.Stream, error)
+	Network() libp2pnetwork.Network
+}
+
+// SyncTo manually triggers a headers-first catch-up against peerID,
+// blocking until the exchange completes (peer turns out not to be
+// ahead, an error, or the local node reaches the peer's reported head).
+func (m *Manager) SyncTo(peerID string) error {
+	if m.syncManager == nil || m.host == nil {
+		return fmt.Errorf("sync manager not initialized")
+	}
+
+	pid, err := peer.Decode(peerID)
+	if err != nil {
+		return fmt.Errorf("invalid peer id %q: %w", peerID, err)
+	}
+
+	return m.syncManager.syncWithPeer(m.ctx, m.host.GetHost(), pid)
+}
+
+// handleIncomingStream serves StatusMsg/GetHeaders/GetBodies requests
+// from a peer that is pulling from us. It never initiates a pull of its
+// own; the local node's own pull (if any) runs on a separate, outbound
+// stream via syncWithPeer.
+func (sm *SyncManager) handleIncomingStream(s libp2pnetwork.Stream) {
+	defer s.Close()
+
+	if injector := sm.getFaultInjector(); injector != nil && !injector.admitSend(s.Conn().RemotePeer().String()) {
+		return
+	}
+
+	enc := json.NewEncoder(s)
+	dec := json.NewDecoder(s)
+
+	if err := enc.Encode(syncEnvelope{Kind: msgKindStatus, Payload: mustMarshal(sm.state.status())}); err != nil {
+		sm.log.WithError(err).Debug("Failed to send status to syncing peer")
+		return
+	}
+
+	for {
+		var env syncEnvelope
+		if err := dec.Decode(&env); err != nil {
+			return
+		}
+
+		switch env.Kind {
+		case msgKindStatus:
+			// Peer's own status, sent for symmetry; nothing to do here —
+			// the local node decides whether to pull on its own outbound
+			// stream (see syncWithPeer), not by reacting to this message.
+
+		case msgKindGetHeaders:
+			var req getHeadersMsg
+			if err := json.Unmarshal(env.Payload, &req); err != nil {
+				return
+			}
+			max := req.Max
+			if max == 0 || max > headerBatchSize {
+				max = headerBatchSize
+			}
+			headers := sm.state.headersByRange(req.FromNumber, max)
+			if err := enc.Encode(syncEnvelope{Kind: msgKindHeaders, Payload: mustMarshal(headersMsg{Headers: headers})}); err != nil {
+				return
+			}
+
+		case msgKindGetBodies:
+			var req getBodiesMsg
+			if err := json.Unmarshal(env.Payload, &req); err != nil {
+				return
+			}
+			blocks := sm.state.blocksByNumbers(req.Numbers)
+			if injector := sm.getFaultInjector(); injector != nil {
+				for i, block := range blocks {
+					blocks[i] = injector.maybeCorruptBlock(block)
+				}
+			}
+			if err := enc.Encode(syncEnvelope{Kind: msgKindBodies, Payload: mustMarshal(bodiesMsg{Blocks: blocks})}); err != nil {
+				return
+			}
+
+		default:
+			return
+		}
+	}
+}
+
+// syncWithPeer opens an outbound sync stream to peerID, exchanges
+// StatusMsg, and — if the peer is ahead by cumulative work — pulls
+// headers in batches, validates each header against its parent before
+// requesting bodies, and hands off every validated block to onBlock in
+// ascending order. Fork-choice is by cumulative work (StatusMsg.
+// TotalWork): a peer with no more work than the local head is left
+// alone even if its head number looks higher.
+func (sm *SyncManager) syncWithPeer(ctx context.Context, host libp2pSyncHost, p peer.ID) error {
+	validator := sm.getValidator()
+	if validator == nil {
+		return fmt.Errorf("no header validator wired (call Manager.SetHeaderValidator before syncing)")
+	}
+
+	if injector := sm.getFaultInjector(); injector != nil && !injector.admitSend(p.String()) {
+		return fmt.Errorf("peer %s is unreachable (fault injected)", p)
+	}
+
+	stream, err := host.NewStream(ctx, p, SyncProtocolID)
+	if err != nil {
+		return fmt.Errorf("failed to open sync stream to %s: %w", p, err)
+	}
+	defer stream.Close()
+
+	enc := json.NewEncoder(stream)
+	dec := json.NewDecoder(stream)
+
+	if err := enc.Encode(syncEnvelope{Kind: msgKindStatus, Payload: mustMarshal(sm.state.status())}); err != nil {
+		return fmt.Errorf("failed to send status to %s: %w", p, err)
+	}
+
+	var statusEnv syncEnvelope
+	if err := dec.Decode(&statusEnv); err != nil {
+		return fmt.Errorf("failed to read status from %s: %w", p, err)
+	}
+	var peerStatus StatusMsg
+	if err := json.Unmarshal(statusEnv.Payload, &peerStatus); err != nil {
+		return fmt.Errorf("failed to parse status from %s: %w", p, err)
+	}
+
+	localGenesis := sm.state.genesisHash()
+	if localGenesis != ([32]byte{}) && peerStatus.Genesis != ([32]byte{}) && localGenesis != peerStatus.Genesis {
+		return fmt.Errorf("peer %s is on a different chain (genesis mismatch)", p)
+	}
+
+	localNumber, localWork, haveHead := sm.state.head()
+	if haveHead && peerStatus.TotalWork <= localWork {
+		sm.markPeerInSync(p, true)
+		return nil
+	}
+
+	sm.mu.Lock()
+	sm.syncing = true
+	sm.target = peerStatus.HeadNumber
+	sm.mu.Unlock()
+	defer func() {
+		sm.mu.Lock()
+		sm.syncing = false
+		sm.mu.Unlock()
+		sm.markPeerInSync(p, true)
+	}()
+
+	from := localNumber + 1
+	parent, haveParent := sm.state.headerByNumber(localNumber)
+
+	for from <= peerStatus.HeadNumber {
+		batchStart := time.Now()
+		if err := enc.Encode(syncEnvelope{Kind: msgKindGetHeaders, Payload: mustMarshal(getHeadersMsg{FromNumber: from, Max: headerBatchSize})}); err != nil {
+			return fmt.Errorf("failed to request headers from %s: %w", p, err)
+		}
+
+		var headersEnv syncEnvelope
+		if err := dec.Decode(&headersEnv); err != nil {
+			return fmt.Errorf("failed to read headers from %s: %w", p, err)
+		}
+		var headersResp headersMsg
+		if err := json.Unmarshal(headersEnv.Payload, &headersResp); err != nil {
+			return fmt.Errorf("failed to parse headers from %s: %w", p, err)
+		}
+		if len(headersResp.Headers) == 0 {
+			break
+		}
+
+		checkpoint := sm.getCheckpoint()
+		headerStore := sm.getHeaderStore()
+		for _, h := range headersResp.Headers {
+			belowCheckpoint := checkpoint != nil && h.BlockNumber <= checkpoint.Height
+			if haveParent && !belowCheckpoint {
+				if err := validator.ValidateHeader(parent, h); err != nil {
+					return fmt.Errorf("peer %s sent an invalid header chain at block %d: %w", p, h.BlockNumber, err)
+				}
+			}
+			headerHash := consensus.HeaderHash(h)
+			if checkpoint != nil && h.BlockNumber == checkpoint.Height && headerHash != checkpoint.HeaderHash {
+				return fmt.Errorf("peer %s sent a header at checkpoint height %d that does not match the trusted checkpoint hash", p, h.BlockNumber)
+			}
+			if headerStore != nil {
+				encoded, err := json.Marshal(h)
+				if err != nil {
+					return fmt.Errorf("failed to encode header %d for persistence: %w", h.BlockNumber, err)
+				}
+				if err := headerStore.PutHeader(state.HeaderRecord{
+					BlockNumber: h.BlockNumber,
+					BlockHash:   headerHash,
+					ParentHash:  h.ParentHash,
+					HeaderData:  encoded,
+				}); err != nil {
+					return fmt.Errorf("failed to persist header %d from %s: %w", h.BlockNumber, p, err)
+				}
+			}
+			parent = h
+			haveParent = true
+		}
+
+		numbers := make([]uint64, len(headersResp.Headers))
+		for i, h := range headersResp.Headers {
+			numbers[i] = h.BlockNumber
+		}
+
+		if err := enc.Encode(syncEnvelope{Kind: msgKindGetBodies, Payload: mustMarshal(getBodiesMsg{Numbers: numbers})}); err != nil {
+			return fmt.Errorf("failed to request bodies from %s: %w", p, err)
+		}
+
+		var bodiesEnv syncEnvelope
+		if err := dec.Decode(&bodiesEnv); err != nil {
+			return fmt.Errorf("failed to read bodies from %s: %w", p, err)
+		}
+		var bodiesResp bodiesMsg
+		if err := json.Unmarshal(bodiesEnv.Payload, &bodiesResp); err != nil {
+			return fmt.Errorf("failed to parse bodies from %s: %w", p, err)
+		}
+		if len(bodiesResp.Blocks) != len(headersResp.Headers) {
+			return fmt.Errorf("peer %s sent %d bodies for %d requested headers", p, len(bodiesResp.Blocks), len(headersResp.Headers))
+		}
+
+		for i, block := range bodiesResp.Blocks {
+			header := headersResp.Headers[i]
+			if block.BlockNumber != header.BlockNumber || block.ParentHash != header.ParentHash || block.TxRoot != header.TxRoot {
+				return fmt.Errorf("peer %s sent a body that doesn't match its already-validated header at block %d", p, header.BlockNumber)
+			}
+
+			if err := sm.onBlock(block); err != nil {
+				return fmt.Errorf("consensus engine rejected synced block %d from %s: %w", header.BlockNumber, p, err)
+			}
+		}
+
+		sm.recordBatchRates(len(headersResp.Headers), len(bodiesResp.Blocks), time.Since(batchStart))
+		from = headersResp.Headers[len(headersResp.Headers)-1].BlockNumber + 1
+	}
+
+	return nil
+}
+
+func (sm *SyncManager) markPeerInSync(p peer.ID, inSync bool) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.peersOK[p] = inSync
+}
+
+func mustMarshal(v interface{}) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("sync_manager: failed to marshal %T: %v", v, err))
+	}
+	return data
+}
@@ -0,0 +1,191 @@
+// Merkle-proof transaction inclusion for light-client verification.
+//
+// BlockMessage.TxRoot is already a Merkle root over transaction hashes
+// (consensus.computeTxRootFromTransactions); this file adds the missing
+// piece that lets a receiver check a single transaction's inclusion
+// under that root without downloading the rest of the block. Each
+// TransactionInBlock carries its own proof, built once at gossip-
+// serialization time (see BlockToP2PMessage), and GetTxProof serves the
+// same proof on request for a light client that only has a header, not
+// the full block.
+//
+// Proofs here are always built and verified under
+// consensus.MerkleSchemeLegacy (consensus.BuildMerkleProof/
+// VerifyMerkleProof). Switching to consensus.MerkleSchemeRFC6962 once
+// new blocks commit to it needs a scheme marker on the wire (BlockMessage
+// doesn't carry a CodecVersion today), so that's deferred until
+// BlockMessage threads one through.
+package p2p
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/consensus"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// TxProofProtocolID is the libp2p stream protocol GetTxProof speaks, a
+// one-shot request/response exchange parallel to SyncProtocolID's
+// headers-first catch-up protocol.
+const TxProofProtocolID protocol.ID = "/coinjecture/txproof/1.0.0"
+
+// Proof is a transaction's Merkle inclusion proof under a block's
+// TxRoot, enough for a light client to verify membership without
+// fetching the rest of the block's transactions.
+type Proof struct {
+	TxHash    [32]byte                    `json:"tx_hash"`
+	BlockHash [32]byte                    `json:"block_hash"`
+	TxRoot    [32]byte                    `json:"tx_root"`
+	Steps     []consensus.MerkleProofStep `json:"steps"`
+}
+
+type getTxProofMsg struct {
+	BlockHash [32]byte `json:"block_hash"`
+	TxHash    [32]byte `json:"tx_hash"`
+}
+
+type txProofMsg struct {
+	Proof *Proof `json:"proof"`
+}
+
+// registerTxProofHandler wires the stream handler that serves GetTxProof
+// requests from peers, called once from Start alongside SyncManager's
+// own stream registration.
+func (m *Manager) registerTxProofHandler() {
+	if m.host == nil {
+		return
+	}
+	m.host.GetHost().SetStreamHandler(TxProofProtocolID, m.handleTxProofStream)
+}
+
+// handleTxProofStream serves a single GetTxProof request, responding
+// with a nil Proof (rather than closing the stream early) if the block
+// or transaction isn't found, so the caller gets a clean "not found"
+// instead of a read error.
+func (m *Manager) handleTxProofStream(s libp2pnetwork.Stream) {
+	defer s.Close()
+
+	var req getTxProofMsg
+	if err := json.NewDecoder(s).Decode(&req); err != nil {
+		return
+	}
+
+	proof, err := m.buildTxProof(req.BlockHash, req.TxHash)
+	if err != nil {
+		m.log.WithError(err).WithField("block_hash", fmt.Sprintf("%x", req.BlockHash[:8])).Debug("Failed to serve tx inclusion proof")
+		proof = nil
+	}
+
+	_ = json.NewEncoder(s).Encode(txProofMsg{Proof: proof})
+}
+
+// GetTxProof asks peerID for txHash's Merkle inclusion proof under
+// blockHash's TxRoot, for a light client that has a header but not the
+// full block.
+func (m *Manager) GetTxProof(peerID string, blockHash, txHash [32]byte) (*Proof, error) {
+	if m.host == nil {
+		return nil, fmt.Errorf("host not initialized")
+	}
+
+	pid, err := peer.Decode(peerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid peer id %q: %w", peerID, err)
+	}
+
+	stream, err := m.host.GetHost().NewStream(m.ctx, pid, TxProofProtocolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open tx-proof stream to %s: %w", peerID, err)
+	}
+	defer stream.Close()
+
+	if err := json.NewEncoder(stream).Encode(getTxProofMsg{BlockHash: blockHash, TxHash: txHash}); err != nil {
+		return nil, fmt.Errorf("failed to send tx-proof request to %s: %w", peerID, err)
+	}
+
+	var resp txProofMsg
+	if err := json.NewDecoder(stream).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to read tx-proof response from %s: %w", peerID, err)
+	}
+	if resp.Proof == nil {
+		return nil, fmt.Errorf("peer %s has no proof for tx %x in block %x", peerID, txHash[:8], blockHash[:8])
+	}
+	return resp.Proof, nil
+}
+
+// buildTxProof looks up blockHash in the local block pool and returns
+// txHash's inclusion proof under it, recomputing the proof from the
+// block's transaction hashes rather than trusting a cached one, so a
+// served proof always matches the block's actual transactions.
+func (m *Manager) buildTxProof(blockHash, txHash [32]byte) (*Proof, error) {
+	block, ok := m.GetBlockByHash(blockHash)
+	if !ok {
+		return nil, fmt.Errorf("block %x not found", blockHash[:8])
+	}
+
+	hashes := make([][32]byte, len(block.Transactions))
+	index := -1
+	for i, tx := range block.Transactions {
+		hashes[i] = tx.TxHash
+		if tx.TxHash == txHash {
+			index = i
+		}
+	}
+	if index == -1 {
+		return nil, fmt.Errorf("tx %x not found in block %x", txHash[:8], blockHash[:8])
+	}
+
+	root, steps, err := consensus.BuildMerkleProof(hashes, index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build merkle proof: %w", err)
+	}
+
+	return &Proof{TxHash: txHash, BlockHash: blockHash, TxRoot: root, Steps: steps}, nil
+}
+
+// VerifyTxInclusion recomputes tx's leaf hash and walks its proof,
+// returning an error if it doesn't resolve to root. Works with either a
+// proof fetched via GetTxProof or the one already embedded in a
+// gossiped TransactionInBlock.
+func VerifyTxInclusion(tx TransactionInBlock, root [32]byte) error {
+	steps, err := decodeMerkleProof(tx.MerkleProof)
+	if err != nil {
+		return fmt.Errorf("failed to decode merkle proof: %w", err)
+	}
+	if !consensus.VerifyMerkleProof(tx.TxHash, steps, root) {
+		return fmt.Errorf("transaction %x is not included under root %x", tx.TxHash[:8], root[:8])
+	}
+	return nil
+}
+
+// encodeMerkleProof/decodeMerkleProof convert between
+// consensus.MerkleProofStep and the [][]byte wire shape
+// TransactionInBlock.MerkleProof carries: each step is one byte (1 if
+// the sibling is the left operand, 0 if right) followed by the 32-byte
+// sibling hash.
+func encodeMerkleProof(steps []consensus.MerkleProofStep) [][]byte {
+	encoded := make([][]byte, len(steps))
+	for i, step := range steps {
+		buf := make([]byte, 33)
+		if step.IsLeft {
+			buf[0] = 1
+		}
+		copy(buf[1:], step.Sibling[:])
+		encoded[i] = buf
+	}
+	return encoded
+}
+
+func decodeMerkleProof(raw [][]byte) ([]consensus.MerkleProofStep, error) {
+	steps := make([]consensus.MerkleProofStep, len(raw))
+	for i, buf := range raw {
+		if len(buf) != 33 {
+			return nil, fmt.Errorf("malformed merkle proof step %d: expected 33 bytes, got %d", i, len(buf))
+		}
+		steps[i] = consensus.MerkleProofStep{IsLeft: buf[0] == 1}
+		copy(steps[i].Sibling[:], buf[1:])
+	}
+	return steps, nil
+}
@@ -0,0 +1,86 @@
+// BFT vote-P2P integration: bridges pkg/consensus/bft's prevote/
+// precommit Vote to a dedicated gossip topic, the same bridging role
+// dispute_integration.go and evidence_pool_integration.go play for their
+// own packages. bft.go's own doc comment notes there's no concrete BFT
+// driver wired up yet to call VoteTransport.Broadcast/Inbox — this just
+// makes *Manager satisfy that interface so one can be, without bft
+// needing to import p2p.
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/consensus/bft"
+)
+
+// VoteTopicID is the dedicated pubsub topic bft.Vote prevotes/
+// precommits are gossiped on, separate from block/tx/CID/evidence gossip
+// so a node that only cares about consensus voting can subscribe to it
+// alone.
+const VoteTopicID = "/coinjecture/consensus-vote/1.0.0"
+
+// VoteGossip adapts *Manager to bft.VoteTransport.
+type VoteGossip struct {
+	m *Manager
+}
+
+// VoteTransport returns a bft.VoteTransport backed by m's vote gossip
+// topic, for a future BFT driver's RoundState to broadcast and receive
+// votes through.
+func (m *Manager) VoteTransport() *VoteGossip {
+	return &VoteGossip{m: m}
+}
+
+func (vg *VoteGossip) Broadcast(v bft.Vote) error {
+	return vg.m.broadcastVote(v)
+}
+
+func (vg *VoteGossip) Inbox() <-chan bft.Vote {
+	return vg.m.voteInbox
+}
+
+var _ bft.VoteTransport = (*VoteGossip)(nil)
+
+// broadcastVote gossips v to peers over VoteTopicID. A nil voteTopic
+// (Start hasn't run, or joining the topic failed) makes this a no-op
+// rather than an error, the same tolerant behavior BroadcastTransaction
+// has when txGossip isn't initialized.
+func (m *Manager) broadcastVote(v bft.Vote) error {
+	if m.voteTopic == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return m.voteTopic.Publish(m.ctx, data)
+}
+
+// consumeVoteTopic relays votes gossiped by other peers into
+// m.voteInbox, where VoteGossip.Inbox delivers them to whatever BFT
+// driver is reading. Runs until ctx is cancelled (Manager.Stop cancels
+// it and tears down the subscription).
+func (m *Manager) consumeVoteTopic(ctx context.Context) {
+	for {
+		msg, err := m.voteSub.Next(ctx)
+		if err != nil {
+			return
+		}
+		if m.host != nil && msg.ReceivedFrom == m.host.ID() {
+			continue
+		}
+
+		var v bft.Vote
+		if err := json.Unmarshal(msg.Data, &v); err != nil {
+			m.log.WithError(err).Debug("Failed to decode gossiped vote")
+			continue
+		}
+
+		select {
+		case m.voteInbox <- v:
+		default:
+			m.log.Warn("Vote inbox full, dropping gossiped vote")
+		}
+	}
+}
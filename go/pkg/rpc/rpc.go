@@ -0,0 +1,551 @@
+// Package rpc is an admin-facing JSON-RPC 2.0 endpoint (HTTP for plain
+// request/response calls, WebSocket for both calls and new-block
+// subscriptions) in the style of go-ethereum/erigon's node RPC: a
+// namespace per concern (admin, consensus, mempool), enabled
+// individually so an operator can run with only the surface they want
+// exposed.
+//
+// Server depends only on the narrow PeerSource/ConsensusSource/
+// MempoolSource interfaces declared here, not on any concrete
+// consensus.Engine or mempool.Mempool — no block-production driver in
+// this tree offers GetStats (consensus.Producer has a
+// SetNewBlockCallback but no stats), per the precedent set in
+// clock.go, engine.go, wal.go, and bft.go, and pkg/mempool has no
+// source files in this tree at all despite being imported by
+// cmd/network-a-node and pkg/consensus/block.go. Defining the
+// interfaces Server actually needs, rather than importing those
+// missing packages, keeps this package buildable and testable against
+// fakes today; a real driver implementing PeerSource/ConsensusSource/
+// MempoolSource is a drop-in once one exists, the same way
+// RoundRobinEngine/CliqueEngine are drop-ins behind the Engine
+// interface.
+package rpc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gorilla/websocket"
+)
+
+// ==================== JSON-RPC 2.0 envelope ====================
+
+// Request is one JSON-RPC 2.0 call.
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is one JSON-RPC 2.0 reply: exactly one of Result or Error
+// is set.
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC 2.0 error object.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Standard JSON-RPC 2.0 error codes.
+const (
+	errCodeParseError     = -32700
+	errCodeInvalidRequest = -32600
+	errCodeMethodNotFound = -32601
+	errCodeInvalidParams  = -32602
+	errCodeInternal       = -32603
+)
+
+// HandlerFunc answers one JSON-RPC call's params, returning the value
+// to marshal as Result, or an error to report as Error.
+type HandlerFunc func(params json.RawMessage) (interface{}, error)
+
+// ==================== Domain types exposed over RPC ====================
+
+// NodeInfo is admin_nodeInfo's result.
+type NodeInfo struct {
+	Name    string   `json:"name"`
+	Version string   `json:"version"`
+	Address [32]byte `json:"address"`
+}
+
+// Peer is one entry of admin_peers' result.
+type Peer struct {
+	Enode     string `json:"enode"`
+	Address   string `json:"address"`
+	Connected bool   `json:"connected"`
+}
+
+// BlockSummary is a block as exposed over RPC — enough to identify and
+// inspect it without exposing consensus.Block's internals directly.
+type BlockSummary struct {
+	Number     uint64   `json:"number"`
+	Hash       [32]byte `json:"hash"`
+	ParentHash [32]byte `json:"parentHash"`
+	Validator  [32]byte `json:"validator"`
+	Timestamp  int64    `json:"timestamp"`
+	TxCount    int      `json:"txCount"`
+}
+
+// Stats is consensus_getStats' result.
+type Stats struct {
+	CurrentHeight   uint64 `json:"currentHeight"`
+	FinalizedHeight uint64 `json:"finalizedHeight"`
+	ValidatorCount  int    `json:"validatorCount"`
+}
+
+// TxSummary is one mempool transaction as exposed over RPC.
+type TxSummary struct {
+	Hash     [32]byte `json:"hash"`
+	From     [32]byte `json:"from"`
+	GasLimit uint64   `json:"gasLimit"`
+}
+
+// MempoolStatus is mempool_status' result.
+type MempoolStatus struct {
+	Pending int `json:"pending"`
+	Queued  int `json:"queued"`
+}
+
+// MerkleProof mirrors consensus.MerkleProof, re-declared here the same
+// way BlockSummary mirrors consensus.Block: Server depends on narrow
+// structural types it owns, not consensus's concrete exports.
+type MerkleProof struct {
+	Index    uint32     `json:"index"`
+	Siblings [][32]byte `json:"siblings"`
+}
+
+// TxProofResult is tx_getMerkleProof's result.
+type TxProofResult struct {
+	BlockHash  [32]byte    `json:"blockHash"`
+	MerkleRoot [32]byte    `json:"merkleRoot"`
+	Proof      MerkleProof `json:"proof"`
+}
+
+// ==================== Source interfaces ====================
+
+// PeerSource backs the admin namespace.
+type PeerSource interface {
+	NodeInfo() NodeInfo
+	Peers() []Peer
+	AddPeer(enode string) error
+	RemovePeer(enode string) error
+}
+
+// ConsensusSource backs the consensus namespace. Subscribe registers
+// fn to be called with every new block and returns an unsubscribe
+// func; it's how a WebSocket subscription is wired to
+// Engine.SetNewBlockCallback once a concrete driver exists.
+type ConsensusSource interface {
+	BlockByNumber(number uint64) (BlockSummary, bool)
+	BlockByHash(hash [32]byte) (BlockSummary, bool)
+	Stats() Stats
+	Validators() [][32]byte
+	// SealBlock produces a block on demand, for the BlockTime==0
+	// dev-chain pattern (erigon's dev.period=0): it returns an error
+	// if on-demand sealing isn't supported right now.
+	SealBlock() (BlockSummary, error)
+	Subscribe(fn func(BlockSummary)) (unsubscribe func())
+}
+
+// MempoolSource backs the mempool namespace.
+type MempoolSource interface {
+	Content() []TxSummary
+	Status() MempoolStatus
+	Submit(rawTx []byte) ([32]byte, error)
+}
+
+// TxProofSource backs the tx namespace. MerkleProofForTx reports false
+// if txHash isn't in any block this node knows about.
+type TxProofSource interface {
+	MerkleProofForTx(txHash [32]byte) (TxProofResult, bool)
+}
+
+// SyncSource backs the sync namespace: SyncStatusResult mirrors
+// p2p.SyncStatus field-for-field, redeclared locally the same way
+// BlockSummary/TxSummary avoid importing pkg/consensus.
+type SyncSource interface {
+	SyncStatus() SyncStatusResult
+}
+
+// SyncStatusResult reports headers-first sync progress.
+type SyncStatusResult struct {
+	Syncing       bool   `json:"syncing"`
+	CurrentHeight uint64 `json:"currentHeight"`
+	TargetHeight  uint64 `json:"targetHeight"`
+	PeersInSync   int    `json:"peersInSync"`
+}
+
+// ==================== Server ====================
+
+// Server dispatches JSON-RPC 2.0 calls to whichever namespaces it was
+// built with, over both plain HTTP POST and WebSocket.
+type Server struct {
+	handlers map[string]HandlerFunc
+	upgrader websocket.Upgrader
+
+	consensusSrc ConsensusSource
+
+	subsMu sync.Mutex
+	subs   map[uint64]chan BlockSummary
+	nextID uint64
+
+	unsubscribeFromSource func()
+}
+
+// NewServer builds an empty Server; call WithAdmin/WithConsensus/
+// WithMempool to enable each namespace — mirroring the --rpc.api flag
+// selecting which of admin/consensus/mempool/debug a node exposes.
+// (debug has no methods of its own here; it's accepted as a flag value
+// and otherwise a no-op, since nothing in this chunk specifies what it
+// should return.)
+func NewServer() *Server {
+	return &Server{
+		handlers: make(map[string]HandlerFunc),
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  4096,
+			WriteBufferSize: 4096,
+			// Admin RPC is meant for trusted local/operator access, not
+			// browser pages from arbitrary origins.
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+		subs: make(map[uint64]chan BlockSummary),
+	}
+}
+
+func (s *Server) register(method string, handler HandlerFunc) {
+	s.handlers[method] = handler
+}
+
+// WithAdmin enables the admin namespace over src.
+func (s *Server) WithAdmin(src PeerSource) *Server {
+	s.register("admin_nodeInfo", func(json.RawMessage) (interface{}, error) {
+		return src.NodeInfo(), nil
+	})
+	s.register("admin_peers", func(json.RawMessage) (interface{}, error) {
+		return src.Peers(), nil
+	})
+	s.register("admin_addPeer", func(params json.RawMessage) (interface{}, error) {
+		var args [1]string
+		if err := json.Unmarshal(params, &args); err != nil {
+			return nil, fmt.Errorf("expected params [enode]: %w", err)
+		}
+		if err := src.AddPeer(args[0]); err != nil {
+			return nil, err
+		}
+		return true, nil
+	})
+	s.register("admin_removePeer", func(params json.RawMessage) (interface{}, error) {
+		var args [1]string
+		if err := json.Unmarshal(params, &args); err != nil {
+			return nil, fmt.Errorf("expected params [enode]: %w", err)
+		}
+		if err := src.RemovePeer(args[0]); err != nil {
+			return nil, err
+		}
+		return true, nil
+	})
+	return s
+}
+
+// WithConsensus enables the consensus namespace over src, including
+// the new-block WebSocket subscription.
+func (s *Server) WithConsensus(src ConsensusSource) *Server {
+	s.consensusSrc = src
+
+	s.register("consensus_getBlockByNumber", func(params json.RawMessage) (interface{}, error) {
+		var args [1]uint64
+		if err := json.Unmarshal(params, &args); err != nil {
+			return nil, fmt.Errorf("expected params [number]: %w", err)
+		}
+		block, ok := src.BlockByNumber(args[0])
+		if !ok {
+			return nil, nil
+		}
+		return block, nil
+	})
+	s.register("consensus_getBlockByHash", func(params json.RawMessage) (interface{}, error) {
+		var args [1][32]byte
+		if err := json.Unmarshal(params, &args); err != nil {
+			return nil, fmt.Errorf("expected params [hash]: %w", err)
+		}
+		block, ok := src.BlockByHash(args[0])
+		if !ok {
+			return nil, nil
+		}
+		return block, nil
+	})
+	s.register("consensus_getStats", func(json.RawMessage) (interface{}, error) {
+		return src.Stats(), nil
+	})
+	s.register("consensus_getValidators", func(json.RawMessage) (interface{}, error) {
+		return src.Validators(), nil
+	})
+	s.register("consensus_sealBlock", func(json.RawMessage) (interface{}, error) {
+		return src.SealBlock()
+	})
+
+	s.unsubscribeFromSource = src.Subscribe(s.broadcastNewBlock)
+	return s
+}
+
+// WithMempool enables the mempool namespace over src.
+func (s *Server) WithMempool(src MempoolSource) *Server {
+	s.register("mempool_content", func(json.RawMessage) (interface{}, error) {
+		return src.Content(), nil
+	})
+	s.register("mempool_status", func(json.RawMessage) (interface{}, error) {
+		return src.Status(), nil
+	})
+	s.register("mempool_submit", func(params json.RawMessage) (interface{}, error) {
+		var args [1]string
+		if err := json.Unmarshal(params, &args); err != nil {
+			return nil, fmt.Errorf("expected params [rawTxHex]: %w", err)
+		}
+		raw, err := decodeHex(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid rawTx hex: %w", err)
+		}
+		hash, err := src.Submit(raw)
+		if err != nil {
+			return nil, err
+		}
+		return hash, nil
+	})
+	return s
+}
+
+// WithTxProof enables the tx namespace: Merkle inclusion proofs for SPV-
+// style light clients and IPFS-anchored inclusion attestations, so they
+// can confirm a transaction is in a block without replaying it.
+func (s *Server) WithTxProof(src TxProofSource) *Server {
+	s.register("tx_getMerkleProof", func(params json.RawMessage) (interface{}, error) {
+		var args [1][32]byte
+		if err := json.Unmarshal(params, &args); err != nil {
+			return nil, fmt.Errorf("expected params [txHash]: %w", err)
+		}
+		result, ok := src.MerkleProofForTx(args[0])
+		if !ok {
+			return nil, nil
+		}
+		return result, nil
+	})
+	s.register("tx_verifyMerkleProof", func(params json.RawMessage) (interface{}, error) {
+		var args struct {
+			Root  [32]byte    `json:"root"`
+			Leaf  [32]byte    `json:"leaf"`
+			Proof MerkleProof `json:"proof"`
+		}
+		if err := json.Unmarshal(params, &args); err != nil {
+			return nil, fmt.Errorf("expected params {root, leaf, proof}: %w", err)
+		}
+		return verifyMerkleProof(args.Root, args.Leaf, args.Proof), nil
+	})
+	return s
+}
+
+// WithSync enables the sync namespace: catch-up progress for CLI tooling
+// and dashboards polling "are we synced yet" instead of tailing logs.
+func (s *Server) WithSync(src SyncSource) *Server {
+	s.register("sync_getStatus", func(json.RawMessage) (interface{}, error) {
+		return src.SyncStatus(), nil
+	})
+	return s
+}
+
+// Close stops forwarding new blocks from the ConsensusSource (if any)
+// and closes every subscriber channel.
+func (s *Server) Close() {
+	if s.unsubscribeFromSource != nil {
+		s.unsubscribeFromSource()
+	}
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+	for id, ch := range s.subs {
+		close(ch)
+		delete(s.subs, id)
+	}
+}
+
+// Call dispatches a single Request and returns its Response, without
+// any transport attached — ServeHTTP and the WebSocket loop both use
+// this, and it's what the package's tests call directly.
+func (s *Server) Call(req Request) Response {
+	resp := Response{JSONRPC: "2.0", ID: req.ID}
+
+	handler, ok := s.handlers[req.Method]
+	if !ok {
+		resp.Error = &Error{Code: errCodeMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+		return resp
+	}
+
+	result, err := handler(req.Params)
+	if err != nil {
+		resp.Error = &Error{Code: errCodeInvalidParams, Message: err.Error()}
+		return resp
+	}
+	resp.Result = result
+	return resp
+}
+
+// ServeHTTP answers a plain HTTP POST JSON-RPC call. WebSocket
+// upgrades are handled by ServeWebSocket.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "JSON-RPC requires POST", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, Response{JSONRPC: "2.0", Error: &Error{Code: errCodeParseError, Message: err.Error()}})
+		return
+	}
+
+	writeJSON(w, s.Call(req))
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// ==================== WebSocket: calls + new-block subscriptions ====================
+
+// subscribeMethod is the one WebSocket-only method: it has no meaning
+// over plain HTTP POST, since there's no connection to push
+// notifications back down.
+const subscribeMethod = "consensus_subscribeNewBlocks"
+
+// subscriptionNotification is what's pushed to a subscriber on every
+// new block, shaped like go-ethereum's eth_subscribe notifications.
+type subscriptionNotification struct {
+	JSONRPC string                 `json:"jsonrpc"`
+	Method  string                 `json:"method"`
+	Params  subscriptionParamsJSON `json:"params"`
+}
+
+type subscriptionParamsJSON struct {
+	Subscription uint64       `json:"subscription"`
+	Result       BlockSummary `json:"result"`
+}
+
+// ServeWebSocket upgrades r to a WebSocket connection and serves
+// JSON-RPC calls (including subscribeMethod) over it until the
+// connection closes.
+func (s *Server) ServeWebSocket(w http.ResponseWriter, r *http.Request) error {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return fmt.Errorf("rpc: websocket upgrade failed: %w", err)
+	}
+	defer conn.Close()
+
+	var writeMu sync.Mutex
+	write := func(v interface{}) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		return conn.WriteJSON(v)
+	}
+
+	var mySubs []uint64
+	defer func() {
+		for _, id := range mySubs {
+			s.removeSubscriber(id)
+		}
+	}()
+
+	for {
+		var req Request
+		if err := conn.ReadJSON(&req); err != nil {
+			return nil
+		}
+
+		if req.Method == subscribeMethod {
+			id, ch := s.addSubscriber()
+			mySubs = append(mySubs, id)
+			write(Response{JSONRPC: "2.0", ID: req.ID, Result: id})
+
+			go func(id uint64, ch chan BlockSummary) {
+				for block := range ch {
+					write(subscriptionNotification{
+						JSONRPC: "2.0",
+						Method:  "consensus_subscription",
+						Params:  subscriptionParamsJSON{Subscription: id, Result: block},
+					})
+				}
+			}(id, ch)
+			continue
+		}
+
+		write(s.Call(req))
+	}
+}
+
+func (s *Server) addSubscriber() (uint64, chan BlockSummary) {
+	id := atomic.AddUint64(&s.nextID, 1)
+	ch := make(chan BlockSummary, 16)
+
+	s.subsMu.Lock()
+	s.subs[id] = ch
+	s.subsMu.Unlock()
+
+	return id, ch
+}
+
+func (s *Server) removeSubscriber(id uint64) {
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+	if ch, ok := s.subs[id]; ok {
+		close(ch)
+		delete(s.subs, id)
+	}
+}
+
+// broadcastNewBlock fans block out to every active subscriber; it's
+// the func passed to ConsensusSource.Subscribe.
+func (s *Server) broadcastNewBlock(block BlockSummary) {
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+	for _, ch := range s.subs {
+		select {
+		case ch <- block:
+		default:
+			// A slow subscriber drops the notification rather than
+			// blocking block production.
+		}
+	}
+}
+
+func decodeHex(s string) ([]byte, error) {
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		s = s[2:]
+	}
+	return hex.DecodeString(s)
+}
+
+// verifyMerkleProof recomputes the root from leaf and proof and reports
+// whether it matches root. This mirrors consensus.VerifyInclusionProof's
+// logic rather than importing pkg/consensus, for the same decoupling
+// reason MerkleProof is redeclared above instead of imported.
+func verifyMerkleProof(root, leaf [32]byte, proof MerkleProof) bool {
+	current := leaf
+	for i, sibling := range proof.Siblings {
+		if proof.Index&(1<<uint(i)) == 0 {
+			current = sha256.Sum256(append(append([]byte{}, current[:]...), sibling[:]...))
+		} else {
+			current = sha256.Sum256(append(append([]byte{}, sibling[:]...), current[:]...))
+		}
+	}
+	return current == root
+}
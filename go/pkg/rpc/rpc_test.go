@@ -0,0 +1,428 @@
+package rpc
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// fakeNode is an in-memory stand-in for a real Engine/Mempool/peer
+// manager, implementing PeerSource, ConsensusSource, and MempoolSource
+// so the integration tests below can exercise Server without any of
+// this tree's still-missing consensus/mempool drivers.
+type fakeNode struct {
+	mu sync.Mutex
+
+	peers          []Peer
+	blocksByNumber map[uint64]BlockSummary
+	blocksByHash   map[[32]byte]BlockSummary
+	stats          Stats
+	validators     [][32]byte
+	sealCount      int
+	subscribers    map[int]func(BlockSummary)
+	nextSubID      int
+
+	txs []TxSummary
+
+	proofs map[[32]byte]TxProofResult
+
+	syncStatus SyncStatusResult
+}
+
+func newFakeNode() *fakeNode {
+	return &fakeNode{
+		blocksByNumber: make(map[uint64]BlockSummary),
+		blocksByHash:   make(map[[32]byte]BlockSummary),
+		subscribers:    make(map[int]func(BlockSummary)),
+		proofs:         make(map[[32]byte]TxProofResult),
+	}
+}
+
+func (f *fakeNode) putBlock(b BlockSummary) {
+	f.blocksByNumber[b.Number] = b
+	f.blocksByHash[b.Hash] = b
+}
+
+func (f *fakeNode) NodeInfo() NodeInfo {
+	return NodeInfo{Name: "fake-node", Version: "test", Address: [32]byte{1}}
+}
+
+func (f *fakeNode) Peers() []Peer { return f.peers }
+
+func (f *fakeNode) AddPeer(enode string) error {
+	f.peers = append(f.peers, Peer{Enode: enode, Connected: true})
+	return nil
+}
+
+func (f *fakeNode) RemovePeer(enode string) error {
+	for i, p := range f.peers {
+		if p.Enode == enode {
+			f.peers = append(f.peers[:i], f.peers[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("peer %s not found", enode)
+}
+
+func (f *fakeNode) BlockByNumber(number uint64) (BlockSummary, bool) {
+	b, ok := f.blocksByNumber[number]
+	return b, ok
+}
+
+func (f *fakeNode) BlockByHash(hash [32]byte) (BlockSummary, bool) {
+	b, ok := f.blocksByHash[hash]
+	return b, ok
+}
+
+func (f *fakeNode) Stats() Stats { return f.stats }
+
+func (f *fakeNode) Validators() [][32]byte { return f.validators }
+
+func (f *fakeNode) SealBlock() (BlockSummary, error) {
+	f.sealCount++
+	b := BlockSummary{Number: uint64(len(f.blocksByNumber)), Hash: [32]byte{byte(f.sealCount)}}
+	f.putBlock(b)
+	return b, nil
+}
+
+func (f *fakeNode) Subscribe(fn func(BlockSummary)) func() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := f.nextSubID
+	f.nextSubID++
+	f.subscribers[id] = fn
+	return func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.subscribers, id)
+	}
+}
+
+// produceBlock simulates Engine.SetNewBlockCallback firing: it calls
+// every subscriber registered via Subscribe, the same thing a real
+// driver's block-production loop would do.
+func (f *fakeNode) produceBlock(b BlockSummary) {
+	f.mu.Lock()
+	f.putBlock(b)
+	fns := make([]func(BlockSummary), 0, len(f.subscribers))
+	for _, fn := range f.subscribers {
+		fns = append(fns, fn)
+	}
+	f.mu.Unlock()
+
+	for _, fn := range fns {
+		fn(b)
+	}
+}
+
+func (f *fakeNode) Content() []TxSummary { return f.txs }
+
+func (f *fakeNode) Status() MempoolStatus { return MempoolStatus{Pending: len(f.txs)} }
+
+func (f *fakeNode) Submit(raw []byte) ([32]byte, error) {
+	var hash [32]byte
+	copy(hash[:], raw)
+	f.txs = append(f.txs, TxSummary{Hash: hash, GasLimit: uint64(len(raw))})
+	return hash, nil
+}
+
+func (f *fakeNode) MerkleProofForTx(txHash [32]byte) (TxProofResult, bool) {
+	result, ok := f.proofs[txHash]
+	return result, ok
+}
+
+func (f *fakeNode) SyncStatus() SyncStatusResult { return f.syncStatus }
+
+// newTestServer wires a fakeNode into all five namespaces and serves
+// both plain JSON-RPC POSTs and WebSocket upgrades on one httptest
+// server, the way cmd/node-a's --rpc.addr listener would.
+func newTestServer(t *testing.T) (*httptest.Server, *Server, *fakeNode) {
+	t.Helper()
+	node := newFakeNode()
+	server := NewServer().WithAdmin(node).WithConsensus(node).WithMempool(node).WithTxProof(node).WithSync(node)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if websocket.IsWebSocketUpgrade(r) {
+			if err := server.ServeWebSocket(w, r); err != nil {
+				t.Logf("ServeWebSocket: %v", err)
+			}
+			return
+		}
+		server.ServeHTTP(w, r)
+	})
+
+	httpServer := httptest.NewServer(mux)
+	t.Cleanup(func() {
+		server.Close()
+		httpServer.Close()
+	})
+	return httpServer, server, node
+}
+
+func call(t *testing.T, url, method string, params interface{}) Response {
+	t.Helper()
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		t.Fatalf("marshal params: %v", err)
+	}
+	reqBody, err := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage(`1`), Method: method, Params: paramsJSON})
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+
+	resp, err := http.Post(url, "application/json", strings.NewReader(string(reqBody)))
+	if err != nil {
+		t.Fatalf("POST %s: %v", method, err)
+	}
+	defer resp.Body.Close()
+
+	var out Response
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("decode response for %s: %v", method, err)
+	}
+	return out
+}
+
+func TestAdminNamespace(t *testing.T) {
+	server, _, _ := newTestServer(t)
+
+	info := call(t, server.URL, "admin_nodeInfo", []interface{}{})
+	if info.Error != nil {
+		t.Fatalf("admin_nodeInfo error: %+v", info.Error)
+	}
+
+	added := call(t, server.URL, "admin_addPeer", []interface{}{"enode://abc@1.2.3.4:30303"})
+	if added.Error != nil {
+		t.Fatalf("admin_addPeer error: %+v", added.Error)
+	}
+
+	peers := call(t, server.URL, "admin_peers", []interface{}{})
+	if peers.Error != nil {
+		t.Fatalf("admin_peers error: %+v", peers.Error)
+	}
+	list, ok := peers.Result.([]interface{})
+	if !ok || len(list) != 1 {
+		t.Fatalf("admin_peers result = %#v, want a single-element list", peers.Result)
+	}
+
+	removed := call(t, server.URL, "admin_removePeer", []interface{}{"enode://abc@1.2.3.4:30303"})
+	if removed.Error != nil {
+		t.Fatalf("admin_removePeer error: %+v", removed.Error)
+	}
+
+	failedRemove := call(t, server.URL, "admin_removePeer", []interface{}{"enode://nonexistent"})
+	if failedRemove.Error == nil {
+		t.Fatal("expected admin_removePeer to error for an unknown peer")
+	}
+}
+
+func TestConsensusNamespace(t *testing.T) {
+	server, _, node := newTestServer(t)
+
+	node.putBlock(BlockSummary{Number: 5, Hash: [32]byte{9}, TxCount: 2})
+	node.stats = Stats{CurrentHeight: 5, FinalizedHeight: 3, ValidatorCount: 4}
+	node.validators = [][32]byte{{1}, {2}}
+
+	byNumber := call(t, server.URL, "consensus_getBlockByNumber", []interface{}{5})
+	if byNumber.Error != nil {
+		t.Fatalf("consensus_getBlockByNumber error: %+v", byNumber.Error)
+	}
+	blockMap, ok := byNumber.Result.(map[string]interface{})
+	if !ok || uint64(blockMap["number"].(float64)) != 5 {
+		t.Fatalf("consensus_getBlockByNumber result = %#v", byNumber.Result)
+	}
+
+	missing := call(t, server.URL, "consensus_getBlockByNumber", []interface{}{999})
+	if missing.Error != nil || missing.Result != nil {
+		t.Fatalf("expected a nil result for an unknown block number, got %+v / %+v", missing.Result, missing.Error)
+	}
+
+	stats := call(t, server.URL, "consensus_getStats", []interface{}{})
+	if stats.Error != nil {
+		t.Fatalf("consensus_getStats error: %+v", stats.Error)
+	}
+	statsMap := stats.Result.(map[string]interface{})
+	if uint64(statsMap["currentHeight"].(float64)) != 5 || uint64(statsMap["finalizedHeight"].(float64)) != 3 {
+		t.Fatalf("consensus_getStats result = %#v", stats.Result)
+	}
+
+	validators := call(t, server.URL, "consensus_getValidators", []interface{}{})
+	if validators.Error != nil {
+		t.Fatalf("consensus_getValidators error: %+v", validators.Error)
+	}
+	validatorList, ok := validators.Result.([]interface{})
+	if !ok || len(validatorList) != 2 {
+		t.Fatalf("consensus_getValidators result = %#v, want 2 validators", validators.Result)
+	}
+
+	sealed := call(t, server.URL, "consensus_sealBlock", []interface{}{})
+	if sealed.Error != nil {
+		t.Fatalf("consensus_sealBlock error: %+v", sealed.Error)
+	}
+	if node.sealCount != 1 {
+		t.Fatalf("expected SealBlock to have been invoked once, sealCount=%d", node.sealCount)
+	}
+}
+
+func TestMempoolNamespace(t *testing.T) {
+	server, _, node := newTestServer(t)
+
+	status := call(t, server.URL, "mempool_status", []interface{}{})
+	if status.Error != nil {
+		t.Fatalf("mempool_status error: %+v", status.Error)
+	}
+	statusMap := status.Result.(map[string]interface{})
+	if int(statusMap["pending"].(float64)) != 0 {
+		t.Fatalf("expected an empty mempool, got %#v", status.Result)
+	}
+
+	submitted := call(t, server.URL, "mempool_submit", []interface{}{"0xdeadbeef"})
+	if submitted.Error != nil {
+		t.Fatalf("mempool_submit error: %+v", submitted.Error)
+	}
+	if len(node.txs) != 1 {
+		t.Fatalf("expected Submit to have recorded 1 tx, got %d", len(node.txs))
+	}
+
+	content := call(t, server.URL, "mempool_content", []interface{}{})
+	if content.Error != nil {
+		t.Fatalf("mempool_content error: %+v", content.Error)
+	}
+	txList, ok := content.Result.([]interface{})
+	if !ok || len(txList) != 1 {
+		t.Fatalf("mempool_content result = %#v, want 1 tx", content.Result)
+	}
+}
+
+func TestTxProofNamespace(t *testing.T) {
+	server, _, node := newTestServer(t)
+
+	var txHash, blockHash, root, sibling [32]byte
+	txHash[0] = 0xAA
+	blockHash[0] = 0xBB
+	sibling[0] = 0xCC
+	root = sha256.Sum256(append(append([]byte{}, txHash[:]...), sibling[:]...))
+
+	proof := TxProofResult{
+		BlockHash:  blockHash,
+		MerkleRoot: root,
+		Proof:      MerkleProof{Index: 0, Siblings: [][32]byte{sibling}},
+	}
+	node.proofs[txHash] = proof
+
+	got := call(t, server.URL, "tx_getMerkleProof", []interface{}{txHash})
+	if got.Error != nil {
+		t.Fatalf("tx_getMerkleProof error: %+v", got.Error)
+	}
+	gotMap := got.Result.(map[string]interface{})
+	if gotMap["blockHash"] == nil {
+		t.Fatalf("tx_getMerkleProof result missing blockHash: %#v", got.Result)
+	}
+
+	missing := call(t, server.URL, "tx_getMerkleProof", []interface{}{[32]byte{0xFF}})
+	if missing.Error != nil {
+		t.Fatalf("tx_getMerkleProof (missing) error: %+v", missing.Error)
+	}
+	if missing.Result != nil {
+		t.Fatalf("expected a nil result for an unknown tx hash, got %#v", missing.Result)
+	}
+
+	verified := call(t, server.URL, "tx_verifyMerkleProof", map[string]interface{}{
+		"root":  root,
+		"leaf":  txHash,
+		"proof": proof.Proof,
+	})
+	if verified.Error != nil {
+		t.Fatalf("tx_verifyMerkleProof error: %+v", verified.Error)
+	}
+	if ok, _ := verified.Result.(bool); !ok {
+		t.Fatalf("expected a genuine proof to verify, got %#v", verified.Result)
+	}
+
+	var wrongRoot [32]byte
+	wrongRoot[0] = 1
+	rejected := call(t, server.URL, "tx_verifyMerkleProof", map[string]interface{}{
+		"root":  wrongRoot,
+		"leaf":  txHash,
+		"proof": proof.Proof,
+	})
+	if rejected.Error != nil {
+		t.Fatalf("tx_verifyMerkleProof error: %+v", rejected.Error)
+	}
+	if ok, _ := rejected.Result.(bool); ok {
+		t.Fatal("expected verification against the wrong root to fail")
+	}
+}
+
+func TestSyncNamespace(t *testing.T) {
+	server, _, node := newTestServer(t)
+
+	node.syncStatus = SyncStatusResult{Syncing: true, CurrentHeight: 10, TargetHeight: 100, PeersInSync: 2}
+
+	got := call(t, server.URL, "sync_getStatus", []interface{}{})
+	if got.Error != nil {
+		t.Fatalf("sync_getStatus error: %+v", got.Error)
+	}
+	gotMap := got.Result.(map[string]interface{})
+	if gotMap["currentHeight"] != float64(10) || gotMap["targetHeight"] != float64(100) {
+		t.Fatalf("sync_getStatus result = %#v, want currentHeight 10, targetHeight 100", got.Result)
+	}
+}
+
+func TestMethodNotFound(t *testing.T) {
+	server, _, _ := newTestServer(t)
+
+	resp := call(t, server.URL, "nonexistent_method", []interface{}{})
+	if resp.Error == nil {
+		t.Fatal("expected an error for an unregistered method")
+	}
+	if resp.Error.Code != errCodeMethodNotFound {
+		t.Fatalf("resp.Error.Code = %d, want %d", resp.Error.Code, errCodeMethodNotFound)
+	}
+}
+
+func TestWebSocketSubscriptionReceivesNewBlocks(t *testing.T) {
+	httpServer, _, node := newTestServer(t)
+
+	wsURL := "ws" + strings.TrimPrefix(httpServer.URL, "http") + "/"
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.WriteJSON(Request{JSONRPC: "2.0", ID: json.RawMessage(`1`), Method: subscribeMethod}); err != nil {
+		t.Fatalf("WriteJSON subscribe: %v", err)
+	}
+
+	var subResp Response
+	if err := conn.ReadJSON(&subResp); err != nil {
+		t.Fatalf("ReadJSON subscribe response: %v", err)
+	}
+	if subResp.Error != nil {
+		t.Fatalf("subscribe error: %+v", subResp.Error)
+	}
+
+	node.produceBlock(BlockSummary{Number: 1, Hash: [32]byte{42}})
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var notification subscriptionNotification
+	if err := conn.ReadJSON(&notification); err != nil {
+		t.Fatalf("ReadJSON notification: %v", err)
+	}
+	if notification.Method != "consensus_subscription" {
+		t.Fatalf("notification.Method = %q, want consensus_subscription", notification.Method)
+	}
+	if notification.Params.Result.Number != 1 {
+		t.Fatalf("notification block number = %d, want 1", notification.Params.Result.Number)
+	}
+}
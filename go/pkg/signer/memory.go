@@ -0,0 +1,24 @@
+package signer
+
+import "golang.org/x/crypto/ed25519"
+
+// MemorySigner signs with an Ed25519 private key held in process
+// memory — the default backend, and the only one where the private key
+// itself (not just a reference to it) ever exists in this process.
+type MemorySigner struct {
+	publicKey  ed25519.PublicKey
+	privateKey ed25519.PrivateKey
+}
+
+// NewMemorySigner wraps an existing Ed25519 keypair as a Signer.
+func NewMemorySigner(publicKey ed25519.PublicKey, privateKey ed25519.PrivateKey) *MemorySigner {
+	return &MemorySigner{publicKey: publicKey, privateKey: privateKey}
+}
+
+func (s *MemorySigner) Public() ed25519.PublicKey {
+	return s.publicKey
+}
+
+func (s *MemorySigner) Sign(msg []byte) ([]byte, error) {
+	return ed25519.Sign(s.privateKey, msg), nil
+}
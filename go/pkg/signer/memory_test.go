@@ -0,0 +1,47 @@
+package signer
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+func TestMemorySigner_SignProducesVerifiableSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	s := NewMemorySigner(pub, priv)
+	msg := []byte("block header bytes")
+
+	sig, err := s.Sign(msg)
+	if err != nil {
+		t.Fatalf("Sign returned error: %v", err)
+	}
+
+	if !ed25519.Verify(s.Public(), msg, sig) {
+		t.Fatal("signature did not verify against the signer's public key")
+	}
+}
+
+func TestMemorySigner_SignRejectsTamperedMessage(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	s := NewMemorySigner(pub, priv)
+	sig, err := s.Sign([]byte("original message"))
+	if err != nil {
+		t.Fatalf("Sign returned error: %v", err)
+	}
+
+	if ed25519.Verify(s.Public(), []byte("tampered message"), sig) {
+		t.Fatal("signature verified against a different message")
+	}
+}
+
+func TestMemorySigner_ImplementsSignerInterface(t *testing.T) {
+	var _ Signer = (*MemorySigner)(nil)
+}
@@ -0,0 +1,18 @@
+// Package signer abstracts over where a validator's Ed25519 private key
+// actually lives: in process memory, inside a PKCS#11-compliant HSM, or
+// behind a YubiKey's PIV applet. Block signing and the coinjecture-keygen
+// utility's -backend flag both depend only on the Signer interface, never
+// on a concrete private key type, so an HSM- or YubiKey-backed validator
+// key is never read into process memory as raw bytes.
+package signer
+
+import "golang.org/x/crypto/ed25519"
+
+// Signer produces Ed25519 signatures without exposing how or where the
+// underlying private key is stored.
+type Signer interface {
+	// Public returns the signer's Ed25519 public key.
+	Public() ed25519.PublicKey
+	// Sign returns msg's Ed25519 signature.
+	Sign(msg []byte) ([]byte, error)
+}
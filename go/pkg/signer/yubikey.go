@@ -0,0 +1,54 @@
+package signer
+
+// DefaultYubiKeyPKCS11Lib is where yubico-piv-tool's PKCS#11 module
+// (ykcs11) is installed by default on most Linux distributions.
+const DefaultYubiKeyPKCS11Lib = "/usr/lib/libykcs11.so"
+
+// YubiKeyConfig identifies a YubiKey PIV slot and its PIN. A YubiKey's
+// PIV applet is exposed as a PKCS#11 token by ykcs11, so OpenYubiKeySigner
+// is a thin adapter over PKCS11Signer rather than a separate protocol
+// implementation.
+//
+// Note: YubiKey PIV firmware signs with RSA and ECDSA P-256/P-384, not
+// Ed25519 — CKM_EC_EDWARDS_KEY_PAIR_GEN/CKM_EDDSA will fail against real
+// YubiKey hardware today. This backend targets PIV-compatible PKCS#11
+// tokens that do support Ed25519 (e.g. a YubiHSM2 configured in PIV-like
+// mode) or a future YubiKey firmware revision; callers on current
+// hardware should use an ECDSA validator key instead.
+type YubiKeyConfig struct {
+	LibPath string // defaults to DefaultYubiKeyPKCS11Lib if empty
+	Slot    uint   // PKCS#11 slot index ykcs11 exposes the PIV applet as
+	PIN     string
+	Label   string
+}
+
+// OpenYubiKeySigner resolves cfg to a PKCS11Signer over ykcs11.
+func OpenYubiKeySigner(cfg YubiKeyConfig) (*PKCS11Signer, error) {
+	libPath := cfg.LibPath
+	if libPath == "" {
+		libPath = DefaultYubiKeyPKCS11Lib
+	}
+
+	return OpenEd25519Key(PKCS11Config{
+		LibPath: libPath,
+		Slot:    cfg.Slot,
+		PIN:     cfg.PIN,
+		Label:   cfg.Label,
+	})
+}
+
+// GenerateYubiKeySigner resolves cfg to a freshly generated PKCS11Signer
+// over ykcs11 — see GenerateEd25519Key.
+func GenerateYubiKeySigner(cfg YubiKeyConfig) (*PKCS11Signer, error) {
+	libPath := cfg.LibPath
+	if libPath == "" {
+		libPath = DefaultYubiKeyPKCS11Lib
+	}
+
+	return GenerateEd25519Key(PKCS11Config{
+		LibPath: libPath,
+		Slot:    cfg.Slot,
+		PIN:     cfg.PIN,
+		Label:   cfg.Label,
+	})
+}
@@ -0,0 +1,210 @@
+package state
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+// ErrNotFound is returned by GetAccount when address has no row under
+// nativeCoinID — the "doesn't exist yet" outcome every caller in
+// pkg/consensus and pkg/tokenomics treats as "create a zero-value
+// account" rather than a real failure.
+var ErrNotFound = errors.New("state: account not found")
+
+// Account is one address's native-coin ($BEANS) balance and nonce, the
+// row GetAccount/UpdateAccount read and write. A multi-asset balance
+// under a registered coin_id (see coins.go/registry.Registry) is a
+// distinct accounts row this type doesn't represent on its own; use
+// GetAccountSnapshotForCoin for those.
+type Account struct {
+	Address   [32]byte
+	Balance   uint64
+	Nonce     uint64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// StateManager owns the node's sqlite-backed account/header/receipt/coin
+// state: every package in pkg/consensus and pkg/tokenomics that needs to
+// read or mutate chain state does so through a *StateManager rather than
+// touching sm.db directly.
+type StateManager struct {
+	db      *sql.DB
+	log     *logger.Logger
+	journal []journalEntry
+}
+
+// NewStateManager opens (creating if necessary) the sqlite database at
+// dbPath, migrates it to the latest schema version, and returns a
+// StateManager over it. dbPath may be ":memory:" for an ephemeral,
+// process-local database (cmd/state-t8n, simulator, and tests all use
+// this for ephemeral state).
+func NewStateManager(dbPath string, log *logger.Logger) (*StateManager, error) {
+	if err := InitializeDB(dbPath); err != nil {
+		return nil, fmt.Errorf("failed to initialize schema: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	return &StateManager{db: db, log: log}, nil
+}
+
+// Close releases the underlying database handle.
+func (sm *StateManager) Close() error {
+	return sm.db.Close()
+}
+
+// GetAccount looks up address's native-coin account, returning
+// ErrNotFound if it has never been credited or debited.
+func (sm *StateManager) GetAccount(address [32]byte) (*Account, error) {
+	row := sm.db.QueryRow(
+		`SELECT balance, nonce, created_at, updated_at FROM accounts WHERE address = ? AND coin_id = ?`,
+		hex.EncodeToString(address[:]), nativeCoinID,
+	)
+
+	account := &Account{Address: address}
+	var createdAt, updatedAt int64
+	if err := row.Scan(&account.Balance, &account.Nonce, &createdAt, &updatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	account.CreatedAt = time.Unix(createdAt, 0)
+	account.UpdatedAt = time.Unix(updatedAt, 0)
+	return account, nil
+}
+
+// UpdateAccount sets address's native-coin balance and nonce, creating
+// the account first if it doesn't exist yet. The account's prior state
+// is journaled before the write, so a subsequent RevertToSnapshot can
+// undo it.
+func (sm *StateManager) UpdateAccount(address [32]byte, balance, nonce uint64) error {
+	existing, err := sm.GetAccount(address)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	if existing == nil {
+		sm.appendJournal(accountCreation{addr: address})
+	} else {
+		sm.appendJournal(balanceChange{addr: address, prev: existing.Balance})
+		sm.appendJournal(nonceChange{addr: address, prev: existing.Nonce})
+	}
+
+	return sm.putAccount(address, nativeCoinID, balance, nonce)
+}
+
+// setBalance overwrites address's balance directly, bypassing the
+// journal — RevertToSnapshot's revert-only counterpart to UpdateAccount.
+// revert (journalEntry's interface method) has no error return for this
+// to propagate through, so a failure here is logged rather than lost
+// silently.
+func (sm *StateManager) setBalance(address [32]byte, balance uint64) {
+	if err := sm.putAccount(address, nativeCoinID, balance, sm.currentNonce(address)); err != nil {
+		sm.log.WithError(err).Warn("Failed to revert account balance")
+	}
+}
+
+// setNonce overwrites address's nonce directly, bypassing the journal —
+// RevertToSnapshot's revert-only counterpart to UpdateAccount.
+func (sm *StateManager) setNonce(address [32]byte, nonce uint64) {
+	if err := sm.putAccount(address, nativeCoinID, sm.currentBalance(address), nonce); err != nil {
+		sm.log.WithError(err).Warn("Failed to revert account nonce")
+	}
+}
+
+// deleteAccount removes address's native-coin row entirely, the
+// revert-only counterpart to UpdateAccount having created it from
+// scratch (see accountCreation.revert).
+func (sm *StateManager) deleteAccount(address [32]byte) {
+	sm.db.Exec(`DELETE FROM accounts WHERE address = ? AND coin_id = ?`, hex.EncodeToString(address[:]), nativeCoinID)
+}
+
+// currentBalance and currentNonce read address's current native-coin
+// balance/nonce, defaulting to 0 if the account doesn't exist — setBalance
+// and setNonce use these so overwriting one field never clobbers the
+// other back to zero.
+func (sm *StateManager) currentBalance(address [32]byte) uint64 {
+	account, err := sm.GetAccount(address)
+	if err != nil {
+		return 0
+	}
+	return account.Balance
+}
+
+func (sm *StateManager) currentNonce(address [32]byte) uint64 {
+	account, err := sm.GetAccount(address)
+	if err != nil {
+		return 0
+	}
+	return account.Nonce
+}
+
+// putAccount upserts one (address, coinID) accounts row, bypassing the
+// journal — GetAccount/UpdateAccount's shared write path, and the
+// building block setBalance/setNonce/deleteAccount layer journal-bypass
+// behavior on top of.
+func (sm *StateManager) putAccount(address [32]byte, coinID string, balance, nonce uint64) error {
+	now := time.Now().Unix()
+	_, err := sm.db.Exec(
+		`INSERT INTO accounts (address, coin_id, balance, nonce, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(address, coin_id) DO UPDATE SET
+		    balance = excluded.balance,
+		    nonce = excluded.nonce,
+		    updated_at = excluded.updated_at`,
+		hex.EncodeToString(address[:]), coinID, balance, nonce, now, now,
+	)
+	return err
+}
+
+// GetAccountSnapshot returns every native-coin account, keyed by
+// address — cmd/validate-supply's vector checks and cmd/state-t8n's
+// final-state dump both read the whole ledger this way rather than
+// address-by-address.
+func (sm *StateManager) GetAccountSnapshot() (map[[32]byte]*Account, error) {
+	return sm.GetAccountSnapshotForCoin(nativeCoinID)
+}
+
+// GetAccountSnapshotForCoin returns every account holding a balance
+// under coinID, keyed by address — the per-coin counterpart
+// GetAccountSnapshot uses for the native coin, and
+// cmd/validate-supply's registry coin checks use directly.
+func (sm *StateManager) GetAccountSnapshotForCoin(coinID string) (map[[32]byte]*Account, error) {
+	rows, err := sm.db.Query(
+		`SELECT address, balance, nonce, created_at, updated_at FROM accounts WHERE coin_id = ?`,
+		coinID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	snapshot := make(map[[32]byte]*Account)
+	for rows.Next() {
+		var addressHex string
+		var createdAt, updatedAt int64
+		account := &Account{}
+		if err := rows.Scan(&addressHex, &account.Balance, &account.Nonce, &createdAt, &updatedAt); err != nil {
+			return nil, err
+		}
+		if err := decodeHashInto(account.Address[:], addressHex); err != nil {
+			return nil, err
+		}
+		account.CreatedAt = time.Unix(createdAt, 0)
+		account.UpdatedAt = time.Unix(updatedAt, 0)
+		snapshot[account.Address] = account
+	}
+	return snapshot, rows.Err()
+}
@@ -0,0 +1,125 @@
+package state
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+// CoinRecord is one persisted coins row: the canonical encoding of a
+// registry.CoinRecord (opaque to this package, see migration3Up).
+// MintPolicy and BurnPolicy are stored as their raw int values rather
+// than registry's own enum types, the same reason HeaderData is opaque
+// bytes in headers.go — this package can't import pkg/tokenomics/registry
+// without creating an import cycle (registry already imports state).
+type CoinRecord struct {
+	CoinID       string
+	Symbol       string
+	Owner        [32]byte
+	Decimals     uint8
+	MintPolicy   int
+	BurnPolicy   int
+	CreatedBlock uint64
+}
+
+// PutCoin persists one coin's CoinRecord, keyed by coin_id.
+func (sm *StateManager) PutCoin(record CoinRecord) error {
+	now := time.Now().Unix()
+	_, err := sm.db.Exec(
+		`INSERT INTO coins (coin_id, symbol, owner, decimals, mint_policy, burn_policy, created_block, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(coin_id) DO UPDATE SET
+			symbol = excluded.symbol,
+			owner = excluded.owner,
+			decimals = excluded.decimals,
+			mint_policy = excluded.mint_policy,
+			burn_policy = excluded.burn_policy,
+			created_block = excluded.created_block,
+			updated_at = excluded.updated_at`,
+		record.CoinID,
+		record.Symbol,
+		hex.EncodeToString(record.Owner[:]),
+		record.Decimals,
+		record.MintPolicy,
+		record.BurnPolicy,
+		record.CreatedBlock,
+		now,
+		now,
+	)
+	return err
+}
+
+// GetCoin looks up a coin by coin_id, returning (nil, nil) if it isn't
+// registered — the same not-found convention GetAccount uses, since a
+// missing coin is an ordinary outcome for registry.CreateCoin/RecreateCoin
+// to check for, not an error.
+func (sm *StateManager) GetCoin(coinID string) (*CoinRecord, error) {
+	return sm.scanCoin(sm.db.QueryRow(
+		`SELECT coin_id, symbol, owner, decimals, mint_policy, burn_policy, created_block FROM coins WHERE coin_id = ?`,
+		coinID,
+	))
+}
+
+// GetCoinBySymbol looks up a coin by its current symbol, returning
+// (nil, nil) if no coin is registered under it.
+func (sm *StateManager) GetCoinBySymbol(symbol string) (*CoinRecord, error) {
+	return sm.scanCoin(sm.db.QueryRow(
+		`SELECT coin_id, symbol, owner, decimals, mint_policy, burn_policy, created_block FROM coins WHERE symbol = ?`,
+		symbol,
+	))
+}
+
+// scanCoin scans a single coins row, translating sql.ErrNoRows into a
+// nil CoinRecord rather than propagating it as an error.
+func (sm *StateManager) scanCoin(row *sql.Row) (*CoinRecord, error) {
+	record := &CoinRecord{}
+	var owner string
+	if err := row.Scan(&record.CoinID, &record.Symbol, &owner, &record.Decimals, &record.MintPolicy, &record.BurnPolicy, &record.CreatedBlock); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := decodeHashInto(record.Owner[:], owner); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// ListCoins returns every registered coin, in coin_id order.
+func (sm *StateManager) ListCoins() ([]CoinRecord, error) {
+	rows, err := sm.db.Query(
+		`SELECT coin_id, symbol, owner, decimals, mint_policy, burn_policy, created_block FROM coins ORDER BY coin_id`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []CoinRecord
+	for rows.Next() {
+		record := CoinRecord{}
+		var owner string
+		if err := rows.Scan(&record.CoinID, &record.Symbol, &owner, &record.Decimals, &record.MintPolicy, &record.BurnPolicy, &record.CreatedBlock); err != nil {
+			return nil, err
+		}
+		if err := decodeHashInto(record.Owner[:], owner); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+// CoinCirculatingSupply sums every account balance held under coinID,
+// the same "circulating supply" RecreateCoin requires to be zero before
+// a retired symbol can be re-issued.
+func (sm *StateManager) CoinCirculatingSupply(coinID string) (int64, error) {
+	var total sql.NullInt64
+	row := sm.db.QueryRow(`SELECT SUM(balance) FROM accounts WHERE coin_id = ?`, coinID)
+	if err := row.Scan(&total); err != nil {
+		return 0, err
+	}
+	return total.Int64, nil
+}
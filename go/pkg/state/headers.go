@@ -0,0 +1,83 @@
+package state
+
+import (
+	"encoding/hex"
+	"time"
+)
+
+// HeaderRecord is one persisted headers row: the canonical encoding of a
+// consensus.Header (opaque to this package, see migration6Up) plus the
+// block_number/block_hash/parent_hash columns the headers-first sync
+// pipeline needs to walk parent linkage without decoding HeaderData.
+type HeaderRecord struct {
+	BlockNumber uint64
+	BlockHash   [32]byte
+	ParentHash  [32]byte
+	HeaderData  []byte
+}
+
+// PutHeader persists one block's header, keyed by block number. Encoding/
+// decoding HeaderData is pkg/consensus's job (header_codec.go) so this
+// package never needs to import it back.
+func (sm *StateManager) PutHeader(record HeaderRecord) error {
+	_, err := sm.db.Exec(
+		`INSERT OR REPLACE INTO headers (block_number, block_hash, parent_hash, header_data, created_at) VALUES (?, ?, ?, ?, ?)`,
+		record.BlockNumber,
+		hex.EncodeToString(record.BlockHash[:]),
+		hex.EncodeToString(record.ParentHash[:]),
+		record.HeaderData,
+		time.Now().Unix(),
+	)
+	return err
+}
+
+// GetHeaderByNumber looks up a persisted header by block number, the
+// lookup a headers-first sync walking forward from a checkpoint needs.
+func (sm *StateManager) GetHeaderByNumber(blockNumber uint64) (*HeaderRecord, error) {
+	row := sm.db.QueryRow(
+		`SELECT block_hash, parent_hash, header_data FROM headers WHERE block_number = ?`,
+		blockNumber,
+	)
+
+	record := &HeaderRecord{BlockNumber: blockNumber}
+	var blockHashHex, parentHashHex string
+	if err := row.Scan(&blockHashHex, &parentHashHex, &record.HeaderData); err != nil {
+		return nil, err
+	}
+	if err := decodeHashInto(record.BlockHash[:], blockHashHex); err != nil {
+		return nil, err
+	}
+	if err := decodeHashInto(record.ParentHash[:], parentHashHex); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// GetHeaderByHash looks up a persisted header by its own hash, the
+// lookup BlockProcessor.ValidateHeader's parent check needs without
+// knowing the parent's block number in advance.
+func (sm *StateManager) GetHeaderByHash(blockHash [32]byte) (*HeaderRecord, error) {
+	row := sm.db.QueryRow(
+		`SELECT block_number, parent_hash, header_data FROM headers WHERE block_hash = ?`,
+		hex.EncodeToString(blockHash[:]),
+	)
+
+	record := &HeaderRecord{BlockHash: blockHash}
+	var parentHashHex string
+	if err := row.Scan(&record.BlockNumber, &parentHashHex, &record.HeaderData); err != nil {
+		return nil, err
+	}
+	if err := decodeHashInto(record.ParentHash[:], parentHashHex); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func decodeHashInto(dst []byte, s string) error {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	copy(dst, b)
+	return nil
+}
@@ -0,0 +1,91 @@
+package state
+
+// This file adds journaled snapshot/revert to StateManager: the type
+// itself, along with GetAccount/UpdateAccount/NewStateManager, is
+// already relied on throughout pkg/consensus and cmd/state-t8n even
+// though its account-storage implementation isn't checked into this
+// package yet (see schema.go's accounts table, which is what it will
+// eventually read and write through). sm.journal, sm.setBalance,
+// sm.setNonce, and sm.deleteAccount below are that same implementation's
+// seam — UpdateAccount is expected to call appendJournal before changing
+// a value, and setBalance/setNonce/deleteAccount are its revert-only,
+// journal-bypassing counterparts.
+
+// journalEntry is one undoable account mutation. revert restores sm to
+// how it looked just before the entry was appended; entries are always
+// reverted in LIFO order by RevertToSnapshot, mirroring the journal
+// go-ethereum's core/state package keeps for the same reason: a failed
+// transaction partway through execution must leave no trace, without
+// every caller needing to hand-roll its own rollback.
+type journalEntry interface {
+	revert(sm *StateManager)
+}
+
+// balanceChange undoes an UpdateAccount call's effect on Balance.
+type balanceChange struct {
+	addr [32]byte
+	prev uint64
+}
+
+func (c balanceChange) revert(sm *StateManager) {
+	sm.setBalance(c.addr, c.prev)
+}
+
+// nonceChange undoes an UpdateAccount call's effect on Nonce.
+type nonceChange struct {
+	addr [32]byte
+	prev uint64
+}
+
+func (c nonceChange) revert(sm *StateManager) {
+	sm.setNonce(c.addr, c.prev)
+}
+
+// accountCreation undoes UpdateAccount having brought a previously
+// nonexistent account into being — reverting it removes the account
+// entirely rather than zeroing its fields, so GetAccount goes back to
+// reporting "not found" exactly as it did before the snapshot.
+type accountCreation struct {
+	addr [32]byte
+}
+
+func (c accountCreation) revert(sm *StateManager) {
+	sm.deleteAccount(c.addr)
+}
+
+// Snapshot records the journal's current length and returns it as a
+// revision id. RevertToSnapshot(id) undoes every mutation recorded since
+// the matching Snapshot call; ids are only valid against the journal
+// that produced them and go stale once Finalise has run.
+func (sm *StateManager) Snapshot() int {
+	return len(sm.journal)
+}
+
+// RevertToSnapshot undoes every account mutation appended to the journal
+// since the Snapshot call that produced id, popping entries in LIFO
+// order so a mutation made on top of an earlier one in the same
+// transaction is undone before the one underneath it. The journal is
+// truncated to id afterward, so a subsequent Snapshot/RevertToSnapshot
+// pair starts clean.
+func (sm *StateManager) RevertToSnapshot(id int) {
+	for i := len(sm.journal) - 1; i >= id; i-- {
+		sm.journal[i].revert(sm)
+	}
+	sm.journal = sm.journal[:id]
+}
+
+// Finalise clears the journal, committing every mutation recorded since
+// the last Finalise (or since the StateManager was created) as permanent.
+// Callers call this once a block has been fully applied and no further
+// RevertToSnapshot against it will ever be needed.
+func (sm *StateManager) Finalise() {
+	sm.journal = sm.journal[:0]
+}
+
+// appendJournal records entry so a future RevertToSnapshot can undo it.
+// UpdateAccount and friends call this before mutating state, never after
+// — appending post-mutation would record the new value instead of the
+// one being overwritten.
+func (sm *StateManager) appendJournal(entry journalEntry) {
+	sm.journal = append(sm.journal, entry)
+}
@@ -0,0 +1,662 @@
+package state
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Migration is one versioned step in the schema's history. Up applies
+// the step forward; Down reverses it, so Migrate can step a database
+// back down to an older binary's expected version as well as forward.
+// Both run inside the caller's transaction, so a failure partway through
+// a multi-migration run leaves the database untouched.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(*sql.Tx) error
+	Down        func(*sql.Tx) error
+}
+
+// migrations is the full ordered history of this package's schema,
+// indexed from version 1. Appending a new Migration here — never
+// editing an already-released one — is how a change like base-fee
+// accounting or staking reaches existing databases without silently
+// diverging from a fresh one.
+var migrations = []Migration{
+	{
+		Version:     1,
+		Description: "accounts, escrows, blocks, vesting, tokenomics governance, and change-log tables",
+		Up:          migration1Up,
+		Down:        migration1Down,
+	},
+	{
+		Version:     2,
+		Description: "validators table for stake-weighted power and control-address rotation",
+		Up:          migration2Up,
+		Down:        migration2Down,
+	},
+	{
+		Version:     3,
+		Description: "coins table and composite-key accounts for multi-asset support",
+		Up:          migration3Up,
+		Down:        migration3Down,
+	},
+	{
+		Version:     4,
+		Description: "vesting_schedules table backing vesting.Ledger's locked-supply release mechanism",
+		Up:          migration4Up,
+		Down:        migration4Down,
+	},
+	{
+		Version:     5,
+		Description: "receipts table backing StateManager's receipt/log persistence and lookup",
+		Up:          migration5Up,
+		Down:        migration5Down,
+	},
+	{
+		Version:     6,
+		Description: "headers table backing StateManager's headers-first sync persistence",
+		Up:          migration6Up,
+		Down:        migration6Down,
+	},
+}
+
+// latestSchemaVersion is the highest version this binary knows how to
+// migrate to or understand; Migrate refuses to open a database recorded
+// at a newer version than this.
+func latestSchemaVersion() int {
+	return migrations[len(migrations)-1].Version
+}
+
+// InitializeDB creates the database schema, migrating it up to the
+// latest version this binary knows. It's the entry point existing
+// callers (cmd/token-demo, cmd/network-a-node) already use; new code
+// that needs a specific version (tests, --schema-version tooling) should
+// call Migrate directly.
+func InitializeDB(dbPath string) error {
+	return Migrate(dbPath, 0)
+}
+
+// SchemaVersion opens dbPath and returns the highest migration version
+// recorded in schema_migrations, or 0 for a database Migrate has never
+// touched.
+func SchemaVersion(dbPath string) (int, error) {
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	if err := ensureMigrationsTable(db); err != nil {
+		return 0, err
+	}
+	return currentSchemaVersion(db)
+}
+
+// Migrate brings the database at dbPath to target's version, running
+// every pending Up migration (or, for a downgrade, every Down migration
+// back to target) inside a single transaction. target of 0 means "the
+// latest version this binary knows." Migrate refuses to touch a
+// database already recorded at a version newer than this binary
+// understands, since running known migrations against unknown state
+// would silently corrupt it.
+func Migrate(dbPath string, target int) error {
+	if target == 0 {
+		target = latestSchemaVersion()
+	}
+	if target > latestSchemaVersion() {
+		return fmt.Errorf("requested schema version %d is newer than this binary supports (max %d)", target, latestSchemaVersion())
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	if err := ensureMigrationsTable(db); err != nil {
+		return err
+	}
+
+	current, err := currentSchemaVersion(db)
+	if err != nil {
+		return err
+	}
+	if current > latestSchemaVersion() {
+		return fmt.Errorf("database schema version %d is newer than this binary supports (max %d); refusing to open", current, latestSchemaVersion())
+	}
+	if current == target {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin migration transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if target > current {
+		for _, m := range pendingUp(current, target) {
+			if err := m.Up(tx); err != nil {
+				return fmt.Errorf("migration %d (%s): %w", m.Version, m.Description, err)
+			}
+			if _, err := tx.Exec(`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`, m.Version, time.Now().Unix()); err != nil {
+				return fmt.Errorf("migration %d: failed to record applied version: %w", m.Version, err)
+			}
+		}
+	} else {
+		for _, m := range pendingDown(current, target) {
+			if m.Down == nil {
+				return fmt.Errorf("migration %d (%s) has no Down step; cannot downgrade below it", m.Version, m.Description)
+			}
+			if err := m.Down(tx); err != nil {
+				return fmt.Errorf("migration %d (%s): down: %w", m.Version, m.Description, err)
+			}
+			if _, err := tx.Exec(`DELETE FROM schema_migrations WHERE version = ?`, m.Version); err != nil {
+				return fmt.Errorf("migration %d: failed to unrecord applied version: %w", m.Version, err)
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// pendingUp returns every migration with current < Version <= target,
+// in ascending version order.
+func pendingUp(current, target int) []Migration {
+	var pending []Migration
+	for _, m := range migrations {
+		if m.Version > current && m.Version <= target {
+			pending = append(pending, m)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].Version < pending[j].Version })
+	return pending
+}
+
+// pendingDown returns every migration with target < Version <= current,
+// in descending version order, so the most recently applied migration
+// is reversed first.
+func pendingDown(current, target int) []Migration {
+	var pending []Migration
+	for _, m := range migrations {
+		if m.Version > target && m.Version <= current {
+			pending = append(pending, m)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].Version > pending[j].Version })
+	return pending
+}
+
+func ensureMigrationsTable(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			applied_at INTEGER NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+	return nil
+}
+
+func currentSchemaVersion(db *sql.DB) (int, error) {
+	var version sql.NullInt64
+	if err := db.QueryRow(`SELECT MAX(version) FROM schema_migrations`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read current schema version: %w", err)
+	}
+	return int(version.Int64), nil
+}
+
+// migration1Up creates every table and index this package shipped with
+// before schema versioning existed: accounts, escrows, blocks (including
+// base_fee), vesting_accounts, the tokenomics governance tables, and
+// their supporting indexes.
+func migration1Up(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS accounts (
+			address TEXT PRIMARY KEY,
+			balance INTEGER NOT NULL DEFAULT 0,
+			nonce INTEGER NOT NULL DEFAULT 0,
+			created_at INTEGER NOT NULL,
+			updated_at INTEGER NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create accounts table: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		CREATE TABLE IF NOT EXISTS escrows (
+			id TEXT PRIMARY KEY,
+			submitter TEXT NOT NULL,
+			amount INTEGER NOT NULL,
+			problem_hash TEXT NOT NULL,
+			created_block INTEGER NOT NULL,
+			expiry_block INTEGER NOT NULL,
+			state INTEGER NOT NULL DEFAULT 0,
+			recipient TEXT,
+			settled_block INTEGER,
+			settlement_tx TEXT,
+			created_at INTEGER NOT NULL,
+			updated_at INTEGER NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create escrows table: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		CREATE TABLE IF NOT EXISTS blocks (
+			block_number INTEGER PRIMARY KEY,
+			block_hash BLOB NOT NULL,
+			parent_hash BLOB NOT NULL,
+			state_root BLOB NOT NULL,
+			tx_root BLOB NOT NULL,
+			timestamp INTEGER NOT NULL,
+			validator BLOB NOT NULL,
+			difficulty INTEGER NOT NULL,
+			nonce INTEGER NOT NULL,
+			gas_limit INTEGER NOT NULL,
+			gas_used INTEGER NOT NULL,
+			base_fee INTEGER NOT NULL DEFAULT 0,
+			extra_data BLOB,
+			tx_count INTEGER NOT NULL,
+			tx_data BLOB,
+			created_at INTEGER NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create blocks table: %w", err)
+	}
+
+	// vesting_accounts: the on-chain record of each GenesisAllocation's
+	// vesting schedule, keyed by beneficiary, plus how much of it has
+	// been claimed so far. Persisting the schedule itself (not just
+	// claimed_amount) lets VestingTracker rebuild its allocations map
+	// from state on restart instead of replaying genesis, and keeps
+	// ClaimVested idempotent across restarts.
+	//
+	// milestones_json holds the schedule's optional []Milestone curve
+	// (JSON-encoded, empty for a plain cliff+linear schedule); revocable
+	// and revoked_at_block support VestingTracker.RevokeAllocation.
+	_, err = tx.Exec(`
+		CREATE TABLE IF NOT EXISTS vesting_accounts (
+			address TEXT PRIMARY KEY,
+			amount INTEGER NOT NULL,
+			start_block INTEGER NOT NULL,
+			cliff_blocks INTEGER NOT NULL,
+			vesting_blocks INTEGER NOT NULL,
+			initial_unlock REAL NOT NULL,
+			claimed_amount INTEGER NOT NULL DEFAULT 0,
+			milestones_json TEXT NOT NULL DEFAULT '',
+			revocable INTEGER NOT NULL DEFAULT 0,
+			revoked_at_block INTEGER,
+			created_at INTEGER NOT NULL,
+			updated_at INTEGER NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create vesting_accounts table: %w", err)
+	}
+
+	// tokenomics_params: a single-row snapshot of the governable
+	// TokenomicsParams (see ParamStore), so a restarted node picks up
+	// whatever a passed TokenomicsProposal last changed instead of
+	// reverting to DefaultTokenomicsParams.
+	_, err = tx.Exec(`
+		CREATE TABLE IF NOT EXISTS tokenomics_params (
+			id INTEGER PRIMARY KEY CHECK (id = 0),
+			initial_block_reward INTEGER NOT NULL,
+			reward_halving_blocks INTEGER NOT NULL,
+			min_block_reward INTEGER NOT NULL,
+			max_supply INTEGER NOT NULL,
+			wei_per_coin INTEGER NOT NULL,
+			default_vesting_cliff_blocks INTEGER NOT NULL,
+			default_vesting_blocks INTEGER NOT NULL,
+			default_vesting_initial_unlock REAL NOT NULL,
+			community_rewards_address TEXT NOT NULL,
+			updated_at INTEGER NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create tokenomics_params table: %w", err)
+	}
+
+	// tokenomics_proposals: every TokenomicsProposal ParamStore has ever
+	// seen, voting or resolved, including the validator signatures
+	// collected so far (signatures_json).
+	_, err = tx.Exec(`
+		CREATE TABLE IF NOT EXISTS tokenomics_proposals (
+			proposal_id TEXT PRIMARY KEY,
+			proposer TEXT NOT NULL,
+			proposed_params_json TEXT NOT NULL,
+			deposit INTEGER NOT NULL,
+			voting_start_block INTEGER NOT NULL,
+			voting_end_block INTEGER NOT NULL,
+			signatures_json TEXT NOT NULL DEFAULT '{}',
+			status INTEGER NOT NULL DEFAULT 0,
+			created_at INTEGER NOT NULL,
+			updated_at INTEGER NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create tokenomics_proposals table: %w", err)
+	}
+
+	// tokenomics_change_log: the on-chain audit trail of every
+	// TokenomicsProposal that has passed and been applied.
+	_, err = tx.Exec(`
+		CREATE TABLE IF NOT EXISTS tokenomics_change_log (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			proposal_id TEXT NOT NULL,
+			applied_at_block INTEGER NOT NULL,
+			params_json TEXT NOT NULL,
+			created_at INTEGER NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create tokenomics_change_log table: %w", err)
+	}
+
+	if _, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_blocks_hash ON blocks(block_hash)`); err != nil {
+		return fmt.Errorf("failed to create block hash index: %w", err)
+	}
+	if _, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_accounts_balance ON accounts(balance)`); err != nil {
+		return fmt.Errorf("failed to create accounts balance index: %w", err)
+	}
+
+	return nil
+}
+
+// migration1Down drops every table migration1Up created, in reverse
+// dependency order.
+func migration1Down(tx *sql.Tx) error {
+	tables := []string{
+		"tokenomics_change_log",
+		"tokenomics_proposals",
+		"tokenomics_params",
+		"vesting_accounts",
+		"blocks",
+		"escrows",
+		"accounts",
+	}
+	for _, table := range tables {
+		if _, err := tx.Exec(fmt.Sprintf(`DROP TABLE IF EXISTS %s`, table)); err != nil {
+			return fmt.Errorf("failed to drop table %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// migration2Up creates the validators table backing
+// tokenomics.PowerTable: each validator's stake and quality-adjusted
+// power, whether it's currently active, and the block it joined at.
+// control_address is stored separately from address so an operator can
+// rotate their operational signing key (control_address) without
+// changing the validator's on-chain identity (address) or its stake
+// history.
+//
+// No StateManager method reads or writes this table yet — like wal.go
+// and bft.go's own standalone seams, PowerTable is built and persisted
+// by callers directly (today, only cmd/validate-supply's offline
+// fixture driver) rather than by a live validator-set path through
+// StateManager. Adding PutValidator/ListActiveValidators here is
+// deferred until such a path exists.
+func migration2Up(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS validators (
+			address TEXT PRIMARY KEY,
+			control_address TEXT NOT NULL,
+			stake INTEGER NOT NULL DEFAULT 0,
+			quality_adjusted_power INTEGER NOT NULL DEFAULT 0,
+			active INTEGER NOT NULL DEFAULT 1,
+			joined_block INTEGER NOT NULL,
+			created_at INTEGER NOT NULL,
+			updated_at INTEGER NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create validators table: %w", err)
+	}
+
+	if _, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_validators_active ON validators(active)`); err != nil {
+		return fmt.Errorf("failed to create validators active index: %w", err)
+	}
+	if _, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_validators_control_address ON validators(control_address)`); err != nil {
+		return fmt.Errorf("failed to create validators control_address index: %w", err)
+	}
+
+	return nil
+}
+
+// migration2Down drops the validators table.
+func migration2Down(tx *sql.Tx) error {
+	if _, err := tx.Exec(`DROP TABLE IF EXISTS validators`); err != nil {
+		return fmt.Errorf("failed to drop validators table: %w", err)
+	}
+	return nil
+}
+
+// nativeCoinID is the coin_id every pre-migration-3 accounts row is
+// assigned, so existing $BEANS balances carry forward unchanged under
+// the composite-key schema. It matches registry.NativeSymbol; pkg/state
+// can't import pkg/tokenomics/registry (the reverse already holds), so
+// the value is duplicated here rather than shared.
+const nativeCoinID = "BEANS"
+
+// migration3Up adds the coins table (see registry.CoinRecord) and widens
+// accounts to a composite (address, coin_id) primary key, so a single
+// address can hold balances in more than one registered coin. SQLite
+// can't alter a primary key in place, so this follows the standard
+// create-new-table/copy/drop/rename sequence, defaulting every existing
+// row to nativeCoinID.
+func migration3Up(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS coins (
+			coin_id TEXT PRIMARY KEY,
+			symbol TEXT NOT NULL,
+			owner TEXT NOT NULL,
+			decimals INTEGER NOT NULL DEFAULT 0,
+			mint_policy INTEGER NOT NULL DEFAULT 0,
+			burn_policy INTEGER NOT NULL DEFAULT 0,
+			created_block INTEGER NOT NULL,
+			created_at INTEGER NOT NULL,
+			updated_at INTEGER NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create coins table: %w", err)
+	}
+	if _, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_coins_symbol ON coins(symbol)`); err != nil {
+		return fmt.Errorf("failed to create coins symbol index: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		CREATE TABLE accounts_new (
+			address TEXT NOT NULL,
+			coin_id TEXT NOT NULL,
+			balance INTEGER NOT NULL DEFAULT 0,
+			nonce INTEGER NOT NULL DEFAULT 0,
+			created_at INTEGER NOT NULL,
+			updated_at INTEGER NOT NULL,
+			PRIMARY KEY (address, coin_id)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create accounts_new table: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO accounts_new (address, coin_id, balance, nonce, created_at, updated_at)
+		SELECT address, ?, balance, nonce, created_at, updated_at FROM accounts
+	`, nativeCoinID)
+	if err != nil {
+		return fmt.Errorf("failed to copy accounts into accounts_new: %w", err)
+	}
+
+	if _, err := tx.Exec(`DROP TABLE accounts`); err != nil {
+		return fmt.Errorf("failed to drop old accounts table: %w", err)
+	}
+	if _, err := tx.Exec(`ALTER TABLE accounts_new RENAME TO accounts`); err != nil {
+		return fmt.Errorf("failed to rename accounts_new to accounts: %w", err)
+	}
+
+	if _, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_accounts_balance ON accounts(balance)`); err != nil {
+		return fmt.Errorf("failed to create accounts balance index: %w", err)
+	}
+	if _, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_accounts_coin_id ON accounts(coin_id)`); err != nil {
+		return fmt.Errorf("failed to create accounts coin_id index: %w", err)
+	}
+
+	return nil
+}
+
+// migration3Down drops the coins table and narrows accounts back to a
+// single-asset, address-keyed table, discarding any balance not under
+// nativeCoinID — the same lossy direction migration1Down already takes
+// with respect to later migrations' columns.
+func migration3Down(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE accounts_old (
+			address TEXT PRIMARY KEY,
+			balance INTEGER NOT NULL DEFAULT 0,
+			nonce INTEGER NOT NULL DEFAULT 0,
+			created_at INTEGER NOT NULL,
+			updated_at INTEGER NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create accounts_old table: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO accounts_old (address, balance, nonce, created_at, updated_at)
+		SELECT address, balance, nonce, created_at, updated_at FROM accounts WHERE coin_id = ?
+	`, nativeCoinID)
+	if err != nil {
+		return fmt.Errorf("failed to copy %s balances into accounts_old: %w", nativeCoinID, err)
+	}
+
+	if _, err := tx.Exec(`DROP TABLE accounts`); err != nil {
+		return fmt.Errorf("failed to drop accounts table: %w", err)
+	}
+	if _, err := tx.Exec(`ALTER TABLE accounts_old RENAME TO accounts`); err != nil {
+		return fmt.Errorf("failed to rename accounts_old to accounts: %w", err)
+	}
+	if _, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_accounts_balance ON accounts(balance)`); err != nil {
+		return fmt.Errorf("failed to create accounts balance index: %w", err)
+	}
+
+	if _, err := tx.Exec(`DROP TABLE IF EXISTS coins`); err != nil {
+		return fmt.Errorf("failed to drop coins table: %w", err)
+	}
+	return nil
+}
+
+// migration4Up creates vesting_schedules: each vesting.Schedule the
+// vesting package's Ledger has ever created, including how much of it
+// Release has already paid out (claimed). This is a distinct table from
+// vesting_accounts (migration 1), which backs tokenomics.VestingTracker's
+// genesis-allocation vesting instead — see the vesting package doc
+// comment for why both exist.
+func migration4Up(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS vesting_schedules (
+			id TEXT PRIMARY KEY,
+			beneficiary TEXT NOT NULL,
+			total INTEGER NOT NULL,
+			cliff INTEGER NOT NULL,
+			duration INTEGER NOT NULL,
+			start_block INTEGER NOT NULL,
+			revocable INTEGER NOT NULL DEFAULT 0,
+			revoked INTEGER NOT NULL DEFAULT 0,
+			claimed INTEGER NOT NULL DEFAULT 0,
+			created_at INTEGER NOT NULL,
+			updated_at INTEGER NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create vesting_schedules table: %w", err)
+	}
+	if _, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_vesting_schedules_beneficiary ON vesting_schedules(beneficiary)`); err != nil {
+		return fmt.Errorf("failed to create vesting_schedules beneficiary index: %w", err)
+	}
+	return nil
+}
+
+// migration4Down drops vesting_schedules.
+func migration4Down(tx *sql.Tx) error {
+	if _, err := tx.Exec(`DROP TABLE IF EXISTS vesting_schedules`); err != nil {
+		return fmt.Errorf("failed to drop vesting_schedules table: %w", err)
+	}
+	return nil
+}
+
+// migration5Up creates receipts: one row per transaction, keyed by its
+// hash, storing the receipt's canonical encoding (data, opaque to this
+// package — pkg/consensus.Receipt owns the encoding) alongside the
+// block_number and logs_bloom columns that back GetLogsByBlock's
+// bloom-precheck-then-scan lookup without needing to decode every
+// receipt in a block just to rule most of them out.
+func migration5Up(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS receipts (
+			tx_hash TEXT PRIMARY KEY,
+			block_number INTEGER NOT NULL,
+			logs_bloom BLOB NOT NULL,
+			data BLOB NOT NULL,
+			created_at INTEGER NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create receipts table: %w", err)
+	}
+	if _, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_receipts_block_number ON receipts(block_number)`); err != nil {
+		return fmt.Errorf("failed to create receipts block_number index: %w", err)
+	}
+	return nil
+}
+
+// migration5Down drops receipts.
+func migration5Down(tx *sql.Tx) error {
+	if _, err := tx.Exec(`DROP TABLE IF EXISTS receipts`); err != nil {
+		return fmt.Errorf("failed to drop receipts table: %w", err)
+	}
+	return nil
+}
+
+// migration6Up adds headers, the headers-first sync pipeline's
+// persistence for a block's header alone: a node mid-catch-up has
+// verified and wants to keep every header in a batch well before it has
+// (or may ever fetch) that block's body, which the blocks table's
+// tx_data/tx_count columns assume are already in hand.
+func migration6Up(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS headers (
+			block_number INTEGER PRIMARY KEY,
+			block_hash BLOB NOT NULL,
+			parent_hash BLOB NOT NULL,
+			header_data BLOB NOT NULL,
+			created_at INTEGER NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create headers table: %w", err)
+	}
+	if _, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_headers_block_hash ON headers(block_hash)`); err != nil {
+		return fmt.Errorf("failed to create headers block_hash index: %w", err)
+	}
+	return nil
+}
+
+// migration6Down drops headers.
+func migration6Down(tx *sql.Tx) error {
+	if _, err := tx.Exec(`DROP TABLE IF EXISTS headers`); err != nil {
+		return fmt.Errorf("failed to drop headers table: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package state
+
+import (
+	"encoding/hex"
+	"time"
+)
+
+// ReceiptRecord is one persisted receipts row: the canonical encoding of
+// a consensus.Receipt (opaque to this package, see migration5Up) plus the
+// block_number and logs_bloom columns GetReceiptsByBlock's callers need
+// without decoding Data.
+type ReceiptRecord struct {
+	TxHash      [32]byte
+	BlockNumber uint64
+	LogsBloom   [256]byte
+	Data        []byte
+}
+
+// PutReceipt persists one transaction's receipt, keyed by its hash.
+// Encoding/decoding Data is pkg/consensus's job (via ReceiptStore) so
+// this package never needs to import it back.
+func (sm *StateManager) PutReceipt(record ReceiptRecord) error {
+	_, err := sm.db.Exec(
+		`INSERT OR REPLACE INTO receipts (tx_hash, block_number, logs_bloom, data, created_at) VALUES (?, ?, ?, ?, ?)`,
+		hex.EncodeToString(record.TxHash[:]),
+		record.BlockNumber,
+		record.LogsBloom[:],
+		record.Data,
+		time.Now().Unix(),
+	)
+	return err
+}
+
+// GetReceipt looks up a single receipt by transaction hash. Returns
+// ErrNotFound (mirroring GetAccount's convention) if no receipt with
+// that hash has been persisted.
+func (sm *StateManager) GetReceipt(txHash [32]byte) (*ReceiptRecord, error) {
+	row := sm.db.QueryRow(
+		`SELECT block_number, logs_bloom, data FROM receipts WHERE tx_hash = ?`,
+		hex.EncodeToString(txHash[:]),
+	)
+
+	record := &ReceiptRecord{TxHash: txHash}
+	var bloom []byte
+	if err := row.Scan(&record.BlockNumber, &bloom, &record.Data); err != nil {
+		return nil, err
+	}
+	copy(record.LogsBloom[:], bloom)
+	return record, nil
+}
+
+// GetReceiptsByBlock returns every receipt persisted for blockNumber, in
+// the order PutReceipt wrote them (insertion / rowid order), which is
+// the same in-block transaction order BlockProcessor.Process produced
+// them in.
+func (sm *StateManager) GetReceiptsByBlock(blockNumber uint64) ([]ReceiptRecord, error) {
+	rows, err := sm.db.Query(
+		`SELECT tx_hash, logs_bloom, data FROM receipts WHERE block_number = ? ORDER BY rowid`,
+		blockNumber,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []ReceiptRecord
+	for rows.Next() {
+		var txHashHex string
+		var bloom []byte
+		record := ReceiptRecord{BlockNumber: blockNumber}
+		if err := rows.Scan(&txHashHex, &bloom, &record.Data); err != nil {
+			return nil, err
+		}
+		txHashBytes, err := hex.DecodeString(txHashHex)
+		if err != nil {
+			return nil, err
+		}
+		copy(record.TxHash[:], txHashBytes)
+		copy(record.LogsBloom[:], bloom)
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
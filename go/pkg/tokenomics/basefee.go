@@ -0,0 +1,112 @@
+package tokenomics
+
+// BaseFeeConfig parameterizes the EIP-1559-style base-fee market: a
+// per-block base fee that adjusts toward a target gas usage and is
+// burned outright, leaving only the priority tip (the portion of a
+// transaction's gas price above the base fee) to flow through the
+// Critical Complex Equilibrium split that DistributeBlockReward already
+// applies to fees. This is additive to that split, not a replacement for
+// it — see ComputeNextBaseFee and PriorityTip.
+type BaseFeeConfig struct {
+	// InitialBaseFee seeds the market at genesis, in wei per gas.
+	InitialBaseFee uint64
+
+	// GasTarget is the per-block gas usage the controller steers
+	// toward; usage above it pushes the base fee up, usage below it
+	// pushes the base fee down.
+	GasTarget uint64
+
+	// MaxChangeDenominator bounds how much the base fee can move in a
+	// single block: at most 1/MaxChangeDenominator of the parent base
+	// fee, matching EIP-1559's 1/8 cap.
+	MaxChangeDenominator uint64
+
+	// MinBaseFee floors ComputeNextBaseFee's output so the base fee
+	// never decays to zero and stalls the burn mechanism.
+	MinBaseFee uint64
+}
+
+// DefaultBaseFeeConfig returns a starting configuration with an 8-block
+// (1/8 per block) maximum adjustment, mirroring EIP-1559's mainnet
+// parameters.
+func DefaultBaseFeeConfig() BaseFeeConfig {
+	return BaseFeeConfig{
+		InitialBaseFee:       1_000_000_000, // 1 gwei-equivalent
+		GasTarget:            15_000_000,
+		MaxChangeDenominator: 8,
+		MinBaseFee:           1,
+	}
+}
+
+// ComputeNextBaseFee derives the base fee for the block following one
+// that used parentGasUsed gas at parentBaseFee, via the same
+// multiplicative controller EIP-1559 uses:
+//
+//	next = parent * (1 + (used - target) / target / maxChangeDenominator)
+//
+// Usage exactly at cfg.GasTarget leaves the base fee unchanged. The
+// move is capped at 1/cfg.MaxChangeDenominator of parentBaseFee in
+// either direction, and the result never drops below cfg.MinBaseFee.
+func ComputeNextBaseFee(cfg BaseFeeConfig, parentBaseFee, parentGasUsed uint64) uint64 {
+	if cfg.GasTarget == 0 {
+		return parentBaseFee
+	}
+
+	maxDelta := parentBaseFee / cfg.MaxChangeDenominator
+	if maxDelta == 0 {
+		maxDelta = 1
+	}
+
+	if parentGasUsed == cfg.GasTarget {
+		return parentBaseFee
+	}
+
+	if parentGasUsed > cfg.GasTarget {
+		gasDelta := parentGasUsed - cfg.GasTarget
+		delta := parentBaseFee * gasDelta / cfg.GasTarget / cfg.MaxChangeDenominator
+		if delta > maxDelta {
+			delta = maxDelta
+		}
+		if delta == 0 {
+			delta = 1
+		}
+		return parentBaseFee + delta
+	}
+
+	gasDelta := cfg.GasTarget - parentGasUsed
+	delta := parentBaseFee * gasDelta / cfg.GasTarget / cfg.MaxChangeDenominator
+	if delta > maxDelta {
+		delta = maxDelta
+	}
+
+	next := parentBaseFee - delta
+	if next < cfg.MinBaseFee {
+		return cfg.MinBaseFee
+	}
+	return next
+}
+
+// EffectiveGasPrice is the actual per-gas price a transaction pays:
+// capped at maxFeeCap, and never more than baseFee+maxPriorityFee — the
+// standard EIP-1559 fee-cap formula.
+func EffectiveGasPrice(baseFee, maxFeeCap, maxPriorityFee uint64) uint64 {
+	if maxFeeCap < baseFee {
+		return maxFeeCap
+	}
+	priorityBid := baseFee + maxPriorityFee
+	if priorityBid > maxFeeCap {
+		return maxFeeCap
+	}
+	return priorityBid
+}
+
+// PriorityTip is the portion of EffectiveGasPrice that exceeds baseFee —
+// the only part of a transaction's gas price that reaches the validator
+// and the Critical Complex Equilibrium split, since baseFee itself is
+// burned in full.
+func PriorityTip(baseFee, effectiveGasPrice uint64) uint64 {
+	if effectiveGasPrice <= baseFee {
+		return 0
+	}
+	return effectiveGasPrice - baseFee
+}
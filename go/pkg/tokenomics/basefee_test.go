@@ -0,0 +1,128 @@
+package tokenomics
+
+import (
+	"testing"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+func TestComputeNextBaseFee_AtTargetIsUnchanged(t *testing.T) {
+	cfg := DefaultBaseFeeConfig()
+	got := ComputeNextBaseFee(cfg, 1_000_000_000, cfg.GasTarget)
+	if got != 1_000_000_000 {
+		t.Errorf("expected base fee unchanged at target usage, got %d", got)
+	}
+}
+
+func TestComputeNextBaseFee_FullBlockIncreasesByAtMostOneEighth(t *testing.T) {
+	cfg := DefaultBaseFeeConfig()
+	parent := uint64(1_000_000_000)
+
+	got := ComputeNextBaseFee(cfg, parent, cfg.GasTarget*2)
+
+	maxExpected := parent + parent/cfg.MaxChangeDenominator
+	if got <= parent || got > maxExpected {
+		t.Errorf("expected base fee to rise by at most 1/%d, parent=%d got=%d max=%d", cfg.MaxChangeDenominator, parent, got, maxExpected)
+	}
+}
+
+func TestComputeNextBaseFee_EmptyBlockDecreases(t *testing.T) {
+	cfg := DefaultBaseFeeConfig()
+	parent := uint64(1_000_000_000)
+
+	got := ComputeNextBaseFee(cfg, parent, 0)
+
+	if got >= parent {
+		t.Errorf("expected base fee to fall for an empty block, parent=%d got=%d", parent, got)
+	}
+}
+
+func TestComputeNextBaseFee_NeverDropsBelowMinBaseFee(t *testing.T) {
+	cfg := DefaultBaseFeeConfig()
+	cfg.MinBaseFee = 500
+
+	got := ComputeNextBaseFee(cfg, 501, 0)
+
+	if got < cfg.MinBaseFee {
+		t.Errorf("expected base fee floored at %d, got %d", cfg.MinBaseFee, got)
+	}
+}
+
+func TestEffectiveGasPrice_CappedAtMaxFeeCap(t *testing.T) {
+	got := EffectiveGasPrice(1_000, 1_200, 500)
+	if got != 1_200 {
+		t.Errorf("expected effective gas price capped at maxFeeCap 1200, got %d", got)
+	}
+}
+
+func TestEffectiveGasPrice_BaseFeeAboveCapPaysCapInFull(t *testing.T) {
+	got := EffectiveGasPrice(2_000, 1_200, 500)
+	if got != 1_200 {
+		t.Errorf("expected effective gas price to equal the cap when base fee exceeds it, got %d", got)
+	}
+}
+
+func TestPriorityTip_ZeroWhenEffectivePriceAtOrBelowBaseFee(t *testing.T) {
+	if got := PriorityTip(1_000, 1_000); got != 0 {
+		t.Errorf("expected zero tip when effective price equals base fee, got %d", got)
+	}
+	if got := PriorityTip(1_000, 800); got != 0 {
+		t.Errorf("expected zero tip when effective price is below base fee, got %d", got)
+	}
+}
+
+func TestPriorityTip_DifferenceAboveBaseFee(t *testing.T) {
+	got := PriorityTip(1_000, 1_300)
+	if got != 300 {
+		t.Errorf("expected tip of 300, got %d", got)
+	}
+}
+
+func TestEconomics_DistributeBlockRewardEIP1559_BurnsBaseFeeInFull(t *testing.T) {
+	cfg := DefaultTokenomicsConfig()
+	econ := NewEconomics(cfg, logger.NewLogger("debug"))
+
+	var validator [32]byte
+	validator[0] = 1
+
+	const baseFee = uint64(1_000_000_000)
+	const gasUsed = uint64(21_000)
+	const priorityTips = uint64(5_000)
+
+	validatorReward, baseFeeBurned, tipBurned, treasury := econ.DistributeBlockRewardEIP1559(
+		1, validator, baseFee, gasUsed, priorityTips,
+	)
+
+	if wantBurned := baseFee * gasUsed; baseFeeBurned != wantBurned {
+		t.Errorf("expected base fee burned %d, got %d", wantBurned, baseFeeBurned)
+	}
+	if validatorReward == 0 {
+		t.Errorf("expected a non-zero validator reward")
+	}
+	if tipBurned+treasury > priorityTips {
+		t.Errorf("tip burn (%d) + treasury (%d) exceed priority tips (%d)", tipBurned, treasury, priorityTips)
+	}
+}
+
+func TestEconomics_DistributeBlockRewardEIP1559_AdvancesCurrentBaseFee(t *testing.T) {
+	cfg := DefaultTokenomicsConfig()
+	econ := NewEconomics(cfg, logger.NewLogger("debug"))
+
+	var validator [32]byte
+	parentBaseFee := econ.CurrentBaseFee()
+
+	econ.DistributeBlockRewardEIP1559(1, validator, parentBaseFee, cfg.BaseFee.GasTarget*2, 0)
+
+	want := ComputeNextBaseFee(cfg.BaseFee, parentBaseFee, cfg.BaseFee.GasTarget*2)
+	if got := econ.CurrentBaseFee(); got != want {
+		t.Errorf("expected CurrentBaseFee to advance to %d, got %d", want, got)
+	}
+
+	metrics := econ.GetMetrics()
+	if metrics.CurrentBaseFee != want {
+		t.Errorf("expected GetMetrics().CurrentBaseFee to reflect %d, got %d", want, metrics.CurrentBaseFee)
+	}
+	if metrics.TotalBaseFeeBurned == 0 {
+		t.Errorf("expected GetMetrics().TotalBaseFeeBurned to be non-zero after a non-empty block")
+	}
+}
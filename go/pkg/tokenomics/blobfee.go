@@ -0,0 +1,85 @@
+package tokenomics
+
+import "math/big"
+
+// BlobFeeConfig parameterizes the EIP-4844-style blob-gas fee market: a
+// second, independent base fee that floats with how much blob gas
+// recent blocks have used relative to BlobGasTarget, burned in full
+// just like the calldata-gas base fee in basefee.go but tracked and
+// adjusted separately since blob gas and calldata gas are unrelated
+// resources.
+type BlobFeeConfig struct {
+	// MinBlobBaseFee floors CalcBlobBaseFee's output, matching EIP-4844's
+	// MIN_BLOB_BASE_FEE = 1 wei.
+	MinBlobBaseFee uint64
+
+	// BlobGasTarget is the per-block blob gas usage the market targets;
+	// ExcessBlobGas (the accumulator CalcBlobBaseFee reads) grows when a
+	// block uses more than this and shrinks (floored at zero) when it
+	// uses less.
+	BlobGasTarget uint64
+
+	// UpdateFraction controls how fast the base fee reacts to excess
+	// blob gas, matching EIP-4844's BLOB_BASE_FEE_UPDATE_FRACTION: larger
+	// values make the fee react more slowly.
+	UpdateFraction uint64
+}
+
+// DefaultBlobFeeConfig returns mainnet EIP-4844 parameters: a 3-blob
+// target (3 blobs/block * 131,072 gas/blob, expressed directly in gas
+// here) and the reference update fraction.
+func DefaultBlobFeeConfig() BlobFeeConfig {
+	return BlobFeeConfig{
+		MinBlobBaseFee: 1,
+		BlobGasTarget:  393_216, // 3 blobs/block * 131,072 gas/blob
+		UpdateFraction: 3_338_477,
+	}
+}
+
+// CalcBlobBaseFee derives the current blob base fee from excessBlobGas
+// (the running accumulator of blob gas used above cfg.BlobGasTarget),
+// via EIP-4844's fake_exponential approximation of
+// MIN_BLOB_BASE_FEE * e^(excessBlobGas / UpdateFraction).
+func CalcBlobBaseFee(cfg BlobFeeConfig, excessBlobGas uint64) uint64 {
+	if cfg.UpdateFraction == 0 {
+		return cfg.MinBlobBaseFee
+	}
+	return fakeExponential(cfg.MinBlobBaseFee, excessBlobGas, cfg.UpdateFraction)
+}
+
+// NextExcessBlobGas advances the excess-blob-gas accumulator given how
+// much blob gas parent actually used: it grows by the amount over
+// cfg.BlobGasTarget, or shrinks back toward (but never below) zero by
+// the amount under it.
+func NextExcessBlobGas(cfg BlobFeeConfig, parentExcessBlobGas, parentBlobGasUsed uint64) uint64 {
+	total := parentExcessBlobGas + parentBlobGasUsed
+	if total < cfg.BlobGasTarget {
+		return 0
+	}
+	return total - cfg.BlobGasTarget
+}
+
+// fakeExponential approximates factor * e^(numerator/denominator) via
+// the same truncated Taylor-series accumulation EIP-4844 specifies
+// (go-ethereum's eip4844.fakeExponential). It uses math/big throughout
+// rather than uint64 because the intermediate accum term can briefly
+// exceed uint64 range for large numerators even though the final result
+// fits comfortably.
+func fakeExponential(factor, numerator, denominator uint64) uint64 {
+	bigFactor := new(big.Int).SetUint64(factor)
+	bigNumerator := new(big.Int).SetUint64(numerator)
+	bigDenominator := new(big.Int).SetUint64(denominator)
+
+	output := new(big.Int)
+	accum := new(big.Int).Mul(bigFactor, bigDenominator)
+
+	for i := int64(1); accum.Sign() > 0; i++ {
+		output.Add(output, accum)
+
+		accum.Mul(accum, bigNumerator)
+		accum.Div(accum, bigDenominator)
+		accum.Div(accum, big.NewInt(i))
+	}
+
+	return output.Div(output, bigDenominator).Uint64()
+}
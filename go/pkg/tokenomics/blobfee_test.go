@@ -0,0 +1,71 @@
+package tokenomics
+
+import (
+	"testing"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+)
+
+func TestCalcBlobBaseFee_FloorsAtMinWhenNoExcess(t *testing.T) {
+	cfg := DefaultBlobFeeConfig()
+
+	if got := CalcBlobBaseFee(cfg, 0); got != cfg.MinBlobBaseFee {
+		t.Errorf("CalcBlobBaseFee(0) = %d, want MinBlobBaseFee %d", got, cfg.MinBlobBaseFee)
+	}
+}
+
+func TestCalcBlobBaseFee_IncreasesWithExcessBlobGas(t *testing.T) {
+	cfg := DefaultBlobFeeConfig()
+
+	low := CalcBlobBaseFee(cfg, cfg.BlobGasTarget)
+	high := CalcBlobBaseFee(cfg, cfg.BlobGasTarget*10)
+
+	if high <= low {
+		t.Errorf("expected blob base fee to rise with more excess blob gas: got low=%d high=%d", low, high)
+	}
+}
+
+func TestNextExcessBlobGas_GrowsAboveTargetAndFloorsAtZero(t *testing.T) {
+	cfg := DefaultBlobFeeConfig()
+
+	grown := NextExcessBlobGas(cfg, 0, cfg.BlobGasTarget*2)
+	if grown != cfg.BlobGasTarget {
+		t.Errorf("NextExcessBlobGas over target = %d, want %d", grown, cfg.BlobGasTarget)
+	}
+
+	floored := NextExcessBlobGas(cfg, 0, 0)
+	if floored != 0 {
+		t.Errorf("NextExcessBlobGas under target = %d, want 0", floored)
+	}
+}
+
+func TestEconomics_DistributeBlobFee_BurnsInFull(t *testing.T) {
+	econ := NewEconomics(DefaultTokenomicsConfig(), logger.NewLogger("debug"))
+	econ.SetBlobFeeConfig(DefaultBlobFeeConfig())
+
+	const blobBaseFee = uint64(1_000)
+	const blobGasUsed = uint64(3 * 131_072)
+
+	burned := econ.DistributeBlobFee(1, blobBaseFee, blobGasUsed)
+
+	if want := blobBaseFee * blobGasUsed; burned != want {
+		t.Errorf("expected blob fee burned %d, got %d", want, burned)
+	}
+
+	metrics := econ.GetMetrics()
+	if metrics.TotalBlobFeeBurned != burned {
+		t.Errorf("expected GetMetrics().TotalBlobFeeBurned %d, got %d", burned, metrics.TotalBlobFeeBurned)
+	}
+}
+
+func TestEconomics_DistributeBlobFee_AdvancesCurrentBlobBaseFee(t *testing.T) {
+	econ := NewEconomics(DefaultTokenomicsConfig(), logger.NewLogger("debug"))
+	cfg := DefaultBlobFeeConfig()
+	econ.SetBlobFeeConfig(cfg)
+
+	econ.DistributeBlobFee(1, econ.CurrentBlobBaseFee(), cfg.BlobGasTarget*2)
+
+	if got := econ.CurrentBlobBaseFee(); got <= cfg.MinBlobBaseFee {
+		t.Errorf("expected blob base fee to rise above the floor after an over-target block, got %d", got)
+	}
+}
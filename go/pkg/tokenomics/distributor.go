@@ -106,6 +106,168 @@ func (rd *RewardDistributor) DistributeBlockRewards(
 	return nil
 }
 
+// DistributeBlockRewardsMultiValidator is DistributeBlockRewards' power-
+// weighted counterpart for a block co-signed by more than one validator:
+// the 41.42% validator slice (plus base reward) is still computed once
+// via Economics.DistributeBlockReward, but pt.Split divides it across
+// signers proportional to their share of power instead of paying it
+// entirely to a single address. Burn and treasury amounts are unaffected
+// by how many validators signed. Falls back to DistributeBlockRewards
+// for a single signer, so callers don't need two code paths.
+//
+// BlockProcessor.Process calls this instead of DistributeBlockRewards
+// once a processor's SetPowerTable has been called and the block being
+// processed carries more than one Header.CoSigners entry. It's also
+// exercised directly by cmd/validate-supply's offline fixture driver,
+// independent of any BlockProcessor.
+func (rd *RewardDistributor) DistributeBlockRewardsMultiValidator(
+	blockHeight uint64,
+	signers [][32]byte,
+	pt *PowerTable,
+	totalFees uint64,
+) error {
+	if len(signers) == 0 {
+		return fmt.Errorf("at least one signer is required")
+	}
+	if len(signers) == 1 {
+		return rd.DistributeBlockRewards(blockHeight, signers[0], totalFees)
+	}
+
+	validatorReward, burnAmount, treasuryAmount := rd.economics.DistributeBlockReward(
+		blockHeight,
+		signers[0],
+		totalFees,
+	)
+
+	signerSet := make(map[[32]byte]bool, len(signers))
+	for _, s := range signers {
+		signerSet[s] = true
+	}
+	signing := make([]Validator, 0, len(signers))
+	for _, v := range pt.validators {
+		if signerSet[v.Address] {
+			signing = append(signing, v)
+		}
+	}
+	shares := NewPowerTable(signing).Split(validatorReward)
+
+	rd.log.WithFields(logger.Fields{
+		"block_height":     blockHeight,
+		"signers":          len(signers),
+		"validator_reward": FormatCoinAmount(validatorReward),
+		"burn_amount":      FormatCoinAmount(burnAmount),
+		"treasury_amount":  FormatCoinAmount(treasuryAmount),
+	}).Info("Distributing power-weighted block rewards")
+
+	for _, signer := range signers {
+		amount := shares[signer]
+		if amount == 0 {
+			continue
+		}
+		if err := rd.mintToAccount(signer, amount, "power-weighted validator reward"); err != nil {
+			return fmt.Errorf("failed to pay validator %x: %w", signer[:8], err)
+		}
+	}
+
+	if burnAmount > 0 {
+		if err := rd.mintToAccount(rd.burnAddress, burnAmount, "fee burn"); err != nil {
+			return fmt.Errorf("failed to burn tokens: %w", err)
+		}
+		rd.totalBurned += burnAmount
+	}
+
+	if treasuryAmount > 0 {
+		if err := rd.mintToAccount(rd.treasuryAddress, treasuryAmount, "treasury allocation"); err != nil {
+			return fmt.Errorf("failed to pay treasury: %w", err)
+		}
+	}
+
+	rd.totalDistributed += validatorReward + burnAmount + treasuryAmount
+	rd.blockCount++
+
+	return nil
+}
+
+// DistributeBlockRewardEIP1559 is DistributeBlockRewards' post-
+// activation counterpart: baseFee is burned in full via
+// Economics.DistributeBlockRewardEIP1559, and only priorityTips flows
+// through the validator/burn/treasury split. baseFee and blockGasUsed
+// must be the values the block's header actually carries, since they
+// also drive the next block's base fee.
+func (rd *RewardDistributor) DistributeBlockRewardEIP1559(
+	blockHeight uint64,
+	validator [32]byte,
+	baseFee uint64,
+	blockGasUsed uint64,
+	priorityTips uint64,
+) error {
+
+	validatorReward, baseFeeBurned, tipBurnAmount, treasuryAmount := rd.economics.DistributeBlockRewardEIP1559(
+		blockHeight,
+		validator,
+		baseFee,
+		blockGasUsed,
+		priorityTips,
+	)
+
+	rd.log.WithFields(logger.Fields{
+		"block_height":     blockHeight,
+		"validator":        fmt.Sprintf("%x", validator[:8]),
+		"validator_reward": FormatCoinAmount(validatorReward),
+		"base_fee_burned":  FormatCoinAmount(baseFeeBurned),
+		"tip_burned":       FormatCoinAmount(tipBurnAmount),
+		"treasury_amount":  FormatCoinAmount(treasuryAmount),
+	}).Info("Distributing block rewards (EIP-1559)")
+
+	if err := rd.mintToAccount(validator, validatorReward, "validator reward"); err != nil {
+		return fmt.Errorf("failed to pay validator reward: %w", err)
+	}
+
+	burnAmount := baseFeeBurned + tipBurnAmount
+	if burnAmount > 0 {
+		if err := rd.mintToAccount(rd.burnAddress, burnAmount, "base fee + tip burn"); err != nil {
+			return fmt.Errorf("failed to burn tokens: %w", err)
+		}
+		rd.totalBurned += burnAmount
+	}
+
+	if treasuryAmount > 0 {
+		if err := rd.mintToAccount(rd.treasuryAddress, treasuryAmount, "treasury allocation"); err != nil {
+			return fmt.Errorf("failed to pay treasury: %w", err)
+		}
+	}
+
+	rd.totalDistributed += validatorReward + burnAmount + treasuryAmount
+	rd.blockCount++
+
+	return nil
+}
+
+// DistributeBlobFee burns blobBaseFee*blobGasUsed in full via
+// Economics.DistributeBlobFee, independent of and in addition to
+// whatever DistributeBlockRewards/DistributeBlockRewardEIP1559 already
+// burned for the same block — there is no validator or treasury share
+// of the blob fee.
+func (rd *RewardDistributor) DistributeBlobFee(blockHeight uint64, blobBaseFee, blobGasUsed uint64) error {
+	burnAmount := rd.economics.DistributeBlobFee(blockHeight, blobBaseFee, blobGasUsed)
+	if burnAmount == 0 {
+		return nil
+	}
+
+	if err := rd.mintToAccount(rd.burnAddress, burnAmount, "blob fee burn"); err != nil {
+		return fmt.Errorf("failed to burn blob fee: %w", err)
+	}
+	rd.totalBurned += burnAmount
+	rd.totalDistributed += burnAmount
+
+	rd.log.WithFields(logger.Fields{
+		"block_height": blockHeight,
+		"blob_burned":  FormatCoinAmount(burnAmount),
+	}).Info("Distributing blob fee burn")
+
+	return nil
+}
+
 // mintToAccount creates tokens and adds them to an account
 //
 // This is the only place where new tokens are created (minted).
@@ -186,9 +348,11 @@ func (rd *RewardDistributor) GetCirculatingSupply(currentBlock uint64, vestingTr
 		return 0, fmt.Errorf("failed to get burned supply: %w", err)
 	}
 
-	// TODO: Subtract unvested genesis allocations if vesting tracker provided
-
 	circulatingSupply := totalSupply - burnedSupply
 
+	if vestingTracker != nil {
+		circulatingSupply -= vestingTracker.Unvested(currentBlock)
+	}
+
 	return circulatingSupply, nil
 }
@@ -70,6 +70,12 @@ type TokenomicsConfig struct {
 
 	// Treasury
 	TreasuryAddress [32]byte // Treasury address for development/grants
+
+	// BaseFee parameterizes the EIP-1559-style base-fee market (see
+	// basefee.go). It's independent of ValidatorFeeShare/BurnFeeShare/
+	// TreasuryFeeShare above: those still govern a transaction's
+	// priority tip once the base fee itself has been burned in full.
+	BaseFee BaseFeeConfig
 }
 
 // DefaultTokenomicsConfig returns institutional-grade default parameters
@@ -107,6 +113,8 @@ func DefaultTokenomicsConfig() TokenomicsConfig {
 
 		// Treasury address (set during initialization)
 		TreasuryAddress: [32]byte{},
+
+		BaseFee: DefaultBaseFeeConfig(),
 	}
 }
 
@@ -121,21 +129,61 @@ type Economics struct {
 	totalRewarded  *big.Int // Total rewards distributed to validators
 	totalFees      *big.Int // Total fees collected
 	blockHeight    uint64   // Current block height
+
+	// EIP-1559 base-fee market state, advanced by
+	// DistributeBlockRewardEIP1559.
+	currentBaseFee     uint64
+	totalBaseFeeBurned *big.Int
+	baseFeeBurnRateEMA float64 // smoothed per-block burn, wei/block
+
+	// EIP-4844 blob-gas fee market state, advanced by
+	// DistributeBlobFee. Independent of the calldata-gas base fee above:
+	// blob gas and calldata gas are unrelated resources with their own
+	// targets and excess accumulators.
+	blobFeeCfg         BlobFeeConfig
+	currentBlobBaseFee uint64
+	excessBlobGas      uint64
+	totalBlobFeeBurned *big.Int
 }
 
 // NewEconomics creates a new token economics manager
 func NewEconomics(cfg TokenomicsConfig, log *logger.Logger) *Economics {
 	return &Economics{
-		config:        cfg,
-		log:           log,
-		currentSupply: big.NewInt(int64(cfg.GenesisSupply)),
-		totalBurned:   big.NewInt(0),
-		totalRewarded: big.NewInt(0),
-		totalFees:     big.NewInt(0),
-		blockHeight:   0,
+		config:             cfg,
+		log:                log,
+		currentSupply:      big.NewInt(int64(cfg.GenesisSupply)),
+		totalBurned:        big.NewInt(0),
+		totalRewarded:      big.NewInt(0),
+		totalFees:          big.NewInt(0),
+		blockHeight:        0,
+		currentBaseFee:     cfg.BaseFee.InitialBaseFee,
+		totalBaseFeeBurned: big.NewInt(0),
+		totalBlobFeeBurned: big.NewInt(0),
 	}
 }
 
+// CurrentBaseFee returns the base fee the next block is expected to
+// carry.
+func (e *Economics) CurrentBaseFee() uint64 {
+	return e.currentBaseFee
+}
+
+// SetBlobFeeConfig activates the EIP-4844-style blob-gas fee market:
+// from this point CurrentBlobBaseFee reports cfg.MinBlobBaseFee (or
+// whatever DistributeBlobFee has since advanced it to) instead of the
+// zero value chains that haven't activated it carry. Mirrors
+// SetBaseFeeConfig on BlockBuilder for the calldata-gas market.
+func (e *Economics) SetBlobFeeConfig(cfg BlobFeeConfig) {
+	e.blobFeeCfg = cfg
+	e.currentBlobBaseFee = cfg.MinBlobBaseFee
+}
+
+// CurrentBlobBaseFee returns the blob base fee the next block is
+// expected to carry.
+func (e *Economics) CurrentBlobBaseFee() uint64 {
+	return e.currentBlobBaseFee
+}
+
 // CalculateBlockReward calculates the reward for a given block height
 //
 // Uses halving schedule similar to Bitcoin:
@@ -205,6 +253,85 @@ func (e *Economics) DistributeBlockReward(
 	return validatorReward, burnAmount, treasuryAmount
 }
 
+// DistributeBlockRewardEIP1559 is DistributeBlockReward's post-
+// activation counterpart: baseFee*blockGasUsed is burned in full rather
+// than running through ValidatorFeeShare/BurnFeeShare/TreasuryFeeShare,
+// and only priorityTips (the portion of fees above the base fee, see
+// PriorityTip) flows through that split. It also advances the base fee
+// for the following block via ComputeNextBaseFee, treating baseFee and
+// blockGasUsed as the new parent.
+func (e *Economics) DistributeBlockRewardEIP1559(
+	blockHeight uint64,
+	validator [32]byte,
+	baseFee uint64,
+	blockGasUsed uint64,
+	priorityTips uint64,
+) (validatorReward, baseFeeBurned, tipBurnAmount, treasuryAmount uint64) {
+
+	baseReward := e.CalculateBlockReward(blockHeight)
+	baseFeeBurned = baseFee * blockGasUsed
+
+	validatorFeeReward := uint64(float64(priorityTips) * e.config.ValidatorFeeShare)
+	tipBurnAmount = uint64(float64(priorityTips) * e.config.BurnFeeShare)
+	treasuryAmount = uint64(float64(priorityTips) * e.config.TreasuryFeeShare)
+
+	validatorReward = baseReward + validatorFeeReward
+
+	e.currentSupply.Add(e.currentSupply, big.NewInt(int64(baseReward)))
+	e.totalRewarded.Add(e.totalRewarded, big.NewInt(int64(validatorReward)))
+	e.totalFees.Add(e.totalFees, big.NewInt(int64(priorityTips)))
+	e.totalBurned.Add(e.totalBurned, big.NewInt(int64(tipBurnAmount+baseFeeBurned)))
+	e.totalBaseFeeBurned.Add(e.totalBaseFeeBurned, big.NewInt(int64(baseFeeBurned)))
+	e.blockHeight = blockHeight
+
+	// Exponential moving average (alpha=0.1) so GetMetrics can expose a
+	// rolling burn rate instead of one block's instantaneous value.
+	const burnRateSmoothing = 0.1
+	e.baseFeeBurnRateEMA = e.baseFeeBurnRateEMA*(1-burnRateSmoothing) + float64(baseFeeBurned)*burnRateSmoothing
+
+	e.currentBaseFee = ComputeNextBaseFee(e.config.BaseFee, baseFee, blockGasUsed)
+
+	e.log.WithFields(logger.Fields{
+		"block_height":     blockHeight,
+		"validator":        fmt.Sprintf("%x", validator[:8]),
+		"base_reward":      formatWei(baseReward),
+		"validator_reward": formatWei(validatorReward),
+		"base_fee":         baseFee,
+		"base_fee_burned":  formatWei(baseFeeBurned),
+		"tip_burned":       formatWei(tipBurnAmount),
+		"treasury":         formatWei(treasuryAmount),
+		"next_base_fee":    e.currentBaseFee,
+	}).Info("Block reward distributed (EIP-1559)")
+
+	return validatorReward, baseFeeBurned, tipBurnAmount, treasuryAmount
+}
+
+// DistributeBlobFee burns blobBaseFee*blobGasUsed in full, bypassing
+// ValidatorFeeShare/BurnFeeShare/TreasuryFeeShare entirely — unlike
+// calldata-gas priority tips, there is no blob-gas equivalent that ever
+// reaches the validator or treasury. It then advances the blob base fee
+// for the block after this one via NextExcessBlobGas/CalcBlobBaseFee,
+// treating blobGasUsed as this block's actual usage.
+func (e *Economics) DistributeBlobFee(blockHeight uint64, blobBaseFee, blobGasUsed uint64) (burned uint64) {
+	burned = blobBaseFee * blobGasUsed
+
+	e.totalBurned.Add(e.totalBurned, big.NewInt(int64(burned)))
+	e.totalBlobFeeBurned.Add(e.totalBlobFeeBurned, big.NewInt(int64(burned)))
+
+	e.excessBlobGas = NextExcessBlobGas(e.blobFeeCfg, e.excessBlobGas, blobGasUsed)
+	e.currentBlobBaseFee = CalcBlobBaseFee(e.blobFeeCfg, e.excessBlobGas)
+
+	e.log.WithFields(logger.Fields{
+		"block_height":    blockHeight,
+		"blob_base_fee":   blobBaseFee,
+		"blob_gas_used":   blobGasUsed,
+		"blob_fee_burned": formatWei(burned),
+		"next_blob_base_fee": e.currentBlobBaseFee,
+	}).Info("Blob fee burned")
+
+	return burned
+}
+
 // GetEmissionSchedule returns the emission schedule for the next N blocks
 func (e *Economics) GetEmissionSchedule(startBlock, numBlocks uint64) []EmissionPeriod {
 	schedule := []EmissionPeriod{}
@@ -266,6 +393,11 @@ func (e *Economics) GetMetrics() EconomicsMetrics {
 		CurrentBlockHeight: e.blockHeight,
 		CurrentBlockReward: e.CalculateBlockReward(e.blockHeight),
 		InflationRate:      e.calculateInflationRate(),
+		CurrentBaseFee:     e.currentBaseFee,
+		TotalBaseFeeBurned: e.totalBaseFeeBurned.Uint64(),
+		BaseFeeBurnRate:    e.baseFeeBurnRateEMA,
+		CurrentBlobBaseFee: e.currentBlobBaseFee,
+		TotalBlobFeeBurned: e.totalBlobFeeBurned.Uint64(),
 	}
 }
 
@@ -305,6 +437,11 @@ type EconomicsMetrics struct {
 	CurrentBlockHeight uint64  // Current block height
 	CurrentBlockReward uint64  // Current block reward (wei)
 	InflationRate      float64 // Estimated annual inflation rate (%)
+	CurrentBaseFee     uint64  // Base fee the next block is expected to carry (wei/gas)
+	TotalBaseFeeBurned uint64  // Cumulative wei burned via the base fee alone
+	BaseFeeBurnRate    float64 // Smoothed (EMA) base-fee burn per block, wei/block
+	CurrentBlobBaseFee uint64  // Blob base fee the next block is expected to carry (wei/blob-gas)
+	TotalBlobFeeBurned uint64  // Cumulative wei burned via the blob fee alone
 }
 
 // formatWei formats wei amount as human-readable $BEANS
@@ -22,6 +22,29 @@ type VestingSchedule struct {
 	CliffBlocks   uint64  // Cliff period (no unlock)
 	VestingBlocks uint64  // Total vesting duration
 	InitialUnlock float64 // % unlocked immediately (0.0 - 1.0)
+
+	// Milestones, if non-empty, replaces the cliff+linear-tail fields
+	// above with an arbitrary piecewise-linear unlock curve: see
+	// CalculateVestedAmount for how it's interpolated.
+	Milestones []Milestone
+
+	// Revocable marks an allocation whose still-unvested remainder the
+	// grantor may claw back via VestingTracker.RevokeAllocation.
+	Revocable bool
+	// RevokedAtBlock is set by RevokeAllocation: vesting is frozen as of
+	// this block, so whatever had vested by then remains claimable but
+	// nothing further ever does.
+	RevokedAtBlock *uint64
+}
+
+// Milestone is one point on a VestingSchedule's unlock curve: by
+// BlockHeight, CumulativeFraction of the total allocation has vested.
+// CumulativeFraction is cumulative, not incremental, and milestones must
+// be given in ascending BlockHeight order with non-decreasing
+// CumulativeFraction ending at 1.0 — see validateMilestones.
+type Milestone struct {
+	BlockHeight        uint64
+	CumulativeFraction float64
 }
 
 // DefaultGenesisAllocations returns institutional-grade initial distribution
@@ -103,21 +126,38 @@ func DefaultGenesisAllocations(
 	}
 }
 
-// ApplyGenesisAllocations applies genesis allocations to state
+// ApplyGenesisAllocations applies genesis allocations to state. For every
+// allocation carrying a VestingSchedule, it also registers the allocation
+// with vt (if non-nil), which persists it as an on-chain vesting account
+// (see VestingTracker.AddAllocation) so transfers from that address are
+// gated by ClaimVested/CheckTransferAllowed from genesis onward.
 func ApplyGenesisAllocations(
 	allocations []GenesisAllocation,
 	stateManager *state.StateManager,
+	vt *VestingTracker,
 	log *logger.Logger,
 ) error {
 
 	var totalAllocated uint64
 
 	for i, alloc := range allocations {
+		if alloc.Vesting != nil && len(alloc.Vesting.Milestones) > 0 {
+			if err := validateMilestones(alloc.Vesting.Milestones); err != nil {
+				return fmt.Errorf("invalid vesting milestones for allocation %d: %w", i, err)
+			}
+		}
+
 		// Create account with allocation
 		if err := stateManager.CreateAccount(alloc.Address, alloc.Amount); err != nil {
 			return fmt.Errorf("failed to create genesis account %d: %w", i, err)
 		}
 
+		if alloc.Vesting != nil && vt != nil {
+			if err := vt.AddAllocation(alloc); err != nil {
+				return fmt.Errorf("failed to register vesting account %d: %w", i, err)
+			}
+		}
+
 		totalAllocated += alloc.Amount
 
 		vestingInfo := "Fully unlocked"
@@ -145,7 +185,42 @@ func ApplyGenesisAllocations(
 	return nil
 }
 
-// CalculateVestedAmount calculates how much has vested at a given block
+// validateMilestones checks that milestones is non-empty, strictly
+// ascending in BlockHeight, non-decreasing in CumulativeFraction, and
+// ends at a cumulative fraction of 1.0 (the full allocation vested) —
+// the shape CalculateVestedAmount's interpolation assumes.
+func validateMilestones(milestones []Milestone) error {
+	if len(milestones) == 0 {
+		return fmt.Errorf("milestones must not be empty")
+	}
+
+	for i, m := range milestones {
+		if m.CumulativeFraction < 0 || m.CumulativeFraction > 1 {
+			return fmt.Errorf("milestone %d has out-of-range cumulative fraction %.4f", i, m.CumulativeFraction)
+		}
+		if i == 0 {
+			continue
+		}
+		prev := milestones[i-1]
+		if m.BlockHeight <= prev.BlockHeight {
+			return fmt.Errorf("milestone %d block height %d is not after milestone %d's %d", i, m.BlockHeight, i-1, prev.BlockHeight)
+		}
+		if m.CumulativeFraction < prev.CumulativeFraction {
+			return fmt.Errorf("milestone %d cumulative fraction %.4f is less than milestone %d's %.4f", i, m.CumulativeFraction, i-1, prev.CumulativeFraction)
+		}
+	}
+
+	if last := milestones[len(milestones)-1].CumulativeFraction; last != 1.0 {
+		return fmt.Errorf("final milestone must reach cumulative fraction 1.0, got %.4f", last)
+	}
+
+	return nil
+}
+
+// CalculateVestedAmount calculates how much has vested at a given block.
+// If the schedule was revoked (RevokedAtBlock set), vesting is frozen as
+// of that block: later calls still return exactly what had vested then,
+// never more.
 func CalculateVestedAmount(
 	allocation GenesisAllocation,
 	currentBlock uint64,
@@ -158,13 +233,22 @@ func CalculateVestedAmount(
 
 	v := allocation.Vesting
 
+	effectiveBlock := currentBlock
+	if v.RevokedAtBlock != nil && *v.RevokedAtBlock < effectiveBlock {
+		effectiveBlock = *v.RevokedAtBlock
+	}
+
+	if len(v.Milestones) > 0 {
+		return vestedFromMilestones(allocation.Amount, v.Milestones, effectiveBlock)
+	}
+
 	// Before vesting starts
-	if currentBlock < v.StartBlock {
+	if effectiveBlock < v.StartBlock {
 		return 0
 	}
 
 	// During cliff period
-	blocksSinceStart := currentBlock - v.StartBlock
+	blocksSinceStart := effectiveBlock - v.StartBlock
 	if blocksSinceStart < v.CliffBlocks {
 		// Only initial unlock available
 		return uint64(float64(allocation.Amount) * v.InitialUnlock)
@@ -185,29 +269,108 @@ func CalculateVestedAmount(
 	return initialUnlocked + vestedAmount
 }
 
-// VestingTracker tracks vesting schedules for genesis allocations
+// vestedFromMilestones linearly interpolates amount's vested fraction
+// between the two milestones surrounding currentBlock, per Milestone's
+// doc comment. currentBlock before the first milestone vests nothing;
+// at or after the last, the full (validateMilestones-enforced 1.0)
+// fraction has vested.
+func vestedFromMilestones(amount uint64, milestones []Milestone, currentBlock uint64) uint64 {
+	if currentBlock < milestones[0].BlockHeight {
+		return 0
+	}
+
+	last := milestones[len(milestones)-1]
+	if currentBlock >= last.BlockHeight {
+		return uint64(float64(amount) * last.CumulativeFraction)
+	}
+
+	for i := 0; i < len(milestones)-1; i++ {
+		a, b := milestones[i], milestones[i+1]
+		if currentBlock >= a.BlockHeight && currentBlock < b.BlockHeight {
+			progress := float64(currentBlock-a.BlockHeight) / float64(b.BlockHeight-a.BlockHeight)
+			fraction := a.CumulativeFraction + (b.CumulativeFraction-a.CumulativeFraction)*progress
+			return uint64(float64(amount) * fraction)
+		}
+	}
+
+	return uint64(float64(amount) * milestones[0].CumulativeFraction)
+}
+
+// VestingTracker tracks vesting schedules for genesis allocations, and
+// (via stateManager) how much of each allocation has already been
+// claimed into its beneficiary's account balance. Every registered
+// allocation is also persisted as a state.VestingAccount, so a node
+// restart can rebuild a VestingTracker's allocations map with
+// LoadVestingTracker instead of replaying genesis.
 type VestingTracker struct {
-	allocations map[[32]byte]GenesisAllocation
-	log         *logger.Logger
+	allocations  map[[32]byte]GenesisAllocation
+	stateManager *state.StateManager
+	log          *logger.Logger
 }
 
-// NewVestingTracker creates a new vesting tracker
-func NewVestingTracker(log *logger.Logger) *VestingTracker {
+// NewVestingTracker creates an empty vesting tracker for a fresh chain:
+// allocations must still be registered via AddAllocation (typically from
+// ApplyGenesisAllocations). Use LoadVestingTracker instead when resuming
+// an existing chain from state.
+func NewVestingTracker(stateManager *state.StateManager, log *logger.Logger) *VestingTracker {
 	return &VestingTracker{
-		allocations: make(map[[32]byte]GenesisAllocation),
-		log:         log,
+		allocations:  make(map[[32]byte]GenesisAllocation),
+		stateManager: stateManager,
+		log:          log,
 	}
 }
 
-// AddAllocation registers a vesting allocation
-func (vt *VestingTracker) AddAllocation(alloc GenesisAllocation) {
-	if alloc.Vesting != nil {
-		vt.allocations[alloc.Address] = alloc
-		vt.log.WithFields(logger.Fields{
-			"address": fmt.Sprintf("%x", alloc.Address[:8]),
-			"amount":  FormatCoinAmount(alloc.Amount),
-		}).Info("Vesting allocation registered")
+// LoadVestingTracker rebuilds a VestingTracker's allocations map from
+// every vesting account already persisted in stateManager, so a
+// restarted node recovers exactly the schedules genesis registered
+// without needing to re-derive or replay them.
+func LoadVestingTracker(stateManager *state.StateManager, log *logger.Logger) (*VestingTracker, error) {
+	vt := NewVestingTracker(stateManager, log)
+
+	snapshot, err := stateManager.GetVestingAccountSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load vesting accounts: %w", err)
 	}
+
+	for address, va := range snapshot {
+		vt.allocations[address] = GenesisAllocation{
+			Address: address,
+			Amount:  va.Amount,
+			Vesting: &VestingSchedule{
+				StartBlock:     va.StartBlock,
+				CliffBlocks:    va.CliffBlocks,
+				VestingBlocks:  va.VestingBlocks,
+				InitialUnlock:  va.InitialUnlock,
+				Milestones:     va.Milestones,
+				Revocable:      va.Revocable,
+				RevokedAtBlock: va.RevokedAtBlock,
+			},
+		}
+	}
+
+	return vt, nil
+}
+
+// AddAllocation registers alloc's vesting schedule in memory and persists
+// it as an on-chain vesting account via stateManager, the same way
+// ApplyGenesisAllocations persists alloc.Amount into address's account
+// balance. Allocations with no vesting schedule are not tracked.
+func (vt *VestingTracker) AddAllocation(alloc GenesisAllocation) error {
+	if alloc.Vesting == nil {
+		return nil
+	}
+
+	if err := vt.stateManager.CreateVestingAccount(alloc); err != nil {
+		return fmt.Errorf("failed to persist vesting account for %x: %w", alloc.Address[:8], err)
+	}
+
+	vt.allocations[alloc.Address] = alloc
+	vt.log.WithFields(logger.Fields{
+		"address": fmt.Sprintf("%x", alloc.Address[:8]),
+		"amount":  FormatCoinAmount(alloc.Amount),
+	}).Info("Vesting allocation registered")
+
+	return nil
 }
 
 // GetVestedAmount returns the currently vested amount for an address
@@ -233,3 +396,191 @@ func (vt *VestingTracker) IsVestingComplete(address [32]byte, currentBlock uint6
 	blocksSinceStart := currentBlock - alloc.Vesting.StartBlock
 	return blocksSinceStart >= alloc.Vesting.VestingBlocks
 }
+
+// Vested returns the total vested amount, summed across every
+// registered allocation, as of currentBlock.
+func (vt *VestingTracker) Vested(currentBlock uint64) uint64 {
+	var total uint64
+	for _, alloc := range vt.allocations {
+		total += CalculateVestedAmount(alloc, currentBlock)
+	}
+	return total
+}
+
+// Unvested returns the total amount still locked across every
+// registered allocation, as of currentBlock.
+func (vt *VestingTracker) Unvested(currentBlock uint64) uint64 {
+	var total uint64
+	for _, alloc := range vt.allocations {
+		total += alloc.Amount - CalculateVestedAmount(alloc, currentBlock)
+	}
+	return total
+}
+
+// Claimable returns how much address can claim right now: what's vested
+// minus what it has already claimed (persisted via stateManager, so this
+// stays correct across restarts).
+func (vt *VestingTracker) Claimable(address [32]byte, currentBlock uint64) (uint64, error) {
+	alloc, exists := vt.allocations[address]
+	if !exists {
+		return 0, nil
+	}
+
+	vested := CalculateVestedAmount(alloc, currentBlock)
+
+	va, err := vt.stateManager.GetVestingAccount(address)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read vesting account for %x: %w", address[:8], err)
+	}
+	if vested <= va.ClaimedAmount {
+		return 0, nil
+	}
+
+	return vested - va.ClaimedAmount, nil
+}
+
+// LockedAmount returns how much of address's genesis allocation is still
+// locked at currentBlock: alloc.Amount minus whatever has vested so far.
+// Addresses with no registered vesting allocation are never locked. Once
+// RevokeAllocation has run for address, nothing further is ever locked:
+// RevokeAllocation already swept the unvested remainder out of the
+// account in the same call, so whatever balance remains is fully
+// spendable from then on.
+func (vt *VestingTracker) LockedAmount(address [32]byte, currentBlock uint64) uint64 {
+	alloc, exists := vt.allocations[address]
+	if !exists || alloc.Vesting == nil {
+		return 0
+	}
+	if alloc.Vesting.RevokedAtBlock != nil {
+		return 0
+	}
+	return alloc.Amount - CalculateVestedAmount(alloc, currentBlock)
+}
+
+// CheckTransferAllowed rejects a transfer of amount out of address's
+// account if it would dip into still-locked vesting funds: balance -
+// LockedAmount(currentBlock) < amount. This is the check BlockProcessor
+// runs before debiting a sender, the same way Filecoin/Lotus actors
+// check locked funds on every send. Addresses with no registered vesting
+// allocation are never restricted.
+func (vt *VestingTracker) CheckTransferAllowed(address [32]byte, balance, amount, currentBlock uint64) error {
+	locked := vt.LockedAmount(address, currentBlock)
+	if locked == 0 {
+		return nil
+	}
+
+	var spendable uint64
+	if balance > locked {
+		spendable = balance - locked
+	}
+
+	if amount > spendable {
+		return fmt.Errorf("transfer of %d from vesting address %x exceeds spendable balance %d (balance %d, locked %d)",
+			amount, address[:8], spendable, balance, locked)
+	}
+
+	return nil
+}
+
+// ClaimVested credits address's account balance with whatever has newly
+// vested since its last claim, and persists the new claimed total so a
+// repeated ClaimVested at the same or an earlier block mints nothing
+// (idempotent and restart-safe). This is the only path that moves funds
+// out of a vesting account's locked sub-balance into its spendable
+// balance.
+func (vt *VestingTracker) ClaimVested(address [32]byte, currentBlock uint64) (uint64, error) {
+	amount, err := vt.Claimable(address, currentBlock)
+	if err != nil {
+		return 0, err
+	}
+	if amount == 0 {
+		return 0, nil
+	}
+
+	account, err := vt.stateManager.GetAccount(address)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get beneficiary account: %w", err)
+	}
+
+	if err := vt.stateManager.UpdateAccount(address, account.Balance+amount, account.Nonce); err != nil {
+		return 0, fmt.Errorf("failed to credit beneficiary account: %w", err)
+	}
+
+	va, err := vt.stateManager.GetVestingAccount(address)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read vesting account for %x: %w", address[:8], err)
+	}
+	if err := vt.stateManager.SetVestingClaimedAmount(address, va.ClaimedAmount+amount); err != nil {
+		return 0, fmt.Errorf("failed to persist claimed amount for %x: %w", address[:8], err)
+	}
+
+	vt.log.WithFields(logger.Fields{
+		"address": fmt.Sprintf("%x", address[:8]),
+		"amount":  FormatCoinAmount(amount),
+		"block":   currentBlock,
+	}).Info("Vesting claim processed")
+
+	return amount, nil
+}
+
+// RevokeAllocation cancels whatever of address's allocation has not yet
+// vested as of currentBlock, sweeping it out of address's account balance
+// and into clawbackAddr's. Whatever had already vested (including any
+// amount already claimed) is unaffected and remains address's to keep or
+// claim. RevokeAllocation is a one-time operation: it errors if address
+// has no registered allocation, the allocation isn't Revocable, or it was
+// already revoked. It returns the amount clawed back.
+func (vt *VestingTracker) RevokeAllocation(address, clawbackAddr [32]byte, currentBlock uint64) (uint64, error) {
+	alloc, exists := vt.allocations[address]
+	if !exists || alloc.Vesting == nil {
+		return 0, fmt.Errorf("no vesting allocation registered for %x", address[:8])
+	}
+	if !alloc.Vesting.Revocable {
+		return 0, fmt.Errorf("vesting allocation for %x is not revocable", address[:8])
+	}
+	if alloc.Vesting.RevokedAtBlock != nil {
+		return 0, fmt.Errorf("vesting allocation for %x was already revoked at block %d", address[:8], *alloc.Vesting.RevokedAtBlock)
+	}
+
+	vested := CalculateVestedAmount(alloc, currentBlock)
+	clawback := alloc.Amount - vested
+	if clawback == 0 {
+		return 0, nil
+	}
+
+	account, err := vt.stateManager.GetAccount(address)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get beneficiary account: %w", err)
+	}
+	if account.Balance < clawback {
+		return 0, fmt.Errorf("beneficiary %x balance %d is less than unvested amount %d", address[:8], account.Balance, clawback)
+	}
+	if err := vt.stateManager.UpdateAccount(address, account.Balance-clawback, account.Nonce); err != nil {
+		return 0, fmt.Errorf("failed to debit beneficiary account: %w", err)
+	}
+
+	clawbackAccount, err := vt.stateManager.GetAccount(clawbackAddr)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get clawback account: %w", err)
+	}
+	if err := vt.stateManager.UpdateAccount(clawbackAddr, clawbackAccount.Balance+clawback, clawbackAccount.Nonce); err != nil {
+		return 0, fmt.Errorf("failed to credit clawback account: %w", err)
+	}
+
+	if err := vt.stateManager.RevokeVestingAccount(address, currentBlock); err != nil {
+		return 0, fmt.Errorf("failed to persist revocation for %x: %w", address[:8], err)
+	}
+
+	revokedAtBlock := currentBlock
+	alloc.Vesting.RevokedAtBlock = &revokedAtBlock
+	vt.allocations[address] = alloc
+
+	vt.log.WithFields(logger.Fields{
+		"address":       fmt.Sprintf("%x", address[:8]),
+		"clawback_addr": fmt.Sprintf("%x", clawbackAddr[:8]),
+		"amount":        FormatCoinAmount(clawback),
+		"block":         currentBlock,
+	}).Info("Vesting allocation revoked")
+
+	return clawback, nil
+}
@@ -0,0 +1,245 @@
+package tokenomics
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GenesisDoc is the canonical, file-based genesis configuration for a
+// chain: chain id, genesis time, consensus parameters, and the full
+// allocation list — the same role a genesis.json plays for cosmos-sdk,
+// algorand, or lotus, rather than baking these values into code. Use
+// LoadGenesis to read one from disk; GenesisHash commits to its exact
+// contents so a misconfigured or tampered genesis file is caught before
+// a node ever starts.
+type GenesisDoc struct {
+	ChainID         string                 `json:"chain_id" yaml:"chain_id"`
+	GenesisTime     int64                  `json:"genesis_time" yaml:"genesis_time"` // Unix seconds
+	ConsensusParams ConsensusParams        `json:"consensus_params" yaml:"consensus_params"`
+	Allocations     []GenesisAllocationDoc `json:"allocations" yaml:"allocations"`
+
+	hash string // set by LoadGenesis/canonicalize; see GenesisHash
+}
+
+// ConsensusParams are the chain-wide consensus parameters fixed at
+// genesis and committed to by GenesisHash.
+type ConsensusParams struct {
+	BlockTimeSeconds uint64 `json:"block_time_seconds" yaml:"block_time_seconds"`
+	GasLimit         uint64 `json:"gas_limit" yaml:"gas_limit"`
+}
+
+// GenesisAllocationDoc is GenesisAllocation's on-disk form: a [32]byte
+// address and a *uint64 RevokedAtBlock don't have a natural JSON/YAML
+// encoding, so the doc form uses a hex string and an omittable field
+// instead. ToAllocation/genesisAllocationDoc convert between the two.
+type GenesisAllocationDoc struct {
+	Address     string              `json:"address" yaml:"address"` // hex-encoded, no 0x prefix
+	Amount      uint64              `json:"amount" yaml:"amount"`
+	Description string              `json:"description" yaml:"description"`
+	Vesting     *VestingScheduleDoc `json:"vesting,omitempty" yaml:"vesting,omitempty"`
+}
+
+// VestingScheduleDoc is VestingSchedule's on-disk form.
+type VestingScheduleDoc struct {
+	StartBlock    uint64      `json:"start_block,omitempty" yaml:"start_block,omitempty"`
+	CliffBlocks   uint64      `json:"cliff_blocks,omitempty" yaml:"cliff_blocks,omitempty"`
+	VestingBlocks uint64      `json:"vesting_blocks,omitempty" yaml:"vesting_blocks,omitempty"`
+	InitialUnlock float64     `json:"initial_unlock,omitempty" yaml:"initial_unlock,omitempty"`
+	Milestones    []Milestone `json:"milestones,omitempty" yaml:"milestones,omitempty"`
+	Revocable     bool        `json:"revocable,omitempty" yaml:"revocable,omitempty"`
+}
+
+// LoadGenesis reads a genesis document from path (JSON if the extension
+// is ".json", YAML otherwise), validates its allocations' vesting
+// milestones the same way ApplyGenesisAllocations does, and computes its
+// GenesisHash over the canonicalized contents.
+func LoadGenesis(path string) (*GenesisDoc, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read genesis file: %w", err)
+	}
+
+	var doc GenesisDoc
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse genesis JSON: %w", err)
+		}
+	} else {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse genesis YAML: %w", err)
+		}
+	}
+
+	if _, err := doc.ResolveAllocations(); err != nil {
+		return nil, fmt.Errorf("invalid genesis allocations: %w", err)
+	}
+
+	canonical, err := doc.canonicalBytes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to canonicalize genesis document: %w", err)
+	}
+	sum := sha256.Sum256(canonical)
+	doc.hash = hex.EncodeToString(sum[:])
+
+	return &doc, nil
+}
+
+// LoadGenesisVerified calls LoadGenesis and then refuses to return it if
+// its GenesisHash doesn't match expectedHash — the check a node runs
+// before starting so it never runs on a genesis file that doesn't match
+// the hash it was configured to trust. An empty expectedHash skips the
+// check (used by gen-genesis and local development).
+func LoadGenesisVerified(path, expectedHash string) (*GenesisDoc, error) {
+	doc, err := LoadGenesis(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if expectedHash != "" && doc.GenesisHash() != expectedHash {
+		return nil, fmt.Errorf("genesis hash mismatch: file %s hashes to %s, expected %s", path, doc.GenesisHash(), expectedHash)
+	}
+
+	return doc, nil
+}
+
+// GenesisHash returns the deterministic SHA-256 of the document's
+// canonicalized contents, computed by LoadGenesis. Empty until the
+// document has been loaded (or saved) at least once.
+func (d *GenesisDoc) GenesisHash() string {
+	return d.hash
+}
+
+// canonicalBytes re-marshals the document as JSON using struct field
+// order (fixed at compile time, unlike map iteration) to produce the
+// same bytes for the same contents regardless of source format or
+// encoder whitespace — the input to GenesisHash.
+func (d *GenesisDoc) canonicalBytes() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+// ResolveAllocations decodes every GenesisAllocationDoc into a
+// GenesisAllocation, validating hex addresses and vesting milestones
+// along the way — the []GenesisAllocation ApplyGenesisAllocations
+// expects.
+func (d *GenesisDoc) ResolveAllocations() ([]GenesisAllocation, error) {
+	allocations := make([]GenesisAllocation, len(d.Allocations))
+	for i, ad := range d.Allocations {
+		alloc, err := ad.toAllocation()
+		if err != nil {
+			return nil, fmt.Errorf("allocation %d: %w", i, err)
+		}
+		if alloc.Vesting != nil && len(alloc.Vesting.Milestones) > 0 {
+			if err := validateMilestones(alloc.Vesting.Milestones); err != nil {
+				return nil, fmt.Errorf("allocation %d: %w", i, err)
+			}
+		}
+		allocations[i] = alloc
+	}
+	return allocations, nil
+}
+
+// toAllocation decodes ad's hex address and vesting schedule into a
+// GenesisAllocation.
+func (ad GenesisAllocationDoc) toAllocation() (GenesisAllocation, error) {
+	addrBytes, err := hex.DecodeString(ad.Address)
+	if err != nil {
+		return GenesisAllocation{}, fmt.Errorf("invalid hex address %q: %w", ad.Address, err)
+	}
+	if len(addrBytes) != 32 {
+		return GenesisAllocation{}, fmt.Errorf("address %q must decode to 32 bytes, got %d", ad.Address, len(addrBytes))
+	}
+
+	var addr [32]byte
+	copy(addr[:], addrBytes)
+
+	alloc := GenesisAllocation{
+		Address:     addr,
+		Amount:      ad.Amount,
+		Description: ad.Description,
+	}
+	if ad.Vesting != nil {
+		alloc.Vesting = &VestingSchedule{
+			StartBlock:    ad.Vesting.StartBlock,
+			CliffBlocks:   ad.Vesting.CliffBlocks,
+			VestingBlocks: ad.Vesting.VestingBlocks,
+			InitialUnlock: ad.Vesting.InitialUnlock,
+			Milestones:    ad.Vesting.Milestones,
+			Revocable:     ad.Vesting.Revocable,
+		}
+	}
+
+	return alloc, nil
+}
+
+// genesisAllocationDoc encodes alloc back into its on-disk form — the
+// inverse of toAllocation, used by gen-genesis to emit
+// DefaultGenesisAllocations as a file.
+func genesisAllocationDoc(alloc GenesisAllocation) GenesisAllocationDoc {
+	ad := GenesisAllocationDoc{
+		Address:     hex.EncodeToString(alloc.Address[:]),
+		Amount:      alloc.Amount,
+		Description: alloc.Description,
+	}
+	if alloc.Vesting != nil {
+		ad.Vesting = &VestingScheduleDoc{
+			StartBlock:    alloc.Vesting.StartBlock,
+			CliffBlocks:   alloc.Vesting.CliffBlocks,
+			VestingBlocks: alloc.Vesting.VestingBlocks,
+			InitialUnlock: alloc.Vesting.InitialUnlock,
+			Milestones:    alloc.Vesting.Milestones,
+			Revocable:     alloc.Vesting.Revocable,
+		}
+	}
+	return ad
+}
+
+// NewGenesisDoc builds a GenesisDoc wrapping allocations, for gen-genesis
+// to write out and for tests to round-trip through LoadGenesis.
+func NewGenesisDoc(chainID string, genesisTime int64, params ConsensusParams, allocations []GenesisAllocation) *GenesisDoc {
+	docs := make([]GenesisAllocationDoc, len(allocations))
+	for i, alloc := range allocations {
+		docs[i] = genesisAllocationDoc(alloc)
+	}
+	return &GenesisDoc{
+		ChainID:         chainID,
+		GenesisTime:     genesisTime,
+		ConsensusParams: params,
+		Allocations:     docs,
+	}
+}
+
+// Save writes doc to path as JSON (if the extension is ".json") or YAML
+// otherwise, and populates doc's GenesisHash as a side effect so callers
+// can log it immediately after writing.
+func (d *GenesisDoc) Save(path string) error {
+	var data []byte
+	var err error
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		data, err = json.MarshalIndent(d, "", "  ")
+	} else {
+		data, err = yaml.Marshal(d)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal genesis document: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write genesis file: %w", err)
+	}
+
+	canonical, err := d.canonicalBytes()
+	if err != nil {
+		return fmt.Errorf("failed to canonicalize genesis document: %w", err)
+	}
+	sum := sha256.Sum256(canonical)
+	d.hash = hex.EncodeToString(sum[:])
+
+	return nil
+}
@@ -0,0 +1,330 @@
+package tokenomics
+
+import (
+	"testing"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
+)
+
+func testVestingAllocation(address [32]byte) GenesisAllocation {
+	return GenesisAllocation{
+		Address:     address,
+		Amount:      1_000 * WeiPerCoin,
+		Description: "test allocation",
+		Vesting: &VestingSchedule{
+			StartBlock:    0,
+			CliffBlocks:   100,
+			VestingBlocks: 200,
+			InitialUnlock: 0.10,
+		},
+	}
+}
+
+func TestCalculateVestedAmount_BeforeCliff(t *testing.T) {
+	alloc := testVestingAllocation([32]byte{1})
+
+	// Within the cliff, only the initial unlock is available.
+	want := uint64(float64(alloc.Amount) * alloc.Vesting.InitialUnlock)
+	if got := CalculateVestedAmount(alloc, 50); got != want {
+		t.Errorf("block 50 (inside cliff): expected %d vested, got %d", want, got)
+	}
+	if got := CalculateVestedAmount(alloc, 0); got != want {
+		t.Errorf("block 0 (inside cliff): expected %d vested, got %d", want, got)
+	}
+}
+
+func TestCalculateVestedAmount_LinearRelease(t *testing.T) {
+	alloc := testVestingAllocation([32]byte{1})
+
+	// Halfway between the cliff (100) and full vesting (200): half of the
+	// post-initial-unlock amount should have linearly released.
+	initialUnlocked := uint64(float64(alloc.Amount) * alloc.Vesting.InitialUnlock)
+	vestingAmount := alloc.Amount - initialUnlocked
+	want := initialUnlocked + vestingAmount/2
+
+	got := CalculateVestedAmount(alloc, 150)
+	if got != want {
+		t.Errorf("block 150 (midway through linear release): expected %d vested, got %d", want, got)
+	}
+}
+
+func TestCalculateVestedAmount_InitialUnlock(t *testing.T) {
+	alloc := testVestingAllocation([32]byte{1})
+
+	// At the very start, only InitialUnlock's share is vested, not 0.
+	got := CalculateVestedAmount(alloc, 0)
+	want := uint64(float64(alloc.Amount) * 0.10)
+	if got != want {
+		t.Errorf("expected initial unlock of %d at block 0, got %d", want, got)
+	}
+	if got == 0 {
+		t.Error("expected a non-zero initial unlock, got 0")
+	}
+}
+
+func TestCalculateVestedAmount_FullyVestedAfterSchedule(t *testing.T) {
+	alloc := testVestingAllocation([32]byte{1})
+
+	if got := CalculateVestedAmount(alloc, 200); got != alloc.Amount {
+		t.Errorf("expected the full amount vested at block 200, got %d", got)
+	}
+	if got := CalculateVestedAmount(alloc, 10_000); got != alloc.Amount {
+		t.Errorf("expected the full amount vested long after the schedule ends, got %d", got)
+	}
+}
+
+func TestCalculateVestedAmount_NoVestingIsFullyUnlocked(t *testing.T) {
+	alloc := GenesisAllocation{Address: [32]byte{1}, Amount: 500, Vesting: nil}
+	if got := CalculateVestedAmount(alloc, 0); got != alloc.Amount {
+		t.Errorf("expected an allocation with no vesting schedule to be fully unlocked, got %d", got)
+	}
+}
+
+func testMilestoneAllocation(address [32]byte) GenesisAllocation {
+	return GenesisAllocation{
+		Address:     address,
+		Amount:      1_000 * WeiPerCoin,
+		Description: "test milestone allocation",
+		Vesting: &VestingSchedule{
+			Milestones: []Milestone{
+				{BlockHeight: 100, CumulativeFraction: 0.25},
+				{BlockHeight: 200, CumulativeFraction: 0.50},
+				{BlockHeight: 300, CumulativeFraction: 1.0},
+			},
+			Revocable: true,
+		},
+	}
+}
+
+func TestCalculateVestedAmount_MilestonesInterpolateLinearly(t *testing.T) {
+	alloc := testMilestoneAllocation([32]byte{1})
+
+	cases := map[uint64]uint64{
+		0:   0,
+		50:  0,
+		100: alloc.Amount / 4,
+		150: alloc.Amount * 375 / 1000,
+		200: alloc.Amount / 2,
+		300: alloc.Amount,
+		400: alloc.Amount,
+	}
+	for block, want := range cases {
+		if got := CalculateVestedAmount(alloc, block); got != want {
+			t.Errorf("block %d: expected %d vested, got %d", block, want, got)
+		}
+	}
+}
+
+func TestValidateMilestones_RejectsNonMonotonicOrMissingFinalFraction(t *testing.T) {
+	cases := []struct {
+		name       string
+		milestones []Milestone
+	}{
+		{"empty", nil},
+		{"descending block height", []Milestone{
+			{BlockHeight: 200, CumulativeFraction: 0.5},
+			{BlockHeight: 100, CumulativeFraction: 1.0},
+		}},
+		{"decreasing fraction", []Milestone{
+			{BlockHeight: 100, CumulativeFraction: 0.5},
+			{BlockHeight: 200, CumulativeFraction: 0.25},
+		}},
+		{"does not end at 1.0", []Milestone{
+			{BlockHeight: 100, CumulativeFraction: 0.5},
+			{BlockHeight: 200, CumulativeFraction: 0.9},
+		}},
+	}
+
+	for _, c := range cases {
+		if err := validateMilestones(c.milestones); err == nil {
+			t.Errorf("%s: expected an error, got nil", c.name)
+		}
+	}
+}
+
+func newTestVestingTracker(t *testing.T) (*VestingTracker, *state.StateManager) {
+	t.Helper()
+
+	log := logger.NewLogger("debug")
+	sm, err := state.NewStateManager(":memory:", log)
+	if err != nil {
+		t.Fatalf("failed to create state manager: %v", err)
+	}
+	t.Cleanup(func() { sm.Close() })
+
+	return NewVestingTracker(sm, log), sm
+}
+
+func TestVestingTracker_CheckTransferAllowed_RejectsOverspend(t *testing.T) {
+	vt, sm := newTestVestingTracker(t)
+	address := [32]byte{1}
+
+	alloc := testVestingAllocation(address)
+	if err := vt.AddAllocation(alloc); err != nil {
+		t.Fatalf("failed to add allocation: %v", err)
+	}
+	if err := sm.UpdateAccount(address, alloc.Amount, 0); err != nil {
+		t.Fatalf("failed to seed account balance: %v", err)
+	}
+
+	// At block 0, only the 10% initial unlock is spendable.
+	spendable := uint64(float64(alloc.Amount) * 0.10)
+
+	if err := vt.CheckTransferAllowed(address, alloc.Amount, spendable, 0); err != nil {
+		t.Errorf("expected a transfer of exactly the spendable amount to be allowed, got error: %v", err)
+	}
+	if err := vt.CheckTransferAllowed(address, alloc.Amount, spendable+1, 0); err == nil {
+		t.Error("expected a transfer exceeding the spendable amount to be rejected")
+	}
+}
+
+func TestVestingTracker_CheckTransferAllowed_UnrestrictedWithoutVesting(t *testing.T) {
+	vt, _ := newTestVestingTracker(t)
+	address := [32]byte{2}
+
+	if err := vt.CheckTransferAllowed(address, 100, 100, 0); err != nil {
+		t.Errorf("expected an address with no registered vesting allocation to be unrestricted, got error: %v", err)
+	}
+}
+
+func TestVestingTracker_ClaimVested_IsIdempotentAndRestartSafe(t *testing.T) {
+	vt, sm := newTestVestingTracker(t)
+	address := [32]byte{1}
+
+	alloc := testVestingAllocation(address)
+	if err := vt.AddAllocation(alloc); err != nil {
+		t.Fatalf("failed to add allocation: %v", err)
+	}
+	if err := sm.CreateAccount(address, 0); err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+
+	claimed, err := vt.ClaimVested(address, 150)
+	if err != nil {
+		t.Fatalf("ClaimVested failed: %v", err)
+	}
+
+	want := CalculateVestedAmount(alloc, 150)
+	if claimed != want {
+		t.Errorf("expected to claim %d at block 150, got %d", want, claimed)
+	}
+
+	account, err := sm.GetAccount(address)
+	if err != nil {
+		t.Fatalf("failed to get account: %v", err)
+	}
+	if account.Balance != claimed {
+		t.Errorf("expected account balance %d after claim, got %d", claimed, account.Balance)
+	}
+
+	// A repeated claim at the same block mints nothing.
+	again, err := vt.ClaimVested(address, 150)
+	if err != nil {
+		t.Fatalf("second ClaimVested failed: %v", err)
+	}
+	if again != 0 {
+		t.Errorf("expected a repeated claim at the same block to mint 0, got %d", again)
+	}
+
+	// Reconstructing the tracker from state (as a restarted node would)
+	// must recover the same schedule and claimed total.
+	reloaded, err := LoadVestingTracker(sm, logger.NewLogger("debug"))
+	if err != nil {
+		t.Fatalf("LoadVestingTracker failed: %v", err)
+	}
+
+	stillClaimable, err := reloaded.Claimable(address, 150)
+	if err != nil {
+		t.Fatalf("Claimable failed after reload: %v", err)
+	}
+	if stillClaimable != 0 {
+		t.Errorf("expected nothing claimable after reload at the same block, got %d", stillClaimable)
+	}
+
+	moreClaimable, err := reloaded.Claimable(address, 200)
+	if err != nil {
+		t.Fatalf("Claimable failed after reload: %v", err)
+	}
+	if want := alloc.Amount - claimed; moreClaimable != want {
+		t.Errorf("expected %d newly claimable after further vesting past reload, got %d", want, moreClaimable)
+	}
+}
+
+func TestVestingTracker_RevokeAllocation_FreezesVestingAndClawsBackRemainder(t *testing.T) {
+	vt, sm := newTestVestingTracker(t)
+	address := [32]byte{1}
+	clawbackAddr := [32]byte{9}
+
+	alloc := testMilestoneAllocation(address)
+	if err := vt.AddAllocation(alloc); err != nil {
+		t.Fatalf("failed to add allocation: %v", err)
+	}
+	if err := sm.CreateAccount(address, alloc.Amount); err != nil {
+		t.Fatalf("failed to create beneficiary account: %v", err)
+	}
+	if err := sm.CreateAccount(clawbackAddr, 0); err != nil {
+		t.Fatalf("failed to create clawback account: %v", err)
+	}
+
+	// At block 150, 37.5% has vested; the rest is clawed back.
+	vested := CalculateVestedAmount(alloc, 150)
+	wantClawback := alloc.Amount - vested
+
+	clawedBack, err := vt.RevokeAllocation(address, clawbackAddr, 150)
+	if err != nil {
+		t.Fatalf("RevokeAllocation failed: %v", err)
+	}
+	if clawedBack != wantClawback {
+		t.Errorf("expected %d clawed back, got %d", wantClawback, clawedBack)
+	}
+
+	beneficiary, err := sm.GetAccount(address)
+	if err != nil {
+		t.Fatalf("failed to get beneficiary account: %v", err)
+	}
+	if beneficiary.Balance != vested {
+		t.Errorf("expected beneficiary balance %d after revocation, got %d", vested, beneficiary.Balance)
+	}
+
+	clawbackAccount, err := sm.GetAccount(clawbackAddr)
+	if err != nil {
+		t.Fatalf("failed to get clawback account: %v", err)
+	}
+	if clawbackAccount.Balance != wantClawback {
+		t.Errorf("expected clawback account balance %d, got %d", wantClawback, clawbackAccount.Balance)
+	}
+
+	// Vesting is frozen: querying well past the revocation block returns
+	// exactly what had vested at revocation, never more.
+	if got := vt.GetVestedAmount(address, 1_000); got != vested {
+		t.Errorf("expected vesting frozen at %d after revocation, got %d", vested, got)
+	}
+
+	// Nothing further is ever locked once revoked.
+	if got := vt.LockedAmount(address, 1_000); got != 0 {
+		t.Errorf("expected 0 locked after revocation, got %d", got)
+	}
+
+	// A second revocation is rejected.
+	if _, err := vt.RevokeAllocation(address, clawbackAddr, 200); err == nil {
+		t.Error("expected revoking an already-revoked allocation to fail")
+	}
+}
+
+func TestVestingTracker_RevokeAllocation_RejectsNonRevocable(t *testing.T) {
+	vt, sm := newTestVestingTracker(t)
+	address := [32]byte{1}
+
+	alloc := testVestingAllocation(address) // Revocable defaults to false.
+	if err := vt.AddAllocation(alloc); err != nil {
+		t.Fatalf("failed to add allocation: %v", err)
+	}
+	if err := sm.CreateAccount(address, alloc.Amount); err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+
+	if _, err := vt.RevokeAllocation(address, [32]byte{9}, 150); err == nil {
+		t.Error("expected revoking a non-revocable allocation to fail")
+	}
+}
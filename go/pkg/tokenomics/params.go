@@ -0,0 +1,360 @@
+package tokenomics
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
+)
+
+// TokenomicsParams is the full set of tokenomics values this chain lets
+// validators amend by governance instead of recompiling the binary:
+// the block reward schedule, vesting defaults for future grants, the
+// supply cap, the wei/coin conversion, and the community-rewards
+// address. ParamStore.CurrentParams returns the live value; everything
+// still reads DefaultTokenomicsParams's values until a TokenomicsProposal
+// changes them.
+type TokenomicsParams struct {
+	InitialBlockReward  uint64 // Starting reward per block, in wei
+	RewardHalvingBlocks uint64 // Blocks between halvings
+	MinBlockReward      uint64 // Reward never goes below this, in wei
+
+	MaxSupply  uint64 // Hard supply cap, in wei (0 = uncapped emission model)
+	WeiPerCoin uint64 // Wei per whole coin
+
+	// Vesting defaults applied to future grants that don't specify their
+	// own cliff/duration/initial-unlock (existing allocations and their
+	// VestingSchedule are never retroactively altered by a params
+	// change — see the package doc comment on ParamStore).
+	DefaultVestingCliffBlocks   uint64
+	DefaultVestingBlocks        uint64
+	DefaultVestingInitialUnlock float64
+
+	CommunityRewardsAddress [32]byte
+}
+
+// DefaultTokenomicsParams mirrors today's hardcoded constants (see
+// economics.go's WeiPerCoin and DefaultTokenomicsConfig), for seeding a
+// fresh chain's ParamStore at genesis — the params-module counterpart to
+// DefaultGenesisAllocations, which seeds account balances rather than
+// these governable values.
+func DefaultTokenomicsParams(communityRewardsAddress [32]byte) TokenomicsParams {
+	return TokenomicsParams{
+		InitialBlockReward:  3_125_000_000, // 3.125 BEANS
+		RewardHalvingBlocks: 1_051_200,     // ~24.3 days at 2s blocks
+		MinBlockReward:      100_000_000,   // 0.1 BEANS
+
+		MaxSupply:  21_000_000 * WeiPerCoin,
+		WeiPerCoin: WeiPerCoin,
+
+		DefaultVestingCliffBlocks:   0,
+		DefaultVestingBlocks:        0,
+		DefaultVestingInitialUnlock: 1.0, // No default vesting unless a grant opts in
+
+		CommunityRewardsAddress: communityRewardsAddress,
+	}
+}
+
+// ProposalStatus is a TokenomicsProposal's lifecycle state.
+type ProposalStatus int
+
+const (
+	ProposalVoting ProposalStatus = iota
+	ProposalPassed
+	ProposalRejected
+)
+
+func (s ProposalStatus) String() string {
+	switch s {
+	case ProposalVoting:
+		return "voting"
+	case ProposalPassed:
+		return "passed"
+	case ProposalRejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
+
+// TokenomicsProposal is a governance transaction proposing to replace
+// the ParamStore's current TokenomicsParams wholesale. It passes once
+// validator signatures covering more than 2/3 of the validator set have
+// been collected before VotingEndBlock; otherwise it's rejected once
+// VotingEndBlock passes.
+type TokenomicsProposal struct {
+	ID               string
+	Proposer         [32]byte
+	ProposedParams   TokenomicsParams
+	Deposit          uint64 // Wei, refunded to Proposer on passage
+	VotingStartBlock uint64
+	VotingEndBlock   uint64
+
+	// Signatures maps each signing validator's address to its Ed25519
+	// signature over the proposal (see signingMessage). A validator may
+	// only sign once; re-signing overwrites its previous signature.
+	Signatures map[[32]byte][]byte
+
+	Status ProposalStatus
+}
+
+// signingMessage is the exact byte sequence a validator's signature
+// authenticates: the proposal ID followed by a canonical encoding of
+// ProposedParams, so a signature can't be replayed against a different
+// proposal or a tampered set of params.
+func (p *TokenomicsProposal) signingMessage() []byte {
+	params := p.ProposedParams
+	return []byte(fmt.Sprintf(
+		"tokenomics-proposal:%s:%d:%d:%d:%d:%d:%d:%d:%.8f:%x",
+		p.ID,
+		params.InitialBlockReward, params.RewardHalvingBlocks, params.MinBlockReward,
+		params.MaxSupply, params.WeiPerCoin,
+		params.DefaultVestingCliffBlocks, params.DefaultVestingBlocks,
+		params.DefaultVestingInitialUnlock,
+		params.CommunityRewardsAddress,
+	))
+}
+
+// ParamChangeLogEntry records one applied TokenomicsProposal, so the
+// full history of governed parameter changes is auditable on-chain.
+type ParamChangeLogEntry struct {
+	ProposalID string
+	AppliedAt  uint64 // Block height the proposal passed at
+	Params     TokenomicsParams
+}
+
+// ParamStore holds the chain's current TokenomicsParams plus the
+// in-flight and resolved TokenomicsProposals that can change them,
+// mirroring cosmos-sdk's params module: everywhere that currently reads
+// a tokenomics constant (block reward schedule, vesting defaults, max
+// supply, WeiPerCoin, the community-rewards address) should instead read
+// CurrentParams(). Note that CalculateVestedAmount's inputs are fully
+// determined by each allocation's own VestingSchedule, not by any
+// package-level constant, so a params change never retroactively alters
+// an existing allocation's vesting curve — only DefaultVesting* affects
+// grants made after the change.
+type ParamStore struct {
+	current TokenomicsParams
+
+	proposals map[string]*TokenomicsProposal
+	changeLog []ParamChangeLogEntry
+
+	stateManager *state.StateManager
+	log          *logger.Logger
+}
+
+// NewParamStore creates a ParamStore seeded with initial (typically
+// DefaultTokenomicsParams's return value) and persists that seed via
+// stateManager, the same way NewVestingTracker's allocations are seeded
+// through ApplyGenesisAllocations.
+func NewParamStore(stateManager *state.StateManager, log *logger.Logger, initial TokenomicsParams) (*ParamStore, error) {
+	ps := &ParamStore{
+		current:      initial,
+		proposals:    make(map[string]*TokenomicsProposal),
+		stateManager: stateManager,
+		log:          log,
+	}
+
+	if err := stateManager.SaveTokenomicsParams(initial); err != nil {
+		return nil, fmt.Errorf("failed to persist initial tokenomics params: %w", err)
+	}
+
+	return ps, nil
+}
+
+// LoadParamStore rebuilds a ParamStore from whatever tokenomics params,
+// proposals, and change log stateManager already has persisted, for a
+// restarted node — the params-module counterpart to LoadVestingTracker.
+func LoadParamStore(stateManager *state.StateManager, log *logger.Logger) (*ParamStore, error) {
+	current, err := stateManager.GetTokenomicsParams()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenomics params: %w", err)
+	}
+
+	proposals, err := stateManager.GetTokenomicsProposals()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenomics proposals: %w", err)
+	}
+
+	changeLog, err := stateManager.GetTokenomicsChangeLog()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokenomics change log: %w", err)
+	}
+
+	return &ParamStore{
+		current:      current,
+		proposals:    proposals,
+		changeLog:    changeLog,
+		stateManager: stateManager,
+		log:          log,
+	}, nil
+}
+
+// CurrentParams returns the ParamStore's live TokenomicsParams snapshot.
+func (ps *ParamStore) CurrentParams() TokenomicsParams {
+	return ps.current
+}
+
+// ChangeLog returns every TokenomicsProposal that has ever passed, in
+// the order they were applied.
+func (ps *ParamStore) ChangeLog() []ParamChangeLogEntry {
+	return ps.changeLog
+}
+
+// SubmitProposal registers a new TokenomicsProposal with a voting window
+// of [currentBlock, currentBlock+votingWindowBlocks], collects its
+// required deposit from the proposer's account, and persists it.
+func (ps *ParamStore) SubmitProposal(id string, proposer [32]byte, params TokenomicsParams, deposit, currentBlock, votingWindowBlocks uint64) (*TokenomicsProposal, error) {
+	if _, exists := ps.proposals[id]; exists {
+		return nil, fmt.Errorf("a proposal with id %q already exists", id)
+	}
+	if votingWindowBlocks == 0 {
+		return nil, fmt.Errorf("voting window must be at least 1 block")
+	}
+
+	account, err := ps.stateManager.GetAccount(proposer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proposer account: %w", err)
+	}
+	if account.Balance < deposit {
+		return nil, fmt.Errorf("proposer balance %d is less than required deposit %d", account.Balance, deposit)
+	}
+	if err := ps.stateManager.UpdateAccount(proposer, account.Balance-deposit, account.Nonce); err != nil {
+		return nil, fmt.Errorf("failed to debit proposal deposit: %w", err)
+	}
+
+	proposal := &TokenomicsProposal{
+		ID:               id,
+		Proposer:         proposer,
+		ProposedParams:   params,
+		Deposit:          deposit,
+		VotingStartBlock: currentBlock,
+		VotingEndBlock:   currentBlock + votingWindowBlocks,
+		Signatures:       make(map[[32]byte][]byte),
+		Status:           ProposalVoting,
+	}
+
+	if err := ps.stateManager.SaveTokenomicsProposal(proposal); err != nil {
+		return nil, fmt.Errorf("failed to persist proposal %q: %w", id, err)
+	}
+	ps.proposals[id] = proposal
+
+	ps.log.WithFields(logger.Fields{
+		"proposal_id": id,
+		"proposer":    fmt.Sprintf("%x", proposer[:8]),
+		"deposit":     FormatCoinAmount(deposit),
+		"voting_end":  proposal.VotingEndBlock,
+	}).Info("Tokenomics proposal submitted")
+
+	return proposal, nil
+}
+
+// AddValidatorSignature verifies signature against validatorPubKey and,
+// if valid, records validatorAddr's vote in favor of proposalID. It
+// rejects signatures submitted after the voting window has closed.
+func (ps *ParamStore) AddValidatorSignature(proposalID string, validatorAddr [32]byte, validatorPubKey ed25519.PublicKey, signature []byte, currentBlock uint64) error {
+	proposal, exists := ps.proposals[proposalID]
+	if !exists {
+		return fmt.Errorf("no proposal with id %q", proposalID)
+	}
+	if proposal.Status != ProposalVoting {
+		return fmt.Errorf("proposal %q is no longer open for voting (status: %s)", proposalID, proposal.Status)
+	}
+	if currentBlock > proposal.VotingEndBlock {
+		return fmt.Errorf("voting window for proposal %q closed at block %d", proposalID, proposal.VotingEndBlock)
+	}
+
+	if !ed25519.Verify(validatorPubKey, proposal.signingMessage(), signature) {
+		return fmt.Errorf("invalid validator signature for proposal %q", proposalID)
+	}
+
+	proposal.Signatures[validatorAddr] = signature
+
+	if err := ps.stateManager.SaveTokenomicsProposal(proposal); err != nil {
+		return fmt.Errorf("failed to persist vote on proposal %q: %w", proposalID, err)
+	}
+
+	return nil
+}
+
+// Tally resolves proposalID once its signatures are counted against the
+// validator set: it passes (and is applied immediately, refunding its
+// deposit) once signatures cover more than 2/3 of totalValidators; it's
+// rejected (forfeiting its deposit) once currentBlock passes
+// VotingEndBlock without reaching that threshold. Called again on an
+// already-resolved proposal is a no-op that just reports its status.
+func (ps *ParamStore) Tally(proposalID string, totalValidators int, currentBlock uint64) (ProposalStatus, error) {
+	proposal, exists := ps.proposals[proposalID]
+	if !exists {
+		return ProposalRejected, fmt.Errorf("no proposal with id %q", proposalID)
+	}
+	if proposal.Status != ProposalVoting {
+		return proposal.Status, nil
+	}
+
+	if len(proposal.Signatures)*3 > totalValidators*2 {
+		if err := ps.apply(proposal, currentBlock); err != nil {
+			return ProposalVoting, err
+		}
+		return ProposalPassed, nil
+	}
+
+	if currentBlock <= proposal.VotingEndBlock {
+		return ProposalVoting, nil
+	}
+
+	proposal.Status = ProposalRejected
+	if err := ps.stateManager.SaveTokenomicsProposal(proposal); err != nil {
+		return ProposalVoting, fmt.Errorf("failed to persist rejection of proposal %q: %w", proposalID, err)
+	}
+
+	ps.log.WithFields(logger.Fields{
+		"proposal_id": proposalID,
+		"signatures":  len(proposal.Signatures),
+		"validators":  totalValidators,
+	}).Info("Tokenomics proposal rejected: insufficient signatures before voting window closed")
+
+	return ProposalRejected, nil
+}
+
+// apply installs proposal's params as current, refunds its deposit to
+// its proposer, appends a ParamChangeLogEntry, and persists all three.
+func (ps *ParamStore) apply(proposal *TokenomicsProposal, currentBlock uint64) error {
+	proposal.Status = ProposalPassed
+	if err := ps.stateManager.SaveTokenomicsProposal(proposal); err != nil {
+		return fmt.Errorf("failed to persist passage of proposal %q: %w", proposal.ID, err)
+	}
+
+	account, err := ps.stateManager.GetAccount(proposal.Proposer)
+	if err != nil {
+		return fmt.Errorf("failed to get proposer account: %w", err)
+	}
+	if err := ps.stateManager.UpdateAccount(proposal.Proposer, account.Balance+proposal.Deposit, account.Nonce); err != nil {
+		return fmt.Errorf("failed to refund proposal deposit: %w", err)
+	}
+
+	ps.current = proposal.ProposedParams
+	if err := ps.stateManager.SaveTokenomicsParams(ps.current); err != nil {
+		return fmt.Errorf("failed to persist new tokenomics params: %w", err)
+	}
+
+	entry := ParamChangeLogEntry{
+		ProposalID: proposal.ID,
+		AppliedAt:  currentBlock,
+		Params:     proposal.ProposedParams,
+	}
+	if err := ps.stateManager.AppendTokenomicsChangeLog(entry); err != nil {
+		return fmt.Errorf("failed to persist change log entry for proposal %q: %w", proposal.ID, err)
+	}
+	ps.changeLog = append(ps.changeLog, entry)
+
+	ps.log.WithFields(logger.Fields{
+		"proposal_id": proposal.ID,
+		"applied_at":  currentBlock,
+		"signatures":  len(proposal.Signatures),
+	}).Info("Tokenomics proposal passed and applied")
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package tokenomics
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
+)
+
+func newTestParamStore(t *testing.T) (*ParamStore, *state.StateManager) {
+	t.Helper()
+
+	log := logger.NewLogger("debug")
+	sm, err := state.NewStateManager(":memory:", log)
+	if err != nil {
+		t.Fatalf("failed to create state manager: %v", err)
+	}
+	t.Cleanup(func() { sm.Close() })
+
+	ps, err := NewParamStore(sm, log, DefaultTokenomicsParams([32]byte{9}))
+	if err != nil {
+		t.Fatalf("failed to create param store: %v", err)
+	}
+
+	return ps, sm
+}
+
+// signProposal signs proposal with validator's private key, matching
+// what AddValidatorSignature will verify against its public key.
+func signProposal(proposal *TokenomicsProposal, priv ed25519.PrivateKey) []byte {
+	return ed25519.Sign(priv, proposal.signingMessage())
+}
+
+func TestParamStore_ProposalPassesAboveTwoThirdsThreshold(t *testing.T) {
+	ps, sm := newTestParamStore(t)
+	proposer := [32]byte{1}
+
+	if err := sm.CreateAccount(proposer, 1_000*WeiPerCoin); err != nil {
+		t.Fatalf("failed to create proposer account: %v", err)
+	}
+
+	newParams := DefaultTokenomicsParams([32]byte{9})
+	newParams.InitialBlockReward = 1_000_000_000 // 1 BEANS
+
+	proposal, err := ps.SubmitProposal("prop-1", proposer, newParams, 100*WeiPerCoin, 0, 1000)
+	if err != nil {
+		t.Fatalf("SubmitProposal failed: %v", err)
+	}
+
+	// 4 validators: more than 2/3 requires 3 signatures, not 2.
+	validators := make([]ed25519.PrivateKey, 4)
+	for i := range validators {
+		pub, priv, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatalf("failed to generate validator key: %v", err)
+		}
+		validators[i] = priv
+
+		var addr [32]byte
+		addr[0] = byte(10 + i)
+
+		if i < 2 {
+			sig := signProposal(proposal, priv)
+			if err := ps.AddValidatorSignature(proposal.ID, addr, pub, sig, 10); err != nil {
+				t.Fatalf("AddValidatorSignature failed: %v", err)
+			}
+		}
+	}
+
+	status, err := ps.Tally(proposal.ID, len(validators), 10)
+	if err != nil {
+		t.Fatalf("Tally failed: %v", err)
+	}
+	if status != ProposalVoting {
+		t.Errorf("expected proposal still voting with only 2/4 signatures, got %s", status)
+	}
+
+	// Third validator signs, crossing the >2/3 threshold.
+	pub3, priv3, _ := ed25519.GenerateKey(nil)
+	sig3 := signProposal(proposal, priv3)
+	if err := ps.AddValidatorSignature(proposal.ID, [32]byte{13}, pub3, sig3, 10); err != nil {
+		t.Fatalf("AddValidatorSignature failed: %v", err)
+	}
+
+	status, err = ps.Tally(proposal.ID, len(validators), 10)
+	if err != nil {
+		t.Fatalf("Tally failed: %v", err)
+	}
+	if status != ProposalPassed {
+		t.Errorf("expected proposal passed with 3/4 signatures, got %s", status)
+	}
+
+	if got := ps.CurrentParams().InitialBlockReward; got != newParams.InitialBlockReward {
+		t.Errorf("expected current params to reflect the passed proposal, got %d", got)
+	}
+
+	proposerAccount, err := sm.GetAccount(proposer)
+	if err != nil {
+		t.Fatalf("failed to get proposer account: %v", err)
+	}
+	if proposerAccount.Balance != 1_000*WeiPerCoin {
+		t.Errorf("expected deposit refunded on passage, got balance %d", proposerAccount.Balance)
+	}
+
+	if len(ps.ChangeLog()) != 1 {
+		t.Errorf("expected 1 change log entry after passage, got %d", len(ps.ChangeLog()))
+	}
+}
+
+func TestParamStore_ProposalRejectedAfterVotingWindowWithoutQuorum(t *testing.T) {
+	ps, sm := newTestParamStore(t)
+	proposer := [32]byte{1}
+
+	if err := sm.CreateAccount(proposer, 1_000*WeiPerCoin); err != nil {
+		t.Fatalf("failed to create proposer account: %v", err)
+	}
+
+	proposal, err := ps.SubmitProposal("prop-1", proposer, DefaultTokenomicsParams([32]byte{9}), 100*WeiPerCoin, 0, 50)
+	if err != nil {
+		t.Fatalf("SubmitProposal failed: %v", err)
+	}
+
+	status, err := ps.Tally(proposal.ID, 4, 51)
+	if err != nil {
+		t.Fatalf("Tally failed: %v", err)
+	}
+	if status != ProposalRejected {
+		t.Errorf("expected proposal rejected once the voting window closed with no signatures, got %s", status)
+	}
+
+	proposerAccount, err := sm.GetAccount(proposer)
+	if err != nil {
+		t.Fatalf("failed to get proposer account: %v", err)
+	}
+	if proposerAccount.Balance != 900*WeiPerCoin {
+		t.Errorf("expected deposit forfeited on rejection, got balance %d", proposerAccount.Balance)
+	}
+}
+
+func TestParamStore_AddValidatorSignature_RejectsInvalidSignature(t *testing.T) {
+	ps, sm := newTestParamStore(t)
+	proposer := [32]byte{1}
+
+	if err := sm.CreateAccount(proposer, 1_000*WeiPerCoin); err != nil {
+		t.Fatalf("failed to create proposer account: %v", err)
+	}
+
+	proposal, err := ps.SubmitProposal("prop-1", proposer, DefaultTokenomicsParams([32]byte{9}), 100*WeiPerCoin, 0, 1000)
+	if err != nil {
+		t.Fatalf("SubmitProposal failed: %v", err)
+	}
+
+	pub, _, _ := ed25519.GenerateKey(nil)
+	_, wrongPriv, _ := ed25519.GenerateKey(nil)
+	badSig := ed25519.Sign(wrongPriv, proposal.signingMessage())
+
+	if err := ps.AddValidatorSignature(proposal.ID, [32]byte{20}, pub, badSig, 10); err == nil {
+		t.Error("expected a signature from a mismatched key to be rejected")
+	}
+}
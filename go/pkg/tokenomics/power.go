@@ -0,0 +1,110 @@
+package tokenomics
+
+// PowerTable and RewardDistributor.DistributeBlockRewardsMultiValidator
+// are BlockProcessor.Process's power-weighted path for a block carrying
+// more than one Header.CoSigners entry (see BlockProcessor.SetPowerTable):
+// configure a processor's PowerTable and Process pays a co-signed
+// block's validator reward out proportional to power instead of to a
+// single Validator, for a block with a zero BaseFee (a block with a
+// non-zero BaseFee pays its Validator alone). Most chains in this tree are still single-signer
+// PoA and never populate CoSigners, so Process falls back to
+// DistributeBlockRewards for them exactly as it always has.
+// cmd/validate-supply's vectors.go also exercises PowerTable/
+// DistributeBlockRewardsMultiValidator directly, as an offline fixture
+// driver independent of any particular block producer.
+
+// Validator is one entry in a PowerTable snapshot: the on-chain identity
+// earning a share of block rewards, its current stake and
+// quality-adjusted power, and the separate ControlAddress it delegates
+// signing duties to. Splitting identity from control lets an operator
+// rotate operational keys (the control address) without changing the
+// validator's stake history or governance identity, the same
+// "ControlAddress for Candidate" separation miners use elsewhere.
+type Validator struct {
+	Address              [32]byte
+	ControlAddress       [32]byte
+	Stake                uint64
+	QualityAdjustedPower uint64
+	Active               bool
+	JoinedBlock          uint64
+}
+
+// PowerTable is a snapshot of every validator's share of total network
+// power, modeled on Filecoin's power actor: a validator's share of
+// rewards is QualityAdjustedPower / total active power rather than an
+// equal split across however many validators co-signed a block.
+type PowerTable struct {
+	validators []Validator
+	totalPower uint64
+}
+
+// NewPowerTable builds a PowerTable from validators, summing
+// QualityAdjustedPower across every Active entry — an inactive validator
+// holds zero share regardless of its recorded stake.
+func NewPowerTable(validators []Validator) *PowerTable {
+	pt := &PowerTable{validators: validators}
+	for _, v := range validators {
+		if v.Active {
+			pt.totalPower += v.QualityAdjustedPower
+		}
+	}
+	return pt
+}
+
+// ShareOf returns validator's fraction of total power: 0 if it's
+// inactive, absent from the table, or the table has no power at all.
+func (pt *PowerTable) ShareOf(validator [32]byte) float64 {
+	if pt.totalPower == 0 {
+		return 0
+	}
+	for _, v := range pt.validators {
+		if v.Address == validator && v.Active {
+			return float64(v.QualityAdjustedPower) / float64(pt.totalPower)
+		}
+	}
+	return 0
+}
+
+// Split divides amount across every active validator in pt proportional
+// to its power share. The rounding remainder left by truncating each
+// validator's float64 share to a uint64 is credited to the
+// first active validator in pt's order, so the returned amounts always
+// sum to exactly amount.
+func (pt *PowerTable) Split(amount uint64) map[[32]byte]uint64 {
+	shares := make(map[[32]byte]uint64, len(pt.validators))
+	if pt.totalPower == 0 || amount == 0 {
+		return shares
+	}
+
+	var distributed uint64
+	var first [32]byte
+	haveFirst := false
+	for _, v := range pt.validators {
+		if !v.Active || v.QualityAdjustedPower == 0 {
+			continue
+		}
+		if !haveFirst {
+			first = v.Address
+			haveFirst = true
+		}
+		share := uint64(float64(amount) * float64(v.QualityAdjustedPower) / float64(pt.totalPower))
+		shares[v.Address] = share
+		distributed += share
+	}
+	if haveFirst {
+		shares[first] += amount - distributed
+	}
+	return shares
+}
+
+// TotalPower returns the sum of every active validator's
+// QualityAdjustedPower.
+func (pt *PowerTable) TotalPower() uint64 {
+	return pt.totalPower
+}
+
+// Validators returns the snapshot's validator list, in the order passed
+// to NewPowerTable.
+func (pt *PowerTable) Validators() []Validator {
+	return pt.validators
+}
@@ -0,0 +1,57 @@
+package tokenomics
+
+import "testing"
+
+func testValidators() []Validator {
+	return []Validator{
+		{Address: [32]byte{1}, ControlAddress: [32]byte{0x11}, QualityAdjustedPower: 300, Active: true},
+		{Address: [32]byte{2}, ControlAddress: [32]byte{0x22}, QualityAdjustedPower: 100, Active: true},
+		{Address: [32]byte{3}, ControlAddress: [32]byte{0x33}, QualityAdjustedPower: 1_000_000, Active: false},
+	}
+}
+
+func TestPowerTable_InactiveValidatorsExcludedFromTotalPower(t *testing.T) {
+	pt := NewPowerTable(testValidators())
+	if pt.TotalPower() != 400 {
+		t.Errorf("expected total power 400 (excluding the inactive validator), got %d", pt.TotalPower())
+	}
+}
+
+func TestPowerTable_ShareOfReflectsQualityAdjustedPower(t *testing.T) {
+	pt := NewPowerTable(testValidators())
+
+	if got := pt.ShareOf([32]byte{1}); got != 0.75 {
+		t.Errorf("expected validator 1 to hold 75%% share, got %v", got)
+	}
+	if got := pt.ShareOf([32]byte{3}); got != 0 {
+		t.Errorf("expected an inactive validator to hold zero share, got %v", got)
+	}
+	if got := pt.ShareOf([32]byte{9}); got != 0 {
+		t.Errorf("expected an unknown validator to hold zero share, got %v", got)
+	}
+}
+
+func TestPowerTable_SplitSumsToExactAmount(t *testing.T) {
+	pt := NewPowerTable(testValidators())
+
+	shares := pt.Split(1_000_000_001)
+
+	var sum uint64
+	for _, amount := range shares {
+		sum += amount
+	}
+	if sum != 1_000_000_001 {
+		t.Errorf("expected split amounts to sum to the original amount, got %d", sum)
+	}
+	if shares[[32]byte{3}] != 0 {
+		t.Error("expected an inactive validator to receive no split")
+	}
+}
+
+func TestPowerTable_SplitOfZeroPowerTableIsEmpty(t *testing.T) {
+	pt := NewPowerTable(nil)
+	shares := pt.Split(1000)
+	if len(shares) != 0 {
+		t.Errorf("expected no shares from a table with no power, got %v", shares)
+	}
+}
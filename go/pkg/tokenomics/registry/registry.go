@@ -0,0 +1,341 @@
+// Package registry lets the chain mint assets beyond the native $BEANS
+// coin. Every other tokenomics package (Economics, RewardDistributor,
+// PowerTable) assumes a single fee-paying, reward-paying asset; registry
+// sits alongside that assumption rather than replacing it — $BEANS stays
+// the sole coin block rewards, base fees, and priority tips are
+// denominated in, and everything registered here is a separate balance
+// ledger an owner controls the mint/burn policy of.
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
+)
+
+// NativeSymbol is the chain's built-in, non-registrable asset: the sole
+// coin block rewards, base fees, and priority tips are paid in. Creating
+// or recreating a coin under this symbol is rejected.
+const NativeSymbol = "BEANS"
+
+// TxType is a coin-lifecycle transaction's kind. It's the first place
+// this module gives TxType values names — cmd/validate-supply and
+// cmd/state-t8n each still encode TxType as a bare uint8 on their own
+// TxData, and are expected to agree with these values rather than define
+// their own.
+type TxType uint8
+
+const (
+	// TxTypeTransfer moves an existing balance between two addresses
+	// under the same coin_id. It's the zero value so that decoding a
+	// TxData predating this enum (tx_type absent, defaulting to 0) is
+	// still interpreted correctly.
+	TxTypeTransfer TxType = iota
+	// TxTypeCreateCoin registers a brand-new CoinRecord.
+	TxTypeCreateCoin
+	// TxTypeRecreateCoin re-issues a symbol whose previous coin_id's
+	// circulating supply has been fully burned.
+	TxTypeRecreateCoin
+	// TxTypeChangeCoinOwner reassigns a CoinRecord's Owner.
+	TxTypeChangeCoinOwner
+	// TxTypeRelease moves a vesting.Schedule's currently claimable
+	// amount from vesting.LockedSupplyAddress into its beneficiary. It's
+	// named here rather than in package vesting so every coin-lifecycle
+	// and balance-movement TxType stays in one enum.
+	TxTypeRelease
+)
+
+func (t TxType) String() string {
+	switch t {
+	case TxTypeTransfer:
+		return "transfer"
+	case TxTypeCreateCoin:
+		return "create_coin"
+	case TxTypeRecreateCoin:
+		return "recreate_coin"
+	case TxTypeChangeCoinOwner:
+		return "change_coin_owner"
+	case TxTypeRelease:
+		return "release"
+	default:
+		return "unknown"
+	}
+}
+
+// MintPolicy governs whether a coin's supply can grow past its genesis
+// mint.
+type MintPolicy int
+
+const (
+	// MintFixed caps a coin's supply at whatever CreateCoin minted;
+	// nothing may mint further units of it.
+	MintFixed MintPolicy = iota
+	// MintOwnerControlled lets the coin's current Owner mint additional
+	// supply at will.
+	MintOwnerControlled
+)
+
+func (p MintPolicy) String() string {
+	switch p {
+	case MintFixed:
+		return "fixed"
+	case MintOwnerControlled:
+		return "owner_controlled"
+	default:
+		return "unknown"
+	}
+}
+
+// BurnPolicy governs who may burn units of a coin out of circulation.
+type BurnPolicy int
+
+const (
+	// BurnDisabled means no holder may burn this coin; its circulating
+	// supply can only fall through the supply itself being reduced
+	// elsewhere (there is no such path today).
+	BurnDisabled BurnPolicy = iota
+	// BurnOwnerControlled lets only the coin's current Owner burn units
+	// of it.
+	BurnOwnerControlled
+	// BurnPermissionless lets any holder burn their own balance of it.
+	BurnPermissionless
+)
+
+func (p BurnPolicy) String() string {
+	switch p {
+	case BurnDisabled:
+		return "disabled"
+	case BurnOwnerControlled:
+		return "owner_controlled"
+	case BurnPermissionless:
+		return "permissionless"
+	default:
+		return "unknown"
+	}
+}
+
+// CoinRecord is one registered asset: a row of the coins table.
+type CoinRecord struct {
+	CoinID       string
+	Symbol       string
+	Owner        [32]byte
+	Decimals     uint8
+	MintPolicy   MintPolicy
+	BurnPolicy   BurnPolicy
+	CreatedBlock uint64
+}
+
+// Registry manages CoinRecords and the composite-key accounts they pay
+// into, the same role RewardDistributor plays for $BEANS specifically.
+type Registry struct {
+	stateManager *state.StateManager
+	log          *logger.Logger
+}
+
+// NewRegistry creates a Registry backed by stateManager.
+func NewRegistry(stateManager *state.StateManager, log *logger.Logger) *Registry {
+	return &Registry{
+		stateManager: stateManager,
+		log:          log,
+	}
+}
+
+// CreateCoin registers a brand-new coin under symbol, rejecting it if
+// symbol is the reserved NativeSymbol or already belongs to a coin_id
+// whose circulating supply hasn't been fully burned (see RecreateCoin).
+func (r *Registry) CreateCoin(
+	symbol string,
+	owner [32]byte,
+	decimals uint8,
+	mint MintPolicy,
+	burn BurnPolicy,
+	createdBlock uint64,
+) (CoinRecord, error) {
+	if symbol == "" {
+		return CoinRecord{}, fmt.Errorf("symbol must not be empty")
+	}
+	if symbol == NativeSymbol {
+		return CoinRecord{}, fmt.Errorf("%s is the reserved native coin symbol", NativeSymbol)
+	}
+
+	existingRecord, err := r.stateManager.GetCoinBySymbol(symbol)
+	if err != nil {
+		return CoinRecord{}, fmt.Errorf("failed to look up symbol %q: %w", symbol, err)
+	}
+	if existingRecord != nil {
+		return CoinRecord{}, fmt.Errorf("symbol %q is already registered as coin %s; use RecreateCoin once its supply is fully burned", symbol, existingRecord.CoinID)
+	}
+
+	coin := CoinRecord{
+		CoinID:       deriveCoinID(symbol, owner, createdBlock),
+		Symbol:       symbol,
+		Owner:        owner,
+		Decimals:     decimals,
+		MintPolicy:   mint,
+		BurnPolicy:   burn,
+		CreatedBlock: createdBlock,
+	}
+	if err := r.stateManager.PutCoin(toStateCoin(coin)); err != nil {
+		return CoinRecord{}, fmt.Errorf("failed to register coin %s: %w", coin.CoinID, err)
+	}
+
+	r.log.WithFields(logger.Fields{
+		"coin_id":     coin.CoinID,
+		"symbol":      coin.Symbol,
+		"owner":       fmt.Sprintf("%x", owner[:8]),
+		"mint_policy": mint.String(),
+		"burn_policy": burn.String(),
+	}).Info("Coin registered")
+
+	return coin, nil
+}
+
+// RecreateCoin re-issues symbol under a fresh coin_id once its previous
+// coin_id's circulating supply has been fully burned. symbol itself
+// isn't unique long-term — only the coin_id pairing at any given moment
+// is — so a re-issued coin starts with zero balances under its new
+// coin_id regardless of what the retired coin_id once held.
+func (r *Registry) RecreateCoin(
+	symbol string,
+	owner [32]byte,
+	decimals uint8,
+	mint MintPolicy,
+	burn BurnPolicy,
+	createdBlock uint64,
+) (CoinRecord, error) {
+	existingRecord, err := r.stateManager.GetCoinBySymbol(symbol)
+	if err != nil {
+		return CoinRecord{}, fmt.Errorf("failed to look up symbol %q: %w", symbol, err)
+	}
+	if existingRecord == nil {
+		return CoinRecord{}, fmt.Errorf("symbol %q has never been registered; use CreateCoin instead", symbol)
+	}
+
+	circulating, err := r.stateManager.CoinCirculatingSupply(existingRecord.CoinID)
+	if err != nil {
+		return CoinRecord{}, fmt.Errorf("failed to read %s's circulating supply: %w", existingRecord.CoinID, err)
+	}
+	if circulating != 0 {
+		return CoinRecord{}, fmt.Errorf("symbol %q still has %d unit(s) in circulation under coin %s; RecreateCoin requires its supply be fully burned first", symbol, circulating, existingRecord.CoinID)
+	}
+
+	coin := CoinRecord{
+		CoinID:       deriveCoinID(symbol, owner, createdBlock),
+		Symbol:       symbol,
+		Owner:        owner,
+		Decimals:     decimals,
+		MintPolicy:   mint,
+		BurnPolicy:   burn,
+		CreatedBlock: createdBlock,
+	}
+	if err := r.stateManager.PutCoin(toStateCoin(coin)); err != nil {
+		return CoinRecord{}, fmt.Errorf("failed to register recreated coin %s: %w", coin.CoinID, err)
+	}
+
+	r.log.WithFields(logger.Fields{
+		"coin_id":         coin.CoinID,
+		"symbol":          coin.Symbol,
+		"retired_coin_id": existingRecord.CoinID,
+	}).Info("Coin recreated")
+
+	return coin, nil
+}
+
+// ChangeCoinOwner reassigns coinID's Owner from currentOwner to
+// newOwner, rejecting the change if currentOwner doesn't match the
+// CoinRecord on file.
+func (r *Registry) ChangeCoinOwner(coinID string, currentOwner, newOwner [32]byte) error {
+	record, err := r.stateManager.GetCoin(coinID)
+	if err != nil {
+		return fmt.Errorf("failed to look up coin %s: %w", coinID, err)
+	}
+	if record == nil {
+		return fmt.Errorf("coin %s is not registered", coinID)
+	}
+	coin := fromStateCoin(*record)
+	if coin.Owner != currentOwner {
+		return fmt.Errorf("coin %s is not owned by the given address", coinID)
+	}
+
+	coin.Owner = newOwner
+	if err := r.stateManager.PutCoin(toStateCoin(coin)); err != nil {
+		return fmt.Errorf("failed to update coin %s's owner: %w", coinID, err)
+	}
+
+	r.log.WithFields(logger.Fields{
+		"coin_id":   coinID,
+		"new_owner": fmt.Sprintf("%x", newOwner[:8]),
+	}).Info("Coin owner changed")
+
+	return nil
+}
+
+// GetCoin returns the CoinRecord registered under coinID.
+func (r *Registry) GetCoin(coinID string) (*CoinRecord, error) {
+	record, err := r.stateManager.GetCoin(coinID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up coin %s: %w", coinID, err)
+	}
+	if record == nil {
+		return nil, nil
+	}
+	coin := fromStateCoin(*record)
+	return &coin, nil
+}
+
+// ListCoins returns every registered coin, NativeSymbol's implicit coin
+// not included since it has no CoinRecord of its own.
+func (r *Registry) ListCoins() ([]CoinRecord, error) {
+	records, err := r.stateManager.ListCoins()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list coins: %w", err)
+	}
+	coins := make([]CoinRecord, len(records))
+	for i, record := range records {
+		coins[i] = fromStateCoin(record)
+	}
+	return coins, nil
+}
+
+// deriveCoinID derives a coin_id deterministically from symbol, owner,
+// and createdBlock, so RecreateCoin issuing a fresh coin_id for a
+// previously-retired symbol can never collide with the one it replaces.
+func deriveCoinID(symbol string, owner [32]byte, createdBlock uint64) string {
+	h := sha256.New()
+	h.Write([]byte(symbol))
+	h.Write(owner[:])
+	h.Write([]byte(fmt.Sprintf(":%d", createdBlock)))
+	return hex.EncodeToString(h.Sum(nil))[:32]
+}
+
+// toStateCoin converts a CoinRecord to the state package's own
+// persisted form: state.CoinRecord stores MintPolicy/BurnPolicy as raw
+// ints rather than this package's enum types, since pkg/state can't
+// import pkg/tokenomics/registry back.
+func toStateCoin(coin CoinRecord) state.CoinRecord {
+	return state.CoinRecord{
+		CoinID:       coin.CoinID,
+		Symbol:       coin.Symbol,
+		Owner:        coin.Owner,
+		Decimals:     coin.Decimals,
+		MintPolicy:   int(coin.MintPolicy),
+		BurnPolicy:   int(coin.BurnPolicy),
+		CreatedBlock: coin.CreatedBlock,
+	}
+}
+
+// fromStateCoin is toStateCoin's inverse.
+func fromStateCoin(record state.CoinRecord) CoinRecord {
+	return CoinRecord{
+		CoinID:       record.CoinID,
+		Symbol:       record.Symbol,
+		Owner:        record.Owner,
+		Decimals:     record.Decimals,
+		MintPolicy:   MintPolicy(record.MintPolicy),
+		BurnPolicy:   BurnPolicy(record.BurnPolicy),
+		CreatedBlock: record.CreatedBlock,
+	}
+}
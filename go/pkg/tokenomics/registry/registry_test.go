@@ -0,0 +1,37 @@
+package registry
+
+import "testing"
+
+func TestDeriveCoinID_DifferentCreatedBlockProducesDifferentID(t *testing.T) {
+	owner := [32]byte{1}
+	first := deriveCoinID("GOLD", owner, 100)
+	second := deriveCoinID("GOLD", owner, 200)
+	if first == second {
+		t.Error("expected distinct created_block values to produce distinct coin_ids")
+	}
+}
+
+func TestDeriveCoinID_IsDeterministic(t *testing.T) {
+	owner := [32]byte{1}
+	first := deriveCoinID("GOLD", owner, 100)
+	second := deriveCoinID("GOLD", owner, 100)
+	if first != second {
+		t.Error("expected identical inputs to produce the same coin_id")
+	}
+}
+
+func TestTxType_String(t *testing.T) {
+	cases := map[TxType]string{
+		TxTypeTransfer:        "transfer",
+		TxTypeCreateCoin:      "create_coin",
+		TxTypeRecreateCoin:    "recreate_coin",
+		TxTypeChangeCoinOwner: "change_coin_owner",
+		TxTypeRelease:         "release",
+		TxType(99):            "unknown",
+	}
+	for txType, want := range cases {
+		if got := txType.String(); got != want {
+			t.Errorf("TxType(%d).String() = %q, want %q", txType, got, want)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package simulator
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// Checkpoint is one point-in-time snapshot of a simulation run, taken at
+// block 0, every height in Config.CheckpointBlocks, and the final
+// block — the per-row shape behind Report's CSV/JSON timeseries.
+type Checkpoint struct {
+	BlockHeight uint64 `json:"block_height"`
+
+	// VestedByAllocation maps each allocation's Description to how much
+	// of it CalculateVestedAmount reports as vested at BlockHeight.
+	VestedByAllocation map[string]uint64 `json:"vested_by_allocation"`
+
+	TotalSupply       uint64 `json:"total_supply"`
+	CirculatingSupply uint64 `json:"circulating_supply"`
+
+	// InflationRate is Economics.GetMetrics' trailing-year estimate as
+	// of BlockHeight, not an actual measurement over the replay so far.
+	InflationRate float64 `json:"inflation_rate"`
+
+	// MaxSupplyUtilization is TotalSupply / Report.MaxSupply, or 0 if
+	// MaxSupply is uncapped.
+	MaxSupplyUtilization float64 `json:"max_supply_utilization"`
+
+	// Gini is the Gini coefficient of every tracked allocation's
+	// current account balance: 0 is perfect equality, 1 is maximal
+	// concentration.
+	Gini float64 `json:"gini"`
+}
+
+// Assertion is one pass/fail check Report records after a run —
+// Report's counterpart to VectorReport.Checks in
+// cmd/validate-supply/vectors.go.
+type Assertion struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail"`
+}
+
+// Report is the output of a Simulator run: the per-checkpoint timeseries
+// plus the assertions checked against it.
+type Report struct {
+	MaxSupply   uint64       `json:"max_supply"`
+	Checkpoints []Checkpoint `json:"checkpoints"`
+	Assertions  []Assertion  `json:"assertions"`
+}
+
+// Passed reports whether every Assertion in r passed (vacuously true if
+// there are none).
+func (r *Report) Passed() bool {
+	for _, a := range r.Assertions {
+		if !a.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckpointAtOrAfter returns the first Checkpoint whose BlockHeight is
+// >= block, or nil if none was recorded. Checkpoints are produced by
+// Simulator.RunBlocks in ascending block order, so a linear scan is
+// sufficient.
+func (r *Report) CheckpointAtOrAfter(block uint64) *Checkpoint {
+	for i := range r.Checkpoints {
+		if r.Checkpoints[i].BlockHeight >= block {
+			return &r.Checkpoints[i]
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes r as indented JSON to path — the full report,
+// including Assertions, the same shape cmd/validate-supply's
+// CorpusReport uses for a CI step to parse.
+func (r *Report) WriteJSON(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// WriteCSV writes r's per-checkpoint timeseries to path, one row per
+// checkpoint and one column per allocation seen across the run, for
+// loading into a spreadsheet or plotting tool. Assertions have no
+// natural place in a flat timeseries, so they're JSON-only (WriteJSON).
+func (r *Report) WriteCSV(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create CSV report: %w", err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	defer w.Flush()
+
+	names := r.allocationNames()
+	header := append([]string{
+		"block_height", "total_supply", "circulating_supply",
+		"inflation_rate", "max_supply_utilization", "gini",
+	}, names...)
+	if err := w.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, cp := range r.Checkpoints {
+		row := []string{
+			strconv.FormatUint(cp.BlockHeight, 10),
+			strconv.FormatUint(cp.TotalSupply, 10),
+			strconv.FormatUint(cp.CirculatingSupply, 10),
+			strconv.FormatFloat(cp.InflationRate, 'f', -1, 64),
+			strconv.FormatFloat(cp.MaxSupplyUtilization, 'f', -1, 64),
+			strconv.FormatFloat(cp.Gini, 'f', -1, 64),
+		}
+		for _, name := range names {
+			row = append(row, strconv.FormatUint(cp.VestedByAllocation[name], 10))
+		}
+		if err := w.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row for block %d: %w", cp.BlockHeight, err)
+		}
+	}
+
+	return w.Error()
+}
+
+// allocationNames collects every allocation Description seen across
+// r.Checkpoints, sorted for a stable CSV column order regardless of map
+// iteration.
+func (r *Report) allocationNames() []string {
+	seen := make(map[string]bool)
+	for _, cp := range r.Checkpoints {
+		for name := range cp.VestedByAllocation {
+			seen[name] = true
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// giniCoefficient computes the Gini coefficient of balances: 0 for
+// perfect equality, approaching 1 as balance concentrates in a single
+// holder. Uses the standard mean-absolute-difference formulation over
+// the sorted sample.
+func giniCoefficient(balances []uint64) float64 {
+	n := len(balances)
+	if n == 0 {
+		return 0
+	}
+
+	sorted := make([]uint64, n)
+	copy(sorted, balances)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum, weightedSum float64
+	for i, b := range sorted {
+		sum += float64(b)
+		weightedSum += float64(i+1) * float64(b)
+	}
+	if sum == 0 {
+		return 0
+	}
+
+	return (2*weightedSum)/(float64(n)*sum) - float64(n+1)/float64(n)
+}
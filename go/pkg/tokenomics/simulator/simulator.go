@@ -0,0 +1,273 @@
+// Package simulator is a single-process, testground-style harness that
+// deterministically replays block production against a set of
+// tokenomics.GenesisAllocations and reports, at configurable
+// checkpoints, each allocation's unlock progress, realized supply growth
+// against tokenomics.TokenomicsParams.MaxSupply, and the Gini
+// coefficient of account balances.
+//
+// Like the lotus-testground harness it's modeled on, it's a single
+// process: a BlockProducer schedule stands in for real validators, and
+// state is an in-memory state.StateManager rather than a live node's
+// database. That makes it cheap to replay years of blocks in a tight
+// loop and gives two things a live devnet can't: exhaustive coverage of
+// CalculateVestedAmount's edge cases (cliff == vesting duration, a 100%
+// InitialUnlock, a StartBlock still in the future) long before mainnet,
+// and a way for governance to preview a TokenomicsProposal's effect on
+// the same curves before it's voted on.
+package simulator
+
+import (
+	"fmt"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/tokenomics"
+)
+
+// BlockProducer decides which validator address produces the block at a
+// given height — the schedule Simulator.RunBlocks consults once per
+// simulated block. It's an interface so a test can substitute a fixed or
+// weighted schedule without changing Simulator, the same narrow-API
+// approach consensus.Clock uses for simulated time.
+type BlockProducer interface {
+	// ProducerAt returns the validator address credited with producing
+	// blockHeight.
+	ProducerAt(blockHeight uint64) [32]byte
+}
+
+// RoundRobin cycles through a fixed validator set in order, one
+// validator per block — the simplest deterministic BlockProducer, and
+// enough to drive the reward-distribution and vesting math this
+// simulator exists to exercise.
+type RoundRobin struct {
+	Validators [][32]byte
+}
+
+// ProducerAt implements BlockProducer.
+func (r RoundRobin) ProducerAt(blockHeight uint64) [32]byte {
+	if len(r.Validators) == 0 {
+		return [32]byte{}
+	}
+	return r.Validators[blockHeight%uint64(len(r.Validators))]
+}
+
+// Config parameterizes one simulation run.
+type Config struct {
+	// Allocations are the genesis allocations replayed and reported on;
+	// typically tokenomics.DefaultGenesisAllocations or a governance
+	// preview built from a TokenomicsProposal.
+	Allocations []tokenomics.GenesisAllocation
+	// Producer supplies the block-producing validator at each height.
+	Producer BlockProducer
+
+	// EconomicsConfig seeds the Economics instance driving block rewards
+	// and emission; typically tokenomics.DefaultTokenomicsConfig or a
+	// governance-proposed variant.
+	EconomicsConfig tokenomics.TokenomicsConfig
+	// MaxSupply is the supply cap checkpoints report utilization
+	// against (see Checkpoint.MaxSupplyUtilization); 0 means uncapped,
+	// matching TokenomicsParams.MaxSupply's convention.
+	MaxSupply uint64
+
+	// BlockTimeSeconds converts RunYears' year count into a block
+	// count.
+	BlockTimeSeconds uint64
+	// FeesPerBlock is the synthetic per-block transaction-fee load fed
+	// to RewardDistributor.DistributeBlockRewards.
+	FeesPerBlock uint64
+
+	// CheckpointBlocks are the block heights Run snapshots into the
+	// Report, in addition to the final block, which is always
+	// snapshotted.
+	CheckpointBlocks []uint64
+
+	// Log receives progress/debug output; defaults to an "error"-level
+	// logger if nil, since a simulation run is normally driven from a
+	// test or CLI tool rather than a long-running node.
+	Log *logger.Logger
+}
+
+// Simulator wires an in-memory state.StateManager, tokenomics.Economics,
+// tokenomics.RewardDistributor, and tokenomics.VestingTracker together to
+// replay a Config's genesis allocations and block schedule.
+type Simulator struct {
+	cfg            Config
+	stateManager   *state.StateManager
+	economics      *tokenomics.Economics
+	distributor    *tokenomics.RewardDistributor
+	vestingTracker *tokenomics.VestingTracker
+}
+
+// NewSimulator applies cfg.Allocations to a fresh in-memory
+// state.StateManager and wires up the Economics/RewardDistributor/
+// VestingTracker a replay needs, the same sequence
+// ApplyGenesisAllocations documents for a real node's startup path.
+func NewSimulator(cfg Config) (*Simulator, error) {
+	if cfg.Producer == nil {
+		return nil, fmt.Errorf("config must specify a BlockProducer")
+	}
+	if cfg.BlockTimeSeconds == 0 {
+		return nil, fmt.Errorf("config must specify a non-zero BlockTimeSeconds")
+	}
+	if cfg.Log == nil {
+		cfg.Log = logger.NewLogger("error")
+	}
+
+	sm, err := state.NewStateManager(":memory:", cfg.Log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create in-memory state manager: %w", err)
+	}
+
+	vt := tokenomics.NewVestingTracker(sm, cfg.Log)
+	if err := tokenomics.ApplyGenesisAllocations(cfg.Allocations, sm, vt, cfg.Log); err != nil {
+		sm.Close()
+		return nil, fmt.Errorf("failed to apply genesis allocations: %w", err)
+	}
+
+	economics := tokenomics.NewEconomics(cfg.EconomicsConfig, cfg.Log)
+	distributor := tokenomics.NewRewardDistributor(economics, sm, cfg.EconomicsConfig.TreasuryAddress, cfg.Log)
+
+	return &Simulator{
+		cfg:            cfg,
+		stateManager:   sm,
+		economics:      economics,
+		distributor:    distributor,
+		vestingTracker: vt,
+	}, nil
+}
+
+// Close releases the simulator's in-memory state manager.
+func (s *Simulator) Close() error {
+	return s.stateManager.Close()
+}
+
+// RunYears replays years worth of block production (at cfg.BlockTimeSeconds
+// per block) and returns the resulting Report. It's a thin convenience
+// over RunBlocks for the common "N years of mainnet" case the package
+// doc describes.
+func (s *Simulator) RunYears(years float64) (*Report, error) {
+	blocksPerYear := (365 * 24 * 60 * 60) / s.cfg.BlockTimeSeconds
+	return s.RunBlocks(uint64(float64(blocksPerYear) * years))
+}
+
+// RunBlocks replays totalBlocks worth of block production, crediting
+// cfg.Producer's validator schedule via RewardDistributor at every
+// block, and returns a Report snapshotting block 0, every height in
+// cfg.CheckpointBlocks, and the final block.
+func (s *Simulator) RunBlocks(totalBlocks uint64) (*Report, error) {
+	checkpointAt := make(map[uint64]bool, len(s.cfg.CheckpointBlocks)+2)
+	checkpointAt[0] = true
+	checkpointAt[totalBlocks] = true
+	for _, b := range s.cfg.CheckpointBlocks {
+		checkpointAt[b] = true
+	}
+
+	report := &Report{MaxSupply: s.cfg.MaxSupply}
+
+	for block := uint64(0); block <= totalBlocks; block++ {
+		if block > 0 {
+			validator := s.cfg.Producer.ProducerAt(block)
+			if err := s.distributor.DistributeBlockRewards(block, validator, s.cfg.FeesPerBlock); err != nil {
+				return nil, fmt.Errorf("block %d: %w", block, err)
+			}
+		}
+
+		if checkpointAt[block] {
+			cp, err := s.snapshot(block)
+			if err != nil {
+				return nil, fmt.Errorf("block %d: snapshot: %w", block, err)
+			}
+			report.Checkpoints = append(report.Checkpoints, cp)
+		}
+	}
+
+	report.Assertions = s.runAssertions(report)
+	return report, nil
+}
+
+// snapshot computes a Checkpoint for block without mutating any
+// simulator state: per-allocation vested amounts (from
+// CalculateVestedAmount, not account balances, so it reflects the
+// schedule even for allocations that haven't claimed), total and
+// circulating supply, realized inflation, supply-cap utilization, and
+// the Gini coefficient over every tracked allocation's current account
+// balance.
+func (s *Simulator) snapshot(block uint64) (Checkpoint, error) {
+	metrics := s.economics.GetMetrics()
+
+	vested := make(map[string]uint64, len(s.cfg.Allocations))
+	balances := make([]uint64, 0, len(s.cfg.Allocations))
+	for _, alloc := range s.cfg.Allocations {
+		vested[alloc.Description] = tokenomics.CalculateVestedAmount(alloc, block)
+
+		account, err := s.stateManager.GetAccount(alloc.Address)
+		if err != nil {
+			return Checkpoint{}, fmt.Errorf("failed to read allocation account %q: %w", alloc.Description, err)
+		}
+		balances = append(balances, account.Balance)
+	}
+
+	circulating, err := s.distributor.GetCirculatingSupply(block, s.vestingTracker)
+	if err != nil {
+		return Checkpoint{}, fmt.Errorf("failed to compute circulating supply: %w", err)
+	}
+
+	var capUtilization float64
+	if s.cfg.MaxSupply > 0 {
+		capUtilization = float64(metrics.CurrentSupply) / float64(s.cfg.MaxSupply)
+	}
+
+	return Checkpoint{
+		BlockHeight:          block,
+		VestedByAllocation:   vested,
+		TotalSupply:          metrics.CurrentSupply,
+		CirculatingSupply:    circulating,
+		InflationRate:        metrics.InflationRate,
+		MaxSupplyUtilization: capUtilization,
+		Gini:                 giniCoefficient(balances),
+	}, nil
+}
+
+// runAssertions checks, for every allocation carrying a VestingSchedule,
+// that report recorded a checkpoint at or after the block the schedule
+// fully vests at (its last Milestone, or StartBlock+VestingBlocks for a
+// plain cliff/linear schedule) where the reported vested amount equals
+// the full allocation. This is the "fully-vested sum at block X matches
+// total allocation" assertion the package doc promises, and it covers
+// the cliff == vesting edge case for free: StartBlock+VestingBlocks
+// still lands past the cliff, so blocksSinceStart >= VestingBlocks holds
+// and CalculateVestedAmount already reports the allocation as complete.
+func (s *Simulator) runAssertions(report *Report) []Assertion {
+	var assertions []Assertion
+
+	for _, alloc := range s.cfg.Allocations {
+		if alloc.Vesting == nil {
+			continue
+		}
+
+		fullyVestedAt := alloc.Vesting.StartBlock + alloc.Vesting.VestingBlocks
+		if n := len(alloc.Vesting.Milestones); n > 0 {
+			fullyVestedAt = alloc.Vesting.Milestones[n-1].BlockHeight
+		}
+
+		name := fmt.Sprintf("%s: fully vested by block %d", alloc.Description, fullyVestedAt)
+
+		cp := report.CheckpointAtOrAfter(fullyVestedAt)
+		if cp == nil {
+			assertions = append(assertions, Assertion{
+				Name:   name,
+				Detail: "no checkpoint at or after the allocation's full-vesting block; add one to Config.CheckpointBlocks",
+			})
+			continue
+		}
+
+		got := cp.VestedByAllocation[alloc.Description]
+		assertions = append(assertions, Assertion{
+			Name:   name,
+			Passed: got == alloc.Amount,
+			Detail: fmt.Sprintf("at block %d, vested %d of %d", cp.BlockHeight, got, alloc.Amount),
+		})
+	}
+
+	return assertions
+}
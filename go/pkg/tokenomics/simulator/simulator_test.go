@@ -0,0 +1,162 @@
+package simulator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/tokenomics"
+)
+
+// edgeCaseAllocations covers the vesting edge cases the package doc
+// calls out: a cliff equal to the full vesting duration, a 100%
+// InitialUnlock, and a StartBlock still in the future relative to block
+// 0.
+func edgeCaseAllocations() []tokenomics.GenesisAllocation {
+	return []tokenomics.GenesisAllocation{
+		{
+			Address:     [32]byte{1},
+			Amount:      1_000 * tokenomics.WeiPerCoin,
+			Description: "cliff-equals-vesting",
+			Vesting: &tokenomics.VestingSchedule{
+				StartBlock:    0,
+				CliffBlocks:   100,
+				VestingBlocks: 100,
+			},
+		},
+		{
+			Address:     [32]byte{2},
+			Amount:      500 * tokenomics.WeiPerCoin,
+			Description: "fully-unlocked-at-start",
+			Vesting: &tokenomics.VestingSchedule{
+				StartBlock:    0,
+				CliffBlocks:   0,
+				VestingBlocks: 100,
+				InitialUnlock: 1.0,
+			},
+		},
+		{
+			Address:     [32]byte{3},
+			Amount:      250 * tokenomics.WeiPerCoin,
+			Description: "future-start",
+			Vesting: &tokenomics.VestingSchedule{
+				StartBlock:    50,
+				CliffBlocks:   10,
+				VestingBlocks: 60,
+			},
+		},
+	}
+}
+
+func newTestSimulator(t *testing.T, allocations []tokenomics.GenesisAllocation, checkpoints []uint64) *Simulator {
+	t.Helper()
+
+	cfg := Config{
+		Allocations:      allocations,
+		Producer:         RoundRobin{Validators: [][32]byte{{0xAA}, {0xBB}}},
+		EconomicsConfig:  tokenomics.DefaultTokenomicsConfig(),
+		MaxSupply:        21_000_000 * tokenomics.WeiPerCoin,
+		BlockTimeSeconds: 2,
+		FeesPerBlock:     1_000_000,
+		CheckpointBlocks: checkpoints,
+		Log:              logger.NewLogger("error"),
+	}
+
+	sim, err := NewSimulator(cfg)
+	if err != nil {
+		t.Fatalf("failed to create simulator: %v", err)
+	}
+	t.Cleanup(func() { sim.Close() })
+
+	return sim
+}
+
+func TestSimulator_EdgeCaseAllocationsFullyVestAndAssertionsPass(t *testing.T) {
+	sim := newTestSimulator(t, edgeCaseAllocations(), []uint64{100, 110})
+
+	report, err := sim.RunBlocks(200)
+	if err != nil {
+		t.Fatalf("RunBlocks failed: %v", err)
+	}
+
+	if len(report.Assertions) != 3 {
+		t.Fatalf("expected 3 assertions (one per vesting allocation), got %d", len(report.Assertions))
+	}
+	if !report.Passed() {
+		for _, a := range report.Assertions {
+			if !a.Passed {
+				t.Errorf("assertion failed: %s (%s)", a.Name, a.Detail)
+			}
+		}
+	}
+}
+
+func TestSimulator_FutureStartAllocationVestsNothingBeforeStartBlock(t *testing.T) {
+	sim := newTestSimulator(t, edgeCaseAllocations(), []uint64{10, 49})
+
+	report, err := sim.RunBlocks(49)
+	if err != nil {
+		t.Fatalf("RunBlocks failed: %v", err)
+	}
+
+	cp := report.CheckpointAtOrAfter(49)
+	if cp == nil {
+		t.Fatal("expected a checkpoint at block 49")
+	}
+	if got := cp.VestedByAllocation["future-start"]; got != 0 {
+		t.Errorf("expected future-start allocation to have 0 vested before its StartBlock, got %d", got)
+	}
+}
+
+func TestSimulator_InflationAndSupplyTrackMaxSupplyUtilization(t *testing.T) {
+	sim := newTestSimulator(t, edgeCaseAllocations(), nil)
+
+	report, err := sim.RunBlocks(10)
+	if err != nil {
+		t.Fatalf("RunBlocks failed: %v", err)
+	}
+
+	last := report.Checkpoints[len(report.Checkpoints)-1]
+	if last.TotalSupply == 0 {
+		t.Error("expected non-zero total supply after block rewards")
+	}
+	wantUtilization := float64(last.TotalSupply) / float64(report.MaxSupply)
+	if last.MaxSupplyUtilization != wantUtilization {
+		t.Errorf("expected max supply utilization %.10f, got %.10f", wantUtilization, last.MaxSupplyUtilization)
+	}
+}
+
+func TestGiniCoefficient_EqualBalancesIsZero(t *testing.T) {
+	got := giniCoefficient([]uint64{100, 100, 100, 100})
+	if got != 0 {
+		t.Errorf("expected Gini coefficient 0 for equal balances, got %.10f", got)
+	}
+}
+
+func TestGiniCoefficient_SingleHolderApproachesOne(t *testing.T) {
+	got := giniCoefficient([]uint64{0, 0, 0, 1000})
+	want := 0.75 // (n-1)/n for one holder owning everything
+	if got != want {
+		t.Errorf("expected Gini coefficient %.4f for a single holder, got %.10f", want, got)
+	}
+}
+
+func TestReport_WriteCSVAndJSONRoundTrip(t *testing.T) {
+	sim := newTestSimulator(t, edgeCaseAllocations(), []uint64{100})
+
+	report, err := sim.RunBlocks(100)
+	if err != nil {
+		t.Fatalf("RunBlocks failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "report.csv")
+	jsonPath := filepath.Join(dir, "report.json")
+
+	if err := report.WriteCSV(csvPath); err != nil {
+		t.Errorf("WriteCSV failed: %v", err)
+	}
+	if err := report.WriteJSON(jsonPath); err != nil {
+		t.Errorf("WriteJSON failed: %v", err)
+	}
+}
@@ -0,0 +1,223 @@
+// Package vesting backs a team/investor allocation with an actual
+// locked balance rather than a subtractive accounting trick: a
+// Schedule's full Total is minted into LockedSupplyAddress up front,
+// and Release moves whatever has vested by a given block out of that
+// pseudo-account into the beneficiary's spendable balance.
+//
+// This is deliberately a second vesting mechanism alongside
+// tokenomics.VestingTracker, not a replacement for it: VestingTracker
+// backs genesis allocations with a milestone curve and computes
+// RewardDistributor.GetCirculatingSupply by subtracting its Unvested
+// total from current supply, with nothing actually held at a locked
+// address. That works for genesis-time allocations where the subtracted
+// total is provenance enough, but it gives the supply validator no
+// single locked balance to check against. Schedule/Ledger exist so
+// cmd/validate-supply can report locked_supply as a real account balance
+// and still have emissions + fees - burns == circulating + locked hold.
+package vesting
+
+import (
+	"fmt"
+
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/internal/logger"
+	"github.com/Quigles1337/COINjecture1337-REFACTOR/go/pkg/state"
+)
+
+// LockedSupplyAddress is the pseudo-account every Schedule's unvested
+// balance is held under between CreateSchedule and Release, the same
+// role RewardDistributor's burnAddress (...dead) and treasuryAddress
+// (0xFF...) play for their own streams.
+var LockedSupplyAddress = deriveSpecialAddress("locked")
+
+func deriveSpecialAddress(tag string) [32]byte {
+	var addr [32]byte
+	copy(addr[32-len(tag):], []byte(tag))
+	return addr
+}
+
+// Schedule is one beneficiary's vesting grant: Total vests linearly
+// from StartBlock to StartBlock+Duration, nothing unlocks before
+// StartBlock+Cliff (the amount vested by then unlocks at once), and the
+// whole Total is available once Duration has elapsed.
+type Schedule struct {
+	ID          string
+	Beneficiary [32]byte
+	Total       uint64
+	Cliff       uint64
+	Duration    uint64
+	StartBlock  uint64
+	Revocable   bool
+	Revoked     bool
+	// Claimed is how much of Total Release has already paid out.
+	Claimed uint64
+}
+
+// ClaimableAt returns how much of s has vested by block but hasn't been
+// claimed yet: 0 before the cliff elapses or once Revoked, otherwise
+// Total * min(1, (block-StartBlock)/Duration) minus Claimed.
+func (s Schedule) ClaimableAt(block uint64) uint64 {
+	if s.Revoked || block < s.StartBlock+s.Cliff {
+		return 0
+	}
+
+	elapsed := block - s.StartBlock
+	var vested uint64
+	if s.Duration == 0 || elapsed >= s.Duration {
+		vested = s.Total
+	} else {
+		vested = s.Total * elapsed / s.Duration
+	}
+
+	if vested <= s.Claimed {
+		return 0
+	}
+	return vested - s.Claimed
+}
+
+// Ledger manages Schedules and the locked/spendable balance movements
+// Release performs between them, the vesting counterpart to
+// tokenomics.RewardDistributor.
+type Ledger struct {
+	stateManager *state.StateManager
+	log          *logger.Logger
+}
+
+// NewLedger creates a Ledger backed by stateManager.
+func NewLedger(stateManager *state.StateManager, log *logger.Logger) *Ledger {
+	return &Ledger{
+		stateManager: stateManager,
+		log:          log,
+	}
+}
+
+// CreateSchedule mints s.Total into LockedSupplyAddress and persists s,
+// so the grant's full amount is reflected in locked_supply immediately
+// rather than appearing only as it's released.
+func (l *Ledger) CreateSchedule(s Schedule) error {
+	locked, err := l.stateManager.GetAccount(LockedSupplyAddress)
+	if err != nil {
+		return fmt.Errorf("failed to get locked-supply account: %w", err)
+	}
+	if err := l.stateManager.UpdateAccount(LockedSupplyAddress, locked.Balance+s.Total, locked.Nonce); err != nil {
+		return fmt.Errorf("failed to lock %d for schedule %s: %w", s.Total, s.ID, err)
+	}
+
+	if err := l.stateManager.PutVestingSchedule(s); err != nil {
+		return fmt.Errorf("failed to persist vesting schedule %s: %w", s.ID, err)
+	}
+
+	l.log.WithFields(logger.Fields{
+		"schedule_id": s.ID,
+		"beneficiary": fmt.Sprintf("%x", s.Beneficiary[:8]),
+		"total":       s.Total,
+		"cliff":       s.Cliff,
+		"duration":    s.Duration,
+	}).Info("Vesting schedule created")
+
+	return nil
+}
+
+// Release is the Release transaction: it moves scheduleID's claimable
+// amount as of atBlock out of LockedSupplyAddress and into the
+// schedule's beneficiary, recording the payout against Claimed so it
+// can never be released twice. It's a no-op (amount 0, no error) if
+// nothing is currently claimable.
+func (l *Ledger) Release(scheduleID string, atBlock uint64) (uint64, error) {
+	schedule, err := l.stateManager.GetVestingSchedule(scheduleID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up vesting schedule %s: %w", scheduleID, err)
+	}
+	if schedule == nil {
+		return 0, fmt.Errorf("vesting schedule %s is not registered", scheduleID)
+	}
+
+	amount := schedule.ClaimableAt(atBlock)
+	if amount == 0 {
+		return 0, nil
+	}
+
+	locked, err := l.stateManager.GetAccount(LockedSupplyAddress)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get locked-supply account: %w", err)
+	}
+	if locked.Balance < amount {
+		return 0, fmt.Errorf("locked-supply account holds %d, less than schedule %s's claimable %d", locked.Balance, scheduleID, amount)
+	}
+	if err := l.stateManager.UpdateAccount(LockedSupplyAddress, locked.Balance-amount, locked.Nonce); err != nil {
+		return 0, fmt.Errorf("failed to debit locked-supply account: %w", err)
+	}
+
+	beneficiary, err := l.stateManager.GetAccount(schedule.Beneficiary)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get beneficiary account: %w", err)
+	}
+	if err := l.stateManager.UpdateAccount(schedule.Beneficiary, beneficiary.Balance+amount, beneficiary.Nonce); err != nil {
+		return 0, fmt.Errorf("failed to credit beneficiary account: %w", err)
+	}
+
+	schedule.Claimed += amount
+	if err := l.stateManager.PutVestingSchedule(*schedule); err != nil {
+		return 0, fmt.Errorf("failed to update schedule %s's claimed amount: %w", scheduleID, err)
+	}
+
+	l.log.WithFields(logger.Fields{
+		"schedule_id": scheduleID,
+		"beneficiary": fmt.Sprintf("%x", schedule.Beneficiary[:8]),
+		"released":    amount,
+		"at_block":    atBlock,
+	}).Info("Vesting amount released")
+
+	return amount, nil
+}
+
+// Revoke marks scheduleID as Revoked, freezing its ClaimableAt at
+// whatever's already been released. It's an error to revoke a schedule
+// that isn't Revocable.
+func (l *Ledger) Revoke(scheduleID string) error {
+	schedule, err := l.stateManager.GetVestingSchedule(scheduleID)
+	if err != nil {
+		return fmt.Errorf("failed to look up vesting schedule %s: %w", scheduleID, err)
+	}
+	if schedule == nil {
+		return fmt.Errorf("vesting schedule %s is not registered", scheduleID)
+	}
+	if !schedule.Revocable {
+		return fmt.Errorf("vesting schedule %s is not revocable", scheduleID)
+	}
+
+	schedule.Revoked = true
+	if err := l.stateManager.PutVestingSchedule(*schedule); err != nil {
+		return fmt.Errorf("failed to revoke schedule %s: %w", scheduleID, err)
+	}
+
+	l.log.WithFields(logger.Fields{"schedule_id": scheduleID}).Info("Vesting schedule revoked")
+	return nil
+}
+
+// GetSchedule returns the Schedule registered under id.
+func (l *Ledger) GetSchedule(id string) (*Schedule, error) {
+	schedule, err := l.stateManager.GetVestingSchedule(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up vesting schedule %s: %w", id, err)
+	}
+	return schedule, nil
+}
+
+// ListSchedules returns every registered Schedule.
+func (l *Ledger) ListSchedules() ([]Schedule, error) {
+	schedules, err := l.stateManager.ListVestingSchedules()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vesting schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+// LockedSupply returns LockedSupplyAddress's current balance: the total
+// still-unreleased amount across every Schedule.
+func (l *Ledger) LockedSupply() (uint64, error) {
+	account, err := l.stateManager.GetAccount(LockedSupplyAddress)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get locked-supply account: %w", err)
+	}
+	return account.Balance, nil
+}
@@ -0,0 +1,38 @@
+package vesting
+
+import "testing"
+
+func TestSchedule_ClaimableAt_ZeroBeforeCliff(t *testing.T) {
+	s := Schedule{Total: 1000, Cliff: 100, Duration: 1000, StartBlock: 0}
+	if got := s.ClaimableAt(50); got != 0 {
+		t.Errorf("expected 0 before cliff, got %d", got)
+	}
+}
+
+func TestSchedule_ClaimableAt_LinearBetweenCliffAndDuration(t *testing.T) {
+	s := Schedule{Total: 1000, Cliff: 0, Duration: 1000, StartBlock: 0}
+	if got := s.ClaimableAt(500); got != 500 {
+		t.Errorf("expected half-vested at the midpoint, got %d", got)
+	}
+}
+
+func TestSchedule_ClaimableAt_FullAfterDuration(t *testing.T) {
+	s := Schedule{Total: 1000, Cliff: 0, Duration: 1000, StartBlock: 0}
+	if got := s.ClaimableAt(5000); got != 1000 {
+		t.Errorf("expected the full amount after duration elapses, got %d", got)
+	}
+}
+
+func TestSchedule_ClaimableAt_SubtractsAlreadyClaimed(t *testing.T) {
+	s := Schedule{Total: 1000, Cliff: 0, Duration: 1000, StartBlock: 0, Claimed: 400}
+	if got := s.ClaimableAt(500); got != 100 {
+		t.Errorf("expected 100 still claimable after 400 already claimed, got %d", got)
+	}
+}
+
+func TestSchedule_ClaimableAt_ZeroOnceRevoked(t *testing.T) {
+	s := Schedule{Total: 1000, Cliff: 0, Duration: 1000, StartBlock: 0, Revoked: true}
+	if got := s.ClaimableAt(5000); got != 0 {
+		t.Errorf("expected 0 once revoked, got %d", got)
+	}
+}